@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/authemail"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, authemail.Plugin,
+		plugintest.Case{Name: "account", Files: []string{"account/v1/account.proto"}},
+		plugintest.Case{Name: "cross_file", Files: []string{"crm/v1/contact.proto"}},
+		plugintest.Case{Name: "diagnostics", Files: []string{"diag/v1/shapes.proto"}},
+	)
+}
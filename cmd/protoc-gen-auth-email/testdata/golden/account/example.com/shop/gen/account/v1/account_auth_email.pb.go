@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-auth-email. DO NOT EDIT.
+// Email/password auth for User.AuthEmail
+
+package accountv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrAuthInvalidEmail       = errors.New("invalid email")
+	ErrAuthInvalidPassword    = errors.New("password must be at least 8 characters")
+	ErrAuthEmailExists        = errors.New("email already registered")
+	ErrAuthInvalidCredentials = errors.New("invalid credentials")
+	ErrAuthEmailNotVerified   = errors.New("email not verified")
+	ErrAuthAccountLocked      = errors.New("account locked")
+	ErrAuthInvalidToken       = errors.New("invalid token")
+	ErrAuthTokenExpired       = errors.New("token expired")
+)
+
+type AuthEmailServiceConfig struct {
+	JWTSecret          string
+	JWTExpiry          time.Duration
+	RefreshExpiry      time.Duration
+	VerificationExpiry time.Duration
+	ResetExpiry        time.Duration
+	MaxFailedAttempts  int
+	LockoutDuration    time.Duration
+	BcryptCost         int
+}
+
+func DefaultAuthEmailServiceConfig() AuthEmailServiceConfig {
+	return AuthEmailServiceConfig{
+		JWTExpiry:          24 * time.Hour,
+		RefreshExpiry:      7 * 24 * time.Hour,
+		VerificationExpiry: 24 * time.Hour,
+		ResetExpiry:        time.Hour,
+		MaxFailedAttempts:  5,
+		LockoutDuration:    15 * time.Minute,
+		BcryptCost:         12,
+	}
+}
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+func authValidateEmail(email string) error {
+	if !emailRegex.MatchString(email) {
+		return ErrAuthInvalidEmail
+	}
+	return nil
+}
+
+func authHashPassword(password string, cost int) (string, error) {
+	if len(password) < 8 {
+		return "", ErrAuthInvalidPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	return string(hash), err
+}
+
+func authVerifyPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func authGenerateToken(length int) string {
+	b := make([]byte, length)
+	rand.Read(b)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+type AuthEmailSender interface {
+	SendVerification(to, token string) error
+	SendPasswordReset(to, token string) error
+	SendWelcome(to string) error
+}
+
+type ConsoleAuthEmailSender struct{}
+
+func (s *ConsoleAuthEmailSender) SendVerification(to, token string) error {
+	fmt.Printf("[VERIFY] %s: %s\n", to, token)
+	return nil
+}
+func (s *ConsoleAuthEmailSender) SendPasswordReset(to, token string) error {
+	fmt.Printf("[RESET] %s: %s\n", to, token)
+	return nil
+}
+func (s *ConsoleAuthEmailSender) SendWelcome(to string) error {
+	fmt.Printf("[WELCOME] %s\n", to)
+	return nil
+}
+
+type AuthEmailService struct {
+	repo   UserRepository
+	email  AuthEmailSender
+	config AuthEmailServiceConfig
+}
+
+func NewAuthEmailService(repo UserRepository, email AuthEmailSender, config AuthEmailServiceConfig) *AuthEmailService {
+	if config.BcryptCost == 0 {
+		config = DefaultAuthEmailServiceConfig()
+	}
+	return &AuthEmailService{repo: repo, email: email, config: config}
+}
+
+func (s *AuthEmailService) SignUp(ctx context.Context, email, password, name string) (*User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := authValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if existing, _ := s.repo.GetByEmail(ctx, email); existing != nil {
+		return nil, ErrAuthEmailExists
+	}
+
+	hash, err := authHashPassword(password, s.config.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{Email: email, Name: name}
+	user.AuthEmail = &AuthEmail{
+		PasswordHash:               hash,
+		EmailVerified:              false,
+		VerificationToken:          authGenerateToken(32),
+		VerificationTokenExpiresAt: func() *time.Time { t := time.Now().Add(s.config.VerificationExpiry); return &t }(),
+	}
+
+	id, err := s.repo.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.Id = id
+
+	if s.email != nil {
+		s.email.SendVerification(user.Email, user.AuthEmail.VerificationToken)
+	}
+
+	return user, nil
+}
+
+func (s *AuthEmailService) VerifyEmail(ctx context.Context, token string) error {
+	users, _ := s.repo.List(ctx, 0)
+	var user *User
+	for _, u := range users {
+		if u.AuthEmail != nil && u.AuthEmail.VerificationToken == token {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return ErrAuthInvalidToken
+	}
+
+	if user.AuthEmail.VerificationTokenExpiresAt != nil && user.AuthEmail.VerificationTokenExpiresAt.Before(time.Now()) {
+		return ErrAuthTokenExpired
+	}
+
+	user.AuthEmail.EmailVerified = true
+	user.AuthEmail.VerificationToken = ""
+	user.AuthEmail.VerificationTokenExpiresAt = nil
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+	if s.email != nil {
+		s.email.SendWelcome(user.Email)
+	}
+	return nil
+}
+
+type AuthLoginResult struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	User         *User
+}
+
+func (s *AuthEmailService) Login(ctx context.Context, email, password string) (*AuthLoginResult, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, ErrAuthInvalidCredentials
+	}
+	if user.AuthEmail == nil {
+		return nil, ErrAuthInvalidCredentials
+	}
+
+	if user.AuthEmail.LockedUntil != nil && user.AuthEmail.LockedUntil.After(time.Now()) {
+		return nil, ErrAuthAccountLocked
+	}
+
+	if !user.AuthEmail.EmailVerified {
+		return nil, ErrAuthEmailNotVerified
+	}
+
+	if !authVerifyPassword(user.AuthEmail.PasswordHash, password) {
+		user.AuthEmail.FailedLoginAttempts++
+		if user.AuthEmail.FailedLoginAttempts >= int32(s.config.MaxFailedAttempts) {
+			t := time.Now().Add(s.config.LockoutDuration)
+			user.AuthEmail.LockedUntil = &t
+		}
+		s.repo.Update(ctx, user)
+		return nil, ErrAuthInvalidCredentials
+	}
+
+	user.AuthEmail.FailedLoginAttempts = 0
+	user.AuthEmail.LockedUntil = nil
+	accessToken := authGenerateToken(32)
+	refreshToken := authGenerateToken(64)
+	user.AuthEmail.RefreshToken = refreshToken
+	refreshExp := time.Now().Add(s.config.RefreshExpiry)
+	user.AuthEmail.RefreshTokenExpiresAt = &refreshExp
+	s.repo.Update(ctx, user)
+	return &AuthLoginResult{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: time.Now().Add(s.config.JWTExpiry), User: user}, nil
+}
+
+func (s *AuthEmailService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+	if user.AuthEmail == nil {
+		user.AuthEmail = &AuthEmail{}
+	}
+
+	token := authGenerateToken(32)
+	user.AuthEmail.ResetToken = token
+	exp := time.Now().Add(s.config.ResetExpiry)
+	user.AuthEmail.ResetTokenExpiresAt = &exp
+	s.repo.Update(ctx, user)
+	if s.email != nil {
+		s.email.SendPasswordReset(user.Email, token)
+	}
+	return nil
+}
+
+func (s *AuthEmailService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	users, _ := s.repo.List(ctx, 0)
+	var user *User
+	for _, u := range users {
+		if u.AuthEmail != nil && u.AuthEmail.ResetToken == token {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return ErrAuthInvalidToken
+	}
+
+	if user.AuthEmail.ResetTokenExpiresAt != nil && user.AuthEmail.ResetTokenExpiresAt.Before(time.Now()) {
+		return ErrAuthTokenExpired
+	}
+
+	hash, err := authHashPassword(newPassword, s.config.BcryptCost)
+	if err != nil {
+		return err
+	}
+	user.AuthEmail.PasswordHash = hash
+	user.AuthEmail.ResetToken = ""
+	user.AuthEmail.ResetTokenExpiresAt = nil
+	user.AuthEmail.FailedLoginAttempts = 0
+	user.AuthEmail.LockedUntil = nil
+	return s.repo.Update(ctx, user)
+}
+
+func (s *AuthEmailService) RefreshAccessToken(ctx context.Context, refreshToken string) (*AuthLoginResult, error) {
+	users, _ := s.repo.List(ctx, 0)
+	var user *User
+	for _, u := range users {
+		if u.AuthEmail != nil && u.AuthEmail.RefreshToken == refreshToken {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return nil, ErrAuthInvalidToken
+	}
+
+	if user.AuthEmail.RefreshTokenExpiresAt != nil && user.AuthEmail.RefreshTokenExpiresAt.Before(time.Now()) {
+		return nil, ErrAuthTokenExpired
+	}
+
+	newAccess := authGenerateToken(32)
+	newRefresh := authGenerateToken(64)
+	user.AuthEmail.RefreshToken = newRefresh
+	exp := time.Now().Add(s.config.RefreshExpiry)
+	user.AuthEmail.RefreshTokenExpiresAt = &exp
+	s.repo.Update(ctx, user)
+	return &AuthLoginResult{AccessToken: newAccess, RefreshToken: newRefresh, ExpiresAt: time.Now().Add(s.config.JWTExpiry), User: user}, nil
+}
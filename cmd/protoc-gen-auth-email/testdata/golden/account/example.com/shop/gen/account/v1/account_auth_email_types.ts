@@ -0,0 +1,6 @@
+// auth_email_types.ts
+export interface SignUpRequest { email: string; password: string; name?: string; }
+export interface LoginRequest { email: string; password: string; }
+export interface LoginResult { accessToken: string; refreshToken: string; expiresAt: string; user: AuthUser; }
+export interface AuthUser { id: string; email: string; name?: string; emailVerified?: boolean; }
+
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-auth-email. DO NOT EDIT.
+// Email/password auth for Member.Auth
+
+package diagv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	accountv1 "example.com/shop/gen/account/v1"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthEmail = accountv1.AuthEmail
+
+var (
+	ErrAuthInvalidEmail       = errors.New("invalid email")
+	ErrAuthInvalidPassword    = errors.New("password must be at least 8 characters")
+	ErrAuthEmailExists        = errors.New("email already registered")
+	ErrAuthInvalidCredentials = errors.New("invalid credentials")
+	ErrAuthEmailNotVerified   = errors.New("email not verified")
+	ErrAuthAccountLocked      = errors.New("account locked")
+	ErrAuthInvalidToken       = errors.New("invalid token")
+	ErrAuthTokenExpired       = errors.New("token expired")
+)
+
+type AuthEmailServiceConfig struct {
+	JWTSecret          string
+	JWTExpiry          time.Duration
+	RefreshExpiry      time.Duration
+	VerificationExpiry time.Duration
+	ResetExpiry        time.Duration
+	MaxFailedAttempts  int
+	LockoutDuration    time.Duration
+	BcryptCost         int
+}
+
+func DefaultAuthEmailServiceConfig() AuthEmailServiceConfig {
+	return AuthEmailServiceConfig{
+		JWTExpiry:          24 * time.Hour,
+		RefreshExpiry:      7 * 24 * time.Hour,
+		VerificationExpiry: 24 * time.Hour,
+		ResetExpiry:        time.Hour,
+		MaxFailedAttempts:  5,
+		LockoutDuration:    15 * time.Minute,
+		BcryptCost:         12,
+	}
+}
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+func authValidateEmail(email string) error {
+	if !emailRegex.MatchString(email) {
+		return ErrAuthInvalidEmail
+	}
+	return nil
+}
+
+func authHashPassword(password string, cost int) (string, error) {
+	if len(password) < 8 {
+		return "", ErrAuthInvalidPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	return string(hash), err
+}
+
+func authVerifyPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func authGenerateToken(length int) string {
+	b := make([]byte, length)
+	rand.Read(b)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+type AuthEmailSender interface {
+	SendVerification(to, token string) error
+	SendPasswordReset(to, token string) error
+	SendWelcome(to string) error
+}
+
+type ConsoleAuthEmailSender struct{}
+
+func (s *ConsoleAuthEmailSender) SendVerification(to, token string) error {
+	fmt.Printf("[VERIFY] %s: %s\n", to, token)
+	return nil
+}
+func (s *ConsoleAuthEmailSender) SendPasswordReset(to, token string) error {
+	fmt.Printf("[RESET] %s: %s\n", to, token)
+	return nil
+}
+func (s *ConsoleAuthEmailSender) SendWelcome(to string) error {
+	fmt.Printf("[WELCOME] %s\n", to)
+	return nil
+}
+
+type AuthEmailService struct {
+	repo   MemberRepository
+	email  AuthEmailSender
+	config AuthEmailServiceConfig
+}
+
+func NewAuthEmailService(repo MemberRepository, email AuthEmailSender, config AuthEmailServiceConfig) *AuthEmailService {
+	if config.BcryptCost == 0 {
+		config = DefaultAuthEmailServiceConfig()
+	}
+	return &AuthEmailService{repo: repo, email: email, config: config}
+}
+
+func (s *AuthEmailService) SignUp(ctx context.Context, email, password, name string) (*Member, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := authValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if existing, _ := s.repo.GetByEmail(ctx, email); existing != nil {
+		return nil, ErrAuthEmailExists
+	}
+
+	hash, err := authHashPassword(password, s.config.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &Member{Email: email, Name: name}
+	user.Auth = &AuthEmail{
+		PasswordHash:               hash,
+		EmailVerified:              false,
+		VerificationToken:          authGenerateToken(32),
+		VerificationTokenExpiresAt: func() *time.Time { t := time.Now().Add(s.config.VerificationExpiry); return &t }(),
+	}
+
+	id, err := s.repo.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.Id = id
+
+	if s.email != nil {
+		s.email.SendVerification(user.Email, user.Auth.VerificationToken)
+	}
+
+	return user, nil
+}
+
+func (s *AuthEmailService) VerifyEmail(ctx context.Context, token string) error {
+	users, _ := s.repo.List(ctx, 0)
+	var user *Member
+	for _, u := range users {
+		if u.Auth != nil && u.Auth.VerificationToken == token {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return ErrAuthInvalidToken
+	}
+
+	if user.Auth.VerificationTokenExpiresAt != nil && user.Auth.VerificationTokenExpiresAt.Before(time.Now()) {
+		return ErrAuthTokenExpired
+	}
+
+	user.Auth.EmailVerified = true
+	user.Auth.VerificationToken = ""
+	user.Auth.VerificationTokenExpiresAt = nil
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+	if s.email != nil {
+		s.email.SendWelcome(user.Email)
+	}
+	return nil
+}
+
+type AuthLoginResult struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	Member       *Member
+}
+
+func (s *AuthEmailService) Login(ctx context.Context, email, password string) (*AuthLoginResult, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, ErrAuthInvalidCredentials
+	}
+	if user.Auth == nil {
+		return nil, ErrAuthInvalidCredentials
+	}
+
+	if user.Auth.LockedUntil != nil && user.Auth.LockedUntil.After(time.Now()) {
+		return nil, ErrAuthAccountLocked
+	}
+
+	if !user.Auth.EmailVerified {
+		return nil, ErrAuthEmailNotVerified
+	}
+
+	if !authVerifyPassword(user.Auth.PasswordHash, password) {
+		user.Auth.FailedLoginAttempts++
+		if user.Auth.FailedLoginAttempts >= int32(s.config.MaxFailedAttempts) {
+			t := time.Now().Add(s.config.LockoutDuration)
+			user.Auth.LockedUntil = &t
+		}
+		s.repo.Update(ctx, user)
+		return nil, ErrAuthInvalidCredentials
+	}
+
+	user.Auth.FailedLoginAttempts = 0
+	user.Auth.LockedUntil = nil
+	accessToken := authGenerateToken(32)
+	refreshToken := authGenerateToken(64)
+	user.Auth.RefreshToken = refreshToken
+	refreshExp := time.Now().Add(s.config.RefreshExpiry)
+	user.Auth.RefreshTokenExpiresAt = &refreshExp
+	s.repo.Update(ctx, user)
+	return &AuthLoginResult{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: time.Now().Add(s.config.JWTExpiry), Member: user}, nil
+}
+
+func (s *AuthEmailService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+	if user.Auth == nil {
+		user.Auth = &AuthEmail{}
+	}
+
+	token := authGenerateToken(32)
+	user.Auth.ResetToken = token
+	exp := time.Now().Add(s.config.ResetExpiry)
+	user.Auth.ResetTokenExpiresAt = &exp
+	s.repo.Update(ctx, user)
+	if s.email != nil {
+		s.email.SendPasswordReset(user.Email, token)
+	}
+	return nil
+}
+
+func (s *AuthEmailService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	users, _ := s.repo.List(ctx, 0)
+	var user *Member
+	for _, u := range users {
+		if u.Auth != nil && u.Auth.ResetToken == token {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return ErrAuthInvalidToken
+	}
+
+	if user.Auth.ResetTokenExpiresAt != nil && user.Auth.ResetTokenExpiresAt.Before(time.Now()) {
+		return ErrAuthTokenExpired
+	}
+
+	hash, err := authHashPassword(newPassword, s.config.BcryptCost)
+	if err != nil {
+		return err
+	}
+	user.Auth.PasswordHash = hash
+	user.Auth.ResetToken = ""
+	user.Auth.ResetTokenExpiresAt = nil
+	user.Auth.FailedLoginAttempts = 0
+	user.Auth.LockedUntil = nil
+	return s.repo.Update(ctx, user)
+}
+
+func (s *AuthEmailService) RefreshAccessToken(ctx context.Context, refreshToken string) (*AuthLoginResult, error) {
+	users, _ := s.repo.List(ctx, 0)
+	var user *Member
+	for _, u := range users {
+		if u.Auth != nil && u.Auth.RefreshToken == refreshToken {
+			user = u
+			break
+		}
+	}
+	if user == nil {
+		return nil, ErrAuthInvalidToken
+	}
+
+	if user.Auth.RefreshTokenExpiresAt != nil && user.Auth.RefreshTokenExpiresAt.Before(time.Now()) {
+		return nil, ErrAuthTokenExpired
+	}
+
+	newAccess := authGenerateToken(32)
+	newRefresh := authGenerateToken(64)
+	user.Auth.RefreshToken = newRefresh
+	exp := time.Now().Add(s.config.RefreshExpiry)
+	user.Auth.RefreshTokenExpiresAt = &exp
+	s.repo.Update(ctx, user)
+	return &AuthLoginResult{AccessToken: newAccess, RefreshToken: newRefresh, ExpiresAt: time.Now().Add(s.config.JWTExpiry), Member: user}, nil
+}
@@ -0,0 +1,41 @@
+// AuthEmailContext.tsx
+import React, { createContext, useContext, useState, useEffect, useCallback } from 'react';
+import type { AuthUser, SignUpRequest, LoginRequest, LoginResult } from './auth_email_types';
+
+interface AuthState { user: AuthUser | null; accessToken: string | null; isAuthenticated: boolean; isLoading: boolean; }
+interface AuthEmailContextType extends AuthState {
+  signUp: (req: SignUpRequest) => Promise<void>;
+  login: (req: LoginRequest) => Promise<void>;
+  logout: () => Promise<void>;
+  forgotPassword: (email: string) => Promise<void>;
+  resetPassword: (token: string, password: string) => Promise<void>;
+}
+
+const AuthEmailContext = createContext<AuthEmailContextType | null>(null);
+
+interface Props { children: React.ReactNode; client: any; }
+
+export function AuthEmailProvider({ children, client }: Props) {
+  const [state, setState] = useState<AuthState>({ user: null, accessToken: null, isAuthenticated: false, isLoading: true });
+
+  useEffect(() => {
+    const stored = localStorage.getItem('auth');
+    if (stored) { const { user, accessToken } = JSON.parse(stored); setState({ user, accessToken, isAuthenticated: true, isLoading: false }); }
+    else setState(s => ({ ...s, isLoading: false }));
+  }, []);
+
+  const signUp = useCallback(async (req: SignUpRequest) => { await client.signUp(req); }, [client]);
+  const login = useCallback(async (req: LoginRequest) => {
+    const result = await client.login(req);
+    localStorage.setItem('auth', JSON.stringify({ user: result.user, accessToken: result.accessToken, refreshToken: result.refreshToken }));
+    setState({ user: result.user, accessToken: result.accessToken, isAuthenticated: true, isLoading: false });
+  }, [client]);
+  const logout = useCallback(async () => { localStorage.removeItem('auth'); setState({ user: null, accessToken: null, isAuthenticated: false, isLoading: false }); }, []);
+  const forgotPassword = useCallback(async (email: string) => { await client.forgotPassword({ email }); }, [client]);
+  const resetPassword = useCallback(async (token: string, password: string) => { await client.resetPassword({ token, password }); }, [client]);
+
+  return <AuthEmailContext.Provider value={{ ...state, signUp, login, logout, forgotPassword, resetPassword }}>{children}</AuthEmailContext.Provider>;
+}
+
+export function useAuthEmail() { const ctx = useContext(AuthEmailContext); if (!ctx) throw new Error('useAuthEmail requires AuthEmailProvider'); return ctx; }
+
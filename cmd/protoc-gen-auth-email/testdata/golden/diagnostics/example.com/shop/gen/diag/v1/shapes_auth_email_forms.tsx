@@ -0,0 +1,101 @@
+// AuthEmailForms.tsx
+import React, { useState } from 'react';
+import { useAuthEmail } from './AuthEmailContext';
+
+export function SignUpForm({ onSuccess }: { onSuccess?: () => void }) {
+  const { signUp } = useAuthEmail();
+  const [form, setForm] = useState({ name: '', email: '', password: '', confirm: '' });
+  const [error, setError] = useState('');
+  const [success, setSuccess] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (form.password !== form.confirm) { setError('Passwords do not match'); return; }
+    setLoading(true); setError('');
+    try { await signUp({ email: form.email, password: form.password, name: form.name }); setSuccess(true); onSuccess?.(); }
+    catch (err: any) { setError(err.message || 'Sign up failed'); }
+    finally { setLoading(false); }
+  };
+
+  if (success) return <div className="p-4 bg-green-50 text-green-800 rounded">Check your email for verification link.</div>;
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      {error && <div className="p-3 bg-red-50 text-red-700 rounded">{error}</div>}
+      <input type="text" placeholder="Name" value={form.name} onChange={e => setForm(f => ({ ...f, name: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <input type="email" placeholder="Email" required value={form.email} onChange={e => setForm(f => ({ ...f, email: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <input type="password" placeholder="Password" required minLength={8} value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <input type="password" placeholder="Confirm" required value={form.confirm} onChange={e => setForm(f => ({ ...f, confirm: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <button type="submit" disabled={loading} className="w-full py-2 bg-blue-600 text-white rounded disabled:opacity-50">{loading ? 'Creating...' : 'Sign Up'}</button>
+    </form>
+  );
+}
+
+export function LoginForm({ onSuccess, onForgot }: { onSuccess?: () => void; onForgot?: () => void }) {
+  const { login } = useAuthEmail();
+  const [form, setForm] = useState({ email: '', password: '' });
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault(); setLoading(true); setError('');
+    try { await login(form); onSuccess?.(); }
+    catch (err: any) { setError(err.message || 'Login failed'); }
+    finally { setLoading(false); }
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      {error && <div className="p-3 bg-red-50 text-red-700 rounded">{error}</div>}
+      <input type="email" placeholder="Email" required value={form.email} onChange={e => setForm(f => ({ ...f, email: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <input type="password" placeholder="Password" required value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      {onForgot && <button type="button" onClick={onForgot} className="text-sm text-blue-600">Forgot password?</button>}
+      <button type="submit" disabled={loading} className="w-full py-2 bg-blue-600 text-white rounded disabled:opacity-50">{loading ? 'Signing in...' : 'Sign In'}</button>
+    </form>
+  );
+}
+
+export function ForgotPasswordForm({ onBack }: { onBack?: () => void }) {
+  const { forgotPassword } = useAuthEmail();
+  const [email, setEmail] = useState('');
+  const [sent, setSent] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => { e.preventDefault(); setLoading(true); await forgotPassword(email); setSent(true); setLoading(false); };
+  if (sent) return <div className="text-center"><p className="text-green-700">Reset link sent</p>{onBack && <button onClick={onBack} className="mt-2 text-blue-600">Back</button>}</div>;
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <input type="email" placeholder="Email" required value={email} onChange={e => setEmail(e.target.value)} className="w-full px-3 py-2 border rounded" />
+      <button type="submit" disabled={loading} className="w-full py-2 bg-blue-600 text-white rounded disabled:opacity-50">{loading ? 'Sending...' : 'Send Reset Link'}</button>
+      {onBack && <button type="button" onClick={onBack} className="w-full text-sm text-gray-600">Back</button>}
+    </form>
+  );
+}
+
+export function ResetPasswordForm({ token, onSuccess }: { token: string; onSuccess?: () => void }) {
+  const { resetPassword } = useAuthEmail();
+  const [form, setForm] = useState({ password: '', confirm: '' });
+  const [error, setError] = useState('');
+  const [success, setSuccess] = useState(false);
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (form.password !== form.confirm) { setError('Passwords do not match'); return; }
+    setLoading(true);
+    try { await resetPassword(token, form.password); setSuccess(true); onSuccess?.(); }
+    catch (err: any) { setError(err.message || 'Reset failed'); }
+    finally { setLoading(false); }
+  };
+
+  if (success) return <div className="p-4 bg-green-50 text-green-800 rounded">Password reset successfully.</div>;
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      {error && <div className="p-3 bg-red-50 text-red-700 rounded">{error}</div>}
+      <input type="password" placeholder="New Password" required minLength={8} value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <input type="password" placeholder="Confirm" required value={form.confirm} onChange={e => setForm(f => ({ ...f, confirm: e.target.value }))} className="w-full px-3 py-2 border rounded" />
+      <button type="submit" disabled={loading} className="w-full py-2 bg-blue-600 text-white rounded disabled:opacity-50">{loading ? 'Resetting...' : 'Reset Password'}</button>
+    </form>
+  );
+}
+
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-auth-oauth. DO NOT EDIT.
+package accountv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrOAuthInvalidState    = errors.New("invalid state")
+	ErrOAuthExchangeFailed  = errors.New("token exchange failed")
+	ErrOAuthUserInfoFailed  = errors.New("user info failed")
+	ErrOAuthUnknownProvider = errors.New("unknown provider")
+	ErrOAuthCannotUnlink    = errors.New("cannot unlink last auth")
+)
+
+type OAuthProvider interface {
+	Name() string
+	AuthURL(state, redirect string) string
+	Exchange(ctx context.Context, code, redirect string) (*OAuthToken, error)
+	UserInfo(ctx context.Context, token *OAuthToken) (*OAuthUserInfo, error)
+}
+
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+type OAuthUserInfo struct {
+	ID, Email, Name, Picture string
+	EmailVerified            bool
+}
+
+type OAuthStateManager struct {
+	mu     sync.Mutex
+	states map[string]time.Time
+	ttl    time.Duration
+}
+
+func NewOAuthStateManager() *OAuthStateManager {
+	return &OAuthStateManager{states: make(map[string]time.Time), ttl: 10 * time.Minute}
+}
+func (m *OAuthStateManager) Generate() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	s := base64.URLEncoding.EncodeToString(b)
+	m.mu.Lock()
+	m.states[s] = time.Now().Add(m.ttl)
+	m.mu.Unlock()
+	return s
+}
+func (m *OAuthStateManager) Validate(s string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	exp, ok := m.states[s]
+	if !ok {
+		return false
+	}
+	delete(m.states, s)
+	return time.Now().Before(exp)
+}
+
+type GoogleProvider struct{ ClientID, ClientSecret string }
+
+func (p *GoogleProvider) Name() string { return "google" }
+func (p *GoogleProvider) AuthURL(state, redirect string) string {
+	v := url.Values{}
+	v.Set("client_id", p.ClientID)
+	v.Set("redirect_uri", redirect)
+	v.Set("response_type", "code")
+	v.Set("scope", "openid email profile")
+	v.Set("state", state)
+	return "https://accounts.google.com/o/oauth2/v2/auth?" + v.Encode()
+}
+func (p *GoogleProvider) Exchange(ctx context.Context, code, redirect string) (*OAuthToken, error) {
+	v := url.Values{}
+	v.Set("code", code)
+	v.Set("client_id", p.ClientID)
+	v.Set("client_secret", p.ClientSecret)
+	v.Set("redirect_uri", redirect)
+	v.Set("grant_type", "authorization_code")
+	resp, err := http.PostForm("https://oauth2.googleapis.com/token", v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var t OAuthToken
+	json.NewDecoder(resp.Body).Decode(&t)
+	return &t, nil
+}
+func (p *GoogleProvider) UserInfo(ctx context.Context, t *OAuthToken) (*OAuthUserInfo, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://www.googleapis.com/oauth2/v2/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+t.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var d struct {
+		ID       string `json:"id"`
+		Email    string `json:"email"`
+		Verified bool   `json:"verified_email"`
+		Name     string `json:"name"`
+		Picture  string `json:"picture"`
+	}
+	json.NewDecoder(resp.Body).Decode(&d)
+	return &OAuthUserInfo{ID: d.ID, Email: d.Email, EmailVerified: d.Verified, Name: d.Name, Picture: d.Picture}, nil
+}
+
+type GitHubProvider struct{ ClientID, ClientSecret string }
+
+func (p *GitHubProvider) Name() string { return "github" }
+func (p *GitHubProvider) AuthURL(state, redirect string) string {
+	v := url.Values{}
+	v.Set("client_id", p.ClientID)
+	v.Set("redirect_uri", redirect)
+	v.Set("scope", "user:email")
+	v.Set("state", state)
+	return "https://github.com/login/oauth/authorize?" + v.Encode()
+}
+func (p *GitHubProvider) Exchange(ctx context.Context, code, redirect string) (*OAuthToken, error) {
+	v := url.Values{}
+	v.Set("code", code)
+	v.Set("client_id", p.ClientID)
+	v.Set("client_secret", p.ClientSecret)
+	req, _ := http.NewRequest("POST", "https://github.com/login/oauth/access_token", strings.NewReader(v.Encode()))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var t OAuthToken
+	json.NewDecoder(resp.Body).Decode(&t)
+	return &t, nil
+}
+func (p *GitHubProvider) UserInfo(ctx context.Context, t *OAuthToken) (*OAuthUserInfo, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/user", nil)
+	req.Header.Set("Authorization", "Bearer "+t.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var d struct {
+		ID     int64  `json:"id"`
+		Email  string `json:"email"`
+		Name   string `json:"name"`
+		Avatar string `json:"avatar_url"`
+	}
+	json.NewDecoder(resp.Body).Decode(&d)
+	return &OAuthUserInfo{ID: fmt.Sprintf("%d", d.ID), Email: d.Email, EmailVerified: true, Name: d.Name, Picture: d.Avatar}, nil
+}
+
+type AuthOAuthService struct {
+	repo      UserRepository
+	providers map[string]OAuthProvider
+	states    *OAuthStateManager
+}
+
+func NewAuthOAuthService(repo UserRepository) *AuthOAuthService {
+	return &AuthOAuthService{repo: repo, providers: make(map[string]OAuthProvider), states: NewOAuthStateManager()}
+}
+
+func (s *AuthOAuthService) RegisterProvider(p OAuthProvider) { s.providers[p.Name()] = p }
+
+func (s *AuthOAuthService) StartAuth(provider, redirect string) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", ErrOAuthUnknownProvider
+	}
+	return p.AuthURL(s.states.Generate(), redirect), nil
+}
+
+type OAuthLoginResult struct {
+	AccessToken, RefreshToken string
+	ExpiresAt                 time.Time
+	User                      *User
+	IsNewUser                 bool
+}
+
+func (s *AuthOAuthService) HandleCallback(ctx context.Context, provider, code, state, redirect string) (*OAuthLoginResult, error) {
+	if !s.states.Validate(state) {
+		return nil, ErrOAuthInvalidState
+	}
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrOAuthUnknownProvider
+	}
+	token, err := p.Exchange(ctx, code, redirect)
+	if err != nil {
+		return nil, ErrOAuthExchangeFailed
+	}
+	info, err := p.UserInfo(ctx, token)
+	if err != nil {
+		return nil, ErrOAuthUserInfoFailed
+	}
+
+	users, _ := s.repo.List(ctx, 0)
+	var user *User
+	var isNew bool
+
+	// Find by OAuth link
+	for _, u := range users {
+		if u.AuthOauth != nil {
+			for _, link := range u.AuthOauth.Links {
+				if link.Provider == provider && link.ProviderId == info.ID {
+					user = u
+					break
+				}
+			}
+		}
+		if user != nil {
+			break
+		}
+	}
+
+	// Find by email
+	if user == nil && info.Email != "" {
+		user, _ = s.repo.GetByEmail(ctx, info.Email)
+	}
+
+	// Create new
+	if user == nil {
+		user = &User{Email: info.Email, Name: info.Name}
+		isNew = true
+	}
+
+	if user.AuthOauth == nil {
+		user.AuthOauth = &AuthOAuth{}
+	}
+
+	// Add link
+	found := false
+	for _, l := range user.AuthOauth.Links {
+		if l.Provider == provider {
+			found = true
+			break
+		}
+	}
+	if !found {
+		now := time.Now()
+		user.AuthOauth.Links = append(user.AuthOauth.Links, &OAuthLink{Provider: provider, ProviderId: info.ID, Email: info.Email, LinkedAt: &now})
+	}
+
+	if isNew {
+		id, _ := s.repo.Create(ctx, user)
+		user.Id = id
+	} else {
+		s.repo.Update(ctx, user)
+	}
+
+	b := make([]byte, 32)
+	rand.Read(b)
+	at := base64.URLEncoding.EncodeToString(b)
+	rand.Read(b)
+	rt := base64.URLEncoding.EncodeToString(b)
+	return &OAuthLoginResult{AccessToken: at, RefreshToken: rt, ExpiresAt: time.Now().Add(24 * time.Hour), User: user, IsNewUser: isNew}, nil
+}
+
+func (s *AuthOAuthService) UnlinkProvider(ctx context.Context, userID, provider string) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.AuthOauth == nil || len(user.AuthOauth.Links) <= 1 {
+		return ErrOAuthCannotUnlink
+	}
+	var links []*OAuthLink
+	for _, l := range user.AuthOauth.Links {
+		if l.Provider != provider {
+			links = append(links, l)
+		}
+	}
+	user.AuthOauth.Links = links
+	return s.repo.Update(ctx, user)
+}
+
+func (s *AuthOAuthService) GetLinkedProviders(ctx context.Context, userID string) ([]string, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AuthOauth == nil {
+		return nil, nil
+	}
+	var out []string
+	for _, l := range user.AuthOauth.Links {
+		out = append(out, l.Provider)
+	}
+	return out, nil
+}
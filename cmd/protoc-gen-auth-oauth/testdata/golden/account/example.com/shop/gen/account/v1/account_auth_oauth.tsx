@@ -0,0 +1,80 @@
+// auth_oauth.tsx
+import React, { createContext, useContext, useState, useEffect, useCallback } from 'react';
+
+interface AuthOAuthContextType {
+  startAuth: (provider: string) => Promise<void>;
+  handleCallback: (provider: string, code: string, state: string) => Promise<any>;
+  unlinkProvider: (provider: string) => Promise<void>;
+  linkedProviders: string[];
+}
+
+const AuthOAuthContext = createContext<AuthOAuthContextType | null>(null);
+
+export function AuthOAuthProvider({ children, client, redirectUri }: { children: React.ReactNode; client: any; redirectUri: string }) {
+  const [linkedProviders, setLinkedProviders] = useState<string[]>([]);
+
+  useEffect(() => { client.getLinkedProviders?.().then((r: any) => setLinkedProviders(r.providers || [])).catch(() => {}); }, [client]);
+
+  const startAuth = useCallback(async (provider: string) => {
+    const { url } = await client.startAuth({ provider, redirectUri });
+    window.location.href = url;
+  }, [client, redirectUri]);
+
+  const handleCallback = useCallback(async (provider: string, code: string, state: string) => {
+    const result = await client.handleCallback({ provider, code, state, redirectUri });
+    localStorage.setItem('auth', JSON.stringify({ user: result.user, accessToken: result.accessToken, refreshToken: result.refreshToken }));
+    return result;
+  }, [client, redirectUri]);
+
+  const unlinkProvider = useCallback(async (provider: string) => {
+    await client.unlinkProvider({ provider });
+    setLinkedProviders(p => p.filter(x => x !== provider));
+  }, [client]);
+
+  return <AuthOAuthContext.Provider value={{ startAuth, handleCallback, unlinkProvider, linkedProviders }}>{children}</AuthOAuthContext.Provider>;
+}
+
+export function useAuthOAuth() { const ctx = useContext(AuthOAuthContext); if (!ctx) throw new Error('useAuthOAuth requires AuthOAuthProvider'); return ctx; }
+
+const providers: Record<string, { name: string; bg: string }> = {
+  google: { name: 'Google', bg: '#4285F4' },
+  github: { name: 'GitHub', bg: '#333' },
+};
+
+export function OAuthButtons({ items = ['google', 'github'], mode = 'login' }: { items?: string[]; mode?: 'login' | 'signup' | 'link' }) {
+  const { startAuth, linkedProviders } = useAuthOAuth();
+  const label = mode === 'login' ? 'Sign in with' : mode === 'signup' ? 'Sign up with' : 'Link';
+
+  return (
+    <div className="space-y-2">
+      {items.map(p => {
+        const cfg = providers[p] || { name: p, bg: '#666' };
+        const linked = linkedProviders.includes(p);
+        return (
+          <button key={p} onClick={() => startAuth(p)} disabled={mode === 'link' && linked}
+            className="w-full py-2 px-4 rounded text-white disabled:opacity-50" style={{ backgroundColor: cfg.bg }}>
+            {linked ? cfg.name + ' Linked' : label + ' ' + cfg.name}
+          </button>
+        );
+      })}
+    </div>
+  );
+}
+
+export function OAuthCallback({ onSuccess }: { onSuccess?: (result: any) => void }) {
+  const { handleCallback } = useAuthOAuth();
+  const [status, setStatus] = useState<'loading' | 'success' | 'error'>('loading');
+
+  useEffect(() => {
+    const params = new URLSearchParams(window.location.search);
+    const code = params.get('code'), state = params.get('state');
+    const provider = window.location.pathname.split('/').pop() || 'google';
+    if (!code || !state) { setStatus('error'); return; }
+    handleCallback(provider, code, state).then(r => { setStatus('success'); onSuccess?.(r); }).catch(() => setStatus('error'));
+  }, [handleCallback, onSuccess]);
+
+  if (status === 'loading') return <div className="p-8 text-center">Completing sign in...</div>;
+  if (status === 'error') return <div className="p-8 text-center text-red-600">Authentication failed</div>;
+  return <div className="p-8 text-center text-green-600">Success!</div>;
+}
+
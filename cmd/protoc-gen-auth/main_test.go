@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/auth"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, auth.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+	)
+}
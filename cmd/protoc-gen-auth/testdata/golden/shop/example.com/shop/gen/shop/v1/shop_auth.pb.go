@@ -0,0 +1,343 @@
+// Code generated by protoc-gen-auth. DO NOT EDIT.
+
+package shopv1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Auth errors
+var (
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrInsufficientRole = errors.New("insufficient permissions")
+)
+
+// Role represents user roles
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAdmin     Role = "admin"
+	RoleModerator Role = "moderator"
+)
+
+// AuthClaims contains JWT claims
+type AuthClaims struct {
+	jwt.RegisteredClaims
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Role   Role   `json:"role"`
+}
+
+// AuthUser represents the authenticated user in context
+type AuthUser struct {
+	ID    string
+	Email string
+	Role  Role
+}
+
+type contextKey string
+
+const authUserKey contextKey = "auth_user"
+
+// GetAuthUser retrieves the authenticated user from context
+func GetAuthUser(ctx context.Context) (*AuthUser, bool) {
+	user, ok := ctx.Value(authUserKey).(*AuthUser)
+	return user, ok
+}
+
+// MustGetAuthUser retrieves the authenticated user or panics
+func MustGetAuthUser(ctx context.Context) *AuthUser {
+	user, ok := GetAuthUser(ctx)
+	if !ok {
+		panic("no authenticated user in context")
+	}
+	return user
+}
+
+// AuthConfig holds authentication configuration
+type AuthConfig struct {
+	SecretKey     string
+	TokenExpiry   time.Duration
+	RefreshExpiry time.Duration
+	Issuer        string
+}
+
+// DefaultAuthConfig returns sensible defaults
+func DefaultAuthConfig(secret string) AuthConfig {
+	return AuthConfig{
+		SecretKey:     secret,
+		TokenExpiry:   15 * time.Minute,
+		RefreshExpiry: 7 * 24 * time.Hour,
+		Issuer:        "app",
+	}
+}
+
+var authConfig AuthConfig
+
+// InitAuth initializes authentication with config
+func InitAuth(cfg AuthConfig) {
+	authConfig = cfg
+}
+
+// GenerateToken creates a new JWT token
+func GenerateToken(userID, email string, role Role) (string, error) {
+	claims := AuthClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(authConfig.TokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    authConfig.Issuer,
+			Subject:   userID,
+		},
+		UserID: userID,
+		Email:  email,
+		Role:   role,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(authConfig.SecretKey))
+}
+
+// GenerateRefreshToken creates a refresh token
+func GenerateRefreshToken(userID string) (string, error) {
+	claims := jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(authConfig.RefreshExpiry)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		Issuer:    authConfig.Issuer,
+		Subject:   userID,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(authConfig.SecretKey))
+}
+
+// ValidateToken validates a JWT token and returns claims
+func ValidateToken(tokenString string) (*AuthClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(authConfig.SecretKey), nil
+	})
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*AuthClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// AuthMiddleware validates JWT and injects user into context
+func AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := ValidateToken(parts[1])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		user := &AuthUser{
+			ID:    claims.UserID,
+			Email: claims.Email,
+			Role:  claims.Role,
+		}
+
+		ctx := context.WithValue(r.Context(), authUserKey, user)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// OptionalAuthMiddleware extracts user if token present, but doesn't require it
+func OptionalAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader != "" {
+			parts := strings.Split(authHeader, " ")
+			if len(parts) == 2 && parts[0] == "Bearer" {
+				if claims, err := ValidateToken(parts[1]); err == nil {
+					user := &AuthUser{ID: claims.UserID, Email: claims.Email, Role: claims.Role}
+					ctx := context.WithValue(r.Context(), authUserKey, user)
+					r = r.WithContext(ctx)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole middleware checks if user has required role
+func RequireRole(roles ...Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := GetAuthUser(r.Context())
+			if !ok {
+				http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			hasRole := false
+			for _, role := range roles {
+				if user.Role == role {
+					hasRole = true
+					break
+				}
+			}
+
+			if !hasRole {
+				http.Error(w, ErrInsufficientRole.Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireOwner middleware checks if user owns the resource
+func RequireOwner(getOwnerID func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := GetAuthUser(r.Context())
+			if !ok {
+				http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			// Admins can access any resource
+			if user.Role == RoleAdmin {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ownerID := getOwnerID(r)
+			if ownerID != user.ID {
+				http.Error(w, ErrInsufficientRole.Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LoginRequest for login endpoint
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse from login endpoint
+type LoginResponse struct {
+	AccessToken  string   `json:"access_token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	User         AuthUser `json:"user"`
+}
+
+// RegisterRequest for registration
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+}
+
+// RefreshRequest for token refresh
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// PasswordHasher interface for password hashing
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hashed, password string) bool
+}
+
+// UserStore interface for user persistence
+type UserStore interface {
+	GetByEmail(ctx context.Context, email string) (*AuthUser, string, error) // returns user, hashed password
+	Create(ctx context.Context, email, hashedPassword, name string) (*AuthUser, error)
+	GetByID(ctx context.Context, id string) (*AuthUser, error)
+}
+
+// AuthService handles authentication
+type AuthService struct {
+	users  UserStore
+	hasher PasswordHasher
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(users UserStore, hasher PasswordHasher) *AuthService {
+	return &AuthService{users: users, hasher: hasher}
+}
+
+// Login authenticates a user
+func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
+	user, hashedPw, err := s.users.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	if !s.hasher.Compare(hashedPw, req.Password) {
+		return nil, ErrUnauthorized
+	}
+
+	accessToken, err := GenerateToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := GenerateRefreshToken(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(authConfig.TokenExpiry.Seconds()),
+		User:         *user,
+	}, nil
+}
+
+// Register creates a new user
+func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*LoginResponse, error) {
+	hashedPw, err := s.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.Create(ctx, req.Email, hashedPw, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Login(ctx, LoginRequest{Email: req.Email, Password: req.Password})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -154,7 +155,7 @@ func (g *Generator) GenerateFile(f *protogen.File) error {
 	}
 
 	filename := f.GeneratedFilenamePrefix + "_category.go"
-	gf := g.plugin.NewGeneratedFile(filename, f.GoImportPath)
+	gf := &source{}
 
 	// Header
 	gf.P("// Code generated by protoc-gen-category. DO NOT EDIT.")
@@ -241,10 +242,24 @@ func (g *Generator) GenerateFile(f *protogen.File) error {
 		}
 	}
 
-	return nil
+	return gosrc.Generate(g.plugin, filename, f.GoImportPath, gf.String())
 }
 
-func (g *Generator) generateCoreTypes(gf *protogen.GeneratedFile) {
+// source is the text of a generated file, written line by line with P like a
+// protogen.GeneratedFile, for gosrc to format and prune the imports of.
+type source struct {
+	strings.Builder
+}
+
+// P writes the arguments and a newline.
+func (s *source) P(v ...interface{}) {
+	for _, x := range v {
+		fmt.Fprint(&s.Builder, x)
+	}
+	s.WriteByte('\n')
+}
+
+func (g *Generator) generateCoreTypes(gf *source) {
 	gf.P("// --- Core Category Theory Types ---")
 	gf.P()
 
@@ -428,7 +443,7 @@ func (g *Generator) generateCoreTypes(gf *protogen.GeneratedFile) {
 	gf.P()
 }
 
-func (g *Generator) generateEffectType(gf *protogen.GeneratedFile, effect EffectKind) {
+func (g *Generator) generateEffectType(gf *source, effect EffectKind) {
 	name := effect.TypeName()
 
 	gf.P("// --- ", name, " Effect Type ---")
@@ -628,7 +643,7 @@ func (g *Generator) generateEffectType(gf *protogen.GeneratedFile, effect Effect
 	gf.P()
 }
 
-func (g *Generator) generateNaturalTransformations(gf *protogen.GeneratedFile, effects []EffectKind) {
+func (g *Generator) generateNaturalTransformations(gf *source, effects []EffectKind) {
 	gf.P("// --- Natural Transformations ---")
 	gf.P()
 
@@ -650,7 +665,7 @@ func (g *Generator) generateNaturalTransformations(gf *protogen.GeneratedFile, e
 	}
 }
 
-func (g *Generator) generateMessageCode(gf *protogen.GeneratedFile, m *protogen.Message, opts *MessageOptions, effects []EffectKind) {
+func (g *Generator) generateMessageCode(gf *source, m *protogen.Message, opts *MessageOptions, effects []EffectKind) {
 	typeName := m.GoIdent.GoName
 
 	gf.P("// --- ", typeName, " ---")
@@ -714,7 +729,7 @@ func (g *Generator) generateMessageCode(gf *protogen.GeneratedFile, m *protogen.
 	}
 }
 
-func (g *Generator) generateSemigroup(gf *protogen.GeneratedFile, m *protogen.Message, opts *MessageOptions) {
+func (g *Generator) generateSemigroup(gf *source, m *protogen.Message, opts *MessageOptions) {
 	typeName := m.GoIdent.GoName
 
 	gf.P("// ", typeName, "Semigroup provides Combine for ", typeName)
@@ -742,7 +757,7 @@ func (g *Generator) generateSemigroup(gf *protogen.GeneratedFile, m *protogen.Me
 	gf.P()
 }
 
-func (g *Generator) generateMonoid(gf *protogen.GeneratedFile, m *protogen.Message, opts *MessageOptions) {
+func (g *Generator) generateMonoid(gf *source, m *protogen.Message, opts *MessageOptions) {
 	typeName := m.GoIdent.GoName
 
 	gf.P("// ", typeName, "Monoid provides Empty and Combine for ", typeName)
@@ -818,7 +833,7 @@ func (g *Generator) getCombineExpr(a, b string, f *protogen.Field, strategy Comb
 	}
 }
 
-func (g *Generator) generateServiceCode(gf *protogen.GeneratedFile, s *protogen.Service, opts *ServiceOptions, effects []EffectKind) {
+func (g *Generator) generateServiceCode(gf *source, s *protogen.Service, opts *ServiceOptions, effects []EffectKind) {
 	serviceName := s.GoName
 
 	gf.P("// --- ", serviceName, " ---")
@@ -1053,7 +1068,7 @@ func (g *Generator) generateServiceCode(gf *protogen.GeneratedFile, s *protogen.
 	}
 }
 
-func (g *Generator) generateConnectBridge(gf *protogen.GeneratedFile, s *protogen.Service, effectName string) {
+func (g *Generator) generateConnectBridge(gf *source, s *protogen.Service, effectName string) {
 	serviceName := s.GoName
 	// Infer connect package path from proto package
 	connectPkg := fmt.Sprintf("%sconnect", strings.ToLower(serviceName))
@@ -1164,7 +1179,7 @@ func (g *Generator) generateConnectBridge(gf *protogen.GeneratedFile, s *protoge
 	gf.P()
 }
 
-func (g *Generator) generateFirestoreBridge(gf *protogen.GeneratedFile, m *protogen.Message, opts *MessageOptions) {
+func (g *Generator) generateFirestoreBridge(gf *source, m *protogen.Message, opts *MessageOptions) {
 	typeName := m.GoIdent.GoName
 	collectionName := toSnakeCase(typeName) + "s" // Simple pluralization
 	isGlobal := opts.Global
@@ -1849,7 +1864,7 @@ func (g *Generator) parseFieldOptions(f *protogen.Field) *FieldOptions {
 
 // --- Stripe Code Generation ---
 
-func (g *Generator) generateStripeCore(gf *protogen.GeneratedFile, cfg *StripeFileConfig) {
+func (g *Generator) generateStripeCore(gf *source, cfg *StripeFileConfig) {
 	gf.P("// --- Stripe Core Types ---")
 	gf.P()
 
@@ -1924,7 +1939,7 @@ func (g *Generator) generateStripeCore(gf *protogen.GeneratedFile, cfg *StripeFi
 	gf.P()
 }
 
-func (g *Generator) generateStripeCustomerOps(gf *protogen.GeneratedFile, m *protogen.Message, cfg *StripeFileConfig) {
+func (g *Generator) generateStripeCustomerOps(gf *source, m *protogen.Message, cfg *StripeFileConfig) {
 	typeName := m.GoIdent.GoName
 
 	// Find stripe_customer_id field
@@ -2026,7 +2041,7 @@ func (g *Generator) generateStripeCustomerOps(gf *protogen.GeneratedFile, m *pro
 	gf.P()
 }
 
-func (g *Generator) generateStripeSubscriptionOps(gf *protogen.GeneratedFile, m *protogen.Message, cfg *StripeFileConfig) {
+func (g *Generator) generateStripeSubscriptionOps(gf *source, m *protogen.Message, cfg *StripeFileConfig) {
 	typeName := m.GoIdent.GoName
 
 	// Find relevant fields
@@ -2187,7 +2202,7 @@ func (g *Generator) generateStripeSubscriptionOps(gf *protogen.GeneratedFile, m
 	gf.P()
 }
 
-func (g *Generator) generateStripeBillingInterceptor(gf *protogen.GeneratedFile, s *protogen.Service, cfg *StripeFileConfig) {
+func (g *Generator) generateStripeBillingInterceptor(gf *source, s *protogen.Service, cfg *StripeFileConfig) {
 	serviceName := s.GoName
 
 	gf.P("// --- ", serviceName, " Stripe Billing ---")
@@ -2,11 +2,16 @@ package main
 
 import (
 	"github.com/vinodhalaharvi/buf-go-plugins/cmd/protoc-gen-category/internal/generator"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
 	"google.golang.org/protobuf/compiler/protogen"
 )
 
-func main() {
-	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+// plugin declares the parameters on flags and returns the generator.
+func plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-category")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
 		g := generator.New(gen)
 		for _, f := range gen.Files {
 			if !f.Generate {
@@ -19,3 +24,8 @@ func main() {
 		return nil
 	})
 }
+
+func main() {
+	var flags params.Set
+	flags.Options().Run(plugin(&flags))
+}
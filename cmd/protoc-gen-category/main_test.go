@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, plugin,
+		plugintest.Case{
+			Name:  "example",
+			Files: []string{"example.proto"},
+			Roots: []string{"example", "proto"},
+		},
+	)
+}
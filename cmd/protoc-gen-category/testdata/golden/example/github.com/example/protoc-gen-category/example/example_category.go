@@ -0,0 +1,3180 @@
+// Code generated by protoc-gen-category. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"connectrpc.com/connect"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/customer"
+	"github.com/stripe/stripe-go/v76/subscription"
+	"github.com/stripe/stripe-go/v76/webhook"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+)
+
+// --- Core Category Theory Types ---
+
+// Morphism represents a function from A to B
+type Morphism[A, B any] func(A) B
+
+// Id returns the identity morphism
+func Id[A any]() Morphism[A, A] {
+	return func(a A) A { return a }
+}
+
+// Compose composes two morphisms (g . f)
+func Compose[A, B, C any](g Morphism[B, C], f Morphism[A, B]) Morphism[A, C] {
+	return func(a A) C { return g(f(a)) }
+}
+
+// Semigroup provides an associative binary operation
+type Semigroup[A any] struct {
+	Combine func(A, A) A
+}
+
+// Monoid extends Semigroup with an identity element
+type Monoid[A any] struct {
+	Semigroup[A]
+	Empty func() A
+}
+
+// Product represents a pair of values
+type Product[A, B any] struct {
+	Fst A
+	Snd B
+}
+
+// Either represents a sum type (coproduct)
+type Either[L, R any] struct {
+	Left    L
+	Right   R
+	IsRight bool
+}
+
+// Result represents a computation that may fail
+type Result[E, A any] struct {
+	Value A
+	Err   E
+	IsOk  bool
+}
+
+// BimapResult applies f to error and g to value
+func BimapResult[E1, E2, A, B any](f Morphism[E1, E2], g Morphism[A, B], r Result[E1, A]) Result[E2, B] {
+	if r.IsOk {
+		return Result[E2, B]{Value: g(r.Value), IsOk: true}
+	}
+	return Result[E2, B]{Err: f(r.Err), IsOk: false}
+}
+
+// MapLeftResult transforms the error type
+func MapLeftResult[E1, E2, A any](f Morphism[E1, E2], r Result[E1, A]) Result[E2, A] {
+	return BimapResult(f, Id[A](), r)
+}
+
+// MapRightResult transforms the value type
+func MapRightResult[E, A, B any](g Morphism[A, B], r Result[E, A]) Result[E, B] {
+	return BimapResult(Id[E](), g, r)
+}
+
+// FoldLeft performs left fold over a slice
+func FoldLeft[A, B any](xs []A, z B, f func(B, A) B) B {
+	acc := z
+	for _, x := range xs {
+		acc = f(acc, x)
+	}
+	return acc
+}
+
+// FoldRight performs right fold over a slice
+func FoldRight[A, B any](xs []A, z B, f func(A, B) B) B {
+	acc := z
+	for i := len(xs) - 1; i >= 0; i-- {
+		acc = f(xs[i], acc)
+	}
+	return acc
+}
+
+// FoldMap maps and folds using a monoid
+func FoldMap[A, M any](xs []A, f Morphism[A, M], m Monoid[M]) M {
+	return FoldLeft(xs, m.Empty(), func(acc M, a A) M {
+		return m.Combine(acc, f(a))
+	})
+}
+
+// FMap applies a morphism to each element of a slice
+func FMap[A, B any](f Morphism[A, B], xs []A) []B {
+	result := make([]B, len(xs))
+	for i, x := range xs {
+		result[i] = f(x)
+	}
+	return result
+}
+
+// --- Utility Functions ---
+
+func lastNonZero[T comparable](a, b T) T {
+	var zero T
+	if b != zero {
+		return b
+	}
+	return a
+}
+
+func firstNonZero[T comparable](a, b T) T {
+	var zero T
+	if a != zero {
+		return a
+	}
+	return b
+}
+
+// --- Multi-Tenancy Context Helpers ---
+
+type tenantKey struct{}
+
+// WithTenantID adds tenant ID to context
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantIDFromContext extracts tenant ID from context
+func TenantIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok
+}
+
+// MustTenantID extracts tenant ID or panics
+func MustTenantID(ctx context.Context) string {
+	id, ok := TenantIDFromContext(ctx)
+	if !ok {
+		panic("tenant ID not in context")
+	}
+	return id
+}
+
+var (
+	_ = context.Background
+	_ = errors.New
+	_ = sync.Mutex{}
+	_ = time.Second
+	_ = errgroup.Group{}
+)
+
+// --- NetworkOp Effect Type ---
+
+// NetworkOp represents a network operation
+type NetworkOp[A any] func(ctx context.Context) (A, error)
+
+// PureNetworkOp lifts a pure value into the effect
+func PureNetworkOp[A any](a A) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) { return a, nil }
+}
+
+// FMapNetworkOp applies a morphism inside the effect
+func FMapNetworkOp[A, B any](f Morphism[A, B], fa NetworkOp[A]) NetworkOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// ApNetworkOp applies a wrapped function to a wrapped value
+func ApNetworkOp[A, B any](ff NetworkOp[Morphism[A, B]], fa NetworkOp[A]) NetworkOp[B] {
+	return func(ctx context.Context) (B, error) {
+		f, err := ff(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// Lift2NetworkOp lifts a binary function into the effect
+func Lift2NetworkOp[A, B, C any](f func(A, B) C, fa NetworkOp[A], fb NetworkOp[B]) NetworkOp[C] {
+	return func(ctx context.Context) (C, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		b, err := fb(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		return f(a, b), nil
+	}
+}
+
+// BindNetworkOp chains effectful computations
+func BindNetworkOp[A, B any](fa NetworkOp[A], f func(A) NetworkOp[B]) NetworkOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx)
+	}
+}
+
+// ComposeKleisliNetworkOp composes Kleisli arrows
+func ComposeKleisliNetworkOp[A, B, C any](f func(A) NetworkOp[B], g func(B) NetworkOp[C]) func(A) NetworkOp[C] {
+	return func(a A) NetworkOp[C] {
+		return BindNetworkOp(f(a), g)
+	}
+}
+
+// TraverseNetworkOp applies an effectful function to each element
+func TraverseNetworkOp[A, B any](xs []A, f func(A) NetworkOp[B]) NetworkOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		for i, x := range xs {
+			b, err := f(x)(ctx)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = b
+		}
+		return results, nil
+	}
+}
+
+// SequenceNetworkOp converts []Effect[A] to Effect[[]A]
+func SequenceNetworkOp[A any](xs []NetworkOp[A]) NetworkOp[[]A] {
+	return TraverseNetworkOp(xs, func(op NetworkOp[A]) NetworkOp[A] { return op })
+}
+
+// TraverseParallelNetworkOp applies an effectful function in parallel
+func TraverseParallelNetworkOp[A, B any](xs []A, f func(A) NetworkOp[B]) NetworkOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		g, ctx := errgroup.WithContext(ctx)
+		for i, x := range xs {
+			i, x := i, x
+			g.Go(func() error {
+				b, err := f(x)(ctx)
+				if err != nil {
+					return err
+				}
+				results[i] = b
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+}
+
+// SequenceParallelNetworkOp runs effects in parallel
+func SequenceParallelNetworkOp[A any](xs []NetworkOp[A]) NetworkOp[[]A] {
+	return TraverseParallelNetworkOp(xs, func(op NetworkOp[A]) NetworkOp[A] { return op })
+}
+
+// RetryNetworkOp retries an effect with exponential backoff
+func RetryNetworkOp[A any](op NetworkOp[A], maxAttempts int, baseDelay, maxDelay time.Duration) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var lastErr error
+		delay := baseDelay
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			result, err := op(ctx)
+			if err == nil {
+				return result, nil
+			}
+			lastErr = err
+			select {
+			case <-ctx.Done():
+				var zero A
+				return zero, ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxDelay)
+		}
+		var zero A
+		return zero, lastErr
+	}
+}
+
+// FallbackNetworkOp tries primary, falls back to secondary on error
+func FallbackNetworkOp[A any](primary, fallback NetworkOp[A]) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) {
+		result, err := primary(ctx)
+		if err == nil {
+			return result, nil
+		}
+		return fallback(ctx)
+	}
+}
+
+// TimeoutNetworkOp adds a timeout to an effect
+func TimeoutNetworkOp[A any](op NetworkOp[A], d time.Duration) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return op(ctx)
+	}
+}
+
+// --- DBOp Effect Type ---
+
+// DBOp represents a db operation
+type DBOp[A any] func(ctx context.Context) (A, error)
+
+// PureDBOp lifts a pure value into the effect
+func PureDBOp[A any](a A) DBOp[A] {
+	return func(ctx context.Context) (A, error) { return a, nil }
+}
+
+// FMapDBOp applies a morphism inside the effect
+func FMapDBOp[A, B any](f Morphism[A, B], fa DBOp[A]) DBOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// ApDBOp applies a wrapped function to a wrapped value
+func ApDBOp[A, B any](ff DBOp[Morphism[A, B]], fa DBOp[A]) DBOp[B] {
+	return func(ctx context.Context) (B, error) {
+		f, err := ff(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// Lift2DBOp lifts a binary function into the effect
+func Lift2DBOp[A, B, C any](f func(A, B) C, fa DBOp[A], fb DBOp[B]) DBOp[C] {
+	return func(ctx context.Context) (C, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		b, err := fb(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		return f(a, b), nil
+	}
+}
+
+// BindDBOp chains effectful computations
+func BindDBOp[A, B any](fa DBOp[A], f func(A) DBOp[B]) DBOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx)
+	}
+}
+
+// ComposeKleisliDBOp composes Kleisli arrows
+func ComposeKleisliDBOp[A, B, C any](f func(A) DBOp[B], g func(B) DBOp[C]) func(A) DBOp[C] {
+	return func(a A) DBOp[C] {
+		return BindDBOp(f(a), g)
+	}
+}
+
+// TraverseDBOp applies an effectful function to each element
+func TraverseDBOp[A, B any](xs []A, f func(A) DBOp[B]) DBOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		for i, x := range xs {
+			b, err := f(x)(ctx)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = b
+		}
+		return results, nil
+	}
+}
+
+// SequenceDBOp converts []Effect[A] to Effect[[]A]
+func SequenceDBOp[A any](xs []DBOp[A]) DBOp[[]A] {
+	return TraverseDBOp(xs, func(op DBOp[A]) DBOp[A] { return op })
+}
+
+// TraverseParallelDBOp applies an effectful function in parallel
+func TraverseParallelDBOp[A, B any](xs []A, f func(A) DBOp[B]) DBOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		g, ctx := errgroup.WithContext(ctx)
+		for i, x := range xs {
+			i, x := i, x
+			g.Go(func() error {
+				b, err := f(x)(ctx)
+				if err != nil {
+					return err
+				}
+				results[i] = b
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+}
+
+// SequenceParallelDBOp runs effects in parallel
+func SequenceParallelDBOp[A any](xs []DBOp[A]) DBOp[[]A] {
+	return TraverseParallelDBOp(xs, func(op DBOp[A]) DBOp[A] { return op })
+}
+
+// RetryDBOp retries an effect with exponential backoff
+func RetryDBOp[A any](op DBOp[A], maxAttempts int, baseDelay, maxDelay time.Duration) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var lastErr error
+		delay := baseDelay
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			result, err := op(ctx)
+			if err == nil {
+				return result, nil
+			}
+			lastErr = err
+			select {
+			case <-ctx.Done():
+				var zero A
+				return zero, ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxDelay)
+		}
+		var zero A
+		return zero, lastErr
+	}
+}
+
+// FallbackDBOp tries primary, falls back to secondary on error
+func FallbackDBOp[A any](primary, fallback DBOp[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		result, err := primary(ctx)
+		if err == nil {
+			return result, nil
+		}
+		return fallback(ctx)
+	}
+}
+
+// TimeoutDBOp adds a timeout to an effect
+func TimeoutDBOp[A any](op DBOp[A], d time.Duration) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return op(ctx)
+	}
+}
+
+// --- DiskOp Effect Type ---
+
+// DiskOp represents a disk operation
+type DiskOp[A any] func(ctx context.Context) (A, error)
+
+// PureDiskOp lifts a pure value into the effect
+func PureDiskOp[A any](a A) DiskOp[A] {
+	return func(ctx context.Context) (A, error) { return a, nil }
+}
+
+// FMapDiskOp applies a morphism inside the effect
+func FMapDiskOp[A, B any](f Morphism[A, B], fa DiskOp[A]) DiskOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// ApDiskOp applies a wrapped function to a wrapped value
+func ApDiskOp[A, B any](ff DiskOp[Morphism[A, B]], fa DiskOp[A]) DiskOp[B] {
+	return func(ctx context.Context) (B, error) {
+		f, err := ff(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// Lift2DiskOp lifts a binary function into the effect
+func Lift2DiskOp[A, B, C any](f func(A, B) C, fa DiskOp[A], fb DiskOp[B]) DiskOp[C] {
+	return func(ctx context.Context) (C, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		b, err := fb(ctx)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		return f(a, b), nil
+	}
+}
+
+// BindDiskOp chains effectful computations
+func BindDiskOp[A, B any](fa DiskOp[A], f func(A) DiskOp[B]) DiskOp[B] {
+	return func(ctx context.Context) (B, error) {
+		a, err := fa(ctx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx)
+	}
+}
+
+// ComposeKleisliDiskOp composes Kleisli arrows
+func ComposeKleisliDiskOp[A, B, C any](f func(A) DiskOp[B], g func(B) DiskOp[C]) func(A) DiskOp[C] {
+	return func(a A) DiskOp[C] {
+		return BindDiskOp(f(a), g)
+	}
+}
+
+// TraverseDiskOp applies an effectful function to each element
+func TraverseDiskOp[A, B any](xs []A, f func(A) DiskOp[B]) DiskOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		for i, x := range xs {
+			b, err := f(x)(ctx)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = b
+		}
+		return results, nil
+	}
+}
+
+// SequenceDiskOp converts []Effect[A] to Effect[[]A]
+func SequenceDiskOp[A any](xs []DiskOp[A]) DiskOp[[]A] {
+	return TraverseDiskOp(xs, func(op DiskOp[A]) DiskOp[A] { return op })
+}
+
+// TraverseParallelDiskOp applies an effectful function in parallel
+func TraverseParallelDiskOp[A, B any](xs []A, f func(A) DiskOp[B]) DiskOp[[]B] {
+	return func(ctx context.Context) ([]B, error) {
+		results := make([]B, len(xs))
+		g, ctx := errgroup.WithContext(ctx)
+		for i, x := range xs {
+			i, x := i, x
+			g.Go(func() error {
+				b, err := f(x)(ctx)
+				if err != nil {
+					return err
+				}
+				results[i] = b
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+}
+
+// SequenceParallelDiskOp runs effects in parallel
+func SequenceParallelDiskOp[A any](xs []DiskOp[A]) DiskOp[[]A] {
+	return TraverseParallelDiskOp(xs, func(op DiskOp[A]) DiskOp[A] { return op })
+}
+
+// RetryDiskOp retries an effect with exponential backoff
+func RetryDiskOp[A any](op DiskOp[A], maxAttempts int, baseDelay, maxDelay time.Duration) DiskOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var lastErr error
+		delay := baseDelay
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			result, err := op(ctx)
+			if err == nil {
+				return result, nil
+			}
+			lastErr = err
+			select {
+			case <-ctx.Done():
+				var zero A
+				return zero, ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxDelay)
+		}
+		var zero A
+		return zero, lastErr
+	}
+}
+
+// FallbackDiskOp tries primary, falls back to secondary on error
+func FallbackDiskOp[A any](primary, fallback DiskOp[A]) DiskOp[A] {
+	return func(ctx context.Context) (A, error) {
+		result, err := primary(ctx)
+		if err == nil {
+			return result, nil
+		}
+		return fallback(ctx)
+	}
+}
+
+// TimeoutDiskOp adds a timeout to an effect
+func TimeoutDiskOp[A any](op DiskOp[A], d time.Duration) DiskOp[A] {
+	return func(ctx context.Context) (A, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return op(ctx)
+	}
+}
+
+// --- Natural Transformations ---
+
+// LiftNetworkOpToDBOp is a natural transformation
+func LiftNetworkOpToDBOp[A any](fa NetworkOp[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// LiftNetworkOpToDiskOp is a natural transformation
+func LiftNetworkOpToDiskOp[A any](fa NetworkOp[A]) DiskOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// LiftDBOpToNetworkOp is a natural transformation
+func LiftDBOpToNetworkOp[A any](fa DBOp[A]) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// LiftDBOpToDiskOp is a natural transformation
+func LiftDBOpToDiskOp[A any](fa DBOp[A]) DiskOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// LiftDiskOpToNetworkOp is a natural transformation
+func LiftDiskOpToNetworkOp[A any](fa DiskOp[A]) NetworkOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// LiftDiskOpToDBOp is a natural transformation
+func LiftDiskOpToDBOp[A any](fa DiskOp[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		return fa(ctx)
+	}
+}
+
+// --- Stripe Core Types ---
+
+// Plan represents subscription plans
+type Plan string
+
+const (
+	PlanFree       Plan = "free"
+	PlanPro        Plan = "pro"
+	PlanEnterprise Plan = "enterprise"
+)
+
+// planOrder defines plan hierarchy for comparison
+var planOrder = map[Plan]int{
+	PlanFree:       0,
+	PlanPro:        1,
+	PlanEnterprise: 2,
+}
+
+// PlanAtLeast checks if plan meets minimum requirement
+func PlanAtLeast(current, minimum Plan) bool {
+	return planOrder[current] >= planOrder[minimum]
+}
+
+// PlanError indicates insufficient plan
+type PlanError struct {
+	Required Plan
+	Current  Plan
+}
+
+func (e *PlanError) Error() string {
+	return "requires plan " + string(e.Required) + ", current: " + string(e.Current)
+}
+
+type userIDKey struct{}
+
+// WithUserID adds user ID to context
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext extracts user ID from context
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+	return id, ok
+}
+
+// MustUserID extracts user ID or panics
+func MustUserID(ctx context.Context) string {
+	id, ok := UserIDFromContext(ctx)
+	if !ok {
+		panic("user ID not in context")
+	}
+	return id
+}
+
+// initStripe initializes Stripe API key from environment
+func initStripe() {
+	stripe.Key = os.Getenv("STRIPE_SECRET_KEY")
+}
+
+// --- User ---
+
+// UserSemigroup provides Combine for User
+var UserSemigroup = Semigroup[*User]{
+	Combine: func(a, b *User) *User {
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		return &User{
+			Id:               a.Id + b.Id,
+			Name:             a.Name + b.Name,
+			Email:            a.Email + b.Email,
+			Tags:             append(a.Tags, b.Tags...),
+			Score:            a.Score + b.Score,
+			Profile:          lastNonZero(a.Profile, b.Profile),
+			StripeCustomerId: a.StripeCustomerId + b.StripeCustomerId,
+		}
+	},
+}
+
+// UserMonoid provides Empty and Combine for User
+var UserMonoid = Monoid[*User]{
+	Semigroup: UserSemigroup,
+	Empty: func() *User {
+		return &User{}
+	},
+}
+
+// FMapUser applies a morphism to each User in a slice
+func FMapUser[B any](f Morphism[*User, B], xs []*User) []B {
+	return FMap(f, xs)
+}
+
+// FoldUser folds over a slice of User using a monoid
+func FoldUser(xs []*User, m Monoid[*User]) *User {
+	return FoldMap(xs, Id[*User](), m)
+}
+
+// FoldMapUser maps and folds over a slice of User
+func FoldMapUser[M any](xs []*User, f Morphism[*User, M], m Monoid[M]) M {
+	return FoldMap(xs, f, m)
+}
+
+// TraverseUserNetworkOp applies an effectful function to each User
+func TraverseUserNetworkOp[B any](xs []*User, f func(*User) NetworkOp[B]) NetworkOp[[]B] {
+	return TraverseNetworkOp(xs, f)
+}
+
+// TraverseParallelUserNetworkOp applies an effectful function in parallel
+func TraverseParallelUserNetworkOp[B any](xs []*User, f func(*User) NetworkOp[B]) NetworkOp[[]B] {
+	return TraverseParallelNetworkOp(xs, f)
+}
+
+// TraverseUserDBOp applies an effectful function to each User
+func TraverseUserDBOp[B any](xs []*User, f func(*User) DBOp[B]) DBOp[[]B] {
+	return TraverseDBOp(xs, f)
+}
+
+// TraverseParallelUserDBOp applies an effectful function in parallel
+func TraverseParallelUserDBOp[B any](xs []*User, f func(*User) DBOp[B]) DBOp[[]B] {
+	return TraverseParallelDBOp(xs, f)
+}
+
+// TraverseUserDiskOp applies an effectful function to each User
+func TraverseUserDiskOp[B any](xs []*User, f func(*User) DiskOp[B]) DiskOp[[]B] {
+	return TraverseDiskOp(xs, f)
+}
+
+// TraverseParallelUserDiskOp applies an effectful function in parallel
+func TraverseParallelUserDiskOp[B any](xs []*User, f func(*User) DiskOp[B]) DiskOp[[]B] {
+	return TraverseParallelDiskOp(xs, f)
+}
+
+// --- User Firestore Bridge ---
+
+// UserCollection provides Firestore operations for User
+type UserCollection struct {
+	client *firestore.Client
+	path   string
+	global bool
+}
+
+// NewUserCollection creates a new collection accessor
+func NewUserCollection(client *firestore.Client) *UserCollection {
+	return &UserCollection{client: client, path: "users", global: false}
+}
+
+// NewUserCollectionWithPath creates a collection accessor with custom path
+func NewUserCollectionWithPath(client *firestore.Client, path string) *UserCollection {
+	return &UserCollection{client: client, path: path, global: false}
+}
+
+// resolveCollectionRef resolves the collection path, scoping to tenant if present
+func (c *UserCollection) resolveCollectionRef(ctx context.Context) *firestore.CollectionRef {
+	if c.global {
+		return c.client.Collection(c.path)
+	}
+	if tenantID, ok := TenantIDFromContext(ctx); ok {
+		return c.client.Collection("tenants").Doc(tenantID).Collection(c.path)
+	}
+	return c.client.Collection(c.path)
+}
+
+// Doc returns a document reference
+func (c *UserCollection) Doc(id string) *UserDoc {
+	return &UserDoc{
+		id:   id,
+		coll: c,
+	}
+}
+
+// Ref returns the underlying Firestore collection reference (requires context for tenant scoping)
+func (c *UserCollection) Ref(ctx context.Context) *firestore.CollectionRef {
+	return c.resolveCollectionRef(ctx)
+}
+
+// UserDoc provides document operations
+type UserDoc struct {
+	id   string
+	coll *UserCollection
+}
+
+// resolveDocRef resolves the document path, scoping to tenant if present
+func (d *UserDoc) resolveDocRef(ctx context.Context) *firestore.DocumentRef {
+	return d.coll.resolveCollectionRef(ctx).Doc(d.id)
+}
+
+// Ref returns the underlying Firestore document reference (requires context for tenant scoping)
+func (d *UserDoc) Ref(ctx context.Context) *firestore.DocumentRef {
+	return d.resolveDocRef(ctx)
+}
+
+// Get retrieves the document as a DBOp
+func (d *UserDoc) Get() DBOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var result User
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// Exists checks if the document exists
+func (d *UserDoc) Exists() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return false, err
+		}
+		return snap.Exists(), nil
+	}
+}
+
+// Set creates or overwrites the document
+func (d *UserDoc) Set(data *User) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data)
+		return err == nil, err
+	}
+}
+
+// SetMerge merges data into the document
+func (d *UserDoc) SetMerge(data *User) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data, firestore.MergeAll)
+		return err == nil, err
+	}
+}
+
+// Create creates the document (fails if it already exists)
+func (d *UserDoc) Create(data *User) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Create(ctx, data)
+		return err == nil, err
+	}
+}
+
+// Update updates specific fields
+func (d *UserDoc) Update(updates []firestore.Update) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Update(ctx, updates)
+		return err == nil, err
+	}
+}
+
+// Delete removes the document
+func (d *UserDoc) Delete() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Delete(ctx)
+		return err == nil, err
+	}
+}
+
+// --- User Transaction Operations ---
+
+// UserTx represents a transactional operation on User
+type UserTx[A any] func(ctx context.Context, tx *firestore.Transaction) (A, error)
+
+// PureUserTx lifts a value into a transaction
+func PureUserTx[A any](a A) UserTx[A] {
+	return func(ctx context.Context, tx *firestore.Transaction) (A, error) {
+		return a, nil
+	}
+}
+
+// BindUserTx chains transactional operations
+func BindUserTx[A, B any](fa UserTx[A], f func(A) UserTx[B]) UserTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx, tx)
+	}
+}
+
+// FMapUserTx applies a function inside a transaction
+func FMapUserTx[A, B any](f func(A) B, fa UserTx[A]) UserTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// GetTx retrieves the document in a transaction
+func (d *UserDoc) GetTx() UserTx[*User] {
+	return func(ctx context.Context, tx *firestore.Transaction) (*User, error) {
+		snap, err := tx.Get(d.resolveDocRef(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var result User
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// SetTx sets the document in a transaction
+func (d *UserDoc) SetTx(data *User) UserTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Set(d.resolveDocRef(ctx), data)
+		return err == nil, err
+	}
+}
+
+// UpdateTx updates the document in a transaction
+func (d *UserDoc) UpdateTx(updates []firestore.Update) UserTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Update(d.resolveDocRef(ctx), updates)
+		return err == nil, err
+	}
+}
+
+// DeleteTx deletes the document in a transaction
+func (d *UserDoc) DeleteTx() UserTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Delete(d.resolveDocRef(ctx))
+		return err == nil, err
+	}
+}
+
+// RunUserTransaction executes a transaction and returns a DBOp
+func (c *UserCollection) RunTransaction(op UserTx[*User]) DBOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		var result *User
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// RunUserTransactionT executes a transaction with any result type
+func RunUserTransactionT[A any](c *UserCollection, op UserTx[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var result A
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// --- User Query Operations ---
+
+// UserQuery wraps a Firestore query
+type UserQuery struct {
+	query firestore.Query
+	coll  *UserCollection
+}
+
+// Where creates a filtered query
+func (c *UserCollection) Where(path, op string, value interface{}) *UserQuery {
+	return &UserQuery{
+		query: c.client.Collection(c.path).Where(path, op, value),
+		coll:  c,
+	}
+}
+
+// Where adds another filter to the query
+func (q *UserQuery) Where(path, op string, value interface{}) *UserQuery {
+	return &UserQuery{
+		query: q.query.Where(path, op, value),
+		coll:  q.coll,
+	}
+}
+
+// OrderBy orders results by a field
+func (q *UserQuery) OrderBy(path string, dir firestore.Direction) *UserQuery {
+	return &UserQuery{
+		query: q.query.OrderBy(path, dir),
+		coll:  q.coll,
+	}
+}
+
+// Limit limits the number of results
+func (q *UserQuery) Limit(n int) *UserQuery {
+	return &UserQuery{
+		query: q.query.Limit(n),
+		coll:  q.coll,
+	}
+}
+
+// Offset skips the first n results
+func (q *UserQuery) Offset(n int) *UserQuery {
+	return &UserQuery{
+		query: q.query.Offset(n),
+		coll:  q.coll,
+	}
+}
+
+// GetAll retrieves all matching documents
+func (q *UserQuery) GetAll() DBOp[[]*User] {
+	return func(ctx context.Context) ([]*User, error) {
+		iter := q.query.Documents(ctx)
+		defer iter.Stop()
+		var results []*User
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item User
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// First retrieves the first matching document
+func (q *UserQuery) First() DBOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		iter := q.query.Limit(1).Documents(ctx)
+		defer iter.Stop()
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item User
+		if err := snap.DataTo(&item); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}
+}
+
+// Count returns the number of matching documents
+func (q *UserQuery) Count() DBOp[int64] {
+	return func(ctx context.Context) (int64, error) {
+		agg, err := q.query.NewAggregationQuery().WithCount("count").Get(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count, ok := agg["count"]
+		if !ok {
+			return 0, nil
+		}
+		if v, ok := count.(*int64); ok {
+			return *v, nil
+		}
+		if v, ok := count.(int64); ok {
+			return v, nil
+		}
+		return 0, nil
+	}
+}
+
+// GetAll retrieves all documents in the collection
+func (c *UserCollection) GetAll() DBOp[[]*User] {
+	return func(ctx context.Context) ([]*User, error) {
+		iter := c.client.Collection(c.path).Documents(ctx)
+		defer iter.Stop()
+		var results []*User
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item User
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// --- User Batch Operations ---
+
+// BatchSet sets multiple documents in a batch
+func (c *UserCollection) BatchSet(items map[string]*User) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for id, item := range items {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Set(ref, item)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// BatchDelete deletes multiple documents in a batch
+func (c *UserCollection) BatchDelete(ids []string) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for _, id := range ids {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Delete(ref)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// GetMultiple retrieves multiple documents by ID
+func (c *UserCollection) GetMultiple(ids []string) DBOp[[]*User] {
+	return TraverseDBOp(ids, func(id string) DBOp[*User] {
+		return c.Doc(id).Get()
+	})
+}
+
+// GetMultipleParallel retrieves multiple documents in parallel
+func (c *UserCollection) GetMultipleParallel(ids []string) DBOp[[]*User] {
+	return TraverseParallelDBOp(ids, func(id string) DBOp[*User] {
+		return c.Doc(id).Get()
+	})
+}
+
+// --- User Subcollection Support ---
+
+// Subcollection returns a collection nested under a document (requires context for tenant scoping)
+func (d *UserDoc) Subcollection(ctx context.Context, name string) *firestore.CollectionRef {
+	return d.resolveDocRef(ctx).Collection(name)
+}
+
+// --- User Stripe Customer Operations ---
+
+// UserStripeOps provides Stripe customer operations for User
+type UserStripeOps struct {
+	collection *UserCollection
+}
+
+// NewUserStripeOps creates Stripe operations for User
+func NewUserStripeOps(collection *UserCollection) *UserStripeOps {
+	initStripe()
+	return &UserStripeOps{collection: collection}
+}
+
+// CreateCustomer creates a Stripe customer and links to User
+func (s *UserStripeOps) CreateCustomer(id string) NetworkOp[*User] {
+	return BindNetwork(
+		LiftDBOpToNetworkOp(s.collection.Doc(id).Get()),
+		func(entity *User) NetworkOp[*User] {
+			return func(ctx context.Context) (*User, error) {
+				params := &stripe.CustomerParams{
+					Email: stripe.String(entity.Email),
+					Metadata: map[string]string{
+						"id": id,
+					},
+				}
+				cust, err := customer.New(params)
+				if err != nil {
+					return nil, err
+				}
+				entity.StripeCustomerId = cust.ID
+				if _, err := s.collection.Doc(id).Set(entity)(ctx); err != nil {
+					return nil, err
+				}
+				return entity, nil
+			}
+		},
+	)
+}
+
+// GetOrCreateCustomer gets existing or creates new Stripe customer
+func (s *UserStripeOps) GetOrCreateCustomer(id string) NetworkOp[*User] {
+	return BindNetwork(
+		LiftDBOpToNetworkOp(s.collection.Doc(id).Get()),
+		func(entity *User) NetworkOp[*User] {
+			if entity.StripeCustomerId != "" {
+				return PureNetwork(entity)
+			}
+			return s.CreateCustomer(id)
+		},
+	)
+}
+
+// GetStripeCustomer retrieves the Stripe customer object
+func (s *UserStripeOps) GetStripeCustomer(id string) NetworkOp[*stripe.Customer] {
+	return BindNetwork(
+		LiftDBOpToNetworkOp(s.collection.Doc(id).Get()),
+		func(entity *User) NetworkOp[*stripe.Customer] {
+			return func(ctx context.Context) (*stripe.Customer, error) {
+				if entity.StripeCustomerId == "" {
+					return nil, errors.New("no stripe customer ID")
+				}
+				return customer.Get(entity.StripeCustomerId, nil)
+			}
+		},
+	)
+}
+
+// --- UserProfile ---
+
+// UserProfileSemigroup provides Combine for UserProfile
+var UserProfileSemigroup = Semigroup[*UserProfile]{
+	Combine: func(a, b *UserProfile) *UserProfile {
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		return &UserProfile{
+			Bio:       a.Bio + b.Bio,
+			AvatarUrl: a.AvatarUrl + b.AvatarUrl,
+			Followers: a.Followers + b.Followers,
+			Following: a.Following + b.Following,
+		}
+	},
+}
+
+// UserProfileMonoid provides Empty and Combine for UserProfile
+var UserProfileMonoid = Monoid[*UserProfile]{
+	Semigroup: UserProfileSemigroup,
+	Empty: func() *UserProfile {
+		return &UserProfile{}
+	},
+}
+
+// --- Order ---
+
+// OrderSemigroup provides Combine for Order
+var OrderSemigroup = Semigroup[*Order]{
+	Combine: func(a, b *Order) *Order {
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		return &Order{
+			Id:         a.Id + b.Id,
+			UserId:     a.UserId + b.UserId,
+			Items:      append(a.Items, b.Items...),
+			TotalCents: a.TotalCents + b.TotalCents,
+			Status:     lastNonZero(a.Status, b.Status),
+		}
+	},
+}
+
+// OrderMonoid provides Empty and Combine for Order
+var OrderMonoid = Monoid[*Order]{
+	Semigroup: OrderSemigroup,
+	Empty: func() *Order {
+		return &Order{}
+	},
+}
+
+// FoldOrder folds over a slice of Order using a monoid
+func FoldOrder(xs []*Order, m Monoid[*Order]) *Order {
+	return FoldMap(xs, Id[*Order](), m)
+}
+
+// FoldMapOrder maps and folds over a slice of Order
+func FoldMapOrder[M any](xs []*Order, f Morphism[*Order, M], m Monoid[M]) M {
+	return FoldMap(xs, f, m)
+}
+
+// --- LineItem ---
+
+// LineItemSemigroup provides Combine for LineItem
+var LineItemSemigroup = Semigroup[*LineItem]{
+	Combine: func(a, b *LineItem) *LineItem {
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		return &LineItem{
+			ProductId:  a.ProductId + b.ProductId,
+			Quantity:   a.Quantity + b.Quantity,
+			PriceCents: a.PriceCents + b.PriceCents,
+		}
+	},
+}
+
+// LineItemMonoid provides Empty and Combine for LineItem
+var LineItemMonoid = Monoid[*LineItem]{
+	Semigroup: LineItemSemigroup,
+	Empty: func() *LineItem {
+		return &LineItem{}
+	},
+}
+
+// --- ApiError ---
+
+// ApiErrorSemigroup provides Combine for ApiError
+var ApiErrorSemigroup = Semigroup[*ApiError]{
+	Combine: func(a, b *ApiError) *ApiError {
+		if a == nil {
+			return b
+		}
+		if b == nil {
+			return a
+		}
+		return &ApiError{
+			Code:     firstNonZero(a.Code, b.Code),
+			Messages: append(a.Messages, b.Messages...),
+			Details:  lastNonZero(a.Details, b.Details),
+		}
+	},
+}
+
+// --- SystemConfig ---
+
+// --- SystemConfig Firestore Bridge ---
+
+// SystemConfigCollection provides Firestore operations for SystemConfig
+type SystemConfigCollection struct {
+	client *firestore.Client
+	path   string
+	global bool
+}
+
+// NewSystemConfigCollection creates a new collection accessor
+func NewSystemConfigCollection(client *firestore.Client) *SystemConfigCollection {
+	return &SystemConfigCollection{client: client, path: "system_configs", global: true}
+}
+
+// NewSystemConfigCollectionWithPath creates a collection accessor with custom path
+func NewSystemConfigCollectionWithPath(client *firestore.Client, path string) *SystemConfigCollection {
+	return &SystemConfigCollection{client: client, path: path, global: true}
+}
+
+// resolveCollectionRef resolves the collection path, scoping to tenant if present
+func (c *SystemConfigCollection) resolveCollectionRef(ctx context.Context) *firestore.CollectionRef {
+	if c.global {
+		return c.client.Collection(c.path)
+	}
+	if tenantID, ok := TenantIDFromContext(ctx); ok {
+		return c.client.Collection("tenants").Doc(tenantID).Collection(c.path)
+	}
+	return c.client.Collection(c.path)
+}
+
+// Doc returns a document reference
+func (c *SystemConfigCollection) Doc(id string) *SystemConfigDoc {
+	return &SystemConfigDoc{
+		id:   id,
+		coll: c,
+	}
+}
+
+// Ref returns the underlying Firestore collection reference (requires context for tenant scoping)
+func (c *SystemConfigCollection) Ref(ctx context.Context) *firestore.CollectionRef {
+	return c.resolveCollectionRef(ctx)
+}
+
+// SystemConfigDoc provides document operations
+type SystemConfigDoc struct {
+	id   string
+	coll *SystemConfigCollection
+}
+
+// resolveDocRef resolves the document path, scoping to tenant if present
+func (d *SystemConfigDoc) resolveDocRef(ctx context.Context) *firestore.DocumentRef {
+	return d.coll.resolveCollectionRef(ctx).Doc(d.id)
+}
+
+// Ref returns the underlying Firestore document reference (requires context for tenant scoping)
+func (d *SystemConfigDoc) Ref(ctx context.Context) *firestore.DocumentRef {
+	return d.resolveDocRef(ctx)
+}
+
+// Get retrieves the document as a DBOp
+func (d *SystemConfigDoc) Get() DBOp[*SystemConfig] {
+	return func(ctx context.Context) (*SystemConfig, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var result SystemConfig
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// Exists checks if the document exists
+func (d *SystemConfigDoc) Exists() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return false, err
+		}
+		return snap.Exists(), nil
+	}
+}
+
+// Set creates or overwrites the document
+func (d *SystemConfigDoc) Set(data *SystemConfig) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data)
+		return err == nil, err
+	}
+}
+
+// SetMerge merges data into the document
+func (d *SystemConfigDoc) SetMerge(data *SystemConfig) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data, firestore.MergeAll)
+		return err == nil, err
+	}
+}
+
+// Create creates the document (fails if it already exists)
+func (d *SystemConfigDoc) Create(data *SystemConfig) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Create(ctx, data)
+		return err == nil, err
+	}
+}
+
+// Update updates specific fields
+func (d *SystemConfigDoc) Update(updates []firestore.Update) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Update(ctx, updates)
+		return err == nil, err
+	}
+}
+
+// Delete removes the document
+func (d *SystemConfigDoc) Delete() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Delete(ctx)
+		return err == nil, err
+	}
+}
+
+// --- SystemConfig Transaction Operations ---
+
+// SystemConfigTx represents a transactional operation on SystemConfig
+type SystemConfigTx[A any] func(ctx context.Context, tx *firestore.Transaction) (A, error)
+
+// PureSystemConfigTx lifts a value into a transaction
+func PureSystemConfigTx[A any](a A) SystemConfigTx[A] {
+	return func(ctx context.Context, tx *firestore.Transaction) (A, error) {
+		return a, nil
+	}
+}
+
+// BindSystemConfigTx chains transactional operations
+func BindSystemConfigTx[A, B any](fa SystemConfigTx[A], f func(A) SystemConfigTx[B]) SystemConfigTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx, tx)
+	}
+}
+
+// FMapSystemConfigTx applies a function inside a transaction
+func FMapSystemConfigTx[A, B any](f func(A) B, fa SystemConfigTx[A]) SystemConfigTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// GetTx retrieves the document in a transaction
+func (d *SystemConfigDoc) GetTx() SystemConfigTx[*SystemConfig] {
+	return func(ctx context.Context, tx *firestore.Transaction) (*SystemConfig, error) {
+		snap, err := tx.Get(d.resolveDocRef(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var result SystemConfig
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// SetTx sets the document in a transaction
+func (d *SystemConfigDoc) SetTx(data *SystemConfig) SystemConfigTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Set(d.resolveDocRef(ctx), data)
+		return err == nil, err
+	}
+}
+
+// UpdateTx updates the document in a transaction
+func (d *SystemConfigDoc) UpdateTx(updates []firestore.Update) SystemConfigTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Update(d.resolveDocRef(ctx), updates)
+		return err == nil, err
+	}
+}
+
+// DeleteTx deletes the document in a transaction
+func (d *SystemConfigDoc) DeleteTx() SystemConfigTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Delete(d.resolveDocRef(ctx))
+		return err == nil, err
+	}
+}
+
+// RunSystemConfigTransaction executes a transaction and returns a DBOp
+func (c *SystemConfigCollection) RunTransaction(op SystemConfigTx[*SystemConfig]) DBOp[*SystemConfig] {
+	return func(ctx context.Context) (*SystemConfig, error) {
+		var result *SystemConfig
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// RunSystemConfigTransactionT executes a transaction with any result type
+func RunSystemConfigTransactionT[A any](c *SystemConfigCollection, op SystemConfigTx[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var result A
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// --- SystemConfig Query Operations ---
+
+// SystemConfigQuery wraps a Firestore query
+type SystemConfigQuery struct {
+	query firestore.Query
+	coll  *SystemConfigCollection
+}
+
+// Where creates a filtered query
+func (c *SystemConfigCollection) Where(path, op string, value interface{}) *SystemConfigQuery {
+	return &SystemConfigQuery{
+		query: c.client.Collection(c.path).Where(path, op, value),
+		coll:  c,
+	}
+}
+
+// Where adds another filter to the query
+func (q *SystemConfigQuery) Where(path, op string, value interface{}) *SystemConfigQuery {
+	return &SystemConfigQuery{
+		query: q.query.Where(path, op, value),
+		coll:  q.coll,
+	}
+}
+
+// OrderBy orders results by a field
+func (q *SystemConfigQuery) OrderBy(path string, dir firestore.Direction) *SystemConfigQuery {
+	return &SystemConfigQuery{
+		query: q.query.OrderBy(path, dir),
+		coll:  q.coll,
+	}
+}
+
+// Limit limits the number of results
+func (q *SystemConfigQuery) Limit(n int) *SystemConfigQuery {
+	return &SystemConfigQuery{
+		query: q.query.Limit(n),
+		coll:  q.coll,
+	}
+}
+
+// Offset skips the first n results
+func (q *SystemConfigQuery) Offset(n int) *SystemConfigQuery {
+	return &SystemConfigQuery{
+		query: q.query.Offset(n),
+		coll:  q.coll,
+	}
+}
+
+// GetAll retrieves all matching documents
+func (q *SystemConfigQuery) GetAll() DBOp[[]*SystemConfig] {
+	return func(ctx context.Context) ([]*SystemConfig, error) {
+		iter := q.query.Documents(ctx)
+		defer iter.Stop()
+		var results []*SystemConfig
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item SystemConfig
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// First retrieves the first matching document
+func (q *SystemConfigQuery) First() DBOp[*SystemConfig] {
+	return func(ctx context.Context) (*SystemConfig, error) {
+		iter := q.query.Limit(1).Documents(ctx)
+		defer iter.Stop()
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item SystemConfig
+		if err := snap.DataTo(&item); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}
+}
+
+// Count returns the number of matching documents
+func (q *SystemConfigQuery) Count() DBOp[int64] {
+	return func(ctx context.Context) (int64, error) {
+		agg, err := q.query.NewAggregationQuery().WithCount("count").Get(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count, ok := agg["count"]
+		if !ok {
+			return 0, nil
+		}
+		if v, ok := count.(*int64); ok {
+			return *v, nil
+		}
+		if v, ok := count.(int64); ok {
+			return v, nil
+		}
+		return 0, nil
+	}
+}
+
+// GetAll retrieves all documents in the collection
+func (c *SystemConfigCollection) GetAll() DBOp[[]*SystemConfig] {
+	return func(ctx context.Context) ([]*SystemConfig, error) {
+		iter := c.client.Collection(c.path).Documents(ctx)
+		defer iter.Stop()
+		var results []*SystemConfig
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item SystemConfig
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// --- SystemConfig Batch Operations ---
+
+// BatchSet sets multiple documents in a batch
+func (c *SystemConfigCollection) BatchSet(items map[string]*SystemConfig) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for id, item := range items {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Set(ref, item)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// BatchDelete deletes multiple documents in a batch
+func (c *SystemConfigCollection) BatchDelete(ids []string) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for _, id := range ids {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Delete(ref)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// GetMultiple retrieves multiple documents by ID
+func (c *SystemConfigCollection) GetMultiple(ids []string) DBOp[[]*SystemConfig] {
+	return TraverseDBOp(ids, func(id string) DBOp[*SystemConfig] {
+		return c.Doc(id).Get()
+	})
+}
+
+// GetMultipleParallel retrieves multiple documents in parallel
+func (c *SystemConfigCollection) GetMultipleParallel(ids []string) DBOp[[]*SystemConfig] {
+	return TraverseParallelDBOp(ids, func(id string) DBOp[*SystemConfig] {
+		return c.Doc(id).Get()
+	})
+}
+
+// --- SystemConfig Subcollection Support ---
+
+// Subcollection returns a collection nested under a document (requires context for tenant scoping)
+func (d *SystemConfigDoc) Subcollection(ctx context.Context, name string) *firestore.CollectionRef {
+	return d.resolveDocRef(ctx).Collection(name)
+}
+
+// --- Subscription ---
+
+// --- Subscription Firestore Bridge ---
+
+// SubscriptionCollection provides Firestore operations for Subscription
+type SubscriptionCollection struct {
+	client *firestore.Client
+	path   string
+	global bool
+}
+
+// NewSubscriptionCollection creates a new collection accessor
+func NewSubscriptionCollection(client *firestore.Client) *SubscriptionCollection {
+	return &SubscriptionCollection{client: client, path: "subscriptions", global: false}
+}
+
+// NewSubscriptionCollectionWithPath creates a collection accessor with custom path
+func NewSubscriptionCollectionWithPath(client *firestore.Client, path string) *SubscriptionCollection {
+	return &SubscriptionCollection{client: client, path: path, global: false}
+}
+
+// resolveCollectionRef resolves the collection path, scoping to tenant if present
+func (c *SubscriptionCollection) resolveCollectionRef(ctx context.Context) *firestore.CollectionRef {
+	if c.global {
+		return c.client.Collection(c.path)
+	}
+	if tenantID, ok := TenantIDFromContext(ctx); ok {
+		return c.client.Collection("tenants").Doc(tenantID).Collection(c.path)
+	}
+	return c.client.Collection(c.path)
+}
+
+// Doc returns a document reference
+func (c *SubscriptionCollection) Doc(id string) *SubscriptionDoc {
+	return &SubscriptionDoc{
+		id:   id,
+		coll: c,
+	}
+}
+
+// Ref returns the underlying Firestore collection reference (requires context for tenant scoping)
+func (c *SubscriptionCollection) Ref(ctx context.Context) *firestore.CollectionRef {
+	return c.resolveCollectionRef(ctx)
+}
+
+// SubscriptionDoc provides document operations
+type SubscriptionDoc struct {
+	id   string
+	coll *SubscriptionCollection
+}
+
+// resolveDocRef resolves the document path, scoping to tenant if present
+func (d *SubscriptionDoc) resolveDocRef(ctx context.Context) *firestore.DocumentRef {
+	return d.coll.resolveCollectionRef(ctx).Doc(d.id)
+}
+
+// Ref returns the underlying Firestore document reference (requires context for tenant scoping)
+func (d *SubscriptionDoc) Ref(ctx context.Context) *firestore.DocumentRef {
+	return d.resolveDocRef(ctx)
+}
+
+// Get retrieves the document as a DBOp
+func (d *SubscriptionDoc) Get() DBOp[*Subscription] {
+	return func(ctx context.Context) (*Subscription, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var result Subscription
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// Exists checks if the document exists
+func (d *SubscriptionDoc) Exists() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		snap, err := d.resolveDocRef(ctx).Get(ctx)
+		if err != nil {
+			return false, err
+		}
+		return snap.Exists(), nil
+	}
+}
+
+// Set creates or overwrites the document
+func (d *SubscriptionDoc) Set(data *Subscription) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data)
+		return err == nil, err
+	}
+}
+
+// SetMerge merges data into the document
+func (d *SubscriptionDoc) SetMerge(data *Subscription) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Set(ctx, data, firestore.MergeAll)
+		return err == nil, err
+	}
+}
+
+// Create creates the document (fails if it already exists)
+func (d *SubscriptionDoc) Create(data *Subscription) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Create(ctx, data)
+		return err == nil, err
+	}
+}
+
+// Update updates specific fields
+func (d *SubscriptionDoc) Update(updates []firestore.Update) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Update(ctx, updates)
+		return err == nil, err
+	}
+}
+
+// Delete removes the document
+func (d *SubscriptionDoc) Delete() DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		_, err := d.resolveDocRef(ctx).Delete(ctx)
+		return err == nil, err
+	}
+}
+
+// --- Subscription Transaction Operations ---
+
+// SubscriptionTx represents a transactional operation on Subscription
+type SubscriptionTx[A any] func(ctx context.Context, tx *firestore.Transaction) (A, error)
+
+// PureSubscriptionTx lifts a value into a transaction
+func PureSubscriptionTx[A any](a A) SubscriptionTx[A] {
+	return func(ctx context.Context, tx *firestore.Transaction) (A, error) {
+		return a, nil
+	}
+}
+
+// BindSubscriptionTx chains transactional operations
+func BindSubscriptionTx[A, B any](fa SubscriptionTx[A], f func(A) SubscriptionTx[B]) SubscriptionTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a)(ctx, tx)
+	}
+}
+
+// FMapSubscriptionTx applies a function inside a transaction
+func FMapSubscriptionTx[A, B any](f func(A) B, fa SubscriptionTx[A]) SubscriptionTx[B] {
+	return func(ctx context.Context, tx *firestore.Transaction) (B, error) {
+		a, err := fa(ctx, tx)
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return f(a), nil
+	}
+}
+
+// GetTx retrieves the document in a transaction
+func (d *SubscriptionDoc) GetTx() SubscriptionTx[*Subscription] {
+	return func(ctx context.Context, tx *firestore.Transaction) (*Subscription, error) {
+		snap, err := tx.Get(d.resolveDocRef(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var result Subscription
+		if err := snap.DataTo(&result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// SetTx sets the document in a transaction
+func (d *SubscriptionDoc) SetTx(data *Subscription) SubscriptionTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Set(d.resolveDocRef(ctx), data)
+		return err == nil, err
+	}
+}
+
+// UpdateTx updates the document in a transaction
+func (d *SubscriptionDoc) UpdateTx(updates []firestore.Update) SubscriptionTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Update(d.resolveDocRef(ctx), updates)
+		return err == nil, err
+	}
+}
+
+// DeleteTx deletes the document in a transaction
+func (d *SubscriptionDoc) DeleteTx() SubscriptionTx[bool] {
+	return func(ctx context.Context, tx *firestore.Transaction) (bool, error) {
+		err := tx.Delete(d.resolveDocRef(ctx))
+		return err == nil, err
+	}
+}
+
+// RunSubscriptionTransaction executes a transaction and returns a DBOp
+func (c *SubscriptionCollection) RunTransaction(op SubscriptionTx[*Subscription]) DBOp[*Subscription] {
+	return func(ctx context.Context) (*Subscription, error) {
+		var result *Subscription
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// RunSubscriptionTransactionT executes a transaction with any result type
+func RunSubscriptionTransactionT[A any](c *SubscriptionCollection, op SubscriptionTx[A]) DBOp[A] {
+	return func(ctx context.Context) (A, error) {
+		var result A
+		err := c.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			var txErr error
+			result, txErr = op(ctx, tx)
+			return txErr
+		})
+		return result, err
+	}
+}
+
+// --- Subscription Query Operations ---
+
+// SubscriptionQuery wraps a Firestore query
+type SubscriptionQuery struct {
+	query firestore.Query
+	coll  *SubscriptionCollection
+}
+
+// Where creates a filtered query
+func (c *SubscriptionCollection) Where(path, op string, value interface{}) *SubscriptionQuery {
+	return &SubscriptionQuery{
+		query: c.client.Collection(c.path).Where(path, op, value),
+		coll:  c,
+	}
+}
+
+// Where adds another filter to the query
+func (q *SubscriptionQuery) Where(path, op string, value interface{}) *SubscriptionQuery {
+	return &SubscriptionQuery{
+		query: q.query.Where(path, op, value),
+		coll:  q.coll,
+	}
+}
+
+// OrderBy orders results by a field
+func (q *SubscriptionQuery) OrderBy(path string, dir firestore.Direction) *SubscriptionQuery {
+	return &SubscriptionQuery{
+		query: q.query.OrderBy(path, dir),
+		coll:  q.coll,
+	}
+}
+
+// Limit limits the number of results
+func (q *SubscriptionQuery) Limit(n int) *SubscriptionQuery {
+	return &SubscriptionQuery{
+		query: q.query.Limit(n),
+		coll:  q.coll,
+	}
+}
+
+// Offset skips the first n results
+func (q *SubscriptionQuery) Offset(n int) *SubscriptionQuery {
+	return &SubscriptionQuery{
+		query: q.query.Offset(n),
+		coll:  q.coll,
+	}
+}
+
+// GetAll retrieves all matching documents
+func (q *SubscriptionQuery) GetAll() DBOp[[]*Subscription] {
+	return func(ctx context.Context) ([]*Subscription, error) {
+		iter := q.query.Documents(ctx)
+		defer iter.Stop()
+		var results []*Subscription
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item Subscription
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// First retrieves the first matching document
+func (q *SubscriptionQuery) First() DBOp[*Subscription] {
+	return func(ctx context.Context) (*Subscription, error) {
+		iter := q.query.Limit(1).Documents(ctx)
+		defer iter.Stop()
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var item Subscription
+		if err := snap.DataTo(&item); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	}
+}
+
+// Count returns the number of matching documents
+func (q *SubscriptionQuery) Count() DBOp[int64] {
+	return func(ctx context.Context) (int64, error) {
+		agg, err := q.query.NewAggregationQuery().WithCount("count").Get(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count, ok := agg["count"]
+		if !ok {
+			return 0, nil
+		}
+		if v, ok := count.(*int64); ok {
+			return *v, nil
+		}
+		if v, ok := count.(int64); ok {
+			return v, nil
+		}
+		return 0, nil
+	}
+}
+
+// GetAll retrieves all documents in the collection
+func (c *SubscriptionCollection) GetAll() DBOp[[]*Subscription] {
+	return func(ctx context.Context) ([]*Subscription, error) {
+		iter := c.client.Collection(c.path).Documents(ctx)
+		defer iter.Stop()
+		var results []*Subscription
+		for {
+			snap, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			var item Subscription
+			if err := snap.DataTo(&item); err != nil {
+				return nil, err
+			}
+			results = append(results, &item)
+		}
+		return results, nil
+	}
+}
+
+// --- Subscription Batch Operations ---
+
+// BatchSet sets multiple documents in a batch
+func (c *SubscriptionCollection) BatchSet(items map[string]*Subscription) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for id, item := range items {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Set(ref, item)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// BatchDelete deletes multiple documents in a batch
+func (c *SubscriptionCollection) BatchDelete(ids []string) DBOp[bool] {
+	return func(ctx context.Context) (bool, error) {
+		batch := c.client.Batch()
+		for _, id := range ids {
+			ref := c.client.Collection(c.path).Doc(id)
+			batch.Delete(ref)
+		}
+		_, err := batch.Commit(ctx)
+		return err == nil, err
+	}
+}
+
+// GetMultiple retrieves multiple documents by ID
+func (c *SubscriptionCollection) GetMultiple(ids []string) DBOp[[]*Subscription] {
+	return TraverseDBOp(ids, func(id string) DBOp[*Subscription] {
+		return c.Doc(id).Get()
+	})
+}
+
+// GetMultipleParallel retrieves multiple documents in parallel
+func (c *SubscriptionCollection) GetMultipleParallel(ids []string) DBOp[[]*Subscription] {
+	return TraverseParallelDBOp(ids, func(id string) DBOp[*Subscription] {
+		return c.Doc(id).Get()
+	})
+}
+
+// --- Subscription Subcollection Support ---
+
+// Subcollection returns a collection nested under a document (requires context for tenant scoping)
+func (d *SubscriptionDoc) Subcollection(ctx context.Context, name string) *firestore.CollectionRef {
+	return d.resolveDocRef(ctx).Collection(name)
+}
+
+// --- Subscription Stripe Subscription Operations ---
+
+// SubscriptionStripeOps provides Stripe subscription operations
+type SubscriptionStripeSubOps struct {
+	collection *SubscriptionCollection
+	priceIDs   map[Plan]string
+}
+
+// NewSubscriptionStripeSubOps creates subscription operations
+func NewSubscriptionStripeSubOps(collection *SubscriptionCollection, priceIDs map[Plan]string) *SubscriptionStripeSubOps {
+	initStripe()
+	return &SubscriptionStripeSubOps{collection: collection, priceIDs: priceIDs}
+}
+
+// Subscribe creates a new subscription
+func (s *SubscriptionStripeSubOps) Subscribe(customerID string, plan Plan, paymentMethodID string) NetworkOp[*Subscription] {
+	return func(ctx context.Context) (*Subscription, error) {
+		priceID, ok := s.priceIDs[plan]
+		if !ok {
+			return nil, errors.New("unknown plan: " + string(plan))
+		}
+
+		params := &stripe.SubscriptionParams{
+			Customer: stripe.String(customerID),
+			Items: []*stripe.SubscriptionItemsParams{
+				{Price: stripe.String(priceID)},
+			},
+			DefaultPaymentMethod: stripe.String(paymentMethodID),
+		}
+
+		sub, err := subscription.New(params)
+		if err != nil {
+			return nil, err
+		}
+
+		userID, _ := UserIDFromContext(ctx)
+		entity := &Subscription{
+			StripeSubscriptionId: sub.ID,
+			UserId:               userID,
+		}
+
+		if _, err := s.collection.Doc(sub.ID).Set(entity)(ctx); err != nil {
+			return nil, err
+		}
+
+		return entity, nil
+	}
+}
+
+// Cancel cancels subscription at period end
+func (s *SubscriptionStripeSubOps) Cancel(subscriptionID string) NetworkOp[*Subscription] {
+	return func(ctx context.Context) (*Subscription, error) {
+		params := &stripe.SubscriptionParams{
+			CancelAtPeriodEnd: stripe.Bool(true),
+		}
+		_, err := subscription.Update(subscriptionID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.collection.Doc(subscriptionID).Get()(ctx)
+	}
+}
+
+// GetByUserID finds active subscription for user
+func (s *SubscriptionStripeSubOps) GetByUserID(userID string) DBOp[*Subscription] {
+	return s.collection.Where("user_id", "==", userID).First()
+}
+
+// NewSubscriptionWebhookHandler creates HTTP handler for Stripe webhooks
+func NewSubscriptionWebhookHandler(collection *SubscriptionCollection, webhookSecret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		event, err := webhook.ConstructEvent(payload, r.Header.Get("Stripe-Signature"), webhookSecret)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+
+		switch event.Type {
+		case "customer.subscription.created", "customer.subscription.updated":
+			var sub stripe.Subscription
+			if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			entity, _ := collection.Doc(sub.ID).Get()(ctx)
+			if entity == nil {
+				entity = &Subscription{StripeSubscriptionId: sub.ID}
+			}
+			collection.Doc(sub.ID).Set(entity)(ctx)
+
+		case "customer.subscription.deleted":
+			var sub stripe.Subscription
+			if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			collection.Doc(sub.ID).Delete()(ctx)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// --- UserService ---
+
+// UserServiceK represents the service as Kleisli arrows
+type UserServiceK struct {
+	GetUser    func(*GetUserRequest) NetworkOp[*User]
+	ListUsers  func(*ListUsersRequest) NetworkOp[*ListUsersResponse]
+	CreateUser func(*CreateUserRequest) NetworkOp[*User]
+	UpdateUser func(*UpdateUserRequest) NetworkOp[*User]
+	DeleteUser func(*DeleteUserRequest) NetworkOp[*DeleteUserResponse]
+}
+
+// UserServiceGetUserAndThen chains GetUser with another effectful function
+func UserServiceGetUserAndThen[B any](s *UserServiceK, f func(*User) NetworkOp[B]) func(*GetUserRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.GetUser, f)
+}
+
+// UserServiceListUsersAndThen chains ListUsers with another effectful function
+func UserServiceListUsersAndThen[B any](s *UserServiceK, f func(*ListUsersResponse) NetworkOp[B]) func(*ListUsersRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.ListUsers, f)
+}
+
+// UserServiceCreateUserAndThen chains CreateUser with another effectful function
+func UserServiceCreateUserAndThen[B any](s *UserServiceK, f func(*User) NetworkOp[B]) func(*CreateUserRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.CreateUser, f)
+}
+
+// UserServiceUpdateUserAndThen chains UpdateUser with another effectful function
+func UserServiceUpdateUserAndThen[B any](s *UserServiceK, f func(*User) NetworkOp[B]) func(*UpdateUserRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.UpdateUser, f)
+}
+
+// UserServiceDeleteUserAndThen chains DeleteUser with another effectful function
+func UserServiceDeleteUserAndThen[B any](s *UserServiceK, f func(*DeleteUserResponse) NetworkOp[B]) func(*DeleteUserRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.DeleteUser, f)
+}
+
+// UserServiceMiddleware wraps service methods
+type UserServiceMiddleware struct {
+	GetUser    func(func(*GetUserRequest) NetworkOp[*User]) func(*GetUserRequest) NetworkOp[*User]
+	ListUsers  func(func(*ListUsersRequest) NetworkOp[*ListUsersResponse]) func(*ListUsersRequest) NetworkOp[*ListUsersResponse]
+	CreateUser func(func(*CreateUserRequest) NetworkOp[*User]) func(*CreateUserRequest) NetworkOp[*User]
+	UpdateUser func(func(*UpdateUserRequest) NetworkOp[*User]) func(*UpdateUserRequest) NetworkOp[*User]
+	DeleteUser func(func(*DeleteUserRequest) NetworkOp[*DeleteUserResponse]) func(*DeleteUserRequest) NetworkOp[*DeleteUserResponse]
+}
+
+// ApplyUserServiceMiddleware applies middleware to a service
+func ApplyUserServiceMiddleware(s *UserServiceK, mw *UserServiceMiddleware) *UserServiceK {
+	result := &UserServiceK{}
+	if mw.GetUser != nil {
+		result.GetUser = mw.GetUser(s.GetUser)
+	} else {
+		result.GetUser = s.GetUser
+	}
+	if mw.ListUsers != nil {
+		result.ListUsers = mw.ListUsers(s.ListUsers)
+	} else {
+		result.ListUsers = s.ListUsers
+	}
+	if mw.CreateUser != nil {
+		result.CreateUser = mw.CreateUser(s.CreateUser)
+	} else {
+		result.CreateUser = s.CreateUser
+	}
+	if mw.UpdateUser != nil {
+		result.UpdateUser = mw.UpdateUser(s.UpdateUser)
+	} else {
+		result.UpdateUser = s.UpdateUser
+	}
+	if mw.DeleteUser != nil {
+		result.DeleteUser = mw.DeleteUser(s.DeleteUser)
+	} else {
+		result.DeleteUser = s.DeleteUser
+	}
+	return result
+}
+
+// ParallelGetUser executes multiple requests in parallel
+func (s *UserServiceK) ParallelGetUser(reqs []*GetUserRequest) NetworkOp[[]*User] {
+	return TraverseParallelNetworkOp(reqs, s.GetUser)
+}
+
+// ParallelListUsers executes multiple requests in parallel
+func (s *UserServiceK) ParallelListUsers(reqs []*ListUsersRequest) NetworkOp[[]*ListUsersResponse] {
+	return TraverseParallelNetworkOp(reqs, s.ListUsers)
+}
+
+// ParallelCreateUser executes multiple requests in parallel
+func (s *UserServiceK) ParallelCreateUser(reqs []*CreateUserRequest) NetworkOp[[]*User] {
+	return TraverseParallelNetworkOp(reqs, s.CreateUser)
+}
+
+// ParallelUpdateUser executes multiple requests in parallel
+func (s *UserServiceK) ParallelUpdateUser(reqs []*UpdateUserRequest) NetworkOp[[]*User] {
+	return TraverseParallelNetworkOp(reqs, s.UpdateUser)
+}
+
+// ParallelDeleteUser executes multiple requests in parallel
+func (s *UserServiceK) ParallelDeleteUser(reqs []*DeleteUserRequest) NetworkOp[[]*DeleteUserResponse] {
+	return TraverseParallelNetworkOp(reqs, s.DeleteUser)
+}
+
+// FanoutGetUser calls multiple services and combines results
+func FanoutGetUser(services []*UserServiceK, combine Monoid[*User]) func(*GetUserRequest) NetworkOp[*User] {
+	return func(req *GetUserRequest) NetworkOp[*User] {
+		ops := make([]NetworkOp[*User], len(services))
+		for i, svc := range services {
+			ops[i] = svc.GetUser(req)
+		}
+		return FMapNetworkOp(
+			func(results []*User) *User {
+				return FoldMap(results, Id[*User](), combine)
+			},
+			SequenceParallelNetworkOp(ops),
+		)
+	}
+}
+
+// FanoutListUsers calls multiple services and combines results
+func FanoutListUsers(services []*UserServiceK, combine Monoid[*ListUsersResponse]) func(*ListUsersRequest) NetworkOp[*ListUsersResponse] {
+	return func(req *ListUsersRequest) NetworkOp[*ListUsersResponse] {
+		ops := make([]NetworkOp[*ListUsersResponse], len(services))
+		for i, svc := range services {
+			ops[i] = svc.ListUsers(req)
+		}
+		return FMapNetworkOp(
+			func(results []*ListUsersResponse) *ListUsersResponse {
+				return FoldMap(results, Id[*ListUsersResponse](), combine)
+			},
+			SequenceParallelNetworkOp(ops),
+		)
+	}
+}
+
+// FanoutCreateUser calls multiple services and combines results
+func FanoutCreateUser(services []*UserServiceK, combine Monoid[*User]) func(*CreateUserRequest) NetworkOp[*User] {
+	return func(req *CreateUserRequest) NetworkOp[*User] {
+		ops := make([]NetworkOp[*User], len(services))
+		for i, svc := range services {
+			ops[i] = svc.CreateUser(req)
+		}
+		return FMapNetworkOp(
+			func(results []*User) *User {
+				return FoldMap(results, Id[*User](), combine)
+			},
+			SequenceParallelNetworkOp(ops),
+		)
+	}
+}
+
+// FanoutUpdateUser calls multiple services and combines results
+func FanoutUpdateUser(services []*UserServiceK, combine Monoid[*User]) func(*UpdateUserRequest) NetworkOp[*User] {
+	return func(req *UpdateUserRequest) NetworkOp[*User] {
+		ops := make([]NetworkOp[*User], len(services))
+		for i, svc := range services {
+			ops[i] = svc.UpdateUser(req)
+		}
+		return FMapNetworkOp(
+			func(results []*User) *User {
+				return FoldMap(results, Id[*User](), combine)
+			},
+			SequenceParallelNetworkOp(ops),
+		)
+	}
+}
+
+// FanoutDeleteUser calls multiple services and combines results
+func FanoutDeleteUser(services []*UserServiceK, combine Monoid[*DeleteUserResponse]) func(*DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+	return func(req *DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+		ops := make([]NetworkOp[*DeleteUserResponse], len(services))
+		for i, svc := range services {
+			ops[i] = svc.DeleteUser(req)
+		}
+		return FMapNetworkOp(
+			func(results []*DeleteUserResponse) *DeleteUserResponse {
+				return FoldMap(results, Id[*DeleteUserResponse](), combine)
+			},
+			SequenceParallelNetworkOp(ops),
+		)
+	}
+}
+
+// UserServiceCircuitBreaker wraps the service with circuit breakers
+type UserServiceCircuitBreaker struct {
+	service      *UserServiceK
+	getUserCB    *circuitBreaker
+	listUsersCB  *circuitBreaker
+	createUserCB *circuitBreaker
+	updateUserCB *circuitBreaker
+	deleteUserCB *circuitBreaker
+}
+
+type circuitBreaker struct {
+	mu          sync.Mutex
+	failures    int
+	threshold   int
+	resetAfter  time.Duration
+	lastFailure time.Time
+	state       string
+}
+
+func newCircuitBreaker(threshold int, resetAfter time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:  threshold,
+		resetAfter: resetAfter,
+		state:      "closed",
+	}
+}
+
+// NewUserServiceCircuitBreaker creates a circuit breaker wrapper
+func NewUserServiceCircuitBreaker(s *UserServiceK, threshold int, resetAfter time.Duration) *UserServiceCircuitBreaker {
+	return &UserServiceCircuitBreaker{
+		service:      s,
+		getUserCB:    newCircuitBreaker(threshold, resetAfter),
+		listUsersCB:  newCircuitBreaker(threshold, resetAfter),
+		createUserCB: newCircuitBreaker(threshold, resetAfter),
+		updateUserCB: newCircuitBreaker(threshold, resetAfter),
+		deleteUserCB: newCircuitBreaker(threshold, resetAfter),
+	}
+}
+
+// GetUser calls the service with circuit breaker protection
+func (cb *UserServiceCircuitBreaker) GetUser(req *GetUserRequest) NetworkOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		cb.getUserCB.mu.Lock()
+		if cb.getUserCB.state == "open" {
+			if time.Since(cb.getUserCB.lastFailure) > cb.getUserCB.resetAfter {
+				cb.getUserCB.state = "half-open"
+			} else {
+				cb.getUserCB.mu.Unlock()
+				return nil, errors.New("circuit open")
+			}
+		}
+		cb.getUserCB.mu.Unlock()
+
+		result, err := cb.service.GetUser(req)(ctx)
+
+		cb.getUserCB.mu.Lock()
+		defer cb.getUserCB.mu.Unlock()
+		if err != nil {
+			cb.getUserCB.failures++
+			cb.getUserCB.lastFailure = time.Now()
+			if cb.getUserCB.failures >= cb.getUserCB.threshold {
+				cb.getUserCB.state = "open"
+			}
+			return result, err
+		}
+		cb.getUserCB.failures = 0
+		cb.getUserCB.state = "closed"
+		return result, nil
+	}
+}
+
+// ListUsers calls the service with circuit breaker protection
+func (cb *UserServiceCircuitBreaker) ListUsers(req *ListUsersRequest) NetworkOp[*ListUsersResponse] {
+	return func(ctx context.Context) (*ListUsersResponse, error) {
+		cb.listUsersCB.mu.Lock()
+		if cb.listUsersCB.state == "open" {
+			if time.Since(cb.listUsersCB.lastFailure) > cb.listUsersCB.resetAfter {
+				cb.listUsersCB.state = "half-open"
+			} else {
+				cb.listUsersCB.mu.Unlock()
+				return nil, errors.New("circuit open")
+			}
+		}
+		cb.listUsersCB.mu.Unlock()
+
+		result, err := cb.service.ListUsers(req)(ctx)
+
+		cb.listUsersCB.mu.Lock()
+		defer cb.listUsersCB.mu.Unlock()
+		if err != nil {
+			cb.listUsersCB.failures++
+			cb.listUsersCB.lastFailure = time.Now()
+			if cb.listUsersCB.failures >= cb.listUsersCB.threshold {
+				cb.listUsersCB.state = "open"
+			}
+			return result, err
+		}
+		cb.listUsersCB.failures = 0
+		cb.listUsersCB.state = "closed"
+		return result, nil
+	}
+}
+
+// CreateUser calls the service with circuit breaker protection
+func (cb *UserServiceCircuitBreaker) CreateUser(req *CreateUserRequest) NetworkOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		cb.createUserCB.mu.Lock()
+		if cb.createUserCB.state == "open" {
+			if time.Since(cb.createUserCB.lastFailure) > cb.createUserCB.resetAfter {
+				cb.createUserCB.state = "half-open"
+			} else {
+				cb.createUserCB.mu.Unlock()
+				return nil, errors.New("circuit open")
+			}
+		}
+		cb.createUserCB.mu.Unlock()
+
+		result, err := cb.service.CreateUser(req)(ctx)
+
+		cb.createUserCB.mu.Lock()
+		defer cb.createUserCB.mu.Unlock()
+		if err != nil {
+			cb.createUserCB.failures++
+			cb.createUserCB.lastFailure = time.Now()
+			if cb.createUserCB.failures >= cb.createUserCB.threshold {
+				cb.createUserCB.state = "open"
+			}
+			return result, err
+		}
+		cb.createUserCB.failures = 0
+		cb.createUserCB.state = "closed"
+		return result, nil
+	}
+}
+
+// UpdateUser calls the service with circuit breaker protection
+func (cb *UserServiceCircuitBreaker) UpdateUser(req *UpdateUserRequest) NetworkOp[*User] {
+	return func(ctx context.Context) (*User, error) {
+		cb.updateUserCB.mu.Lock()
+		if cb.updateUserCB.state == "open" {
+			if time.Since(cb.updateUserCB.lastFailure) > cb.updateUserCB.resetAfter {
+				cb.updateUserCB.state = "half-open"
+			} else {
+				cb.updateUserCB.mu.Unlock()
+				return nil, errors.New("circuit open")
+			}
+		}
+		cb.updateUserCB.mu.Unlock()
+
+		result, err := cb.service.UpdateUser(req)(ctx)
+
+		cb.updateUserCB.mu.Lock()
+		defer cb.updateUserCB.mu.Unlock()
+		if err != nil {
+			cb.updateUserCB.failures++
+			cb.updateUserCB.lastFailure = time.Now()
+			if cb.updateUserCB.failures >= cb.updateUserCB.threshold {
+				cb.updateUserCB.state = "open"
+			}
+			return result, err
+		}
+		cb.updateUserCB.failures = 0
+		cb.updateUserCB.state = "closed"
+		return result, nil
+	}
+}
+
+// DeleteUser calls the service with circuit breaker protection
+func (cb *UserServiceCircuitBreaker) DeleteUser(req *DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+	return func(ctx context.Context) (*DeleteUserResponse, error) {
+		cb.deleteUserCB.mu.Lock()
+		if cb.deleteUserCB.state == "open" {
+			if time.Since(cb.deleteUserCB.lastFailure) > cb.deleteUserCB.resetAfter {
+				cb.deleteUserCB.state = "half-open"
+			} else {
+				cb.deleteUserCB.mu.Unlock()
+				return nil, errors.New("circuit open")
+			}
+		}
+		cb.deleteUserCB.mu.Unlock()
+
+		result, err := cb.service.DeleteUser(req)(ctx)
+
+		cb.deleteUserCB.mu.Lock()
+		defer cb.deleteUserCB.mu.Unlock()
+		if err != nil {
+			cb.deleteUserCB.failures++
+			cb.deleteUserCB.lastFailure = time.Now()
+			if cb.deleteUserCB.failures >= cb.deleteUserCB.threshold {
+				cb.deleteUserCB.state = "open"
+			}
+			return result, err
+		}
+		cb.deleteUserCB.failures = 0
+		cb.deleteUserCB.state = "closed"
+		return result, nil
+	}
+}
+
+// MockUserServiceK creates a mock implementation
+type MockUserServiceK struct {
+	GetUserFunc     func(*GetUserRequest) NetworkOp[*User]
+	GetUserCalls    []*GetUserRequest
+	ListUsersFunc   func(*ListUsersRequest) NetworkOp[*ListUsersResponse]
+	ListUsersCalls  []*ListUsersRequest
+	CreateUserFunc  func(*CreateUserRequest) NetworkOp[*User]
+	CreateUserCalls []*CreateUserRequest
+	UpdateUserFunc  func(*UpdateUserRequest) NetworkOp[*User]
+	UpdateUserCalls []*UpdateUserRequest
+	DeleteUserFunc  func(*DeleteUserRequest) NetworkOp[*DeleteUserResponse]
+	DeleteUserCalls []*DeleteUserRequest
+}
+
+// NewMockUserServiceK creates a new mock
+func NewMockUserServiceK() *MockUserServiceK {
+	return &MockUserServiceK{}
+}
+
+// GetUser records the call and delegates to the mock function
+func (m *MockUserServiceK) GetUser(req *GetUserRequest) NetworkOp[*User] {
+	m.GetUserCalls = append(m.GetUserCalls, req)
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(req)
+	}
+	return PureNetworkOp[*User](nil)
+}
+
+// ListUsers records the call and delegates to the mock function
+func (m *MockUserServiceK) ListUsers(req *ListUsersRequest) NetworkOp[*ListUsersResponse] {
+	m.ListUsersCalls = append(m.ListUsersCalls, req)
+	if m.ListUsersFunc != nil {
+		return m.ListUsersFunc(req)
+	}
+	return PureNetworkOp[*ListUsersResponse](nil)
+}
+
+// CreateUser records the call and delegates to the mock function
+func (m *MockUserServiceK) CreateUser(req *CreateUserRequest) NetworkOp[*User] {
+	m.CreateUserCalls = append(m.CreateUserCalls, req)
+	if m.CreateUserFunc != nil {
+		return m.CreateUserFunc(req)
+	}
+	return PureNetworkOp[*User](nil)
+}
+
+// UpdateUser records the call and delegates to the mock function
+func (m *MockUserServiceK) UpdateUser(req *UpdateUserRequest) NetworkOp[*User] {
+	m.UpdateUserCalls = append(m.UpdateUserCalls, req)
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(req)
+	}
+	return PureNetworkOp[*User](nil)
+}
+
+// DeleteUser records the call and delegates to the mock function
+func (m *MockUserServiceK) DeleteUser(req *DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+	m.DeleteUserCalls = append(m.DeleteUserCalls, req)
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(req)
+	}
+	return PureNetworkOp[*DeleteUserResponse](nil)
+}
+
+// ToKleisli converts mock to Kleisli service
+func (m *MockUserServiceK) ToKleisli() *UserServiceK {
+	return &UserServiceK{
+		GetUser:    m.GetUser,
+		ListUsers:  m.ListUsers,
+		CreateUser: m.CreateUser,
+		UpdateUser: m.UpdateUser,
+		DeleteUser: m.DeleteUser,
+	}
+}
+
+// --- UserService Connect Bridge ---
+
+// Connect bridge requires: connectrpc.com/connect
+// Import the generated connect package for your service
+
+// UserServiceConnectClient interface mirrors connect-go generated client
+type UserServiceConnectClient interface {
+	GetUser(context.Context, *connect.Request[GetUserRequest]) (*connect.Response[User], error)
+	ListUsers(context.Context, *connect.Request[ListUsersRequest]) (*connect.Response[ListUsersResponse], error)
+	CreateUser(context.Context, *connect.Request[CreateUserRequest]) (*connect.Response[User], error)
+	UpdateUser(context.Context, *connect.Request[UpdateUserRequest]) (*connect.Response[User], error)
+	DeleteUser(context.Context, *connect.Request[DeleteUserRequest]) (*connect.Response[DeleteUserResponse], error)
+}
+
+// NewUserServiceKFromConnect wraps a Connect client as Kleisli arrows
+func NewUserServiceKFromConnect(client UserServiceConnectClient) *UserServiceK {
+	return &UserServiceK{
+		GetUser: func(req *GetUserRequest) NetworkOp[*User] {
+			return func(ctx context.Context) (*User, error) {
+				resp, err := client.GetUser(ctx, connect.NewRequest(req))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg, nil
+			}
+		},
+		ListUsers: func(req *ListUsersRequest) NetworkOp[*ListUsersResponse] {
+			return func(ctx context.Context) (*ListUsersResponse, error) {
+				resp, err := client.ListUsers(ctx, connect.NewRequest(req))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg, nil
+			}
+		},
+		CreateUser: func(req *CreateUserRequest) NetworkOp[*User] {
+			return func(ctx context.Context) (*User, error) {
+				resp, err := client.CreateUser(ctx, connect.NewRequest(req))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg, nil
+			}
+		},
+		UpdateUser: func(req *UpdateUserRequest) NetworkOp[*User] {
+			return func(ctx context.Context) (*User, error) {
+				resp, err := client.UpdateUser(ctx, connect.NewRequest(req))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg, nil
+			}
+		},
+		DeleteUser: func(req *DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+			return func(ctx context.Context) (*DeleteUserResponse, error) {
+				resp, err := client.DeleteUser(ctx, connect.NewRequest(req))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg, nil
+			}
+		},
+	}
+}
+
+// UserServiceConnectHandler implements Connect handler using Kleisli service
+type UserServiceConnectHandler struct {
+	svc *UserServiceK
+}
+
+// NewUserServiceConnectHandler creates a Connect handler from Kleisli service
+func NewUserServiceConnectHandler(svc *UserServiceK) *UserServiceConnectHandler {
+	return &UserServiceConnectHandler{svc: svc}
+}
+
+// GetUser implements userserviceconnect.UserServiceHandler
+func (h *UserServiceConnectHandler) GetUser(
+	ctx context.Context,
+	req *connect.Request[GetUserRequest],
+) (*connect.Response[User], error) {
+	result, err := h.svc.GetUser(req.Msg)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(result), nil
+}
+
+// ListUsers implements userserviceconnect.UserServiceHandler
+func (h *UserServiceConnectHandler) ListUsers(
+	ctx context.Context,
+	req *connect.Request[ListUsersRequest],
+) (*connect.Response[ListUsersResponse], error) {
+	result, err := h.svc.ListUsers(req.Msg)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(result), nil
+}
+
+// CreateUser implements userserviceconnect.UserServiceHandler
+func (h *UserServiceConnectHandler) CreateUser(
+	ctx context.Context,
+	req *connect.Request[CreateUserRequest],
+) (*connect.Response[User], error) {
+	result, err := h.svc.CreateUser(req.Msg)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(result), nil
+}
+
+// UpdateUser implements userserviceconnect.UserServiceHandler
+func (h *UserServiceConnectHandler) UpdateUser(
+	ctx context.Context,
+	req *connect.Request[UpdateUserRequest],
+) (*connect.Response[User], error) {
+	result, err := h.svc.UpdateUser(req.Msg)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(result), nil
+}
+
+// DeleteUser implements userserviceconnect.UserServiceHandler
+func (h *UserServiceConnectHandler) DeleteUser(
+	ctx context.Context,
+	req *connect.Request[DeleteUserRequest],
+) (*connect.Response[DeleteUserResponse], error) {
+	result, err := h.svc.DeleteUser(req.Msg)(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(result), nil
+}
+
+// NewUserServiceKFromConnectWithDefaults wraps Connect client with retry and timeout
+func NewUserServiceKFromConnectWithDefaults(
+	client UserServiceConnectClient,
+	timeout time.Duration,
+	maxRetries int,
+) *UserServiceK {
+	base := NewUserServiceKFromConnect(client)
+	return &UserServiceK{
+		GetUser: func(req *GetUserRequest) NetworkOp[*User] {
+			return TimeoutNetworkOp(
+				RetryNetworkOp(base.GetUser(req), maxRetries, 100*time.Millisecond, 2*time.Second),
+				timeout,
+			)
+		},
+		ListUsers: func(req *ListUsersRequest) NetworkOp[*ListUsersResponse] {
+			return TimeoutNetworkOp(
+				RetryNetworkOp(base.ListUsers(req), maxRetries, 100*time.Millisecond, 2*time.Second),
+				timeout,
+			)
+		},
+		CreateUser: func(req *CreateUserRequest) NetworkOp[*User] {
+			return TimeoutNetworkOp(base.CreateUser(req), timeout)
+		},
+		UpdateUser: func(req *UpdateUserRequest) NetworkOp[*User] {
+			return TimeoutNetworkOp(
+				RetryNetworkOp(base.UpdateUser(req), maxRetries, 100*time.Millisecond, 2*time.Second),
+				timeout,
+			)
+		},
+		DeleteUser: func(req *DeleteUserRequest) NetworkOp[*DeleteUserResponse] {
+			return TimeoutNetworkOp(
+				RetryNetworkOp(base.DeleteUser(req), maxRetries, 100*time.Millisecond, 2*time.Second),
+				timeout,
+			)
+		},
+	}
+}
+
+// --- OrderService ---
+
+// OrderServiceK represents the service as Kleisli arrows
+type OrderServiceK struct {
+	GetOrder         func(*GetOrderRequest) NetworkOp[*Order]
+	ListOrders       func(*ListOrdersRequest) NetworkOp[*ListOrdersResponse]
+	BulkExportOrders func(*BulkExportOrdersRequest) NetworkOp[*BulkExportOrdersResponse]
+}
+
+// OrderServiceGetOrderAndThen chains GetOrder with another effectful function
+func OrderServiceGetOrderAndThen[B any](s *OrderServiceK, f func(*Order) NetworkOp[B]) func(*GetOrderRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.GetOrder, f)
+}
+
+// OrderServiceListOrdersAndThen chains ListOrders with another effectful function
+func OrderServiceListOrdersAndThen[B any](s *OrderServiceK, f func(*ListOrdersResponse) NetworkOp[B]) func(*ListOrdersRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.ListOrders, f)
+}
+
+// OrderServiceBulkExportOrdersAndThen chains BulkExportOrders with another effectful function
+func OrderServiceBulkExportOrdersAndThen[B any](s *OrderServiceK, f func(*BulkExportOrdersResponse) NetworkOp[B]) func(*BulkExportOrdersRequest) NetworkOp[B] {
+	return ComposeKleisliNetworkOp(s.BulkExportOrders, f)
+}
+
+// OrderServiceMiddleware wraps service methods
+type OrderServiceMiddleware struct {
+	GetOrder         func(func(*GetOrderRequest) NetworkOp[*Order]) func(*GetOrderRequest) NetworkOp[*Order]
+	ListOrders       func(func(*ListOrdersRequest) NetworkOp[*ListOrdersResponse]) func(*ListOrdersRequest) NetworkOp[*ListOrdersResponse]
+	BulkExportOrders func(func(*BulkExportOrdersRequest) NetworkOp[*BulkExportOrdersResponse]) func(*BulkExportOrdersRequest) NetworkOp[*BulkExportOrdersResponse]
+}
+
+// ApplyOrderServiceMiddleware applies middleware to a service
+func ApplyOrderServiceMiddleware(s *OrderServiceK, mw *OrderServiceMiddleware) *OrderServiceK {
+	result := &OrderServiceK{}
+	if mw.GetOrder != nil {
+		result.GetOrder = mw.GetOrder(s.GetOrder)
+	} else {
+		result.GetOrder = s.GetOrder
+	}
+	if mw.ListOrders != nil {
+		result.ListOrders = mw.ListOrders(s.ListOrders)
+	} else {
+		result.ListOrders = s.ListOrders
+	}
+	if mw.BulkExportOrders != nil {
+		result.BulkExportOrders = mw.BulkExportOrders(s.BulkExportOrders)
+	} else {
+		result.BulkExportOrders = s.BulkExportOrders
+	}
+	return result
+}
+
+// ParallelGetOrder executes multiple requests in parallel
+func (s *OrderServiceK) ParallelGetOrder(reqs []*GetOrderRequest) NetworkOp[[]*Order] {
+	return TraverseParallelNetworkOp(reqs, s.GetOrder)
+}
+
+// ParallelListOrders executes multiple requests in parallel
+func (s *OrderServiceK) ParallelListOrders(reqs []*ListOrdersRequest) NetworkOp[[]*ListOrdersResponse] {
+	return TraverseParallelNetworkOp(reqs, s.ListOrders)
+}
+
+// ParallelBulkExportOrders executes multiple requests in parallel
+func (s *OrderServiceK) ParallelBulkExportOrders(reqs []*BulkExportOrdersRequest) NetworkOp[[]*BulkExportOrdersResponse] {
+	return TraverseParallelNetworkOp(reqs, s.BulkExportOrders)
+}
+
+// MockOrderServiceK creates a mock implementation
+type MockOrderServiceK struct {
+	GetOrderFunc          func(*GetOrderRequest) NetworkOp[*Order]
+	GetOrderCalls         []*GetOrderRequest
+	ListOrdersFunc        func(*ListOrdersRequest) NetworkOp[*ListOrdersResponse]
+	ListOrdersCalls       []*ListOrdersRequest
+	BulkExportOrdersFunc  func(*BulkExportOrdersRequest) NetworkOp[*BulkExportOrdersResponse]
+	BulkExportOrdersCalls []*BulkExportOrdersRequest
+}
+
+// NewMockOrderServiceK creates a new mock
+func NewMockOrderServiceK() *MockOrderServiceK {
+	return &MockOrderServiceK{}
+}
+
+// GetOrder records the call and delegates to the mock function
+func (m *MockOrderServiceK) GetOrder(req *GetOrderRequest) NetworkOp[*Order] {
+	m.GetOrderCalls = append(m.GetOrderCalls, req)
+	if m.GetOrderFunc != nil {
+		return m.GetOrderFunc(req)
+	}
+	return PureNetworkOp[*Order](nil)
+}
+
+// ListOrders records the call and delegates to the mock function
+func (m *MockOrderServiceK) ListOrders(req *ListOrdersRequest) NetworkOp[*ListOrdersResponse] {
+	m.ListOrdersCalls = append(m.ListOrdersCalls, req)
+	if m.ListOrdersFunc != nil {
+		return m.ListOrdersFunc(req)
+	}
+	return PureNetworkOp[*ListOrdersResponse](nil)
+}
+
+// BulkExportOrders records the call and delegates to the mock function
+func (m *MockOrderServiceK) BulkExportOrders(req *BulkExportOrdersRequest) NetworkOp[*BulkExportOrdersResponse] {
+	m.BulkExportOrdersCalls = append(m.BulkExportOrdersCalls, req)
+	if m.BulkExportOrdersFunc != nil {
+		return m.BulkExportOrdersFunc(req)
+	}
+	return PureNetworkOp[*BulkExportOrdersResponse](nil)
+}
+
+// ToKleisli converts mock to Kleisli service
+func (m *MockOrderServiceK) ToKleisli() *OrderServiceK {
+	return &OrderServiceK{
+		GetOrder:         m.GetOrder,
+		ListOrders:       m.ListOrders,
+		BulkExportOrders: m.BulkExportOrders,
+	}
+}
+
+// --- OrderService Stripe Billing ---
+
+// OrderServiceMethodPlans maps methods to minimum required plans
+var OrderServiceMethodPlans = map[string]Plan{
+	"/example.OrderService/GetOrder":         PlanFree,
+	"/example.OrderService/ListOrders":       PlanPro,
+	"/example.OrderService/BulkExportOrders": PlanEnterprise,
+}
+
+// OrderServiceMeteredMethods defines which methods are metered
+var OrderServiceMeteredMethods = map[string]string{
+	"/example.OrderService/BulkExportOrders": "bulk_exports",
+}
+
+// RequireOrderServicePlan checks if user meets plan requirement
+func RequireOrderServicePlan(ctx context.Context, getUserPlan func(ctx context.Context, userID string) (Plan, error), method string) error {
+	minPlan, ok := OrderServiceMethodPlans[method]
+	if !ok {
+		return nil // no plan requirement
+	}
+
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return errors.New("user ID required")
+	}
+
+	currentPlan, err := getUserPlan(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !PlanAtLeast(currentPlan, minPlan) {
+		return &PlanError{Required: minPlan, Current: currentPlan}
+	}
+
+	return nil
+}
+
+// OrderServiceBillingInterceptor creates a Connect interceptor for plan checks
+func OrderServiceBillingInterceptor(getUserPlan func(ctx context.Context, userID string) (Plan, error)) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if err := RequireOrderServicePlan(ctx, getUserPlan, req.Spec().Procedure); err != nil {
+				return nil, connect.NewError(connect.CodePermissionDenied, err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
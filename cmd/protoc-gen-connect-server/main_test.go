@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/connectserver"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, connectserver.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "cors_auth", Files: []string{"shop/v1/shop.proto"}, Param: "cors=true,auth=true"},
+		plugintest.Case{Name: "cross_file", Files: []string{"crm/v1/service.proto"}},
+		plugintest.Case{Name: "explain_json", Files: []string{"crm/v1/service.proto"}, Param: "explain=true,explain_format=json"},
+	)
+}
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-connect-server. DO NOT EDIT.
+// Pattern-based generation using proto reflection.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	pb "example.com/shop/gen/shop/v1"
+	"example.com/shop/gen/shop/v1/shopv1connect"
+	"github.com/google/wire"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UserServiceServer implements UserService
+type UserServiceServer struct {
+	shopv1connect.UnimplementedUserServiceHandler
+	repos *pb.Repositories
+}
+
+func NewUserServiceServer(repos *pb.Repositories) *UserServiceServer {
+	return &UserServiceServer{repos: repos}
+}
+
+func (s *UserServiceServer) GetUser(ctx context.Context, req *connect.Request[pb.GetUserRequest]) (*connect.Response[pb.User], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	entity, err := s.repos.User.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(entity), nil
+}
+
+func (s *UserServiceServer) UpdateUser(ctx context.Context, req *connect.Request[pb.UpdateUserRequest]) (*connect.Response[pb.User], error) {
+	entity := req.Msg.GetUser()
+	if entity.GetUserId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	if err := s.repos.User.Update(ctx, entity); err != nil {
+		switch {
+		case errors.Is(err, pb.ErrNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, pb.ErrConflict):
+			return nil, connect.NewError(connect.CodeAborted, err)
+		case errors.Is(err, pb.ErrAlreadyExists):
+			return nil, connect.NewError(connect.CodeAlreadyExists, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(entity), nil
+}
+
+func (s *UserServiceServer) DeleteUser(ctx context.Context, req *connect.Request[pb.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	if err := s.repos.User.Delete(ctx, id); err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *UserServiceServer) ListUsers(ctx context.Context, req *connect.Request[pb.ListUsersRequest]) (*connect.Response[pb.ListUsersResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	entities, err := s.repos.User.List(ctx, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.ListUsersResponse{Users: entities}), nil
+}
+
+// NewUserServiceHandler mounts srv with the configured middleware.
+func NewUserServiceHandler(srv *UserServiceServer, opts ...connect.HandlerOption) (string, http.Handler) {
+	path, handler := shopv1connect.NewUserServiceHandler(srv, opts...)
+	handler = pb.AuthMiddleware(handler)
+	handler = withCORS(handler)
+	return path, handler
+}
+
+// CORSAllowedOrigins lists the origins allowed by withCORS; none until set. "*"
+// allows any origin, without credentials: only the origins listed by name get
+// Access-Control-Allow-Credentials.
+var CORSAllowedOrigins []string
+
+// withCORS answers preflight requests and sets the headers Connect clients need.
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if allowed, named := corsAllowed(origin); origin != "" && allowed {
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			if named {
+				h.Set("Access-Control-Allow-Origin", origin)
+				h.Set("Access-Control-Allow-Credentials", "true")
+			} else {
+				h.Set("Access-Control-Allow-Origin", "*")
+			}
+			h.Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
+			if r.Method == http.MethodOptions {
+				h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				h.Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent")
+				h.Set("Access-Control-Max-Age", "7200")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsAllowed reports whether CORSAllowedOrigins allows origin, and whether it
+// lists it by name rather than through "*".
+func corsAllowed(origin string) (allowed, named bool) {
+	for _, o := range CORSAllowedOrigins {
+		switch o {
+		case origin:
+			return true, true
+		case "*":
+			allowed = true
+		}
+	}
+	return allowed, false
+}
+
+// ServiceServerSet provides all generated service servers for Wire.
+var ServiceServerSet = wire.NewSet(
+	NewUserServiceServer,
+)
@@ -0,0 +1,77 @@
+// Code generated by protoc-gen-connect-server. DO NOT EDIT.
+// Pattern-based generation using proto reflection.
+
+package servers
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	pb "example.com/shop/gen/crm/v1"
+	"example.com/shop/gen/crm/v1/crmv1connect"
+	"github.com/google/wire"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ContactServiceServer implements ContactService
+type ContactServiceServer struct {
+	crmv1connect.UnimplementedContactServiceHandler
+	repos *pb.Repositories
+}
+
+func NewContactServiceServer(repos *pb.Repositories) *ContactServiceServer {
+	return &ContactServiceServer{repos: repos}
+}
+
+func (s *ContactServiceServer) GetContact(ctx context.Context, req *connect.Request[pb.GetContactRequest]) (*connect.Response[pb.Contact], error) {
+	id := req.Msg.GetContactId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	entity, err := s.repos.Contact.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(entity), nil
+}
+
+func (s *ContactServiceServer) DeleteContact(ctx context.Context, req *connect.Request[pb.DeleteContactRequest]) (*connect.Response[emptypb.Empty], error) {
+	id := req.Msg.GetContactId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	if err := s.repos.Contact.Delete(ctx, id); err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *ContactServiceServer) ListContacts(ctx context.Context, req *connect.Request[pb.ListContactsRequest]) (*connect.Response[pb.ListContactsResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	entities, err := s.repos.Contact.List(ctx, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.ListContactsResponse{Contacts: entities}), nil
+}
+
+// ServiceServerSet provides all generated service servers for Wire.
+var ServiceServerSet = wire.NewSet(
+	NewContactServiceServer,
+)
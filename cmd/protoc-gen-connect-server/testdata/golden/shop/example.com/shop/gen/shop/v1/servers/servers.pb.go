@@ -0,0 +1,98 @@
+// Code generated by protoc-gen-connect-server. DO NOT EDIT.
+// Pattern-based generation using proto reflection.
+
+package servers
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	pb "example.com/shop/gen/shop/v1"
+	"example.com/shop/gen/shop/v1/shopv1connect"
+	"github.com/google/wire"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UserServiceServer implements UserService
+type UserServiceServer struct {
+	shopv1connect.UnimplementedUserServiceHandler
+	repos *pb.Repositories
+}
+
+func NewUserServiceServer(repos *pb.Repositories) *UserServiceServer {
+	return &UserServiceServer{repos: repos}
+}
+
+func (s *UserServiceServer) GetUser(ctx context.Context, req *connect.Request[pb.GetUserRequest]) (*connect.Response[pb.User], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	entity, err := s.repos.User.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(entity), nil
+}
+
+func (s *UserServiceServer) UpdateUser(ctx context.Context, req *connect.Request[pb.UpdateUserRequest]) (*connect.Response[pb.User], error) {
+	entity := req.Msg.GetUser()
+	if entity.GetUserId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	if err := s.repos.User.Update(ctx, entity); err != nil {
+		switch {
+		case errors.Is(err, pb.ErrNotFound):
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		case errors.Is(err, pb.ErrConflict):
+			return nil, connect.NewError(connect.CodeAborted, err)
+		case errors.Is(err, pb.ErrAlreadyExists):
+			return nil, connect.NewError(connect.CodeAlreadyExists, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(entity), nil
+}
+
+func (s *UserServiceServer) DeleteUser(ctx context.Context, req *connect.Request[pb.DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+
+	if err := s.repos.User.Delete(ctx, id); err != nil {
+		if errors.Is(err, pb.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *UserServiceServer) ListUsers(ctx context.Context, req *connect.Request[pb.ListUsersRequest]) (*connect.Response[pb.ListUsersResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	entities, err := s.repos.User.List(ctx, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.ListUsersResponse{Users: entities}), nil
+}
+
+// ServiceServerSet provides all generated service servers for Wire.
+var ServiceServerSet = wire.NewSet(
+	NewUserServiceServer,
+)
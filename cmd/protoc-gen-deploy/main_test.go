@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/deploy"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, deploy.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "region", Files: []string{"shop/v1/shop.proto"}, Param: "region=europe-west1,service=storefront"},
+	)
+}
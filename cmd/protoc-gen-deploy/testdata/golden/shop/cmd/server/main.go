@@ -0,0 +1,74 @@
+// Code generated by protoc-gen-deploy. DO NOT EDIT.
+// Server entrypoint for Cloud Run deployment
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	pb "example.com/shop/gen/shop/v1"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func main() {
+	// Get port from environment (Cloud Run sets PORT)
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	// Create HTTP mux
+	mux := http.NewServeMux()
+
+	// Health check endpoint
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	// Initialize repositories (using in-memory for now, swap for Firestore in production)
+	userRepo := pb.NewInMemoryUserRepository()
+
+	// Register service handlers
+	userServer := pb.NewUserServiceServer(userRepo)
+	path, handler := pb.NewUserServiceHandler(userServer)
+	mux.Handle(path, handler)
+
+	// Create server with HTTP/2 support (required for Connect)
+	server := &http.Server{
+		Addr:         ":" + port,
+		Handler:      h2c.NewHandler(mux, &http2.Server{}),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	// Graceful shutdown
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+
+		log.Println("Shutting down server...")
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Server shutdown error: %v", err)
+		}
+	}()
+
+	log.Printf("Server starting on port %s", port)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	log.Println("Server stopped")
+}
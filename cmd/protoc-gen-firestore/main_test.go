@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/firestore"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, firestore.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "no_soft_delete", Files: []string{"shop/v1/shop.proto"}, Param: "soft_delete=false,timestamps=false"},
+		plugintest.Case{Name: "bad_param", Files: []string{"shop/v1/shop.proto"}, Param: "softdelete=false"},
+		plugintest.Case{Name: "explain", Files: []string{"shop/v1/shop.proto"}, Param: "explain=true"},
+		plugintest.Case{Name: "bad_entity", Files: []string{"diag/v1/bad_entity.proto"}},
+		plugintest.Case{Name: "catalog", Files: []string{"catalog/v1/catalog.proto"}},
+		plugintest.Case{Name: "listing", Files: []string{"catalog/v1/listing.proto"}, Param: "enums=name"},
+		plugintest.Case{Name: "bad_enums", Files: []string{"catalog/v1/listing.proto"}, Param: "enums=string"},
+	)
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-firestore. DO NOT EDIT.
+//
+// Security rules tests against the Firestore emulator:
+//
+//   npm install --save-dev vitest firebase @firebase/rules-unit-testing
+//   firebase emulators:exec --only firestore 'npx vitest run firestore.rules.test.ts'
+
+import { readFileSync } from 'node:fs';
+import {
+  assertFails,
+  assertSucceeds,
+  initializeTestEnvironment,
+  type RulesTestEnvironment,
+} from '@firebase/rules-unit-testing';
+import { deleteDoc, doc, getDoc, setDoc, updateDoc, type DocumentData } from 'firebase/firestore';
+import { afterAll, beforeAll, beforeEach, describe, it } from 'vitest';
+
+let env: RulesTestEnvironment;
+
+beforeAll(async () => {
+  env = await initializeTestEnvironment({
+    projectId: 'demo-rules',
+    firestore: { rules: readFileSync(new URL('./firestore.rules', import.meta.url), 'utf8') },
+  });
+});
+afterAll(() => env.cleanup());
+beforeEach(() => env.clearFirestore());
+
+// as returns a Firestore client signed in as uid with the given token claims.
+const as = (uid: string, claims: Record<string, unknown> = {}) => env.authenticatedContext(uid, claims).firestore();
+const anonymous = () => env.unauthenticatedContext().firestore();
+
+// seed writes a document with the rules disabled.
+async function seed(path: string, data: DocumentData) {
+  await env.withSecurityRulesDisabled((ctx) => setDoc(doc(ctx.firestore(), path), data));
+}
+
+describe('products (catalog.v1.Product)', () => {
+  const path = 'products/doc-1';
+  const doc1 = { seller_id: 'alice', shop_id: 'tenant-a' };
+
+  it('denies signed-out reads', async () => {
+    await seed(path, doc1);
+    await assertFails(getDoc(doc(anonymous(), path)));
+  });
+
+  it('lets the owner create, read and delete their document', async () => {
+    const db = as('alice', { shop_id: 'tenant-a' });
+    await assertSucceeds(setDoc(doc(db, path), doc1));
+    await assertSucceeds(getDoc(doc(db, path)));
+    await assertSucceeds(deleteDoc(doc(db, path)));
+  });
+
+  it('denies creating a document owned by someone else', async () => {
+    await assertFails(setDoc(doc(as('mallory', { shop_id: 'tenant-a' }), path), doc1));
+  });
+
+  it('denies handing the document to another owner', async () => {
+    await seed(path, doc1);
+    await assertFails(updateDoc(doc(as('alice', { shop_id: 'tenant-a' }), path), { seller_id: 'mallory' }));
+  });
+
+  it('denies access from another tenant', async () => {
+    await seed(path, doc1);
+    await assertFails(getDoc(doc(as('alice', { shop_id: 'tenant-b' }), path)));
+  });
+
+  it('lets any signed-in user read', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(getDoc(doc(as('bob', { shop_id: 'tenant-a' }), path)));
+  });
+
+  it('lets admin update', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(updateDoc(doc(as('bob', { role: 'admin', shop_id: 'tenant-a' }), path), { name: 'changed' }));
+  });
+
+  it('lets moderator update', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(updateDoc(doc(as('bob', { role: 'moderator', shop_id: 'tenant-a' }), path), { name: 'changed' }));
+  });
+
+  it('lets admin delete', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(deleteDoc(doc(as('bob', { role: 'admin', shop_id: 'tenant-a' }), path)));
+  });
+
+  it('denies changing sku', async () => {
+    await seed(path, doc1);
+    await assertFails(updateDoc(doc(as('alice', { shop_id: 'tenant-a' }), path), { sku: 'changed' }));
+  });
+});
+
+describe('reviews (catalog.v1.Review)', () => {
+  const path = 'reviews/doc-1';
+  const doc1 = { author_id: 'alice' };
+
+  it('denies signed-out reads', async () => {
+    await seed(path, doc1);
+    await assertFails(getDoc(doc(anonymous(), path)));
+  });
+
+  it('lets the owner create, read and delete their document', async () => {
+    const db = as('alice', {});
+    await assertSucceeds(setDoc(doc(db, path), doc1));
+    await assertSucceeds(getDoc(doc(db, path)));
+    await assertSucceeds(deleteDoc(doc(db, path)));
+  });
+
+  it('denies creating a document owned by someone else', async () => {
+    await assertFails(setDoc(doc(as('mallory', {}), path), doc1));
+  });
+
+  it('denies handing the document to another owner', async () => {
+    await seed(path, doc1);
+    await assertFails(updateDoc(doc(as('alice', {}), path), { author_id: 'mallory' }));
+  });
+
+  it('lets any signed-in user read', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(getDoc(doc(as('bob', {}), path)));
+  });
+
+  it('lets moderator delete', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(deleteDoc(doc(as('bob', { role: 'moderator' }), path)));
+  });
+
+  it('lets admin delete', async () => {
+    await seed(path, doc1);
+    await assertSucceeds(deleteDoc(doc(as('bob', { role: 'admin' }), path)));
+  });
+
+  it('denies changing product_id', async () => {
+    await seed(path, doc1);
+    await assertFails(updateDoc(doc(as('alice', {}), path), { product_id: 'changed' }));
+  });
+});
@@ -0,0 +1,13 @@
+// protoc-gen-fullstack runs the generators selected by a manifest over one
+// request; see internal/plugins/fullstack.
+package main
+
+import (
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/fullstack"
+)
+
+func main() {
+	var flags params.Set
+	flags.Options().Run(fullstack.Plugin(&flags))
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/fullstack"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, fullstack.Plugin,
+		plugintest.Case{Name: "backend", Files: []string{"shop/v1/shop.proto"}, Param: "generators=repository+firestore+connect-server+wire+wire-inject,soft_delete=false,cors"},
+		plugintest.Case{Name: "manifest", Files: []string{"shop/v1/shop.proto"}, Param: "manifest=testdata/shop.json,timestamps=true"},
+		plugintest.Case{Name: "unknown_generator", Files: []string{"shop/v1/shop.proto"}, Param: "generators=firestore+graph"},
+		plugintest.Case{Name: "unused_param", Files: []string{"shop/v1/shop.proto"}, Param: "generators=firestore,cors"},
+	)
+}
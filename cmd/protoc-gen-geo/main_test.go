@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/geo"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, geo.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "explain", Files: []string{"shop/v1/shop.proto"}, Param: "explain=true"},
+		plugintest.Case{Name: "diagnostics", Files: []string{"diag/v1/shapes.proto"}},
+	)
+}
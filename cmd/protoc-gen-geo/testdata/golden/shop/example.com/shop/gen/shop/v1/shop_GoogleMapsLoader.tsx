@@ -0,0 +1,80 @@
+// GoogleMapsLoader.tsx
+// Generated by protoc-gen-geo
+
+import React, { createContext, useContext, useEffect, useState } from 'react';
+
+interface GoogleMapsContextType {
+  isLoaded: boolean;
+  loadError: Error | null;
+  google: typeof google | null;
+}
+
+const GoogleMapsContext = createContext<GoogleMapsContextType>({
+  isLoaded: false,
+  loadError: null,
+  google: null,
+});
+
+interface GoogleMapsProviderProps {
+  apiKey: string;
+  children: React.ReactNode;
+  libraries?: string[];
+}
+
+export function GoogleMapsProvider({ 
+  apiKey, 
+  children,
+  libraries = ['places', 'geometry'],
+}: GoogleMapsProviderProps) {
+  const [isLoaded, setIsLoaded] = useState(false);
+  const [loadError, setLoadError] = useState<Error | null>(null);
+
+  useEffect(() => {
+    // Check if already loaded
+    if (window.google?.maps) {
+      setIsLoaded(true);
+      return;
+    }
+
+    // Check if script is already being loaded
+    const existingScript = document.querySelector('script[src*="maps.googleapis.com"]');
+    if (existingScript) {
+      existingScript.addEventListener('load', () => setIsLoaded(true));
+      return;
+    }
+
+    // Load the script
+    const script = document.createElement('script');
+    script.src = `https://maps.googleapis.com/maps/api/js?key=${apiKey}&libraries=${libraries.join(',')}`;
+    script.async = true;
+    script.defer = true;
+
+    script.onload = () => setIsLoaded(true);
+    script.onerror = () => setLoadError(new Error('Failed to load Google Maps'));
+
+    document.head.appendChild(script);
+
+    return () => {
+      // Cleanup if needed
+    };
+  }, [apiKey, libraries]);
+
+  return (
+    <GoogleMapsContext.Provider value={{ isLoaded, loadError, google: isLoaded ? window.google : null }}>
+      {children}
+    </GoogleMapsContext.Provider>
+  );
+}
+
+export function useGoogleMaps() {
+  return useContext(GoogleMapsContext);
+}
+
+// Type declaration for window.google
+declare global {
+  interface Window {
+    google: typeof google;
+  }
+}
+
+
@@ -0,0 +1,197 @@
+// MapView.tsx
+// Generated by protoc-gen-geo
+
+import React, { useRef, useEffect, useState, useCallback } from 'react';
+import { useGoogleMaps } from './GoogleMapsLoader';
+import type { Coordinates, BoundingBox } from './geo_types';
+
+interface Marker {
+  id: string;
+  position: Coordinates;
+  title?: string;
+  icon?: string;
+}
+
+interface MapViewProps {
+  center?: Coordinates;
+  zoom?: number;
+  markers?: Marker[];
+  onMarkerClick?: (id: string) => void;
+  onBoundsChange?: (bounds: BoundingBox) => void;
+  onCenterChange?: (center: Coordinates) => void;
+  selectedMarkerId?: string;
+  height?: string;
+  className?: string;
+  showUserLocation?: boolean;
+  userLocation?: Coordinates | null;
+}
+
+export function MapView({
+  center = { latitude: 37.7749, longitude: -122.4194 }, // Default: San Francisco
+  zoom = 12,
+  markers = [],
+  onMarkerClick,
+  onBoundsChange,
+  onCenterChange,
+  selectedMarkerId,
+  height = '400px',
+  className = '',
+  showUserLocation = true,
+  userLocation,
+}: MapViewProps) {
+  const { isLoaded, google } = useGoogleMaps();
+  const mapRef = useRef<HTMLDivElement>(null);
+  const mapInstanceRef = useRef<google.maps.Map | null>(null);
+  const markersRef = useRef<Map<string, google.maps.Marker>>(new Map());
+  const userMarkerRef = useRef<google.maps.Marker | null>(null);
+
+  // Initialize map
+  useEffect(() => {
+    if (!isLoaded || !google || !mapRef.current || mapInstanceRef.current) return;
+
+    mapInstanceRef.current = new google.maps.Map(mapRef.current, {
+      center: { lat: center.latitude, lng: center.longitude },
+      zoom,
+      styles: defaultMapStyles,
+      mapTypeControl: false,
+      fullscreenControl: false,
+      streetViewControl: false,
+    });
+
+    // Listen for bounds change
+    mapInstanceRef.current.addListener('idle', () => {
+      const bounds = mapInstanceRef.current?.getBounds();
+      if (bounds && onBoundsChange) {
+        const ne = bounds.getNorthEast();
+        const sw = bounds.getSouthWest();
+        onBoundsChange({
+          minLat: sw.lat(),
+          maxLat: ne.lat(),
+          minLng: sw.lng(),
+          maxLng: ne.lng(),
+        });
+      }
+    });
+
+    mapInstanceRef.current.addListener('center_changed', () => {
+      const mapCenter = mapInstanceRef.current?.getCenter();
+      if (mapCenter && onCenterChange) {
+        onCenterChange({
+          latitude: mapCenter.lat(),
+          longitude: mapCenter.lng(),
+        });
+      }
+    });
+  }, [isLoaded, google]);
+
+  // Update center
+  useEffect(() => {
+    if (!mapInstanceRef.current) return;
+    mapInstanceRef.current.setCenter({ lat: center.latitude, lng: center.longitude });
+  }, [center.latitude, center.longitude]);
+
+  // Update markers
+  useEffect(() => {
+    if (!isLoaded || !google || !mapInstanceRef.current) return;
+
+    // Remove old markers
+    markersRef.current.forEach((marker, id) => {
+      if (!markers.find(m => m.id === id)) {
+        marker.setMap(null);
+        markersRef.current.delete(id);
+      }
+    });
+
+    // Add/update markers
+    markers.forEach((m) => {
+      let marker = markersRef.current.get(m.id);
+      
+      if (!marker) {
+        marker = new google.maps.Marker({
+          map: mapInstanceRef.current,
+          position: { lat: m.position.latitude, lng: m.position.longitude },
+          title: m.title,
+          icon: m.icon || defaultMarkerIcon,
+          animation: google.maps.Animation.DROP,
+        });
+
+        marker.addListener('click', () => {
+          onMarkerClick?.(m.id);
+        });
+
+        markersRef.current.set(m.id, marker);
+      } else {
+        marker.setPosition({ lat: m.position.latitude, lng: m.position.longitude });
+      }
+
+      // Highlight selected marker
+      if (m.id === selectedMarkerId) {
+        marker.setAnimation(google.maps.Animation.BOUNCE);
+        setTimeout(() => marker?.setAnimation(null), 1500);
+      }
+    });
+  }, [isLoaded, google, markers, selectedMarkerId, onMarkerClick]);
+
+  // User location marker
+  useEffect(() => {
+    if (!isLoaded || !google || !mapInstanceRef.current || !showUserLocation || !userLocation) return;
+
+    if (!userMarkerRef.current) {
+      userMarkerRef.current = new google.maps.Marker({
+        map: mapInstanceRef.current,
+        position: { lat: userLocation.latitude, lng: userLocation.longitude },
+        title: 'Your Location',
+        icon: {
+          path: google.maps.SymbolPath.CIRCLE,
+          scale: 10,
+          fillColor: '#4285F4',
+          fillOpacity: 1,
+          strokeColor: '#ffffff',
+          strokeWeight: 2,
+        },
+        zIndex: 999,
+      });
+    } else {
+      userMarkerRef.current.setPosition({ lat: userLocation.latitude, lng: userLocation.longitude });
+    }
+  }, [isLoaded, google, showUserLocation, userLocation]);
+
+  if (!isLoaded) {
+    return (
+      <div 
+        className={`flex items-center justify-center bg-gray-100 ${className}`}
+        style={{ height }}
+      >
+        <div className="text-gray-500">Loading map...</div>
+      </div>
+    );
+  }
+
+  return (
+    <div 
+      ref={mapRef} 
+      className={className}
+      style={{ height, width: '100%' }}
+    />
+  );
+}
+
+const defaultMarkerIcon = {
+  url: 'data:image/svg+xml,' + encodeURIComponent(`
+    <svg xmlns="http://www.w3.org/2000/svg" width="32" height="40" viewBox="0 0 32 40">
+      <path fill="#EA4335" d="M16 0C7.16 0 0 7.16 0 16c0 12 16 24 16 24s16-12 16-24C32 7.16 24.84 0 16 0zm0 22c-3.31 0-6-2.69-6-6s2.69-6 6-6 6 2.69 6 6-2.69 6-6 6z"/>
+    </svg>
+  `),
+  scaledSize: { width: 32, height: 40 } as google.maps.Size,
+  anchor: { x: 16, y: 40 } as google.maps.Point,
+};
+
+const defaultMapStyles: google.maps.MapTypeStyle[] = [
+  {
+    featureType: 'poi',
+    elementType: 'labels',
+    stylers: [{ visibility: 'off' }],
+  },
+];
+
+
@@ -0,0 +1,145 @@
+// StoreList.tsx
+// Generated by protoc-gen-geo
+
+import React from 'react';
+import { formatDistance } from './useGeolocation';
+
+interface Store {
+  id: string;
+  name: string;
+  address?: string;
+  phone?: string;
+  latitude: number;
+  longitude: number;
+}
+
+interface StoreListProps<T extends Store> {
+  stores: Array<{ store: T; distanceKm: number }>;
+  selectedId?: string;
+  onSelect?: (store: T) => void;
+  onGetDirections?: (store: T) => void;
+  isLoading?: boolean;
+  emptyMessage?: string;
+}
+
+export function StoreList<T extends Store>({
+  stores,
+  selectedId,
+  onSelect,
+  onGetDirections,
+  isLoading = false,
+  emptyMessage = 'No locations found nearby',
+}: StoreListProps<T>) {
+  if (isLoading) {
+    return (
+      <div className="p-4 space-y-4">
+        {[1, 2, 3].map((i) => (
+          <div key={i} className="animate-pulse">
+            <div className="h-4 bg-gray-200 rounded w-3/4 mb-2" />
+            <div className="h-3 bg-gray-200 rounded w-1/2" />
+          </div>
+        ))}
+      </div>
+    );
+  }
+
+  if (stores.length === 0) {
+    return (
+      <div className="p-8 text-center text-gray-500">
+        <MapPinIcon className="w-12 h-12 mx-auto mb-4 text-gray-300" />
+        <p>{emptyMessage}</p>
+      </div>
+    );
+  }
+
+  return (
+    <div className="divide-y divide-gray-200">
+      {stores.map(({ store, distanceKm }) => (
+        <StoreCard
+          key={store.id}
+          store={store}
+          distance={distanceKm}
+          isSelected={store.id === selectedId}
+          onSelect={() => onSelect?.(store)}
+          onGetDirections={() => onGetDirections?.(store)}
+        />
+      ))}
+    </div>
+  );
+}
+
+interface StoreCardProps<T extends Store> {
+  store: T;
+  distance: number;
+  isSelected?: boolean;
+  onSelect?: () => void;
+  onGetDirections?: () => void;
+}
+
+export function StoreCard<T extends Store>({
+  store,
+  distance,
+  isSelected = false,
+  onSelect,
+  onGetDirections,
+}: StoreCardProps<T>) {
+  return (
+    <div
+      className={`p-4 cursor-pointer transition hover:bg-gray-50 ${isSelected ? 'bg-blue-50 border-l-4 border-blue-500' : ''}`}
+      onClick={onSelect}
+    >
+      <div className="flex justify-between items-start">
+        <div className="flex-1">
+          <h3 className="font-medium text-gray-900">{store.name}</h3>
+          {store.address && (
+            <p className="text-sm text-gray-600 mt-1">{store.address}</p>
+          )}
+          {store.phone && (
+            <p className="text-sm text-gray-500 mt-1">
+              <a href={`tel:${store.phone}`} className="hover:text-blue-600" onClick={e => e.stopPropagation()}>
+                {store.phone}
+              </a>
+            </p>
+          )}
+        </div>
+        <div className="text-right ml-4">
+          <span className="text-sm font-medium text-gray-700">
+            {formatDistance(distance)}
+          </span>
+        </div>
+      </div>
+      
+      <div className="mt-3 flex gap-2">
+        <button
+          onClick={(e) => {
+            e.stopPropagation();
+            onGetDirections?.();
+          }}
+          className="text-sm text-blue-600 hover:text-blue-800 flex items-center gap-1"
+        >
+          <DirectionsIcon className="w-4 h-4" />
+          Directions
+        </button>
+      </div>
+    </div>
+  );
+}
+
+function MapPinIcon({ className }: { className?: string }) {
+  return (
+    <svg className={className} fill="none" viewBox="0 0 24 24" stroke="currentColor">
+      <path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M17.657 16.657L13.414 20.9a1.998 1.998 0 01-2.827 0l-4.244-4.243a8 8 0 1111.314 0z" />
+      <path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M15 11a3 3 0 11-6 0 3 3 0 016 0z" />
+    </svg>
+  );
+}
+
+function DirectionsIcon({ className }: { className?: string }) {
+  return (
+    <svg className={className} fill="none" viewBox="0 0 24 24" stroke="currentColor">
+      <path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M9 20l-5.447-2.724A1 1 0 013 16.382V5.618a1 1 0 011.447-.894L9 7m0 13l6-3m-6 3V7m6 10l4.553 2.276A1 1 0 0021 18.382V7.618a1 1 0 00-.553-.894L15 4m0 13V4m0 0L9 7" />
+    </svg>
+  );
+}
+
+
@@ -0,0 +1,260 @@
+// StoreLocator.tsx
+// Generated by protoc-gen-geo
+
+import React, { useState, useEffect, useCallback } from 'react';
+import { MapView } from './MapView';
+import { StoreList } from './StoreList';
+import { useGeolocation } from './useGeolocation';
+import type { Coordinates, BoundingBox } from './geo_types';
+
+interface Store {
+  id: string;
+  name: string;
+  address?: string;
+  phone?: string;
+  latitude: number;
+  longitude: number;
+}
+
+interface StoreWithDistance<T extends Store> {
+  store: T;
+  distanceKm: number;
+}
+
+interface StoreLocatorProps<T extends Store> {
+  findNearest: (lat: number, lng: number, radiusKm: number, limit: number) => Promise<StoreWithDistance<T>[]>;
+  defaultCenter?: Coordinates;
+  defaultRadius?: number;
+  title?: string;
+  className?: string;
+}
+
+export function StoreLocator<T extends Store>({
+  findNearest,
+  defaultCenter = { latitude: 37.7749, longitude: -122.4194 },
+  defaultRadius = 50,
+  title = 'Find a Location',
+  className = '',
+}: StoreLocatorProps<T>) {
+  const { coordinates: userLocation, isLoading: locationLoading, error: locationError } = useGeolocation();
+  const [stores, setStores] = useState<StoreWithDistance<T>[]>([]);
+  const [selectedStore, setSelectedStore] = useState<T | null>(null);
+  const [isLoading, setIsLoading] = useState(false);
+  const [searchRadius, setSearchRadius] = useState(defaultRadius);
+  const [mapCenter, setMapCenter] = useState<Coordinates>(defaultCenter);
+
+  // Update center when user location is available
+  useEffect(() => {
+    if (userLocation) {
+      setMapCenter(userLocation);
+    }
+  }, [userLocation]);
+
+  // Fetch stores when center changes
+  const loadStores = useCallback(async (center: Coordinates) => {
+    setIsLoading(true);
+    try {
+      const results = await findNearest(center.latitude, center.longitude, searchRadius, 20);
+      setStores(results);
+    } catch (error) {
+      console.error('Failed to fetch stores:', error);
+      setStores([]);
+    } finally {
+      setIsLoading(false);
+    }
+  }, [findNearest, searchRadius]);
+
+  useEffect(() => {
+    loadStores(mapCenter);
+  }, [mapCenter, loadStores]);
+
+  // Convert stores to map markers
+  const markers = stores.map(({ store }) => ({
+    id: store.id,
+    position: { latitude: store.latitude, longitude: store.longitude },
+    title: store.name,
+  }));
+
+  // Handle getting directions
+  const handleGetDirections = (store: T) => {
+    const origin = userLocation 
+      ? `${userLocation.latitude},${userLocation.longitude}`
+      : '';
+    const destination = `${store.latitude},${store.longitude}`;
+    const url = `https://www.google.com/maps/dir/${origin}/${destination}`;
+    window.open(url, '_blank');
+  };
+
+  return (
+    <div className={`flex flex-col lg:flex-row h-[600px] ${className}`}>
+      {/* Sidebar */}
+      <div className="w-full lg:w-96 flex flex-col bg-white border-r">
+        <div className="p-4 border-b">
+          <h2 className="text-xl font-semibold">{title}</h2>
+          
+          {/* Search radius selector */}
+          <div className="mt-3">
+            <label className="text-sm text-gray-600">Search radius</label>
+            <select
+              value={searchRadius}
+              onChange={(e) => setSearchRadius(Number(e.target.value))}
+              className="mt-1 block w-full px-3 py-2 border border-gray-300 rounded-md text-sm"
+            >
+              <option value={10}>10 km</option>
+              <option value={25}>25 km</option>
+              <option value={50}>50 km</option>
+              <option value={100}>100 km</option>
+            </select>
+          </div>
+
+          {/* Location status */}
+          {locationLoading && (
+            <p className="mt-2 text-sm text-gray-500">Getting your location...</p>
+          )}
+          {locationError && (
+            <p className="mt-2 text-sm text-red-500">{locationError}</p>
+          )}
+          {userLocation && (
+            <button
+              onClick={() => setMapCenter(userLocation)}
+              className="mt-2 text-sm text-blue-600 hover:text-blue-800"
+            >
+              Center on my location
+            </button>
+          )}
+        </div>
+
+        {/* Store list */}
+        <div className="flex-1 overflow-y-auto">
+          <StoreList
+            stores={stores}
+            selectedId={selectedStore?.id}
+            onSelect={(store) => {
+              setSelectedStore(store);
+              setMapCenter({ latitude: store.latitude, longitude: store.longitude });
+            }}
+            onGetDirections={handleGetDirections}
+            isLoading={isLoading}
+            emptyMessage="No locations found in this area"
+          />
+        </div>
+
+        {/* Results count */}
+        {!isLoading && stores.length > 0 && (
+          <div className="p-3 border-t text-sm text-gray-500">
+            {stores.length} location{stores.length !== 1 ? 's' : ''} found
+          </div>
+        )}
+      </div>
+
+      {/* Map */}
+      <div className="flex-1 h-64 lg:h-full">
+        <MapView
+          center={mapCenter}
+          zoom={12}
+          markers={markers}
+          selectedMarkerId={selectedStore?.id}
+          onMarkerClick={(id) => {
+            const found = stores.find(s => s.store.id === id);
+            if (found) setSelectedStore(found.store);
+          }}
+          onCenterChange={setMapCenter}
+          userLocation={userLocation}
+          showUserLocation={!!userLocation}
+          height="100%"
+        />
+      </div>
+    </div>
+  );
+}
+
+// =============================================================================
+// CONNECT CLIENT HOOK
+// =============================================================================
+
+// Creates a findNearest function from a Connect client
+// Usage:
+//   import { createConnectTransport } from '@connectrpc/connect-web';
+//   import { createClient } from '@connectrpc/connect';
+//   import { StoreLocatorService } from './gen/store/v1/store_connect';
+//
+//   const transport = createConnectTransport({ baseUrl: '/api' });
+//   const client = createClient(StoreLocatorService, transport);
+//   const { findNearest } = useStoreLocatorClient(client);
+//
+//   <StoreLocator findNearest={findNearest} />
+
+export function useStoreLocatorClient<
+  TStore extends Store,
+  TClient extends {
+    findNearest: (req: { latitude: number; longitude: number; radiusKm: number; limit: number }) 
+      => Promise<{ stores: Array<{ store: TStore; distanceKm: number }> }>;
+  }
+>(client: TClient) {
+  const findNearest = useCallback(
+    async (lat: number, lng: number, radiusKm: number, limit: number) => {
+      const response = await client.findNearest({
+        latitude: lat,
+        longitude: lng,
+        radiusKm,
+        limit,
+      });
+      return response.stores.map(s => ({
+        store: s.store,
+        distanceKm: s.distanceKm,
+      }));
+    },
+    [client]
+  );
+
+  return { findNearest };
+}
+
+// =============================================================================
+// REACT QUERY HOOK (Optional)
+// =============================================================================
+
+// For use with @tanstack/react-query
+// Usage:
+//   const { data, isLoading } = useNearestStores(client, center, radius);
+
+export function useNearestStoresQuery<
+  TStore extends Store,
+  TClient extends {
+    findNearest: (req: { latitude: number; longitude: number; radiusKm: number; limit: number }) 
+      => Promise<{ stores: Array<{ store: TStore; distanceKm: number }> }>;
+  }
+>(
+  client: TClient,
+  center: Coordinates | null,
+  radiusKm: number = 50,
+  limit: number = 20
+) {
+  const [stores, setStores] = useState<StoreWithDistance<TStore>[]>([]);
+  const [isLoading, setIsLoading] = useState(false);
+  const [error, setError] = useState<Error | null>(null);
+
+  useEffect(() => {
+    if (!center) return;
+
+    setIsLoading(true);
+    setError(null);
+
+    client
+      .findNearest({ latitude: center.latitude, longitude: center.longitude, radiusKm, limit })
+      .then((response) => {
+        setStores(response.stores.map(s => ({ store: s.store, distanceKm: s.distanceKm })));
+      })
+      .catch((err) => {
+        setError(err);
+        setStores([]);
+      })
+      .finally(() => {
+        setIsLoading(false);
+      });
+  }, [client, center?.latitude, center?.longitude, radiusKm, limit]);
+
+  return { stores, isLoading, error };
+}
+
+
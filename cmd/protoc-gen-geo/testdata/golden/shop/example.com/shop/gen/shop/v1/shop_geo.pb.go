@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-geo. DO NOT EDIT.
+// Geolocation features: distance calculation, spatial queries, store locator
+
+package shopv1
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// CONSTANTS
+// =============================================================================
+
+const (
+	// Earth's radius in kilometers
+	EarthRadiusKm = 6371.0
+	// Earth's radius in miles
+	EarthRadiusMiles = 3959.0
+	// Default search radius in km
+	DefaultRadiusKm = 50.0
+	// Default result limit
+	DefaultLimit = 20
+	// GeoHash precision (characters)
+	GeoHashPrecision = 7
+)
+
+// =============================================================================
+// GEO TYPES
+// =============================================================================
+
+// Coordinates represents a geographic point
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// BoundingBox represents a geographic rectangle
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// GeoResult wraps an item with its distance from a reference point
+type GeoResult[T any] struct {
+	Item       T
+	DistanceKm float64
+}
+
+// FindNearestOptions configures nearest-neighbor search
+type FindNearestOptions struct {
+	Center   Coordinates
+	RadiusKm float64
+	Limit    int
+}
+
+// GeoLocatable interface for items with coordinates
+type GeoLocatable interface {
+	GetCoordinates() Coordinates
+	GetID() string
+}
+
+// =============================================================================
+// HAVERSINE DISTANCE CALCULATION
+// =============================================================================
+
+// Haversine calculates the great-circle distance between two points
+// using the Haversine formula. Returns distance in kilometers.
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	// Convert to radians
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	lng1Rad := lng1 * math.Pi / 180
+	lng2Rad := lng2 * math.Pi / 180
+
+	// Differences
+	dLat := lat2Rad - lat1Rad
+	dLng := lng2Rad - lng1Rad
+
+	// Haversine formula
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
+			math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return EarthRadiusKm * c
+}
+
+// HaversineCoords calculates distance between two Coordinates
+func HaversineCoords(c1, c2 Coordinates) float64 {
+	return Haversine(c1.Latitude, c1.Longitude, c2.Latitude, c2.Longitude)
+}
+
+// HaversineMiles calculates distance in miles
+func HaversineMiles(lat1, lng1, lat2, lng2 float64) float64 {
+	return Haversine(lat1, lng1, lat2, lng2) * 0.621371
+}
+
+// =============================================================================
+// BOUNDING BOX
+// =============================================================================
+
+// BoundingBoxFromCenter creates a bounding box around a center point
+func BoundingBoxFromCenter(center Coordinates, radiusKm float64) BoundingBox {
+	// Latitude: 1 degree ≈ 111 km
+	latDelta := radiusKm / 111.0
+
+	// Longitude: varies by latitude
+	lngDelta := radiusKm / (111.0 * math.Cos(center.Latitude*math.Pi/180))
+
+	return BoundingBox{
+		MinLat: center.Latitude - latDelta,
+		MaxLat: center.Latitude + latDelta,
+		MinLng: center.Longitude - lngDelta,
+		MaxLng: center.Longitude + lngDelta,
+	}
+}
+
+// Contains checks if a point is within the bounding box
+func (bb BoundingBox) Contains(c Coordinates) bool {
+	return c.Latitude >= bb.MinLat &&
+		c.Latitude <= bb.MaxLat &&
+		c.Longitude >= bb.MinLng &&
+		c.Longitude <= bb.MaxLng
+}
+
+// Expand increases the bounding box by a factor
+func (bb BoundingBox) Expand(factor float64) BoundingBox {
+	latDelta := (bb.MaxLat - bb.MinLat) * (factor - 1) / 2
+	lngDelta := (bb.MaxLng - bb.MinLng) * (factor - 1) / 2
+	return BoundingBox{
+		MinLat: bb.MinLat - latDelta,
+		MaxLat: bb.MaxLat + latDelta,
+		MinLng: bb.MinLng - lngDelta,
+		MaxLng: bb.MaxLng + lngDelta,
+	}
+}
+
+// =============================================================================
+// GEOHASH
+// =============================================================================
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeoHash encodes coordinates to a geohash string
+func EncodeGeoHash(lat, lng float64, precision int) string {
+	if precision <= 0 {
+		precision = GeoHashPrecision
+	}
+
+	var (
+		minLat, maxLat = -90.0, 90.0
+		minLng, maxLng = -180.0, 180.0
+		hash           = make([]byte, precision)
+		bit            = 0
+		ch             = 0
+		isLng          = true
+	)
+
+	for i := 0; i < precision; i++ {
+		for j := 0; j < 5; j++ {
+			if isLng {
+				mid := (minLng + maxLng) / 2
+				if lng >= mid {
+					ch |= 1 << (4 - bit)
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if lat >= mid {
+					ch |= 1 << (4 - bit)
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			isLng = !isLng
+			bit++
+			if bit == 5 {
+				hash[i] = base32[ch]
+				bit = 0
+				ch = 0
+			}
+		}
+	}
+
+	return string(hash)
+}
+
+// GeoHashNeighbors returns the 8 neighboring geohash cells
+func GeoHashNeighbors(hash string) []string {
+	// Simplified: return prefix matches for nearby search
+	if len(hash) <= 1 {
+		return []string{hash}
+	}
+	prefix := hash[:len(hash)-1]
+	neighbors := make([]string, 0, 32)
+	for _, c := range base32 {
+		neighbors = append(neighbors, prefix+string(c))
+	}
+	return neighbors
+}
+
+// =============================================================================
+// SPATIAL INDEX (GeoHash-based)
+// =============================================================================
+
+// SpatialIndex provides fast spatial lookups using geohash
+type SpatialIndex[T GeoLocatable] struct {
+	mu        sync.RWMutex
+	items     map[string]T        // id -> item
+	geoIndex  map[string][]string // geohash -> []id
+	precision int
+}
+
+// NewSpatialIndex creates a new spatial index
+func NewSpatialIndex[T GeoLocatable](precision int) *SpatialIndex[T] {
+	if precision <= 0 {
+		precision = GeoHashPrecision
+	}
+	return &SpatialIndex[T]{
+		items:     make(map[string]T),
+		geoIndex:  make(map[string][]string),
+		precision: precision,
+	}
+}
+
+// Insert adds an item to the index
+func (idx *SpatialIndex[T]) Insert(item T) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	id := item.GetID()
+	coords := item.GetCoordinates()
+	hash := EncodeGeoHash(coords.Latitude, coords.Longitude, idx.precision)
+
+	idx.items[id] = item
+	idx.geoIndex[hash] = append(idx.geoIndex[hash], id)
+}
+
+// Remove removes an item from the index
+func (idx *SpatialIndex[T]) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	item, ok := idx.items[id]
+	if !ok {
+		return
+	}
+
+	coords := item.GetCoordinates()
+	hash := EncodeGeoHash(coords.Latitude, coords.Longitude, idx.precision)
+
+	delete(idx.items, id)
+
+	// Remove from geohash index
+	ids := idx.geoIndex[hash]
+	for i, itemID := range ids {
+		if itemID == id {
+			idx.geoIndex[hash] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// FindNearest finds items near a point within radius
+func (idx *SpatialIndex[T]) FindNearest(opts FindNearestOptions) []GeoResult[T] {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if opts.RadiusKm <= 0 {
+		opts.RadiusKm = DefaultRadiusKm
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+
+	// Get candidate geohashes
+	centerHash := EncodeGeoHash(opts.Center.Latitude, opts.Center.Longitude, idx.precision)
+	candidateHashes := GeoHashNeighbors(centerHash)
+	candidateHashes = append(candidateHashes, centerHash)
+
+	// Collect candidate items
+	seen := make(map[string]bool)
+	var results []GeoResult[T]
+
+	for _, hash := range candidateHashes {
+		for _, id := range idx.geoIndex[hash] {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			item := idx.items[id]
+			coords := item.GetCoordinates()
+			dist := HaversineCoords(opts.Center, coords)
+
+			if dist <= opts.RadiusKm {
+				results = append(results, GeoResult[T]{
+					Item:       item,
+					DistanceKm: dist,
+				})
+			}
+		}
+	}
+
+	// Sort by distance
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].DistanceKm < results[j].DistanceKm
+	})
+
+	// Limit results
+	if len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	return results
+}
+
+// FindWithinBox finds items within a bounding box
+func (idx *SpatialIndex[T]) FindWithinBox(bb BoundingBox) []T {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var results []T
+	for _, item := range idx.items {
+		coords := item.GetCoordinates()
+		if bb.Contains(coords) {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
+// Count returns the number of indexed items
+func (idx *SpatialIndex[T]) Count() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.items)
+}
+
+// =============================================================================
+// GEO-ENABLED REPOSITORIES
+// =============================================================================
+
+// StoreGeoWrapper wraps Store to implement GeoLocatable
+type StoreGeoWrapper struct {
+	*Store
+}
+
+func (w *StoreGeoWrapper) GetCoordinates() Coordinates {
+	return Coordinates{
+		Latitude:  w.Store.GetLatitude(),
+		Longitude: w.Store.GetLongitude(),
+	}
+}
+
+func (w *StoreGeoWrapper) GetID() string {
+	return w.Store.GetId()
+}
+
+// StoreGeoRepository adds geo queries to StoreRepository
+type StoreGeoRepository struct {
+	repo  StoreRepository
+	index *SpatialIndex[*StoreGeoWrapper]
+}
+
+// NewGeoStoreRepository creates a geo-enabled repository
+func NewGeoStoreRepository(repo StoreRepository) *StoreGeoRepository {
+	return &StoreGeoRepository{
+		repo:  repo,
+		index: NewSpatialIndex[*StoreGeoWrapper](GeoHashPrecision),
+	}
+}
+
+// Create creates and indexes a store
+func (r *StoreGeoRepository) Create(ctx context.Context, item *Store) (string, error) {
+	id, err := r.repo.Create(ctx, item)
+	if err != nil {
+		return "", err
+	}
+	r.index.Insert(&StoreGeoWrapper{item})
+	return id, nil
+}
+
+// Update updates and re-indexes a store
+func (r *StoreGeoRepository) Update(ctx context.Context, item *Store) error {
+	if err := r.repo.Update(ctx, item); err != nil {
+		return err
+	}
+	r.index.Remove(item.GetId())
+	r.index.Insert(&StoreGeoWrapper{item})
+	return nil
+}
+
+// Delete removes a store from repo and index
+func (r *StoreGeoRepository) Delete(ctx context.Context, id string) error {
+	if err := r.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.index.Remove(id)
+	return nil
+}
+
+// Get retrieves a store by ID
+func (r *StoreGeoRepository) Get(ctx context.Context, id string) (*Store, error) {
+	return r.repo.Get(ctx, id)
+}
+
+// List retrieves all stores
+func (r *StoreGeoRepository) List(ctx context.Context, limit int) ([]*Store, error) {
+	return r.repo.List(ctx, limit)
+}
+
+// FindNearest finds stores near a point
+func (r *StoreGeoRepository) FindNearest(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]*StoreWithDistance, error) {
+	results := r.index.FindNearest(FindNearestOptions{
+		Center:   Coordinates{Latitude: lat, Longitude: lng},
+		RadiusKm: radiusKm,
+		Limit:    limit,
+	})
+
+	out := make([]*StoreWithDistance, len(results))
+	for i, r := range results {
+		out[i] = &StoreWithDistance{
+			Store:      r.Item.Store,
+			DistanceKm: r.DistanceKm,
+		}
+	}
+	return out, nil
+}
+
+// FindWithinBox finds stores within a bounding box
+func (r *StoreGeoRepository) FindWithinBox(ctx context.Context, minLat, maxLat, minLng, maxLng float64) ([]*Store, error) {
+	wrappers := r.index.FindWithinBox(BoundingBox{
+		MinLat: minLat,
+		MaxLat: maxLat,
+		MinLng: minLng,
+		MaxLng: maxLng,
+	})
+
+	out := make([]*Store, len(wrappers))
+	for i, w := range wrappers {
+		out[i] = w.Store
+	}
+	return out, nil
+}
+
+// RebuildIndex rebuilds the spatial index from the repository
+func (r *StoreGeoRepository) RebuildIndex(ctx context.Context) error {
+	items, err := r.repo.List(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	r.index = NewSpatialIndex[*StoreGeoWrapper](GeoHashPrecision)
+	for _, item := range items {
+		r.index.Insert(&StoreGeoWrapper{item})
+	}
+	return nil
+}
+
+// StoreWithDistance pairs a Store with its distance from a reference point
+type StoreWithDistance struct {
+	*Store
+	DistanceKm float64
+}
+
+// =============================================================================
+// GEO SERVICE
+// =============================================================================
+
+// StoreLocatorService provides store-locator functionality for Store
+type StoreLocatorService struct {
+	repo *StoreGeoRepository
+}
+
+// NewStoreLocatorService creates a new locator service
+func NewStoreLocatorService(repo *StoreGeoRepository) *StoreLocatorService {
+	return &StoreLocatorService{repo: repo}
+}
+
+// FindNearest finds the nearest Stores to a location
+func (s *StoreLocatorService) FindNearest(ctx context.Context, lat, lng float64, radiusKm float64, limit int) ([]*StoreWithDistance, error) {
+	if radiusKm <= 0 {
+		radiusKm = DefaultRadiusKm
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return s.repo.FindNearest(ctx, lat, lng, radiusKm, limit)
+}
+
+// FindWithinBounds finds Stores within a map viewport
+func (s *StoreLocatorService) FindWithinBounds(ctx context.Context, minLat, maxLat, minLng, maxLng float64) ([]*Store, error) {
+	return s.repo.FindWithinBox(ctx, minLat, maxLat, minLng, maxLng)
+}
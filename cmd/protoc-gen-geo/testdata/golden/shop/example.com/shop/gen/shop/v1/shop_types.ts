@@ -0,0 +1,27 @@
+// geo_types.ts
+// Generated by protoc-gen-geo
+
+export interface Coordinates {
+  latitude: number;
+  longitude: number;
+}
+
+export interface BoundingBox {
+  minLat: number;
+  maxLat: number;
+  minLng: number;
+  maxLng: number;
+}
+
+export interface StoreWithDistance<T> {
+  store: T;
+  distanceKm: number;
+}
+
+export interface MapViewport {
+  center: Coordinates;
+  zoom: number;
+  bounds?: BoundingBox;
+}
+
+
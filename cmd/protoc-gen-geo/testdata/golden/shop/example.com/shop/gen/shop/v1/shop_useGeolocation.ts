@@ -0,0 +1,111 @@
+// useGeolocation.ts
+// Generated by protoc-gen-geo
+
+import { useState, useEffect, useCallback } from 'react';
+import type { Coordinates } from './geo_types';
+
+interface GeolocationState {
+  coordinates: Coordinates | null;
+  error: string | null;
+  isLoading: boolean;
+}
+
+interface UseGeolocationOptions {
+  enableHighAccuracy?: boolean;
+  timeout?: number;
+  maximumAge?: number;
+}
+
+export function useGeolocation(options: UseGeolocationOptions = {}) {
+  const [state, setState] = useState<GeolocationState>({
+    coordinates: null,
+    error: null,
+    isLoading: true,
+  });
+
+  const { enableHighAccuracy = true, timeout = 10000, maximumAge = 0 } = options;
+
+  const getCurrentPosition = useCallback(() => {
+    setState(s => ({ ...s, isLoading: true, error: null }));
+
+    if (!navigator.geolocation) {
+      setState({
+        coordinates: null,
+        error: 'Geolocation is not supported by your browser',
+        isLoading: false,
+      });
+      return;
+    }
+
+    navigator.geolocation.getCurrentPosition(
+      (position) => {
+        setState({
+          coordinates: {
+            latitude: position.coords.latitude,
+            longitude: position.coords.longitude,
+          },
+          error: null,
+          isLoading: false,
+        });
+      },
+      (error) => {
+        let message = 'Failed to get location';
+        switch (error.code) {
+          case error.PERMISSION_DENIED:
+            message = 'Location permission denied';
+            break;
+          case error.POSITION_UNAVAILABLE:
+            message = 'Location information unavailable';
+            break;
+          case error.TIMEOUT:
+            message = 'Location request timed out';
+            break;
+        }
+        setState({
+          coordinates: null,
+          error: message,
+          isLoading: false,
+        });
+      },
+      { enableHighAccuracy, timeout, maximumAge }
+    );
+  }, [enableHighAccuracy, timeout, maximumAge]);
+
+  useEffect(() => {
+    getCurrentPosition();
+  }, [getCurrentPosition]);
+
+  return { ...state, refresh: getCurrentPosition };
+}
+
+// Calculate distance between two points (Haversine formula)
+export function calculateDistance(
+  lat1: number,
+  lng1: number,
+  lat2: number,
+  lng2: number
+): number {
+  const R = 6371; // Earth's radius in km
+  const dLat = toRad(lat2 - lat1);
+  const dLng = toRad(lng2 - lng1);
+  const a =
+    Math.sin(dLat / 2) * Math.sin(dLat / 2) +
+    Math.cos(toRad(lat1)) * Math.cos(toRad(lat2)) *
+    Math.sin(dLng / 2) * Math.sin(dLng / 2);
+  const c = 2 * Math.atan2(Math.sqrt(a), Math.sqrt(1 - a));
+  return R * c;
+}
+
+function toRad(deg: number): number {
+  return deg * (Math.PI / 180);
+}
+
+// Format distance for display
+export function formatDistance(km: number): string {
+  if (km < 1) {
+    return `${Math.round(km * 1000)} m`;
+  }
+  return `${km.toFixed(1)} km`;
+}
+
+
@@ -0,0 +1,1281 @@
+// Code generated by protoc-gen-graphql. DO NOT EDIT.
+package shopv1
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Resolver is the root resolver
+type Resolver struct {
+	UserRepo              UserRepository
+	StoreRepo             StoreRepository
+	CreateUserRequestRepo CreateUserRequestRepository
+	GetUserRequestRepo    GetUserRequestRepository
+	UpdateUserRequestRepo UpdateUserRequestRepository
+	DeleteUserRequestRepo DeleteUserRequestRepository
+	ListUsersRequestRepo  ListUsersRequestRepository
+	ListUsersResponseRepo ListUsersResponseRepository
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+// User Query Resolvers
+func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {
+	return r.UserRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) Users(ctx context.Context, limit *int, offset *int, filter *UserFilter) ([]*User, error) {
+	items, err := r.UserRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyUserFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// User Mutation Resolvers
+func (r *mutationResolver) CreateUser(ctx context.Context, input UserInput) (*User, error) {
+	entity := input.ToUser()
+	id, err := r.UserRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateUser(ctx context.Context, id string, input UserInput) (*User, error) {
+	entity := input.ToUser()
+	entity.Id = id
+	if err := r.UserRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteUser(ctx context.Context, id string) (bool, error) {
+	if err := r.UserRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// User Subscription Resolvers
+func (r *subscriptionResolver) UserCreated(ctx context.Context) (<-chan *User, error) {
+	ch := make(chan *User, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) UserUpdated(ctx context.Context, id *string) (<-chan *User, error) {
+	ch := make(chan *User, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) UserDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type UserInput struct {
+	UserId    string
+	Email     string
+	Name      string
+	OrgId     string
+	Role      interface{}
+	Age       int32
+	Active    bool
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+	Etag      string
+}
+
+func (i *UserInput) ToUser() *User {
+	return &User{
+		UserId:    i.UserId,
+		Email:     i.Email,
+		Name:      i.Name,
+		OrgId:     i.OrgId,
+		Role:      i.Role,
+		Age:       i.Age,
+		Active:    i.Active,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+		DeletedAt: i.DeletedAt,
+		Etag:      i.Etag,
+	}
+}
+
+type UserFilter struct {
+	UserId           *string
+	UserIdContains   *string
+	UserIdStartsWith *string
+	Email            *string
+	EmailContains    *string
+	EmailStartsWith  *string
+	Name             *string
+	NameContains     *string
+	NameStartsWith   *string
+	OrgId            *string
+	OrgIdContains    *string
+	OrgIdStartsWith  *string
+	Age              *int
+	AgeGt            *int
+	AgeGte           *int
+	AgeLt            *int
+	AgeLte           *int
+	Active           *bool
+	Etag             *string
+	EtagContains     *string
+	EtagStartsWith   *string
+	AND              []*UserFilter
+	OR               []*UserFilter
+}
+
+func applyUserFilter(items []*User, f *UserFilter) []*User {
+	var result []*User
+	for _, item := range items {
+		if matchesUser(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesUser(item *User, f *UserFilter) bool {
+	if f.UserId != nil && item.UserId != *f.UserId {
+		return false
+	}
+	if f.UserIdContains != nil && !strings.Contains(item.UserId, *f.UserIdContains) {
+		return false
+	}
+	if f.UserIdStartsWith != nil && !strings.HasPrefix(item.UserId, *f.UserIdStartsWith) {
+		return false
+	}
+	if f.Email != nil && item.Email != *f.Email {
+		return false
+	}
+	if f.EmailContains != nil && !strings.Contains(item.Email, *f.EmailContains) {
+		return false
+	}
+	if f.EmailStartsWith != nil && !strings.HasPrefix(item.Email, *f.EmailStartsWith) {
+		return false
+	}
+	if f.Name != nil && item.Name != *f.Name {
+		return false
+	}
+	if f.NameContains != nil && !strings.Contains(item.Name, *f.NameContains) {
+		return false
+	}
+	if f.NameStartsWith != nil && !strings.HasPrefix(item.Name, *f.NameStartsWith) {
+		return false
+	}
+	if f.OrgId != nil && item.OrgId != *f.OrgId {
+		return false
+	}
+	if f.OrgIdContains != nil && !strings.Contains(item.OrgId, *f.OrgIdContains) {
+		return false
+	}
+	if f.OrgIdStartsWith != nil && !strings.HasPrefix(item.OrgId, *f.OrgIdStartsWith) {
+		return false
+	}
+	if f.Etag != nil && item.Etag != *f.Etag {
+		return false
+	}
+	if f.EtagContains != nil && !strings.Contains(item.Etag, *f.EtagContains) {
+		return false
+	}
+	if f.EtagStartsWith != nil && !strings.HasPrefix(item.Etag, *f.EtagStartsWith) {
+		return false
+	}
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesUser(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesUser(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// Store Query Resolvers
+func (r *queryResolver) Store(ctx context.Context, id string) (*Store, error) {
+	return r.StoreRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) Stores(ctx context.Context, limit *int, offset *int, filter *StoreFilter) ([]*Store, error) {
+	items, err := r.StoreRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyStoreFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// Store Mutation Resolvers
+func (r *mutationResolver) CreateStore(ctx context.Context, input StoreInput) (*Store, error) {
+	entity := input.ToStore()
+	id, err := r.StoreRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateStore(ctx context.Context, id string, input StoreInput) (*Store, error) {
+	entity := input.ToStore()
+	entity.Id = id
+	if err := r.StoreRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteStore(ctx context.Context, id string) (bool, error) {
+	if err := r.StoreRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Store Subscription Resolvers
+func (r *subscriptionResolver) StoreCreated(ctx context.Context) (<-chan *Store, error) {
+	ch := make(chan *Store, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) StoreUpdated(ctx context.Context, id *string) (<-chan *Store, error) {
+	ch := make(chan *Store, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) StoreDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type StoreInput struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+func (i *StoreInput) ToStore() *Store {
+	return &Store{
+		Name:      i.Name,
+		Latitude:  i.Latitude,
+		Longitude: i.Longitude,
+	}
+}
+
+type StoreFilter struct {
+	Id             *string
+	IdContains     *string
+	IdStartsWith   *string
+	Name           *string
+	NameContains   *string
+	NameStartsWith *string
+	AND            []*StoreFilter
+	OR             []*StoreFilter
+}
+
+func applyStoreFilter(items []*Store, f *StoreFilter) []*Store {
+	var result []*Store
+	for _, item := range items {
+		if matchesStore(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesStore(item *Store, f *StoreFilter) bool {
+	if f.Id != nil && item.Id != *f.Id {
+		return false
+	}
+	if f.IdContains != nil && !strings.Contains(item.Id, *f.IdContains) {
+		return false
+	}
+	if f.IdStartsWith != nil && !strings.HasPrefix(item.Id, *f.IdStartsWith) {
+		return false
+	}
+	if f.Name != nil && item.Name != *f.Name {
+		return false
+	}
+	if f.NameContains != nil && !strings.Contains(item.Name, *f.NameContains) {
+		return false
+	}
+	if f.NameStartsWith != nil && !strings.HasPrefix(item.Name, *f.NameStartsWith) {
+		return false
+	}
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesStore(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesStore(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateUserRequest Query Resolvers
+func (r *queryResolver) CreateUserRequest(ctx context.Context, id string) (*CreateUserRequest, error) {
+	return r.CreateUserRequestRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) CreateUserRequests(ctx context.Context, limit *int, offset *int, filter *CreateUserRequestFilter) ([]*CreateUserRequest, error) {
+	items, err := r.CreateUserRequestRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyCreateUserRequestFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// CreateUserRequest Mutation Resolvers
+func (r *mutationResolver) CreateCreateUserRequest(ctx context.Context, input CreateUserRequestInput) (*CreateUserRequest, error) {
+	entity := input.ToCreateUserRequest()
+	id, err := r.CreateUserRequestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateCreateUserRequest(ctx context.Context, id string, input CreateUserRequestInput) (*CreateUserRequest, error) {
+	entity := input.ToCreateUserRequest()
+	entity.Id = id
+	if err := r.CreateUserRequestRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteCreateUserRequest(ctx context.Context, id string) (bool, error) {
+	if err := r.CreateUserRequestRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateUserRequest Subscription Resolvers
+func (r *subscriptionResolver) CreateUserRequestCreated(ctx context.Context) (<-chan *CreateUserRequest, error) {
+	ch := make(chan *CreateUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) CreateUserRequestUpdated(ctx context.Context, id *string) (<-chan *CreateUserRequest, error) {
+	ch := make(chan *CreateUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) CreateUserRequestDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type CreateUserRequestInput struct {
+	User *User
+}
+
+func (i *CreateUserRequestInput) ToCreateUserRequest() *CreateUserRequest {
+	return &CreateUserRequest{
+		User: i.User,
+	}
+}
+
+type CreateUserRequestFilter struct {
+	AND []*CreateUserRequestFilter
+	OR  []*CreateUserRequestFilter
+}
+
+func applyCreateUserRequestFilter(items []*CreateUserRequest, f *CreateUserRequestFilter) []*CreateUserRequest {
+	var result []*CreateUserRequest
+	for _, item := range items {
+		if matchesCreateUserRequest(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesCreateUserRequest(item *CreateUserRequest, f *CreateUserRequestFilter) bool {
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesCreateUserRequest(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesCreateUserRequest(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// GetUserRequest Query Resolvers
+func (r *queryResolver) GetUserRequest(ctx context.Context, id string) (*GetUserRequest, error) {
+	return r.GetUserRequestRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) GetUserRequests(ctx context.Context, limit *int, offset *int, filter *GetUserRequestFilter) ([]*GetUserRequest, error) {
+	items, err := r.GetUserRequestRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyGetUserRequestFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// GetUserRequest Mutation Resolvers
+func (r *mutationResolver) CreateGetUserRequest(ctx context.Context, input GetUserRequestInput) (*GetUserRequest, error) {
+	entity := input.ToGetUserRequest()
+	id, err := r.GetUserRequestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateGetUserRequest(ctx context.Context, id string, input GetUserRequestInput) (*GetUserRequest, error) {
+	entity := input.ToGetUserRequest()
+	entity.Id = id
+	if err := r.GetUserRequestRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteGetUserRequest(ctx context.Context, id string) (bool, error) {
+	if err := r.GetUserRequestRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetUserRequest Subscription Resolvers
+func (r *subscriptionResolver) GetUserRequestCreated(ctx context.Context) (<-chan *GetUserRequest, error) {
+	ch := make(chan *GetUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) GetUserRequestUpdated(ctx context.Context, id *string) (<-chan *GetUserRequest, error) {
+	ch := make(chan *GetUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) GetUserRequestDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type GetUserRequestInput struct {
+	UserId string
+}
+
+func (i *GetUserRequestInput) ToGetUserRequest() *GetUserRequest {
+	return &GetUserRequest{
+		UserId: i.UserId,
+	}
+}
+
+type GetUserRequestFilter struct {
+	UserId           *string
+	UserIdContains   *string
+	UserIdStartsWith *string
+	AND              []*GetUserRequestFilter
+	OR               []*GetUserRequestFilter
+}
+
+func applyGetUserRequestFilter(items []*GetUserRequest, f *GetUserRequestFilter) []*GetUserRequest {
+	var result []*GetUserRequest
+	for _, item := range items {
+		if matchesGetUserRequest(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesGetUserRequest(item *GetUserRequest, f *GetUserRequestFilter) bool {
+	if f.UserId != nil && item.UserId != *f.UserId {
+		return false
+	}
+	if f.UserIdContains != nil && !strings.Contains(item.UserId, *f.UserIdContains) {
+		return false
+	}
+	if f.UserIdStartsWith != nil && !strings.HasPrefix(item.UserId, *f.UserIdStartsWith) {
+		return false
+	}
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesGetUserRequest(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesGetUserRequest(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateUserRequest Query Resolvers
+func (r *queryResolver) UpdateUserRequest(ctx context.Context, id string) (*UpdateUserRequest, error) {
+	return r.UpdateUserRequestRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) UpdateUserRequests(ctx context.Context, limit *int, offset *int, filter *UpdateUserRequestFilter) ([]*UpdateUserRequest, error) {
+	items, err := r.UpdateUserRequestRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyUpdateUserRequestFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// UpdateUserRequest Mutation Resolvers
+func (r *mutationResolver) CreateUpdateUserRequest(ctx context.Context, input UpdateUserRequestInput) (*UpdateUserRequest, error) {
+	entity := input.ToUpdateUserRequest()
+	id, err := r.UpdateUserRequestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateUpdateUserRequest(ctx context.Context, id string, input UpdateUserRequestInput) (*UpdateUserRequest, error) {
+	entity := input.ToUpdateUserRequest()
+	entity.Id = id
+	if err := r.UpdateUserRequestRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteUpdateUserRequest(ctx context.Context, id string) (bool, error) {
+	if err := r.UpdateUserRequestRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateUserRequest Subscription Resolvers
+func (r *subscriptionResolver) UpdateUserRequestCreated(ctx context.Context) (<-chan *UpdateUserRequest, error) {
+	ch := make(chan *UpdateUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) UpdateUserRequestUpdated(ctx context.Context, id *string) (<-chan *UpdateUserRequest, error) {
+	ch := make(chan *UpdateUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) UpdateUserRequestDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type UpdateUserRequestInput struct {
+	User *User
+}
+
+func (i *UpdateUserRequestInput) ToUpdateUserRequest() *UpdateUserRequest {
+	return &UpdateUserRequest{
+		User: i.User,
+	}
+}
+
+type UpdateUserRequestFilter struct {
+	AND []*UpdateUserRequestFilter
+	OR  []*UpdateUserRequestFilter
+}
+
+func applyUpdateUserRequestFilter(items []*UpdateUserRequest, f *UpdateUserRequestFilter) []*UpdateUserRequest {
+	var result []*UpdateUserRequest
+	for _, item := range items {
+		if matchesUpdateUserRequest(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesUpdateUserRequest(item *UpdateUserRequest, f *UpdateUserRequestFilter) bool {
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesUpdateUserRequest(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesUpdateUserRequest(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteUserRequest Query Resolvers
+func (r *queryResolver) DeleteUserRequest(ctx context.Context, id string) (*DeleteUserRequest, error) {
+	return r.DeleteUserRequestRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) DeleteUserRequests(ctx context.Context, limit *int, offset *int, filter *DeleteUserRequestFilter) ([]*DeleteUserRequest, error) {
+	items, err := r.DeleteUserRequestRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyDeleteUserRequestFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// DeleteUserRequest Mutation Resolvers
+func (r *mutationResolver) CreateDeleteUserRequest(ctx context.Context, input DeleteUserRequestInput) (*DeleteUserRequest, error) {
+	entity := input.ToDeleteUserRequest()
+	id, err := r.DeleteUserRequestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateDeleteUserRequest(ctx context.Context, id string, input DeleteUserRequestInput) (*DeleteUserRequest, error) {
+	entity := input.ToDeleteUserRequest()
+	entity.Id = id
+	if err := r.DeleteUserRequestRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteDeleteUserRequest(ctx context.Context, id string) (bool, error) {
+	if err := r.DeleteUserRequestRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteUserRequest Subscription Resolvers
+func (r *subscriptionResolver) DeleteUserRequestCreated(ctx context.Context) (<-chan *DeleteUserRequest, error) {
+	ch := make(chan *DeleteUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) DeleteUserRequestUpdated(ctx context.Context, id *string) (<-chan *DeleteUserRequest, error) {
+	ch := make(chan *DeleteUserRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) DeleteUserRequestDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type DeleteUserRequestInput struct {
+	UserId string
+}
+
+func (i *DeleteUserRequestInput) ToDeleteUserRequest() *DeleteUserRequest {
+	return &DeleteUserRequest{
+		UserId: i.UserId,
+	}
+}
+
+type DeleteUserRequestFilter struct {
+	UserId           *string
+	UserIdContains   *string
+	UserIdStartsWith *string
+	AND              []*DeleteUserRequestFilter
+	OR               []*DeleteUserRequestFilter
+}
+
+func applyDeleteUserRequestFilter(items []*DeleteUserRequest, f *DeleteUserRequestFilter) []*DeleteUserRequest {
+	var result []*DeleteUserRequest
+	for _, item := range items {
+		if matchesDeleteUserRequest(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesDeleteUserRequest(item *DeleteUserRequest, f *DeleteUserRequestFilter) bool {
+	if f.UserId != nil && item.UserId != *f.UserId {
+		return false
+	}
+	if f.UserIdContains != nil && !strings.Contains(item.UserId, *f.UserIdContains) {
+		return false
+	}
+	if f.UserIdStartsWith != nil && !strings.HasPrefix(item.UserId, *f.UserIdStartsWith) {
+		return false
+	}
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesDeleteUserRequest(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesDeleteUserRequest(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// ListUsersRequest Query Resolvers
+func (r *queryResolver) ListUsersRequest(ctx context.Context, id string) (*ListUsersRequest, error) {
+	return r.ListUsersRequestRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) ListUsersRequests(ctx context.Context, limit *int, offset *int, filter *ListUsersRequestFilter) ([]*ListUsersRequest, error) {
+	items, err := r.ListUsersRequestRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyListUsersRequestFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// ListUsersRequest Mutation Resolvers
+func (r *mutationResolver) CreateListUsersRequest(ctx context.Context, input ListUsersRequestInput) (*ListUsersRequest, error) {
+	entity := input.ToListUsersRequest()
+	id, err := r.ListUsersRequestRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateListUsersRequest(ctx context.Context, id string, input ListUsersRequestInput) (*ListUsersRequest, error) {
+	entity := input.ToListUsersRequest()
+	entity.Id = id
+	if err := r.ListUsersRequestRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteListUsersRequest(ctx context.Context, id string) (bool, error) {
+	if err := r.ListUsersRequestRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListUsersRequest Subscription Resolvers
+func (r *subscriptionResolver) ListUsersRequestCreated(ctx context.Context) (<-chan *ListUsersRequest, error) {
+	ch := make(chan *ListUsersRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) ListUsersRequestUpdated(ctx context.Context, id *string) (<-chan *ListUsersRequest, error) {
+	ch := make(chan *ListUsersRequest, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) ListUsersRequestDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type ListUsersRequestInput struct {
+	Limit int32
+}
+
+func (i *ListUsersRequestInput) ToListUsersRequest() *ListUsersRequest {
+	return &ListUsersRequest{
+		Limit: i.Limit,
+	}
+}
+
+type ListUsersRequestFilter struct {
+	Limit    *int
+	LimitGt  *int
+	LimitGte *int
+	LimitLt  *int
+	LimitLte *int
+	AND      []*ListUsersRequestFilter
+	OR       []*ListUsersRequestFilter
+}
+
+func applyListUsersRequestFilter(items []*ListUsersRequest, f *ListUsersRequestFilter) []*ListUsersRequest {
+	var result []*ListUsersRequest
+	for _, item := range items {
+		if matchesListUsersRequest(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesListUsersRequest(item *ListUsersRequest, f *ListUsersRequestFilter) bool {
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesListUsersRequest(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesListUsersRequest(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// ListUsersResponse Query Resolvers
+func (r *queryResolver) ListUsersResponse(ctx context.Context, id string) (*ListUsersResponse, error) {
+	return r.ListUsersResponseRepo.Get(ctx, id)
+}
+
+func (r *queryResolver) ListUsersResponses(ctx context.Context, limit *int, offset *int, filter *ListUsersResponseFilter) ([]*ListUsersResponse, error) {
+	items, err := r.ListUsersResponseRepo.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply filter
+	if filter != nil {
+		items = applyListUsersResponseFilter(items, filter)
+	}
+
+	// Apply pagination
+	start := 0
+	if offset != nil {
+		start = *offset
+	}
+	end := len(items)
+	if limit != nil && start+*limit < end {
+		end = start + *limit
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end], nil
+}
+
+// ListUsersResponse Mutation Resolvers
+func (r *mutationResolver) CreateListUsersResponse(ctx context.Context, input ListUsersResponseInput) (*ListUsersResponse, error) {
+	entity := input.ToListUsersResponse()
+	id, err := r.ListUsersResponseRepo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	entity.Id = id
+	return entity, nil
+}
+
+func (r *mutationResolver) UpdateListUsersResponse(ctx context.Context, id string, input ListUsersResponseInput) (*ListUsersResponse, error) {
+	entity := input.ToListUsersResponse()
+	entity.Id = id
+	if err := r.ListUsersResponseRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *mutationResolver) DeleteListUsersResponse(ctx context.Context, id string) (bool, error) {
+	if err := r.ListUsersResponseRepo.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListUsersResponse Subscription Resolvers
+func (r *subscriptionResolver) ListUsersResponseCreated(ctx context.Context) (<-chan *ListUsersResponse, error) {
+	ch := make(chan *ListUsersResponse, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) ListUsersResponseUpdated(ctx context.Context, id *string) (<-chan *ListUsersResponse, error) {
+	ch := make(chan *ListUsersResponse, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+func (r *subscriptionResolver) ListUsersResponseDeleted(ctx context.Context) (<-chan string, error) {
+	ch := make(chan string, 1)
+	// TODO: Implement with your pubsub system
+	return ch, nil
+}
+
+type ListUsersResponseInput struct {
+	Users []*User
+}
+
+func (i *ListUsersResponseInput) ToListUsersResponse() *ListUsersResponse {
+	return &ListUsersResponse{
+		Users: i.Users,
+	}
+}
+
+type ListUsersResponseFilter struct {
+	AND []*ListUsersResponseFilter
+	OR  []*ListUsersResponseFilter
+}
+
+func applyListUsersResponseFilter(items []*ListUsersResponse, f *ListUsersResponseFilter) []*ListUsersResponse {
+	var result []*ListUsersResponse
+	for _, item := range items {
+		if matchesListUsersResponse(item, f) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func matchesListUsersResponse(item *ListUsersResponse, f *ListUsersResponseFilter) bool {
+	// AND conditions
+	for _, af := range f.AND {
+		if !matchesListUsersResponse(item, af) {
+			return false
+		}
+	}
+	// OR conditions
+	if len(f.OR) > 0 {
+		anyMatch := false
+		for _, of := range f.OR {
+			if matchesListUsersResponse(item, of) {
+				anyMatch = true
+				break
+			}
+		}
+		if !anyMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolver interfaces
+type QueryResolver interface {
+	User(ctx context.Context, id string) (*User, error)
+	Users(ctx context.Context, limit *int, offset *int, filter *UserFilter) ([]*User, error)
+	Store(ctx context.Context, id string) (*Store, error)
+	Stores(ctx context.Context, limit *int, offset *int, filter *StoreFilter) ([]*Store, error)
+	CreateUserRequest(ctx context.Context, id string) (*CreateUserRequest, error)
+	CreateUserRequests(ctx context.Context, limit *int, offset *int, filter *CreateUserRequestFilter) ([]*CreateUserRequest, error)
+	GetUserRequest(ctx context.Context, id string) (*GetUserRequest, error)
+	GetUserRequests(ctx context.Context, limit *int, offset *int, filter *GetUserRequestFilter) ([]*GetUserRequest, error)
+	UpdateUserRequest(ctx context.Context, id string) (*UpdateUserRequest, error)
+	UpdateUserRequests(ctx context.Context, limit *int, offset *int, filter *UpdateUserRequestFilter) ([]*UpdateUserRequest, error)
+	DeleteUserRequest(ctx context.Context, id string) (*DeleteUserRequest, error)
+	DeleteUserRequests(ctx context.Context, limit *int, offset *int, filter *DeleteUserRequestFilter) ([]*DeleteUserRequest, error)
+	ListUsersRequest(ctx context.Context, id string) (*ListUsersRequest, error)
+	ListUsersRequests(ctx context.Context, limit *int, offset *int, filter *ListUsersRequestFilter) ([]*ListUsersRequest, error)
+	ListUsersResponse(ctx context.Context, id string) (*ListUsersResponse, error)
+	ListUsersResponses(ctx context.Context, limit *int, offset *int, filter *ListUsersResponseFilter) ([]*ListUsersResponse, error)
+}
+
+type MutationResolver interface {
+	CreateUser(ctx context.Context, input UserInput) (*User, error)
+	UpdateUser(ctx context.Context, id string, input UserInput) (*User, error)
+	DeleteUser(ctx context.Context, id string) (bool, error)
+	CreateStore(ctx context.Context, input StoreInput) (*Store, error)
+	UpdateStore(ctx context.Context, id string, input StoreInput) (*Store, error)
+	DeleteStore(ctx context.Context, id string) (bool, error)
+	CreateCreateUserRequest(ctx context.Context, input CreateUserRequestInput) (*CreateUserRequest, error)
+	UpdateCreateUserRequest(ctx context.Context, id string, input CreateUserRequestInput) (*CreateUserRequest, error)
+	DeleteCreateUserRequest(ctx context.Context, id string) (bool, error)
+	CreateGetUserRequest(ctx context.Context, input GetUserRequestInput) (*GetUserRequest, error)
+	UpdateGetUserRequest(ctx context.Context, id string, input GetUserRequestInput) (*GetUserRequest, error)
+	DeleteGetUserRequest(ctx context.Context, id string) (bool, error)
+	CreateUpdateUserRequest(ctx context.Context, input UpdateUserRequestInput) (*UpdateUserRequest, error)
+	UpdateUpdateUserRequest(ctx context.Context, id string, input UpdateUserRequestInput) (*UpdateUserRequest, error)
+	DeleteUpdateUserRequest(ctx context.Context, id string) (bool, error)
+	CreateDeleteUserRequest(ctx context.Context, input DeleteUserRequestInput) (*DeleteUserRequest, error)
+	UpdateDeleteUserRequest(ctx context.Context, id string, input DeleteUserRequestInput) (*DeleteUserRequest, error)
+	DeleteDeleteUserRequest(ctx context.Context, id string) (bool, error)
+	CreateListUsersRequest(ctx context.Context, input ListUsersRequestInput) (*ListUsersRequest, error)
+	UpdateListUsersRequest(ctx context.Context, id string, input ListUsersRequestInput) (*ListUsersRequest, error)
+	DeleteListUsersRequest(ctx context.Context, id string) (bool, error)
+	CreateListUsersResponse(ctx context.Context, input ListUsersResponseInput) (*ListUsersResponse, error)
+	UpdateListUsersResponse(ctx context.Context, id string, input ListUsersResponseInput) (*ListUsersResponse, error)
+	DeleteListUsersResponse(ctx context.Context, id string) (bool, error)
+}
+
+type SubscriptionResolver interface {
+	UserCreated(ctx context.Context) (<-chan *User, error)
+	UserUpdated(ctx context.Context, id *string) (<-chan *User, error)
+	UserDeleted(ctx context.Context) (<-chan string, error)
+	StoreCreated(ctx context.Context) (<-chan *Store, error)
+	StoreUpdated(ctx context.Context, id *string) (<-chan *Store, error)
+	StoreDeleted(ctx context.Context) (<-chan string, error)
+	CreateUserRequestCreated(ctx context.Context) (<-chan *CreateUserRequest, error)
+	CreateUserRequestUpdated(ctx context.Context, id *string) (<-chan *CreateUserRequest, error)
+	CreateUserRequestDeleted(ctx context.Context) (<-chan string, error)
+	GetUserRequestCreated(ctx context.Context) (<-chan *GetUserRequest, error)
+	GetUserRequestUpdated(ctx context.Context, id *string) (<-chan *GetUserRequest, error)
+	GetUserRequestDeleted(ctx context.Context) (<-chan string, error)
+	UpdateUserRequestCreated(ctx context.Context) (<-chan *UpdateUserRequest, error)
+	UpdateUserRequestUpdated(ctx context.Context, id *string) (<-chan *UpdateUserRequest, error)
+	UpdateUserRequestDeleted(ctx context.Context) (<-chan string, error)
+	DeleteUserRequestCreated(ctx context.Context) (<-chan *DeleteUserRequest, error)
+	DeleteUserRequestUpdated(ctx context.Context, id *string) (<-chan *DeleteUserRequest, error)
+	DeleteUserRequestDeleted(ctx context.Context) (<-chan string, error)
+	ListUsersRequestCreated(ctx context.Context) (<-chan *ListUsersRequest, error)
+	ListUsersRequestUpdated(ctx context.Context, id *string) (<-chan *ListUsersRequest, error)
+	ListUsersRequestDeleted(ctx context.Context) (<-chan string, error)
+	ListUsersResponseCreated(ctx context.Context) (<-chan *ListUsersResponse, error)
+	ListUsersResponseUpdated(ctx context.Context, id *string) (<-chan *ListUsersResponse, error)
+	ListUsersResponseDeleted(ctx context.Context) (<-chan string, error)
+}
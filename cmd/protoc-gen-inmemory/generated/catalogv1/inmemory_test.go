@@ -0,0 +1,322 @@
+package catalogv1
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// checkIndexes compares the indexes of r with the ones its data gives.
+func checkIndexes(t *testing.T, r *InMemoryProductRepository) {
+	t.Helper()
+	skus := make(map[string]string)
+	sellers := newValueIndex[string]()
+	statuses := newValueIndex[Status]()
+	tags := newValueIndex[string]()
+	for id, p := range r.data {
+		if p.Sku != "" {
+			skus[p.Sku] = id
+		}
+		sellers.add(p.SellerId, id)
+		statuses.add(p.Status, id)
+		for _, tag := range p.Tags {
+			tags.add(tag, id)
+		}
+	}
+	if !maps.Equal(r.idxSku, skus) {
+		t.Errorf("sku index = %v, want %v", r.idxSku, skus)
+	}
+	checkValueIndex(t, "seller_id", r.bySellerId, sellers)
+	checkValueIndex(t, "status", r.byStatus, statuses)
+	checkValueIndex(t, "tags", r.byTags, tags)
+}
+
+func checkValueIndex[K comparable](t *testing.T, field string, got, want *valueIndex[K]) {
+	t.Helper()
+	if !slices.Equal(got.sorted, want.sorted) {
+		t.Errorf("%s index values = %v, want %v", field, got.sorted, want.sorted)
+	}
+	if !maps.EqualFunc(got.ids, want.ids, maps.Equal) {
+		t.Errorf("%s index = %v, want %v", field, got.ids, want.ids)
+	}
+}
+
+func TestRepeatedFieldIndex(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryProductRepository()
+	p := &Product{Sku: "A-1", Tags: []string{"red", "blue", "red"}}
+	if _, err := r.Create(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	checkIndexes(t, r)
+
+	p.Tags = []string{"green"}
+	if err := r.Update(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	checkIndexes(t, r)
+
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"tags"}}
+	if err := r.Patch(ctx, p.Id, &Product{Tags: []string{"blue", "green"}}, mask); err != nil {
+		t.Fatal(err)
+	}
+	checkIndexes(t, r)
+	if err := r.Patch(ctx, p.Id, &Product{}, mask); err != nil {
+		t.Fatal(err)
+	}
+	checkIndexes(t, r)
+	if len(r.byTags.sorted) != 0 {
+		t.Errorf("tags index = %v after clearing the tags", r.byTags.sorted)
+	}
+}
+
+// products creates the products the query tests filter, keyed by SKU.
+func products(t *testing.T) (*InMemoryProductRepository, map[string]string) {
+	t.Helper()
+	r := NewInMemoryProductRepository()
+	ids := make(map[string]string)
+	for _, p := range []*Product{
+		{Sku: "a", Price: 100, Rating: 4.5, Status: Status_STATUS_DRAFT, Tags: []string{"red", "blue"}},
+		{Sku: "b", Price: 150, Rating: 3, Status: Status_STATUS_PUBLISHED, Tags: []string{"red"}},
+		{Sku: "c", Price: 200, Rating: 5, Status: Status_STATUS_PUBLISHED},
+		{Sku: "d", Price: 250, Rating: 1, Status: Status_STATUS_DRAFT, Tags: []string{"blue"}},
+		{Sku: "gone", Price: 150, Rating: 4, Status: Status_STATUS_PUBLISHED, Tags: []string{"red"}},
+	} {
+		id, err := r.Create(context.Background(), p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[p.Sku] = id
+	}
+	if err := r.SoftDelete(context.Background(), ids["gone"]); err != nil {
+		t.Fatal(err)
+	}
+	return r, ids
+}
+
+func skus(found []*Product) []string {
+	var s []string
+	for _, p := range found {
+		s = append(s, p.Sku)
+	}
+	return s
+}
+
+func TestQueryWhere(t *testing.T) {
+	r, _ := products(t)
+	tests := []struct {
+		name      string
+		field, op string
+		value     interface{}
+		want      []string // SKUs, sorted
+	}{
+		{"int field, float value", "price", ">", 149.5, []string{"b", "c", "d"}},
+		{"int field, equal float", "price", "==", 150.0, []string{"b"}},
+		{"float field, int value", "rating", ">=", 4, []string{"a", "c"}},
+		{"float field, int32 value", "rating", "==", int32(3), []string{"b"}},
+		{"int field, mixed in", "price", "in", []interface{}{100.0, int32(200)}, []string{"a", "c"}},
+		{"not comparable", "price", "<", "cheap", nil},
+		{"enum by name", "status", "==", "STATUS_PUBLISHED", []string{"b", "c"}},
+		{"enum by number", "status", "<", 2, []string{"a", "d"}},
+		{"enum range by name", "status", ">", "STATUS_DRAFT", []string{"b", "c"}},
+		{"enum in, deduplicated", "status", "in", []interface{}{"STATUS_DRAFT", 1, Status_STATUS_DRAFT}, []string{"a", "d"}},
+		{"enum not-in", "status", "not-in", []string{"STATUS_DRAFT"}, []string{"b", "c"}},
+		{"array-contains", "tags", "array-contains", "blue", []string{"a", "d"}},
+		{"array-contains-any, deduplicated", "tags", "array-contains-any", []string{"red", "blue", "red"}, []string{"a", "b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := r.Query().Where(tt.field, tt.op, tt.value).OrderBy("sku", Asc).Get(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := skus(found); !slices.Equal(got, tt.want) {
+				t.Errorf("Where(%s %s %v) = %v, want %v", tt.field, tt.op, tt.value, got, tt.want)
+			}
+			n, err := r.CountWhere(context.Background(), tt.field, tt.op, tt.value)
+			if err != nil || n != int64(len(tt.want)) {
+				t.Errorf("CountWhere = %d, %v, want %d", n, err, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	r, _ := products(t)
+	for _, q := range []ProductQuery{
+		r.Query().Where("colour", "==", "red"),
+		r.Query().Where("tags", "==", "red"),
+		r.Query().Where("status", "in", "STATUS_DRAFT"),
+		r.Query().Where("sku", "array-contains", "a"),
+		r.Query().Where("sku", "like", "a"),
+		r.Query().OrderBy("colour", Asc),
+	} {
+		if _, err := q.Get(context.Background()); err == nil {
+			t.Errorf("query %+v succeeded", q)
+		}
+	}
+}
+
+func TestQueryOrder(t *testing.T) {
+	r, _ := products(t)
+	tests := []struct {
+		name string
+		q    ProductQuery
+		want []string
+	}{
+		{"order by", r.Query().OrderBy("price", Desc), []string{"d", "c", "b", "a"}},
+		{"then by inequality field", r.Query().Where("rating", ">", 2).Where("price", "<", 300), []string{"a", "b", "c"}},
+		{"inequality field in the last direction", r.Query().Where("rating", ">", 2).OrderBy("status", Desc), []string{"c", "b", "a"}},
+		{"limit and offset", r.Query().OrderBy("price", Asc).Offset(1).Limit(2), []string{"b", "c"}},
+		{"offset past the end", r.Query().Offset(10), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := tt.q.Get(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := skus(found); !slices.Equal(got, tt.want) {
+				t.Errorf("Get = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	first, err := r.Query().Where("tags", "array-contains", "red").OrderBy("price", Desc).First(context.Background())
+	if err != nil || first.Sku != "b" {
+		t.Errorf("First = %v, %v, want b: the soft-deleted product is left out", first, err)
+	}
+	if n, err := r.Query().Where("price", "==", 150).Count(context.Background()); err != nil || n != 1 {
+		t.Errorf("Count = %d, %v, want 1", n, err)
+	}
+}
+
+// checkQueries stores products through repo and queries them. It only uses the
+// repository contract, so every backend runs the same queries through it.
+func checkQueries(t *testing.T, repo ProductRepository) {
+	ctx := context.Background()
+	for _, p := range []*Product{
+		{Sku: "a", Price: 100, Status: Status_STATUS_DRAFT, Tags: []string{"red", "blue"}},
+		{Sku: "b", Price: 150, Status: Status_STATUS_PUBLISHED, Tags: []string{"red"}},
+		{Sku: "c", Price: 200, Status: Status_STATUS_PUBLISHED},
+		{Sku: "d", Price: 250, Status: Status_STATUS_DRAFT, Tags: []string{"blue"}},
+	} {
+		if _, err := repo.Create(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		q    ProductQuery
+		want []string
+	}{
+		{"where and order by", repo.Query().Where("price", ">=", 150).OrderBy("price", Desc), []string{"d", "c", "b"}},
+		{"enum", repo.Query().Where("status", "==", Status_STATUS_PUBLISHED).OrderBy("sku", Asc), []string{"b", "c"}},
+		{"array-contains", repo.Query().Where("tags", "array-contains", "blue").OrderBy("sku", Desc), []string{"d", "a"}},
+		{"limit and offset", repo.Query().OrderBy("price", Asc).Offset(1).Limit(2), []string{"b", "c"}},
+	}
+	for _, tt := range tests {
+		found, err := tt.q.Get(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := skus(found); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Get = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	first, err := repo.Query().Where("tags", "array-contains", "red").OrderBy("price", Desc).First(ctx)
+	if err != nil || first.Sku != "b" {
+		t.Errorf("First = %v, %v, want b", first, err)
+	}
+	if _, err := repo.Query().Where("price", ">", 1000).First(ctx); !errors.Is(err, ErrNotFound) {
+		t.Errorf("First of no results = %v, want ErrNotFound", err)
+	}
+	if n, err := repo.Query().Where("status", "==", Status_STATUS_DRAFT).Limit(1).Count(ctx); err != nil || n != 2 {
+		t.Errorf("Count = %d, %v, want 2: Limit doesn't apply", n, err)
+	}
+}
+
+func TestQueryContract(t *testing.T) {
+	checkQueries(t, NewInMemoryProductRepository())
+}
+
+func TestUpsertVersioned(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryProductRepository()
+	p := &Product{Id: "p1", Sku: "A-1", Name: "first"}
+	if err := r.Upsert(ctx, p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Version != 1 {
+		t.Fatalf("version after the create = %d, want 1", p.Version)
+	}
+
+	// an Upsert knows nothing of the stored version
+	if err := r.Upsert(ctx, &Product{Id: "p1", Sku: "A-1", Name: "second"}); err != nil {
+		t.Fatalf("Upsert of a stored product: %v", err)
+	}
+	got, err := r.Get(ctx, "p1")
+	if err != nil || got.Name != "second" || got.Version != 2 {
+		t.Fatalf("Get = %v, %v, want the second name at version 2", got, err)
+	}
+
+	// Update still rejects the stale read
+	p.Name = "stale"
+	if err := r.Update(ctx, p); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update of version 1 = %v, want ErrConflict", err)
+	}
+	if err := r.Upsert(ctx, &Product{Id: "p2", Sku: "A-1"}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Upsert with a taken SKU = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestPatchNestedPaths(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryListingRepository()
+	id, err := r.Create(ctx, &Listing{
+		ShipFrom: &Address{Lines: []string{"1 Main St"}, Country: "US", Location: &Address_Location{Latitude: 1, Longitude: 2}},
+		Sale:     &Listing_QuoteUrl{QuoteUrl: "https://example.com/quote"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	patch := func(entity *Listing, paths ...string) error {
+		return r.Patch(ctx, id, entity, &fieldmaskpb.FieldMask{Paths: paths})
+	}
+
+	entity := &Listing{ShipFrom: &Address{Country: "CA", Location: &Address_Location{Latitude: 45}}}
+	if err := patch(entity, "ship_from.country", "ship_from.location.latitude"); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := r.Get(ctx, id)
+	if sf := got.ShipFrom; sf.Country != "CA" || sf.Location.Latitude != 45 || sf.Location.Longitude != 2 || len(sf.Lines) != 1 {
+		t.Errorf("ship_from = %v, want only the country and latitude changed", sf)
+	}
+
+	// A path below an unset message clears the field; one below a oneof member sets it
+	if err := patch(&Listing{}, "ship_from.location.longitude", "auction.reserve"); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = r.Get(ctx, id)
+	if got.ShipFrom.Location.Longitude != 0 || got.ShipFrom.Location.Latitude != 45 {
+		t.Errorf("location = %v, want the longitude cleared", got.ShipFrom.Location)
+	}
+	if got.GetAuction() == nil || got.GetQuoteUrl() != "" {
+		t.Errorf("sale = %v, want an empty auction", got.Sale)
+	}
+
+	for _, path := range []string{"ship_from.zip", "tiers.currency", "regional_prices.us", "created_at.seconds", "subtitle.value", "id.x", "ship_from."} {
+		if err := patch(&Listing{ShipFrom: &Address{Country: "MX"}}, "ship_from.country", path); !errors.Is(err, ErrInvalidMask) {
+			t.Errorf("Patch %q = %v, want ErrInvalidMask", path, err)
+		}
+	}
+	if after, _ := r.Get(ctx, id); after.ShipFrom.Country != "CA" || after.UpdatedAt.AsTime() != got.UpdatedAt.AsTime() {
+		t.Errorf("a rejected mask changed the listing")
+	}
+}
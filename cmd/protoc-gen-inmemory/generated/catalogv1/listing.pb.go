@@ -0,0 +1,911 @@
+// Fixture for the document codecs: an entity with every shape of field a
+// backend stores, nested and repeated messages, maps, a oneof, optional
+// scalars, enums and the well-known types.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: catalog/v1/listing.proto
+
+package catalogv1
+
+import (
+	_ "github.com/vinodhalaharvi/buf-go-plugins/proto/entity"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Listing_Channel int32
+
+const (
+	Listing_CHANNEL_UNSPECIFIED Listing_Channel = 0
+	Listing_CHANNEL_WEB         Listing_Channel = 1
+	Listing_CHANNEL_STORE       Listing_Channel = 2
+)
+
+// Enum value maps for Listing_Channel.
+var (
+	Listing_Channel_name = map[int32]string{
+		0: "CHANNEL_UNSPECIFIED",
+		1: "CHANNEL_WEB",
+		2: "CHANNEL_STORE",
+	}
+	Listing_Channel_value = map[string]int32{
+		"CHANNEL_UNSPECIFIED": 0,
+		"CHANNEL_WEB":         1,
+		"CHANNEL_STORE":       2,
+	}
+)
+
+func (x Listing_Channel) Enum() *Listing_Channel {
+	p := new(Listing_Channel)
+	*p = x
+	return p
+}
+
+func (x Listing_Channel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Listing_Channel) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_v1_listing_proto_enumTypes[0].Descriptor()
+}
+
+func (Listing_Channel) Type() protoreflect.EnumType {
+	return &file_catalog_v1_listing_proto_enumTypes[0]
+}
+
+func (x Listing_Channel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Listing_Channel.Descriptor instead.
+func (Listing_Channel) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Units         int64                  `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	Nanos         int32                  `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_catalog_v1_listing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_listing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Money) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Money) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []string               `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Location      *Address_Location      `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_catalog_v1_listing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_listing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Address) GetLines() []string {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetLocation() *Address_Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type Auction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reserve       *Money                 `protobuf:"bytes,1,opt,name=reserve,proto3" json:"reserve,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Bids          []*Money               `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auction) Reset() {
+	*x = Auction{}
+	mi := &file_catalog_v1_listing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auction) ProtoMessage() {}
+
+func (x *Auction) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_listing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auction.ProtoReflect.Descriptor instead.
+func (*Auction) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Auction) GetReserve() *Money {
+	if x != nil {
+		return x.Reserve
+	}
+	return nil
+}
+
+func (x *Auction) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Auction) GetBids() []*Money {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+type Listing struct {
+	state          protoimpl.MessageState            `protogen:"open.v1"`
+	Id             string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         Status                            `protobuf:"varint,2,opt,name=status,proto3,enum=catalog.v1.Status" json:"status,omitempty"`
+	Channel        Listing_Channel                   `protobuf:"varint,3,opt,name=channel,proto3,enum=catalog.v1.Listing_Channel" json:"channel,omitempty"`
+	Channels       []Listing_Channel                 `protobuf:"varint,4,rep,packed,name=channels,proto3,enum=catalog.v1.Listing_Channel" json:"channels,omitempty"`
+	Price          *Money                            `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	ShipFrom       *Address                          `protobuf:"bytes,6,opt,name=ship_from,json=shipFrom,proto3" json:"ship_from,omitempty"`
+	Tiers          []*Money                          `protobuf:"bytes,7,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	RegionalPrices map[string]*Money                 `protobuf:"bytes,8,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels         map[int32]string                  `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Restocks       map[uint64]*timestamppb.Timestamp `protobuf:"bytes,10,rep,name=restocks,proto3" json:"restocks,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Flags          map[bool]Status                   `protobuf:"bytes,11,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=catalog.v1.Status"`
+	// Types that are valid to be assigned to Sale:
+	//
+	//	*Listing_Fixed
+	//	*Listing_Auction
+	//	*Listing_QuoteUrl
+	Sale          isListing_Sale           `protobuf_oneof:"sale"`
+	Stock         *int32                   `protobuf:"varint,15,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	Note          *string                  `protobuf:"bytes,16,opt,name=note,proto3,oneof" json:"note,omitempty"`
+	Serial        uint64                   `protobuf:"varint,17,opt,name=serial,proto3" json:"serial,omitempty"`
+	Delta         int64                    `protobuf:"zigzag64,18,opt,name=delta,proto3" json:"delta,omitempty"`
+	Batch         uint32                   `protobuf:"fixed32,19,opt,name=batch,proto3" json:"batch,omitempty"`
+	Shelf         uint32                   `protobuf:"varint,20,opt,name=shelf,proto3" json:"shelf,omitempty"`
+	Weight        float32                  `protobuf:"fixed32,21,opt,name=weight,proto3" json:"weight,omitempty"`
+	Checksum      []byte                   `protobuf:"bytes,22,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Featured      bool                     `protobuf:"varint,23,opt,name=featured,proto3" json:"featured,omitempty"`
+	Subtitle      *wrapperspb.StringValue  `protobuf:"bytes,24,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	Views         *wrapperspb.Int64Value   `protobuf:"bytes,25,opt,name=views,proto3" json:"views,omitempty"`
+	Impressions   *wrapperspb.UInt64Value  `protobuf:"bytes,26,opt,name=impressions,proto3" json:"impressions,omitempty"`
+	Rank          *wrapperspb.Int32Value   `protobuf:"bytes,27,opt,name=rank,proto3" json:"rank,omitempty"`
+	Slot          *wrapperspb.UInt32Value  `protobuf:"bytes,28,opt,name=slot,proto3" json:"slot,omitempty"`
+	Score         *wrapperspb.DoubleValue  `protobuf:"bytes,29,opt,name=score,proto3" json:"score,omitempty"`
+	Discount      *wrapperspb.FloatValue   `protobuf:"bytes,30,opt,name=discount,proto3" json:"discount,omitempty"`
+	Gift          *wrapperspb.BoolValue    `protobuf:"bytes,31,opt,name=gift,proto3" json:"gift,omitempty"`
+	Thumbnail     *wrapperspb.BytesValue   `protobuf:"bytes,32,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Metadata      *structpb.Struct         `protobuf:"bytes,33,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Extra         *structpb.Value          `protobuf:"bytes,34,opt,name=extra,proto3" json:"extra,omitempty"`
+	History       *structpb.ListValue      `protobuf:"bytes,35,opt,name=history,proto3" json:"history,omitempty"`
+	LeadTime      *durationpb.Duration     `protobuf:"bytes,36,opt,name=lead_time,json=leadTime,proto3" json:"lead_time,omitempty"`
+	Details       *anypb.Any               `protobuf:"bytes,37,opt,name=details,proto3" json:"details,omitempty"`
+	PriceChanges  []*timestamppb.Timestamp `protobuf:"bytes,38,rep,name=price_changes,json=priceChanges,proto3" json:"price_changes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp   `protobuf:"bytes,39,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp   `protobuf:"bytes,40,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Listing) Reset() {
+	*x = Listing{}
+	mi := &file_catalog_v1_listing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Listing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Listing) ProtoMessage() {}
+
+func (x *Listing) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_listing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Listing.ProtoReflect.Descriptor instead.
+func (*Listing) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Listing) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Listing) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Listing) GetChannel() Listing_Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Listing_CHANNEL_UNSPECIFIED
+}
+
+func (x *Listing) GetChannels() []Listing_Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *Listing) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Listing) GetShipFrom() *Address {
+	if x != nil {
+		return x.ShipFrom
+	}
+	return nil
+}
+
+func (x *Listing) GetTiers() []*Money {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *Listing) GetRegionalPrices() map[string]*Money {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *Listing) GetLabels() map[int32]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Listing) GetRestocks() map[uint64]*timestamppb.Timestamp {
+	if x != nil {
+		return x.Restocks
+	}
+	return nil
+}
+
+func (x *Listing) GetFlags() map[bool]Status {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *Listing) GetSale() isListing_Sale {
+	if x != nil {
+		return x.Sale
+	}
+	return nil
+}
+
+func (x *Listing) GetFixed() *Money {
+	if x != nil {
+		if x, ok := x.Sale.(*Listing_Fixed); ok {
+			return x.Fixed
+		}
+	}
+	return nil
+}
+
+func (x *Listing) GetAuction() *Auction {
+	if x != nil {
+		if x, ok := x.Sale.(*Listing_Auction); ok {
+			return x.Auction
+		}
+	}
+	return nil
+}
+
+func (x *Listing) GetQuoteUrl() string {
+	if x != nil {
+		if x, ok := x.Sale.(*Listing_QuoteUrl); ok {
+			return x.QuoteUrl
+		}
+	}
+	return ""
+}
+
+func (x *Listing) GetStock() int32 {
+	if x != nil && x.Stock != nil {
+		return *x.Stock
+	}
+	return 0
+}
+
+func (x *Listing) GetNote() string {
+	if x != nil && x.Note != nil {
+		return *x.Note
+	}
+	return ""
+}
+
+func (x *Listing) GetSerial() uint64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *Listing) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *Listing) GetBatch() uint32 {
+	if x != nil {
+		return x.Batch
+	}
+	return 0
+}
+
+func (x *Listing) GetShelf() uint32 {
+	if x != nil {
+		return x.Shelf
+	}
+	return 0
+}
+
+func (x *Listing) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Listing) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *Listing) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Listing) GetSubtitle() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Subtitle
+	}
+	return nil
+}
+
+func (x *Listing) GetViews() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *Listing) GetImpressions() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.Impressions
+	}
+	return nil
+}
+
+func (x *Listing) GetRank() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.Rank
+	}
+	return nil
+}
+
+func (x *Listing) GetSlot() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.Slot
+	}
+	return nil
+}
+
+func (x *Listing) GetScore() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *Listing) GetDiscount() *wrapperspb.FloatValue {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Listing) GetGift() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Gift
+	}
+	return nil
+}
+
+func (x *Listing) GetThumbnail() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return nil
+}
+
+func (x *Listing) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Listing) GetExtra() *structpb.Value {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+func (x *Listing) GetHistory() *structpb.ListValue {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Listing) GetLeadTime() *durationpb.Duration {
+	if x != nil {
+		return x.LeadTime
+	}
+	return nil
+}
+
+func (x *Listing) GetDetails() *anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *Listing) GetPriceChanges() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceChanges
+	}
+	return nil
+}
+
+func (x *Listing) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Listing) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type isListing_Sale interface {
+	isListing_Sale()
+}
+
+type Listing_Fixed struct {
+	Fixed *Money `protobuf:"bytes,12,opt,name=fixed,proto3,oneof"`
+}
+
+type Listing_Auction struct {
+	Auction *Auction `protobuf:"bytes,13,opt,name=auction,proto3,oneof"`
+}
+
+type Listing_QuoteUrl struct {
+	QuoteUrl string `protobuf:"bytes,14,opt,name=quote_url,json=quoteUrl,proto3,oneof"`
+}
+
+func (*Listing_Fixed) isListing_Sale() {}
+
+func (*Listing_Auction) isListing_Sale() {}
+
+func (*Listing_QuoteUrl) isListing_Sale() {}
+
+type Address_Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address_Location) Reset() {
+	*x = Address_Location{}
+	mi := &file_catalog_v1_listing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address_Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address_Location) ProtoMessage() {}
+
+func (x *Address_Location) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_v1_listing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address_Location.ProtoReflect.Descriptor instead.
+func (*Address_Location) Descriptor() ([]byte, []int) {
+	return file_catalog_v1_listing_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Address_Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Address_Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+var File_catalog_v1_listing_proto protoreflect.FileDescriptor
+
+const file_catalog_v1_listing_proto_rawDesc = "" +
+	"\n" +
+	"\x18catalog/v1/listing.proto\x12\n" +
+	"catalog.v1\x1a\x18catalog/v1/catalog.proto\x1a\x14entity/options.proto\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"O\n" +
+	"\x05Money\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x03R\x05units\x12\x14\n" +
+	"\x05nanos\x18\x03 \x01(\x05R\x05nanos\"\xb9\x01\n" +
+	"\aAddress\x12\x14\n" +
+	"\x05lines\x18\x01 \x03(\tR\x05lines\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x128\n" +
+	"\blocation\x18\x03 \x01(\v2\x1c.catalog.v1.Address.LocationR\blocation\x1aD\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\x92\x01\n" +
+	"\aAuction\x12+\n" +
+	"\areserve\x18\x01 \x01(\v2\x11.catalog.v1.MoneyR\areserve\x123\n" +
+	"\aends_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12%\n" +
+	"\x04bids\x18\x03 \x03(\v2\x11.catalog.v1.MoneyR\x04bids\"\xee\x11\n" +
+	"\aListing\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x12.catalog.v1.StatusR\x06status\x125\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x1b.catalog.v1.Listing.ChannelR\achannel\x127\n" +
+	"\bchannels\x18\x04 \x03(\x0e2\x1b.catalog.v1.Listing.ChannelR\bchannels\x12'\n" +
+	"\x05price\x18\x05 \x01(\v2\x11.catalog.v1.MoneyR\x05price\x120\n" +
+	"\tship_from\x18\x06 \x01(\v2\x13.catalog.v1.AddressR\bshipFrom\x12'\n" +
+	"\x05tiers\x18\a \x03(\v2\x11.catalog.v1.MoneyR\x05tiers\x12P\n" +
+	"\x0fregional_prices\x18\b \x03(\v2'.catalog.v1.Listing.RegionalPricesEntryR\x0eregionalPrices\x127\n" +
+	"\x06labels\x18\t \x03(\v2\x1f.catalog.v1.Listing.LabelsEntryR\x06labels\x12=\n" +
+	"\brestocks\x18\n" +
+	" \x03(\v2!.catalog.v1.Listing.RestocksEntryR\brestocks\x124\n" +
+	"\x05flags\x18\v \x03(\v2\x1e.catalog.v1.Listing.FlagsEntryR\x05flags\x12)\n" +
+	"\x05fixed\x18\f \x01(\v2\x11.catalog.v1.MoneyH\x00R\x05fixed\x12/\n" +
+	"\aauction\x18\r \x01(\v2\x13.catalog.v1.AuctionH\x00R\aauction\x12\x1d\n" +
+	"\tquote_url\x18\x0e \x01(\tH\x00R\bquoteUrl\x12\x19\n" +
+	"\x05stock\x18\x0f \x01(\x05H\x01R\x05stock\x88\x01\x01\x12\x17\n" +
+	"\x04note\x18\x10 \x01(\tH\x02R\x04note\x88\x01\x01\x12\x16\n" +
+	"\x06serial\x18\x11 \x01(\x04R\x06serial\x12\x14\n" +
+	"\x05delta\x18\x12 \x01(\x12R\x05delta\x12\x14\n" +
+	"\x05batch\x18\x13 \x01(\aR\x05batch\x12\x14\n" +
+	"\x05shelf\x18\x14 \x01(\rR\x05shelf\x12\x16\n" +
+	"\x06weight\x18\x15 \x01(\x02R\x06weight\x12\x1a\n" +
+	"\bchecksum\x18\x16 \x01(\fR\bchecksum\x12\x1a\n" +
+	"\bfeatured\x18\x17 \x01(\bR\bfeatured\x128\n" +
+	"\bsubtitle\x18\x18 \x01(\v2\x1c.google.protobuf.StringValueR\bsubtitle\x121\n" +
+	"\x05views\x18\x19 \x01(\v2\x1b.google.protobuf.Int64ValueR\x05views\x12>\n" +
+	"\vimpressions\x18\x1a \x01(\v2\x1c.google.protobuf.UInt64ValueR\vimpressions\x12/\n" +
+	"\x04rank\x18\x1b \x01(\v2\x1b.google.protobuf.Int32ValueR\x04rank\x120\n" +
+	"\x04slot\x18\x1c \x01(\v2\x1c.google.protobuf.UInt32ValueR\x04slot\x122\n" +
+	"\x05score\x18\x1d \x01(\v2\x1c.google.protobuf.DoubleValueR\x05score\x127\n" +
+	"\bdiscount\x18\x1e \x01(\v2\x1b.google.protobuf.FloatValueR\bdiscount\x12.\n" +
+	"\x04gift\x18\x1f \x01(\v2\x1a.google.protobuf.BoolValueR\x04gift\x129\n" +
+	"\tthumbnail\x18  \x01(\v2\x1b.google.protobuf.BytesValueR\tthumbnail\x123\n" +
+	"\bmetadata\x18! \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12,\n" +
+	"\x05extra\x18\" \x01(\v2\x16.google.protobuf.ValueR\x05extra\x124\n" +
+	"\ahistory\x18# \x01(\v2\x1a.google.protobuf.ListValueR\ahistory\x126\n" +
+	"\tlead_time\x18$ \x01(\v2\x19.google.protobuf.DurationR\bleadTime\x12.\n" +
+	"\adetails\x18% \x01(\v2\x14.google.protobuf.AnyR\adetails\x12?\n" +
+	"\rprice_changes\x18& \x03(\v2\x1a.google.protobuf.TimestampR\fpriceChanges\x129\n" +
+	"\n" +
+	"created_at\x18' \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18( \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aT\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.catalog.v1.MoneyR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aW\n" +
+	"\rRestocksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x04R\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05value:\x028\x01\x1aL\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\bR\x03key\x12(\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x12.catalog.v1.StatusR\x05value:\x028\x01\"F\n" +
+	"\aChannel\x12\x17\n" +
+	"\x13CHANNEL_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vCHANNEL_WEB\x10\x01\x12\x11\n" +
+	"\rCHANNEL_STORE\x10\x02:\x1f\x82\xb5\x18\x1b\n" +
+	"\blistings\x1a\x06status\x1a\achannelB\x06\n" +
+	"\x04saleB\b\n" +
+	"\x06_stockB\a\n" +
+	"\x05_noteB+Z)example.com/shop/gen/catalog/v1;catalogv1b\x06proto3"
+
+var (
+	file_catalog_v1_listing_proto_rawDescOnce sync.Once
+	file_catalog_v1_listing_proto_rawDescData []byte
+)
+
+func file_catalog_v1_listing_proto_rawDescGZIP() []byte {
+	file_catalog_v1_listing_proto_rawDescOnce.Do(func() {
+		file_catalog_v1_listing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_v1_listing_proto_rawDesc), len(file_catalog_v1_listing_proto_rawDesc)))
+	})
+	return file_catalog_v1_listing_proto_rawDescData
+}
+
+var file_catalog_v1_listing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_catalog_v1_listing_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_catalog_v1_listing_proto_goTypes = []any{
+	(Listing_Channel)(0),           // 0: catalog.v1.Listing.Channel
+	(*Money)(nil),                  // 1: catalog.v1.Money
+	(*Address)(nil),                // 2: catalog.v1.Address
+	(*Auction)(nil),                // 3: catalog.v1.Auction
+	(*Listing)(nil),                // 4: catalog.v1.Listing
+	(*Address_Location)(nil),       // 5: catalog.v1.Address.Location
+	nil,                            // 6: catalog.v1.Listing.RegionalPricesEntry
+	nil,                            // 7: catalog.v1.Listing.LabelsEntry
+	nil,                            // 8: catalog.v1.Listing.RestocksEntry
+	nil,                            // 9: catalog.v1.Listing.FlagsEntry
+	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+	(Status)(0),                    // 11: catalog.v1.Status
+	(*wrapperspb.StringValue)(nil), // 12: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 13: google.protobuf.Int64Value
+	(*wrapperspb.UInt64Value)(nil), // 14: google.protobuf.UInt64Value
+	(*wrapperspb.Int32Value)(nil),  // 15: google.protobuf.Int32Value
+	(*wrapperspb.UInt32Value)(nil), // 16: google.protobuf.UInt32Value
+	(*wrapperspb.DoubleValue)(nil), // 17: google.protobuf.DoubleValue
+	(*wrapperspb.FloatValue)(nil),  // 18: google.protobuf.FloatValue
+	(*wrapperspb.BoolValue)(nil),   // 19: google.protobuf.BoolValue
+	(*wrapperspb.BytesValue)(nil),  // 20: google.protobuf.BytesValue
+	(*structpb.Struct)(nil),        // 21: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 22: google.protobuf.Value
+	(*structpb.ListValue)(nil),     // 23: google.protobuf.ListValue
+	(*durationpb.Duration)(nil),    // 24: google.protobuf.Duration
+	(*anypb.Any)(nil),              // 25: google.protobuf.Any
+}
+var file_catalog_v1_listing_proto_depIdxs = []int32{
+	5,  // 0: catalog.v1.Address.location:type_name -> catalog.v1.Address.Location
+	1,  // 1: catalog.v1.Auction.reserve:type_name -> catalog.v1.Money
+	10, // 2: catalog.v1.Auction.ends_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: catalog.v1.Auction.bids:type_name -> catalog.v1.Money
+	11, // 4: catalog.v1.Listing.status:type_name -> catalog.v1.Status
+	0,  // 5: catalog.v1.Listing.channel:type_name -> catalog.v1.Listing.Channel
+	0,  // 6: catalog.v1.Listing.channels:type_name -> catalog.v1.Listing.Channel
+	1,  // 7: catalog.v1.Listing.price:type_name -> catalog.v1.Money
+	2,  // 8: catalog.v1.Listing.ship_from:type_name -> catalog.v1.Address
+	1,  // 9: catalog.v1.Listing.tiers:type_name -> catalog.v1.Money
+	6,  // 10: catalog.v1.Listing.regional_prices:type_name -> catalog.v1.Listing.RegionalPricesEntry
+	7,  // 11: catalog.v1.Listing.labels:type_name -> catalog.v1.Listing.LabelsEntry
+	8,  // 12: catalog.v1.Listing.restocks:type_name -> catalog.v1.Listing.RestocksEntry
+	9,  // 13: catalog.v1.Listing.flags:type_name -> catalog.v1.Listing.FlagsEntry
+	1,  // 14: catalog.v1.Listing.fixed:type_name -> catalog.v1.Money
+	3,  // 15: catalog.v1.Listing.auction:type_name -> catalog.v1.Auction
+	12, // 16: catalog.v1.Listing.subtitle:type_name -> google.protobuf.StringValue
+	13, // 17: catalog.v1.Listing.views:type_name -> google.protobuf.Int64Value
+	14, // 18: catalog.v1.Listing.impressions:type_name -> google.protobuf.UInt64Value
+	15, // 19: catalog.v1.Listing.rank:type_name -> google.protobuf.Int32Value
+	16, // 20: catalog.v1.Listing.slot:type_name -> google.protobuf.UInt32Value
+	17, // 21: catalog.v1.Listing.score:type_name -> google.protobuf.DoubleValue
+	18, // 22: catalog.v1.Listing.discount:type_name -> google.protobuf.FloatValue
+	19, // 23: catalog.v1.Listing.gift:type_name -> google.protobuf.BoolValue
+	20, // 24: catalog.v1.Listing.thumbnail:type_name -> google.protobuf.BytesValue
+	21, // 25: catalog.v1.Listing.metadata:type_name -> google.protobuf.Struct
+	22, // 26: catalog.v1.Listing.extra:type_name -> google.protobuf.Value
+	23, // 27: catalog.v1.Listing.history:type_name -> google.protobuf.ListValue
+	24, // 28: catalog.v1.Listing.lead_time:type_name -> google.protobuf.Duration
+	25, // 29: catalog.v1.Listing.details:type_name -> google.protobuf.Any
+	10, // 30: catalog.v1.Listing.price_changes:type_name -> google.protobuf.Timestamp
+	10, // 31: catalog.v1.Listing.created_at:type_name -> google.protobuf.Timestamp
+	10, // 32: catalog.v1.Listing.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 33: catalog.v1.Listing.RegionalPricesEntry.value:type_name -> catalog.v1.Money
+	10, // 34: catalog.v1.Listing.RestocksEntry.value:type_name -> google.protobuf.Timestamp
+	11, // 35: catalog.v1.Listing.FlagsEntry.value:type_name -> catalog.v1.Status
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_catalog_v1_listing_proto_init() }
+func file_catalog_v1_listing_proto_init() {
+	if File_catalog_v1_listing_proto != nil {
+		return
+	}
+	file_catalog_v1_catalog_proto_init()
+	file_catalog_v1_listing_proto_msgTypes[3].OneofWrappers = []any{
+		(*Listing_Fixed)(nil),
+		(*Listing_Auction)(nil),
+		(*Listing_QuoteUrl)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_v1_listing_proto_rawDesc), len(file_catalog_v1_listing_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_catalog_v1_listing_proto_goTypes,
+		DependencyIndexes: file_catalog_v1_listing_proto_depIdxs,
+		EnumInfos:         file_catalog_v1_listing_proto_enumTypes,
+		MessageInfos:      file_catalog_v1_listing_proto_msgTypes,
+	}.Build()
+	File_catalog_v1_listing_proto = out.File
+	file_catalog_v1_listing_proto_goTypes = nil
+	file_catalog_v1_listing_proto_depIdxs = nil
+}
@@ -0,0 +1,974 @@
+// Code generated by protoc-gen-inmemory. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+// Thread-safe in-memory storage for testing and prototyping.
+
+package catalogv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ============================================================================
+// Listing Repository - Thread-Safe In-Memory CRUD
+// ============================================================================
+
+// InMemoryListingRepository implements ListingRepository using in-memory storage
+type InMemoryListingRepository struct {
+	mu   sync.RWMutex
+	data map[string]*Listing
+	// Indexes for fast lookups
+	byStatus  *valueIndex[Status]          // status -> ids
+	byChannel *valueIndex[Listing_Channel] // channel -> ids
+
+	undo    map[string]*Listing // the entities the running write replaced, nil for none
+	journal *journal            // nil unless durable
+}
+
+var _ ListingRepository = (*InMemoryListingRepository)(nil)
+
+// NewInMemoryListingRepository creates a new in-memory repository
+func NewInMemoryListingRepository() *InMemoryListingRepository {
+	return &InMemoryListingRepository{
+		data:      make(map[string]*Listing),
+		byStatus:  newValueIndex[Status](),
+		byChannel: newValueIndex[Listing_Channel](),
+		undo:      make(map[string]*Listing),
+	}
+}
+
+// clone creates a deep copy to prevent external mutation
+func (r *InMemoryListingRepository) clone(entity *Listing) *Listing {
+	if entity == nil {
+		return nil
+	}
+	clone := proto.Clone(entity).(*Listing)
+	return clone
+}
+
+// Create creates a new Listing. It fails with ErrAlreadyExists when a stored Listing
+// has its ID.
+func (r *InMemoryListingRepository) Create(ctx context.Context, entity *Listing) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := r.create(entity)
+	if err = r.journal.commit(err, r); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// create is Create with r.mu held.
+func (r *InMemoryListingRepository) create(entity *Listing) (string, error) {
+	if entity == nil {
+		return "", errors.New("entity cannot be nil")
+	}
+
+	// Generate ID if not provided
+	if entity.Id == "" {
+		entity.Id = uuid.New().String()
+	} else {
+		if _, exists := r.data[entity.Id]; exists {
+			return "", ErrAlreadyExists
+		}
+	}
+
+	// Set timestamps
+	now := timestamppb.Now()
+	entity.CreatedAt = now
+	entity.UpdatedAt = now
+
+	// Store a clone to prevent external mutation
+	r.touch(entity.Id)
+	r.data[entity.Id] = r.clone(entity)
+
+	// Update indexes
+	r.byStatus.add(entity.Status, entity.Id)
+	r.byChannel.add(entity.Channel, entity.Id)
+
+	return entity.Id, nil
+}
+
+// Get retrieves a Listing by ID
+func (r *InMemoryListingRepository) Get(ctx context.Context, id string) (*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.get(id)
+}
+
+// get is Get with r.mu held.
+func (r *InMemoryListingRepository) get(id string) (*Listing, error) {
+	if id == "" {
+		return nil, ErrInvalidID
+	}
+
+	entity, exists := r.data[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return r.clone(entity), nil
+}
+
+// GetOrNil returns nil if not found
+func (r *InMemoryListingRepository) GetOrNil(ctx context.Context, id string) (*Listing, error) {
+	entity, err := r.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return entity, err
+}
+
+// MustGet panics if not found
+func (r *InMemoryListingRepository) MustGet(ctx context.Context, id string) *Listing {
+	entity, err := r.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return entity
+}
+
+// Update updates an existing Listing
+func (r *InMemoryListingRepository) Update(ctx context.Context, entity *Listing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.journal.commit(r.update(entity), r)
+}
+
+// update is Update with r.mu held.
+func (r *InMemoryListingRepository) update(entity *Listing) error {
+	if entity == nil {
+		return errors.New("entity cannot be nil")
+	}
+	if entity.Id == "" {
+		return ErrInvalidID
+	}
+
+	old, exists := r.data[entity.Id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Clean up old index entries
+	r.byStatus.remove(old.Status, old.Id)
+	r.byChannel.remove(old.Channel, old.Id)
+
+	entity.UpdatedAt = timestamppb.Now()
+	entity.CreatedAt = old.CreatedAt // Preserve original
+
+	r.touch(entity.Id)
+	r.data[entity.Id] = r.clone(entity)
+
+	// Update indexes
+	r.byStatus.add(entity.Status, entity.Id)
+	r.byChannel.add(entity.Channel, entity.Id)
+
+	return nil
+}
+
+// Upsert creates or updates a Listing
+func (r *InMemoryListingRepository) Upsert(ctx context.Context, entity *Listing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.journal.commit(r.upsert(entity), r)
+}
+
+// upsert is Upsert with r.mu held.
+func (r *InMemoryListingRepository) upsert(entity *Listing) error {
+	if entity == nil {
+		return errors.New("entity cannot be nil")
+	}
+
+	_, exists := r.data[entity.Id]
+	if !exists {
+		_, err := r.create(entity)
+		return err
+	}
+	return r.update(entity)
+}
+
+// Patch sets the fields of the stored Listing that mask names to entity's. A path
+// names a field or, with dots, a field of a message field (see patchPath). A
+// path naming no field, the ID or a field the repository manages fails with
+// ErrInvalidMask and changes nothing.
+func (r *InMemoryListingRepository) Patch(ctx context.Context, id string, entity *Listing, mask *fieldmaskpb.FieldMask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.journal.commit(r.patch(id, entity, mask), r)
+}
+
+// patch is Patch with r.mu held.
+func (r *InMemoryListingRepository) patch(id string, entity *Listing, mask *fieldmaskpb.FieldMask) error {
+	if entity == nil {
+		return errors.New("entity cannot be nil")
+	}
+	if id == "" {
+		return ErrInvalidID
+	}
+	if len(mask.GetPaths()) == 0 {
+		return fmt.Errorf("%w: no paths", ErrInvalidMask)
+	}
+
+	old, exists := r.data[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Patch a copy, so that an invalid path leaves the stored entity as it was
+	patched := r.clone(old)
+	src, dst := entity.ProtoReflect(), patched.ProtoReflect()
+	for _, path := range mask.GetPaths() {
+		switch top, _, _ := strings.Cut(path, "."); top {
+		case "status", "channel", "channels", "price", "ship_from", "tiers", "regional_prices", "labels", "restocks", "flags", "fixed", "auction", "quote_url", "stock", "note", "serial", "delta", "batch", "shelf", "weight", "checksum", "featured", "subtitle", "views", "impressions", "rank", "slot", "score", "discount", "gift", "thumbnail", "metadata", "extra", "history", "lead_time", "details", "price_changes":
+		default:
+			return fmt.Errorf("%w: %q is not a patchable field of Listing", ErrInvalidMask, path)
+		}
+		if err := patchPath(src, dst, path); err != nil {
+			return err
+		}
+	}
+	patched.UpdatedAt = timestamppb.Now()
+
+	r.byStatus.remove(old.Status, old.Id)
+	r.byChannel.remove(old.Channel, old.Id)
+	r.byStatus.add(patched.Status, patched.Id)
+	r.byChannel.add(patched.Channel, patched.Id)
+	r.touch(id)
+	r.data[id] = r.clone(patched)
+	return nil
+}
+
+// Delete permanently deletes a Listing
+func (r *InMemoryListingRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.journal.commit(r.remove(id), r)
+}
+
+// remove is Delete with r.mu held.
+func (r *InMemoryListingRepository) remove(id string) error {
+	if id == "" {
+		return ErrInvalidID
+	}
+
+	entity, exists := r.data[id]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Clean up indexes
+	r.byStatus.remove(entity.Status, entity.Id)
+	r.byChannel.remove(entity.Channel, entity.Id)
+
+	r.touch(id)
+	delete(r.data, id)
+	return nil
+}
+
+// List retrieves up to limit Listing (all when limit <= 0)
+func (r *InMemoryListingRepository) List(ctx context.Context, limit int) ([]*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*Listing, 0, len(r.data))
+	for _, entity := range r.data {
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+		results = append(results, r.clone(entity))
+	}
+	return results, nil
+}
+
+// ListAll retrieves all Listing including soft-deleted
+func (r *InMemoryListingRepository) ListAll(ctx context.Context) ([]*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*Listing, 0, len(r.data))
+	for _, entity := range r.data {
+		results = append(results, r.clone(entity))
+	}
+	return results, nil
+}
+
+// Exists checks if Listing exists
+func (r *InMemoryListingRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if id == "" {
+		return false, ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.data[id]
+	if !exists {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Count returns total Listing (excluding soft-deleted)
+func (r *InMemoryListingRepository) Count(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.data)), nil
+}
+
+// CountWhere returns the number of Listings whose field compares to value as op says
+func (r *InMemoryListingRepository) CountWhere(ctx context.Context, field, op string, value interface{}) (int64, error) {
+	match, err := where((&Listing{}).ProtoReflect().Descriptor(), field, op, value)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := int64(0)
+	r.candidates([]Filter{{Field: field, Op: op, Value: value}}, func(entity *Listing) {
+		if match(entity.ProtoReflect()) {
+			count++
+		}
+	})
+	return count, nil
+}
+
+// SumStock returns the sum of stock over the Listings
+func (r *InMemoryListingRepository) SumStock(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum int64
+	for _, entity := range r.data {
+		if entity.Stock == nil {
+			continue
+		}
+		sum += int64(entity.GetStock())
+	}
+	return sum, nil
+}
+
+// AvgStock returns the average of stock over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgStock(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		if entity.Stock == nil {
+			continue
+		}
+		sum += float64(entity.GetStock())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// SumSerial returns the sum of serial over the Listings
+func (r *InMemoryListingRepository) SumSerial(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum int64
+	for _, entity := range r.data {
+		sum += int64(entity.GetSerial())
+	}
+	return sum, nil
+}
+
+// AvgSerial returns the average of serial over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgSerial(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		sum += float64(entity.GetSerial())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// SumDelta returns the sum of delta over the Listings
+func (r *InMemoryListingRepository) SumDelta(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum int64
+	for _, entity := range r.data {
+		sum += int64(entity.GetDelta())
+	}
+	return sum, nil
+}
+
+// AvgDelta returns the average of delta over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgDelta(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		sum += float64(entity.GetDelta())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// SumBatch returns the sum of batch over the Listings
+func (r *InMemoryListingRepository) SumBatch(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum int64
+	for _, entity := range r.data {
+		sum += int64(entity.GetBatch())
+	}
+	return sum, nil
+}
+
+// AvgBatch returns the average of batch over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgBatch(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		sum += float64(entity.GetBatch())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// SumShelf returns the sum of shelf over the Listings
+func (r *InMemoryListingRepository) SumShelf(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum int64
+	for _, entity := range r.data {
+		sum += int64(entity.GetShelf())
+	}
+	return sum, nil
+}
+
+// AvgShelf returns the average of shelf over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgShelf(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		sum += float64(entity.GetShelf())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// SumWeight returns the sum of weight over the Listings
+func (r *InMemoryListingRepository) SumWeight(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	for _, entity := range r.data {
+		sum += float64(entity.GetWeight())
+	}
+	return sum, nil
+}
+
+// AvgWeight returns the average of weight over the Listings, 0 when there are none
+func (r *InMemoryListingRepository) AvgWeight(ctx context.Context) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sum float64
+	n := 0
+	for _, entity := range r.data {
+		sum += float64(entity.GetWeight())
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return sum / float64(n), nil
+}
+
+// FindByStatus finds all Listing by status (indexed)
+func (r *InMemoryListingRepository) FindByStatus(ctx context.Context, status Status, limit int) ([]*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*Listing
+	for id := range r.byStatus.ids[status] {
+		entity := r.data[id]
+		results = append(results, r.clone(entity))
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// FindByChannel finds all Listing by channel (indexed)
+func (r *InMemoryListingRepository) FindByChannel(ctx context.Context, channel Listing_Channel, limit int) ([]*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*Listing
+	for id := range r.byChannel.ids[channel] {
+		entity := r.data[id]
+		results = append(results, r.clone(entity))
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// Filter finds all Listing matching predicate
+func (r *InMemoryListingRepository) Filter(ctx context.Context, predicate func(*Listing) bool, limit int) ([]*Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*Listing
+	for _, entity := range r.data {
+		if predicate(entity) {
+			results = append(results, r.clone(entity))
+			if limit > 0 && len(results) >= limit {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+// FindOne finds first Listing matching predicate
+func (r *InMemoryListingRepository) FindOne(ctx context.Context, predicate func(*Listing) bool) (*Listing, error) {
+	results, err := r.Filter(ctx, predicate, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+	return results[0], nil
+}
+
+// === Query Builder ===
+
+// InMemoryListingQuery is a query of the Listings, which evaluates filters and orders
+// like the Firestore repository's queries do.
+type InMemoryListingQuery struct {
+	repo      *InMemoryListingRepository
+	filters   []Filter
+	orders    []order
+	limitVal  int
+	offsetVal int
+}
+
+var _ ListingQuery = (*InMemoryListingQuery)(nil)
+
+// Query starts a query of the Listings.
+func (r *InMemoryListingRepository) Query() ListingQuery {
+	return &InMemoryListingQuery{repo: r}
+}
+
+// Where keeps the results whose field, a proto field name, compares to value as
+// op says (see the where helper). Get, First and Count fail for invalid filters.
+func (q *InMemoryListingQuery) Where(field string, op string, value interface{}) ListingQuery {
+	q.filters = append(q.filters, Filter{Field: field, Op: op, Value: value})
+	return q
+}
+
+func (q *InMemoryListingQuery) OrderBy(field string, dir Direction) ListingQuery {
+	q.orders = append(q.orders, order{field: field, dir: dir})
+	return q
+}
+
+func (q *InMemoryListingQuery) Limit(n int) ListingQuery {
+	q.limitVal = n
+	return q
+}
+
+func (q *InMemoryListingQuery) Offset(n int) ListingQuery {
+	q.offsetVal = n
+	return q
+}
+
+// Get returns the results in the order Firestore returns them in (see the orderBy
+// helper): the OrderBy fields, then the fields of inequality filters, then ID.
+func (q *InMemoryListingQuery) Get(ctx context.Context) ([]*Listing, error) {
+	desc := (&Listing{}).ProtoReflect().Descriptor()
+	match, err := whereAll(desc, q.filters)
+	if err != nil {
+		return nil, err
+	}
+	compare, err := orderBy(desc, "id", q.filters, q.orders)
+	if err != nil {
+		return nil, err
+	}
+
+	q.repo.mu.RLock()
+	defer q.repo.mu.RUnlock()
+
+	var results []*Listing
+	q.repo.candidates(q.filters, func(entity *Listing) {
+		if match(entity.ProtoReflect()) {
+			results = append(results, entity)
+		}
+	})
+	slices.SortFunc(results, func(a, b *Listing) int { return compare(a.ProtoReflect(), b.ProtoReflect()) })
+	results = window(results, q.offsetVal, q.limitVal)
+	for i, entity := range results {
+		results[i] = q.repo.clone(entity)
+	}
+	return results, nil
+}
+
+// Count returns the number of results; Limit and Offset do not apply.
+func (q *InMemoryListingQuery) Count(ctx context.Context) (int64, error) {
+	match, err := whereAll((&Listing{}).ProtoReflect().Descriptor(), q.filters)
+	if err != nil {
+		return 0, err
+	}
+
+	q.repo.mu.RLock()
+	defer q.repo.mu.RUnlock()
+
+	count := int64(0)
+	q.repo.candidates(q.filters, func(entity *Listing) {
+		if match(entity.ProtoReflect()) {
+			count++
+		}
+	})
+	return count, nil
+}
+
+func (q *InMemoryListingQuery) First(ctx context.Context) (*Listing, error) {
+	q.limitVal = 1
+	results, err := q.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrNotFound
+	}
+	return results[0], nil
+}
+
+// lookup returns the IDs of the entities whose field compares to value as op
+// says, from the value index of the field, or false when none serves the filter.
+func (r *InMemoryListingRepository) lookup(field, op string, value interface{}) ([]string, bool) {
+	fd := (&Listing{}).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))
+	switch field {
+	case "status":
+		return r.byStatus.lookup(fd, op, value)
+	case "channel":
+		return r.byChannel.lookup(fd, op, value)
+	}
+	return nil, false
+}
+
+// candidates calls fn with the stored entities that may match every filter:
+// those the value index of a filtered field gives, from the filter that leaves
+// the fewest, or else all of them.
+func (r *InMemoryListingRepository) candidates(filters []Filter, fn func(entity *Listing)) {
+	var ids []string
+	indexed := false
+	for _, f := range filters {
+		if found, ok := r.lookup(f.Field, f.Op, f.Value); ok && (!indexed || len(found) < len(ids)) {
+			ids, indexed = found, true
+		}
+	}
+	if !indexed {
+		for _, entity := range r.data {
+			fn(entity)
+		}
+		return
+	}
+	for _, id := range ids {
+		fn(r.data[id])
+	}
+}
+
+// Clear removes all data (useful for tests). A durable repository that fails to
+// record it keeps its data.
+func (r *InMemoryListingRepository) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entity := range r.data {
+		r.touch(id)
+		r.byStatus.remove(entity.Status, entity.Id)
+		r.byChannel.remove(entity.Channel, entity.Id)
+		delete(r.data, id)
+	}
+	r.journal.commit(nil, r)
+}
+
+// Snapshot returns a copy of all data (for debugging/testing)
+func (r *InMemoryListingRepository) Snapshot() map[string]*Listing {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]*Listing, len(r.data))
+	for id, entity := range r.data {
+		snapshot[id] = r.clone(entity)
+	}
+	return snapshot
+}
+
+// Load replaces all data from a snapshot (for testing), keyed by ID. A durable
+// repository that fails to record it keeps its data.
+func (r *InMemoryListingRepository) Load(data map[string]*Listing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entity := range r.data {
+		r.touch(id)
+		r.byStatus.remove(entity.Status, entity.Id)
+		r.byChannel.remove(entity.Channel, entity.Id)
+		delete(r.data, id)
+	}
+	for id, entity := range data {
+		entity = r.clone(entity)
+		entity.Id = id
+		r.touch(id)
+		r.data[id] = entity
+		r.byStatus.add(entity.Status, entity.Id)
+		r.byChannel.add(entity.Channel, entity.Id)
+	}
+	r.journal.commit(nil, r)
+}
+
+// === Transaction Support ===
+
+// RunTransaction runs fn in a transaction over the Listings; see
+// InMemoryStore.RunTransaction.
+func (r *InMemoryListingRepository) RunTransaction(ctx context.Context, fn func(context.Context, ListingTx) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	committed := false
+	defer func() {
+		if !committed {
+			r.revert()
+		}
+	}()
+	err := fn(ctx, &InMemoryListingTx{repo: r})
+	committed = true
+	return r.journal.commit(err, r)
+}
+
+// InMemoryListingTx is the Listing side of an in-memory transaction.
+type InMemoryListingTx struct {
+	repo *InMemoryListingRepository
+}
+
+var _ ListingTx = (*InMemoryListingTx)(nil)
+
+func (t *InMemoryListingTx) Get(id string) (*Listing, error) {
+	return t.repo.get(id)
+}
+
+func (t *InMemoryListingTx) GetAll(ids []string) ([]*Listing, error) {
+	var results []*Listing
+	for _, id := range ids {
+		entity, err := t.repo.get(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, entity)
+	}
+	return results, nil
+}
+
+func (t *InMemoryListingTx) Create(entity *Listing) (string, error) {
+	return t.repo.create(entity)
+}
+
+func (t *InMemoryListingTx) Update(entity *Listing) error {
+	return t.repo.update(entity)
+}
+
+func (t *InMemoryListingTx) Patch(id string, entity *Listing, mask *fieldmaskpb.FieldMask) error {
+	return t.repo.patch(id, entity, mask)
+}
+
+func (t *InMemoryListingTx) Delete(id string) error {
+	return t.repo.remove(id)
+}
+
+// Query evaluates filters like Firestore does (see the where helper).
+func (t *InMemoryListingTx) Query(filters ...Filter) ([]*Listing, error) {
+	match, err := whereAll((&Listing{}).ProtoReflect().Descriptor(), filters)
+	if err != nil {
+		return nil, err
+	}
+	var results []*Listing
+	t.repo.candidates(filters, func(entity *Listing) {
+		if match(entity.ProtoReflect()) {
+			results = append(results, t.repo.clone(entity))
+		}
+	})
+	slices.SortFunc(results, func(a, b *Listing) int { return strings.Compare(a.Id, b.Id) })
+	return results, nil
+}
+
+// === Durability ===
+
+// OpenInMemoryListingRepository opens the durable repository in dir, creating it
+// when missing: it replays the journal of the writes of earlier runs, and
+// records every write before it returns. Close it to stop the periodic
+// compaction of the journal.
+func OpenInMemoryListingRepository(dir string, opts DurableOptions) (*InMemoryListingRepository, error) {
+	r := NewInMemoryListingRepository()
+	j, err := openJournal(dir, opts, map[string]journaled{"listings": r})
+	if err != nil {
+		return nil, err
+	}
+	r.journal = j
+	j.start(r.Compact)
+	return r, nil
+}
+
+// Compact replaces the journal of a durable repository with a snapshot of its
+// data. It runs every DurableOptions.SnapshotInterval. The repositories of a
+// durable InMemoryStore share the store's journal, which the store compacts.
+func (r *InMemoryListingRepository) Compact() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.journal.compact(r)
+}
+
+// Close compacts the journal of a durable repository a last time and closes it;
+// writes fail afterwards. Like Compact, it fails for the repositories of a store.
+func (r *InMemoryListingRepository) Close() error {
+	return r.journal.close(r.Compact, r)
+}
+
+// touch records the entity with the given ID before the running write changes it.
+func (r *InMemoryListingRepository) touch(id string) {
+	if _, ok := r.undo[id]; !ok {
+		r.undo[id] = r.data[id]
+	}
+}
+
+// revert undoes the running write: the stored entities are replaced, never
+// modified, so the entities it touched are as they were.
+func (r *InMemoryListingRepository) revert() {
+	for id := range r.undo {
+		if entity, ok := r.data[id]; ok {
+			r.byStatus.remove(entity.Status, entity.Id)
+			r.byChannel.remove(entity.Channel, entity.Id)
+		}
+	}
+	for id, entity := range r.undo {
+		if entity == nil {
+			delete(r.data, id)
+			continue
+		}
+		r.data[id] = entity
+		r.byStatus.add(entity.Status, entity.Id)
+		r.byChannel.add(entity.Channel, entity.Id)
+	}
+	clear(r.undo)
+}
+
+// keep ends the running write, keeping its changes.
+func (r *InMemoryListingRepository) keep() {
+	clear(r.undo)
+}
+
+// changes returns the mutations of the running write.
+func (r *InMemoryListingRepository) changes() ([]mutation, error) {
+	batch := make([]mutation, 0, len(r.undo))
+	for id := range r.undo {
+		entity, ok := r.data[id]
+		if !ok {
+			batch = append(batch, mutation{collection: "listings", kind: mutationDelete, id: id})
+			continue
+		}
+		b, err := proto.Marshal(entity)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, mutation{collection: "listings", kind: mutationPut, id: id, entity: b})
+	}
+	return batch, nil
+}
+
+// apply replays a mutation of the journal.
+func (r *InMemoryListingRepository) apply(m mutation) error {
+	if old, ok := r.data[m.id]; ok {
+		r.byStatus.remove(old.Status, old.Id)
+		r.byChannel.remove(old.Channel, old.Id)
+	}
+	if m.kind == mutationDelete {
+		delete(r.data, m.id)
+		return nil
+	}
+	entity := &Listing{}
+	if err := proto.Unmarshal(m.entity, entity); err != nil {
+		return fmt.Errorf("journal: listings %s: %w", m.id, err)
+	}
+	r.data[m.id] = entity
+	r.byStatus.add(entity.Status, entity.Id)
+	r.byChannel.add(entity.Channel, entity.Id)
+	return nil
+}
+
+// entities calls put with a mutation storing every entity.
+func (r *InMemoryListingRepository) entities(put func(mutation) error) error {
+	for id, entity := range r.data {
+		b, err := proto.Marshal(entity)
+		if err != nil {
+			return err
+		}
+		if err := put(mutation{collection: "listings", kind: mutationPut, id: id, entity: b}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
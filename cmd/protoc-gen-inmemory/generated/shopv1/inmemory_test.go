@@ -0,0 +1,417 @@
+package shopv1
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// checkIndexes compares the indexes of r with the ones its data gives.
+func checkIndexes(t *testing.T, r *InMemoryUserRepository) {
+	t.Helper()
+	emails := make(map[string]string)
+	orgs := newValueIndex[string]()
+	roles := newValueIndex[Role]()
+	for id, u := range r.data {
+		if u.UserId != id {
+			t.Errorf("entity %s has ID %q", id, u.UserId)
+		}
+		if u.Email != "" {
+			emails[u.Email] = id
+		}
+		orgs.add(u.OrgId, id)
+		roles.add(u.Role, id)
+	}
+	if !maps.Equal(r.idxEmail, emails) {
+		t.Errorf("email index = %v, want %v", r.idxEmail, emails)
+	}
+	checkValueIndex(t, "org_id", r.byOrgId, orgs)
+	checkValueIndex(t, "role", r.byRole, roles)
+}
+
+func checkValueIndex[K comparable](t *testing.T, field string, got, want *valueIndex[K]) {
+	t.Helper()
+	if !slices.Equal(got.sorted, want.sorted) {
+		t.Errorf("%s index values = %v, want %v", field, got.sorted, want.sorted)
+	}
+	if !maps.EqualFunc(got.ids, want.ids, maps.Equal) {
+		t.Errorf("%s index = %v, want %v", field, got.ids, want.ids)
+	}
+}
+
+func TestIndexesFollowWrites(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryUserRepository()
+	step := func(name string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		checkIndexes(t, r)
+	}
+
+	ann := &User{Email: "ann@example.com", OrgId: "acme", Role: Role_ROLE_ADMIN}
+	_, err := r.Create(ctx, ann)
+	step("create ann", err)
+	bob := &User{Email: "bob@example.com", OrgId: "acme", Role: Role_ROLE_MEMBER}
+	_, err = r.Create(ctx, bob)
+	step("create bob", err)
+
+	bob.OrgId, bob.Role = "initech", Role_ROLE_ADMIN
+	step("update bob", r.Update(ctx, bob))
+	step("soft delete ann", r.SoftDelete(ctx, ann.UserId))
+	step("restore ann", r.Restore(ctx, ann.UserId))
+	step("soft delete bob", r.SoftDelete(ctx, bob.UserId))
+
+	err = r.RunTransaction(ctx, func(ctx context.Context, tx UserTx) error {
+		if _, err := tx.Create(&User{Email: "cat@example.com", OrgId: "umbrella"}); err != nil {
+			return err
+		}
+		if err := tx.Delete(ann.UserId); err != nil {
+			return err
+		}
+		return errors.New("roll back")
+	})
+	if err == nil {
+		t.Fatal("transaction committed")
+	}
+	step("roll back", nil)
+	if n := len(r.byOrgId.ids["acme"]); n != 1 {
+		t.Errorf("acme has %d users after the rollback, want 1", n)
+	}
+
+	snapshot := r.Snapshot()
+	r.Clear()
+	step("clear", nil)
+	if len(r.byOrgId.sorted) != 0 || len(r.byRole.sorted) != 0 || len(r.idxEmail) != 0 {
+		t.Errorf("indexes not empty after Clear")
+	}
+
+	// Load keys entities by ID, even when the entity says otherwise
+	snapshot["dan"] = &User{UserId: "someone-else", Email: "dan@example.com", OrgId: "acme"}
+	r.Load(snapshot)
+	step("load", nil)
+	step("delete dan", r.Delete(ctx, "dan"))
+}
+
+func TestQueriesSkipSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryUserRepository()
+	var ids []string
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		id, err := r.Create(ctx, &User{Email: email, OrgId: "acme", Role: Role_ROLE_MEMBER})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := r.SoftDelete(ctx, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := r.CountWhere(ctx, "org_id", "==", "acme"); err != nil || n != 2 {
+		t.Errorf("CountWhere = %d, %v, want 2", n, err)
+	}
+	users, err := r.Query().Where("role", "in", []interface{}{"ROLE_MEMBER"}).Get(ctx)
+	if err != nil || len(users) != 2 {
+		t.Fatalf("Query = %d users, %v, want 2", len(users), err)
+	}
+	for _, u := range users {
+		if u.UserId == ids[1] {
+			t.Errorf("Query returned the soft-deleted user")
+		}
+	}
+	if found, err := r.FindByOrgId(ctx, "acme", 0); err != nil || len(found) != 2 {
+		t.Errorf("FindByOrgId = %d users, %v, want 2", len(found), err)
+	}
+}
+
+func TestUpsertWithETag(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryUserRepository()
+	id, err := r.Create(ctx, &User{Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := r.MustGet(ctx, id)
+	if err := r.Upsert(ctx, &User{UserId: id, Email: "ann@example.com", Name: "Ann"}); err != nil {
+		t.Fatalf("Upsert of a stored user: %v", err)
+	}
+	got := r.MustGet(ctx, id)
+	if got.Name != "Ann" || got.Etag == "" || got.Etag == stored.Etag {
+		t.Errorf("after Upsert: name %q, etag %q (was %q)", got.Name, got.Etag, stored.Etag)
+	}
+	if err := r.Update(ctx, stored); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update with the old etag = %v, want ErrConflict", err)
+	}
+}
+
+func TestUniqueFields(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryUserRepository()
+	ann := &User{Email: "ann@example.com"}
+	bob := &User{Email: "bob@example.com"}
+	for _, u := range []*User{ann, bob} {
+		if _, err := r.Create(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rejected := func(name string, err error) {
+		t.Helper()
+		if !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("%s = %v, want ErrAlreadyExists", name, err)
+		}
+		checkIndexes(t, r)
+	}
+
+	_, err := r.Create(ctx, &User{UserId: ann.UserId, Email: "new@example.com"})
+	rejected("Create with a taken ID", err)
+	_, err = r.Create(ctx, &User{Email: "bob@example.com"})
+	rejected("Create with a taken email", err)
+
+	taken := r.MustGet(ctx, ann.UserId)
+	taken.Email = "bob@example.com"
+	rejected("Update to a taken email", r.Update(ctx, taken))
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"email"}}
+	rejected("Patch to a taken email", r.Patch(ctx, ann.UserId, &User{Email: "bob@example.com"}, mask))
+	if got := r.MustGet(ctx, ann.UserId); got.Email != "ann@example.com" {
+		t.Errorf("ann's email = %q after the rejected writes", got.Email)
+	}
+	if got, err := r.FindByEmail(ctx, "bob@example.com"); err != nil || got.UserId != bob.UserId {
+		t.Errorf("FindByEmail(bob) = %v, %v after the rejected writes", got, err)
+	}
+
+	// A soft-deleted user keeps their email, as a row would in SQL, though
+	// FindByEmail no longer finds them
+	if err := r.SoftDelete(ctx, bob.UserId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.FindByEmail(ctx, "bob@example.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByEmail of a soft-deleted user = %v, want ErrNotFound", err)
+	}
+	_, err = r.Create(ctx, &User{Email: "bob@example.com"})
+	rejected("Create with a soft-deleted user's email", err)
+
+	// The index moves with the value, and keeping one's own value is no collision
+	if err := r.Patch(ctx, ann.UserId, &User{Email: "ann@example.org"}, mask); err != nil {
+		t.Fatal(err)
+	}
+	ann = r.MustGet(ctx, ann.UserId)
+	ann.Name = "Ann"
+	if err := r.Update(ctx, ann); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create(ctx, &User{Email: "ann@example.com"}); err != nil {
+		t.Errorf("Create with ann's old email: %v", err)
+	}
+	checkIndexes(t, r)
+
+	// Empty emails, like NULLs, never collide
+	for range 2 {
+		if _, err := r.Create(ctx, &User{}); err != nil {
+			t.Errorf("Create without an email: %v", err)
+		}
+	}
+}
+
+func TestFindByAfterLoad(t *testing.T) {
+	ctx := context.Background()
+	r := NewInMemoryUserRepository()
+	r.Load(map[string]*User{
+		"ann": {UserId: "ann", Email: "ann@example.com", OrgId: "acme"},
+		"bob": {UserId: "bob", Email: "bob@example.com", OrgId: "acme"},
+	})
+	checkIndexes(t, r)
+	if got, err := r.FindByEmail(ctx, "bob@example.com"); err != nil || got.UserId != "bob" {
+		t.Errorf("FindByEmail = %v, %v, want bob", got, err)
+	}
+	if found, err := r.FindByOrgId(ctx, "acme", 0); err != nil || len(found) != 2 {
+		t.Errorf("FindByOrgId = %d users, %v, want 2", len(found), err)
+	}
+	if _, err := r.Create(ctx, &User{Email: "ann@example.com"}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Create with a loaded email = %v, want ErrAlreadyExists", err)
+	}
+
+	// Loading again replaces the indexes with the new data's
+	r.Load(map[string]*User{"cat": {UserId: "cat", Email: "cat@example.com"}})
+	checkIndexes(t, r)
+	if _, err := r.FindByEmail(ctx, "ann@example.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByEmail of an unloaded user = %v, want ErrNotFound", err)
+	}
+}
+
+// seed returns a store with a user and a store, and the function that checks
+// that the store holds just them, as they were.
+func seed(t *testing.T) (*InMemoryStore, func(string)) {
+	t.Helper()
+	ctx := context.Background()
+	s := NewInMemoryStore()
+	ann := &User{UserId: "ann", Email: "ann@example.com", OrgId: "acme"}
+	if _, err := s.User.Create(ctx, ann); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Store.Create(ctx, &Store{Id: "s1", Name: "First"}); err != nil {
+		t.Fatal(err)
+	}
+	ann = s.User.MustGet(ctx, "ann")
+	return s, func(name string) {
+		t.Helper()
+		if n, _ := s.User.Count(ctx); n != 1 {
+			t.Errorf("%s: %d users, want 1", name, n)
+		}
+		if got, err := s.User.Get(ctx, "ann"); err != nil || got.Name != "" || got.Etag != ann.Etag {
+			t.Errorf("%s: ann = %v, %v, want her as she was", name, got, err)
+		}
+		if got, err := s.Store.Get(ctx, "s1"); err != nil || got.Name != "First" {
+			t.Errorf("%s: store = %v, %v, want it as it was", name, got, err)
+		}
+		if n, _ := s.Store.Count(ctx); n != 1 {
+			t.Errorf("%s: %d stores, want 1", name, n)
+		}
+		checkIndexes(t, s.User)
+	}
+}
+
+// writeBoth writes to both repositories of tx.
+func writeBoth(tx Tx) error {
+	ann, err := tx.User().Get("ann")
+	if err != nil {
+		return err
+	}
+	ann.Name = "Ann"
+	if err := tx.User().Update(ann); err != nil {
+		return err
+	}
+	if _, err := tx.User().Create(&User{Email: "bob@example.com", OrgId: "acme"}); err != nil {
+		return err
+	}
+	if err := tx.Store().Patch("s1", &Store{Name: "Renamed"}, &fieldmaskpb.FieldMask{Paths: []string{"name"}}); err != nil {
+		return err
+	}
+	_, err = tx.Store().Create(&Store{Id: "s2"})
+	return err
+}
+
+func TestStoreRollback(t *testing.T) {
+	ctx := context.Background()
+	t.Run("error", func(t *testing.T) {
+		s, unchanged := seed(t)
+		failed := errors.New("failed")
+		err := s.RunTransaction(ctx, func(ctx context.Context, tx Tx) error {
+			if err := writeBoth(tx); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("RunTransaction = %v, want fn's error", err)
+		}
+		unchanged("after the error")
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		s, unchanged := seed(t)
+		err := s.RunTransaction(ctx, func(ctx context.Context, tx Tx) error {
+			if err := writeBoth(tx); err != nil {
+				return err
+			}
+			_, err := tx.User().Create(&User{Email: "ann@example.com"})
+			return err
+		})
+		if !errors.Is(err, ErrAlreadyExists) {
+			t.Fatalf("RunTransaction = %v, want ErrAlreadyExists", err)
+		}
+		unchanged("after the failed write")
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		s, unchanged := seed(t)
+		func() {
+			defer func() {
+				if r := recover(); r != "boom" {
+					t.Errorf("recovered %v, want fn's panic", r)
+				}
+			}()
+			s.RunTransaction(ctx, func(ctx context.Context, tx Tx) error {
+				if err := writeBoth(tx); err != nil {
+					t.Error(err)
+				}
+				panic("boom")
+			})
+		}()
+		unchanged("after the panic")
+
+		// the repositories are unlocked again
+		if _, err := s.User.Create(ctx, &User{Email: "bob@example.com"}); err != nil {
+			t.Errorf("Create after the panic: %v", err)
+		}
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		s, _ := seed(t)
+		if err := s.RunTransaction(ctx, func(ctx context.Context, tx Tx) error { return writeBoth(tx) }); err != nil {
+			t.Fatal(err)
+		}
+		if n, _ := s.User.Count(ctx); n != 2 {
+			t.Errorf("%d users, want 2", n)
+		}
+		if got := s.Store.MustGet(ctx, "s1"); got.Name != "Renamed" {
+			t.Errorf("store name = %q, want Renamed", got.Name)
+		}
+		checkIndexes(t, s.User)
+	})
+}
+
+func TestStoreConflicts(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		conflicts, runs int
+		err             error
+	}{
+		{conflicts: 0, runs: 1},
+		{conflicts: 1, runs: 2},
+		{conflicts: transactionAttempts - 1, runs: transactionAttempts},
+		{conflicts: transactionAttempts, runs: transactionAttempts, err: ErrConflict},
+		{conflicts: transactionAttempts + 3, runs: transactionAttempts, err: ErrConflict},
+	} {
+		s, unchanged := seed(t)
+		s.InjectConflicts(tt.conflicts)
+		runs := 0
+		err := s.RunTransaction(ctx, func(ctx context.Context, tx Tx) error {
+			runs++
+			// each run starts from the data as it was, not from the last run's writes
+			if users, err := tx.User().Query(); err != nil || len(users) != 1 {
+				t.Errorf("run %d sees %d users, %v, want 1", runs, len(users), err)
+			}
+			return writeBoth(tx)
+		})
+		if !errors.Is(err, tt.err) || (err != nil && tt.err == nil) {
+			t.Errorf("%d conflicts: RunTransaction = %v, want %v", tt.conflicts, err, tt.err)
+		}
+		if runs != tt.runs {
+			t.Errorf("%d conflicts: fn ran %d times, want %d", tt.conflicts, runs, tt.runs)
+		}
+		if tt.err != nil {
+			unchanged("after the conflicts")
+		} else if n, _ := s.User.Count(ctx); n != 2 {
+			t.Errorf("%d conflicts: %d users after the commit, want 2", tt.conflicts, n)
+		}
+	}
+
+	// only the commits of transactions conflict, and a cancelled context stops
+	// the retries
+	s, _ := seed(t)
+	s.InjectConflicts(1)
+	if _, err := s.User.Create(ctx, &User{Email: "bob@example.com"}); err != nil {
+		t.Errorf("Create with a conflict injected: %v", err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := s.RunTransaction(cancelled, func(context.Context, Tx) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("RunTransaction with a cancelled context = %v", err)
+	}
+}
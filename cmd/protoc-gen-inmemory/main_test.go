@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/inmemory"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/repository"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, inmemory.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "no_soft_delete", Files: []string{"shop/v1/shop.proto"}, Param: "soft_delete=false,timestamps=false"},
+		plugintest.Case{Name: "catalog", Files: []string{"catalog/v1/catalog.proto"}},
+	)
+}
+
+// TestGenerated keeps the repositories of generated/ up to date; the tests
+// there run them.
+func TestGenerated(t *testing.T) {
+	t.Run("shop", func(t *testing.T) {
+		plugintest.Generated(t, "generated/shopv1",
+			plugintest.Case{Files: []string{"shop/v1/shop.proto"}, Param: "paths=source_relative"},
+			plugintest.Go, repository.Plugin, inmemory.Plugin)
+	})
+	t.Run("catalog", func(t *testing.T) {
+		plugintest.Generated(t, "generated/catalogv1",
+			plugintest.Case{Files: []string{"catalog/v1/catalog.proto", "catalog/v1/listing.proto"}, Param: "paths=source_relative"},
+			plugintest.Go, repository.Plugin, inmemory.Plugin)
+	})
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/llm"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	// Without an API key the plugin emits its deterministic fallback logic
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	plugintest.Golden(t, llm.Plugin,
+		plugintest.Case{Name: "assistant", Files: []string{"assistant/v1/assistant.proto"}},
+	)
+}
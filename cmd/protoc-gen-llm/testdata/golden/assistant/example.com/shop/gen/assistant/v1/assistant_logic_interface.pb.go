@@ -0,0 +1,23 @@
+// Code generated by protoc-gen-llm. DO NOT EDIT.
+
+package assistantv1
+
+import (
+	"context"
+)
+
+// TicketServiceLogic defines business logic hooks for TicketService
+type TicketServiceLogic interface {
+	BeforeTriage(ctx context.Context, req *TriageRequest) error
+	AfterTriage(ctx context.Context, req *TriageRequest, result *TriageResponse) error
+}
+
+// TicketServiceLogicNoop is a no-op implementation
+type TicketServiceLogicNoop struct{}
+
+func (l *TicketServiceLogicNoop) BeforeTriage(ctx context.Context, req *TriageRequest) error {
+	return nil
+}
+func (l *TicketServiceLogicNoop) AfterTriage(ctx context.Context, req *TriageRequest, result *TriageResponse) error {
+	return nil
+}
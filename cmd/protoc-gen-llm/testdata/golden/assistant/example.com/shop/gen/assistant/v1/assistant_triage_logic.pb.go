@@ -0,0 +1,33 @@
+// Code generated by protoc-gen-llm. DO NOT EDIT.
+// NOTE: Generated without Claude API - implement TODO sections
+// Directive: Classify the ticket priority as low, medium or high from its subject and body, and write a one-sentence summary.
+
+package assistantv1
+
+import (
+	"context"
+)
+
+// TriageLogic handles business logic for Triage
+type TriageLogic struct {
+	// TODO: Add repository dependencies
+}
+
+// NewTriageLogic creates a new logic handler
+func NewTriageLogic() *TriageLogic {
+	return &TriageLogic{}
+}
+
+// BeforeTriage runs before the operation
+// Directive: Classify the ticket priority as low, medium or high from its subject and body, and write a one-sentence summary.
+func (l *TriageLogic) BeforeTriage(ctx context.Context, req *TriageRequest) error {
+	// TODO: Implement based on directive:
+	// Classify the ticket priority as low, medium or high from its subject and body, and write a one-sentence summary.
+	return nil
+}
+
+// AfterTriage runs after the operation
+func (l *TriageLogic) AfterTriage(ctx context.Context, req *TriageRequest, result *TriageResponse) error {
+	// TODO: Implement side effects based on directive
+	return nil
+}
@@ -0,0 +1,123 @@
+// Code generated by protoc-gen-mock. DO NOT EDIT.
+// Fake data generators and seeders for testing
+
+package shopv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func init() {
+	gofakeit.Seed(0) // Random seed; use gofakeit.Seed(12345) for reproducible data
+}
+
+// FakeUser generates a fake User with realistic data
+func FakeUser() *User {
+	return &User{
+		UserId:    uuid.New().String(),
+		Email:     gofakeit.Email(),
+		Name:      gofakeit.Name(),
+		OrgId:     gofakeit.LoremIpsumWord(),
+		Role:      Role(gofakeit.Number(1, 2)),
+		Age:       int32(gofakeit.Number(18, 80)),
+		Active:    gofakeit.Bool(),
+		CreatedAt: timestamppb.Now(),
+		UpdatedAt: timestamppb.Now(),
+		DeletedAt: timestamppb.Now(),
+		Etag:      gofakeit.LoremIpsumWord(),
+	}
+}
+
+// FakeStore generates a fake Store with realistic data
+func FakeStore() *Store {
+	return &Store{
+		Id:        uuid.New().String(),
+		Name:      gofakeit.Name(),
+		Latitude:  gofakeit.Latitude(),
+		Longitude: gofakeit.Longitude(),
+	}
+}
+
+// FakeUsers generates n fake User instances
+func FakeUsers(n int) []*User {
+	result := make([]*User, n)
+	for i := 0; i < n; i++ {
+		result[i] = FakeUser()
+	}
+	return result
+}
+
+// FakeStores generates n fake Store instances
+func FakeStores(n int) []*Store {
+	result := make([]*Store, n)
+	for i := 0; i < n; i++ {
+		result[i] = FakeStore()
+	}
+	return result
+}
+
+// SeedUsers seeds the repository with n fake User instances
+func SeedUsers(ctx context.Context, repo UserRepository, n int) ([]*User, error) {
+	var created []*User
+	for i := 0; i < n; i++ {
+		user := FakeUser()
+		if _, err := repo.Create(ctx, user); err != nil {
+			return created, fmt.Errorf("failed to seed User %d: %w", i, err)
+		}
+		created = append(created, user)
+	}
+	return created, nil
+}
+
+// SeedStores seeds the repository with n fake Store instances
+func SeedStores(ctx context.Context, repo StoreRepository, n int) ([]*Store, error) {
+	var created []*Store
+	for i := 0; i < n; i++ {
+		store := FakeStore()
+		if _, err := repo.Create(ctx, store); err != nil {
+			return created, fmt.Errorf("failed to seed Store %d: %w", i, err)
+		}
+		created = append(created, store)
+	}
+	return created, nil
+}
+
+// SeedAll seeds all repositories with fake data
+type Repositories struct {
+	User  UserRepository
+	Store StoreRepository
+}
+
+// SeedCounts specifies how many of each entity to create
+type SeedCounts struct {
+	User  int
+	Store int
+}
+
+// DefaultSeedCounts returns sensible defaults
+func DefaultSeedCounts() SeedCounts {
+	return SeedCounts{
+		User:  10,
+		Store: 10,
+	}
+}
+
+// SeedAllRepositories seeds all repositories with fake data
+func SeedAllRepositories(ctx context.Context, repos Repositories, counts SeedCounts) error {
+	if repos.User != nil && counts.User > 0 {
+		if _, err := SeedUsers(ctx, repos.User, counts.User); err != nil {
+			return err
+		}
+	}
+	if repos.Store != nil && counts.Store > 0 {
+		if _, err := SeedStores(ctx, repos.Store, counts.Store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
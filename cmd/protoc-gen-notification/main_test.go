@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/notification"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, notification.Plugin,
+		plugintest.Case{Name: "account", Files: []string{"account/v1/account.proto"}},
+	)
+}
@@ -0,0 +1,511 @@
+// Code generated by protoc-gen-notification. DO NOT EDIT.
+// Notification service for User.Notifications
+
+package accountv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotificationDisabled = errors.New("notifications disabled")
+	ErrQuietHours           = errors.New("quiet hours active")
+	ErrNoToken              = errors.New("no device token")
+	ErrSendFailed           = errors.New("send failed")
+)
+
+type NotificationType string
+
+const (
+	NotificationTypePush  NotificationType = "push"
+	NotificationTypeEmail NotificationType = "email"
+	NotificationTypeSMS   NotificationType = "sms"
+)
+
+type Notification struct {
+	ID        string
+	UserID    string
+	Type      NotificationType
+	Title     string
+	Body      string
+	Data      map[string]string
+	ImageURL  string
+	ActionURL string
+	Priority  string // high, normal, low
+	CreatedAt time.Time
+	SentAt    *time.Time
+	ReadAt    *time.Time
+}
+
+// Push Provider interface
+type PushProvider interface {
+	Send(ctx context.Context, token string, n *Notification) error
+}
+
+// FCM Provider (Firebase Cloud Messaging)
+type FCMProvider struct {
+	ServerKey string
+	ProjectID string
+}
+
+func NewFCMProvider(serverKey, projectID string) *FCMProvider {
+	return &FCMProvider{ServerKey: serverKey, ProjectID: projectID}
+}
+
+func (p *FCMProvider) Send(ctx context.Context, token string, n *Notification) error {
+	payload := map[string]interface{}{
+		"message": map[string]interface{}{
+			"token": token,
+			"notification": map[string]string{
+				"title": n.Title,
+				"body":  n.Body,
+			},
+			"data": n.Data,
+		},
+	}
+	if n.ImageURL != "" {
+		payload["message"].(map[string]interface{})["notification"].(map[string]string)["image"] = n.ImageURL
+	}
+
+	body, _ := json.Marshal(payload)
+	url := fmt.Sprintf("https://fcm.googleapis.com/v1/projects/%s/messages:send", p.ProjectID)
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+p.ServerKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return ErrSendFailed
+	}
+	return nil
+}
+
+// APNS Provider (Apple Push Notification Service)
+type APNSProvider struct {
+	KeyID      string
+	TeamID     string
+	BundleID   string
+	PrivateKey []byte
+	Production bool
+}
+
+func NewAPNSProvider(keyID, teamID, bundleID string, privateKey []byte, production bool) *APNSProvider {
+	return &APNSProvider{KeyID: keyID, TeamID: teamID, BundleID: bundleID, PrivateKey: privateKey, Production: production}
+}
+
+func (p *APNSProvider) Send(ctx context.Context, token string, n *Notification) error {
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": n.Title,
+				"body":  n.Body,
+			},
+			"sound": "default",
+		},
+	}
+	for k, v := range n.Data {
+		payload[k] = v
+	}
+
+	body, _ := json.Marshal(payload)
+
+	host := "api.sandbox.push.apple.com"
+	if p.Production {
+		host = "api.push.apple.com"
+	}
+	url := fmt.Sprintf("https://%s/3/device/%s", host, token)
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	req.Header.Set("apns-topic", p.BundleID)
+	req.Header.Set("apns-push-type", "alert")
+	// Note: In production, use JWT auth with the private key
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return ErrSendFailed
+	}
+	return nil
+}
+
+// Email Provider interface
+type EmailProvider interface {
+	Send(ctx context.Context, to, subject, htmlBody, textBody string) error
+}
+
+// SMTP Email Provider
+type SMTPEmailProvider struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (p *SMTPEmailProvider) Send(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	// Simplified - in production use net/smtp or a library
+	fmt.Printf("[EMAIL] To: %s, Subject: %s\n", to, subject)
+	return nil
+}
+
+// SMS Provider interface
+type SMSProvider interface {
+	Send(ctx context.Context, to, message string) error
+}
+
+// Twilio SMS Provider
+type TwilioSMSProvider struct {
+	AccountSID string
+	AuthToken  string
+	FromNumber string
+}
+
+func (p *TwilioSMSProvider) Send(ctx context.Context, to, message string) error {
+	url := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", p.AccountSID)
+
+	data := fmt.Sprintf("To=%s&From=%s&Body=%s", to, p.FromNumber, message)
+	req, _ := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(data))
+	req.SetBasicAuth(p.AccountSID, p.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return ErrSendFailed
+	}
+	return nil
+}
+
+// Notification Service
+type NotificationService struct {
+	repo      UserRepository
+	fcm       PushProvider
+	apns      PushProvider
+	email     EmailProvider
+	sms       SMSProvider
+	templates *TemplateEngine
+
+	// In-memory notification store (replace with persistent storage)
+	mu            sync.RWMutex
+	notifications map[string][]*Notification
+}
+
+func NewNotificationService(repo UserRepository) *NotificationService {
+	return &NotificationService{
+		repo:          repo,
+		templates:     NewTemplateEngine(),
+		notifications: make(map[string][]*Notification),
+	}
+}
+
+func (s *NotificationService) SetFCMProvider(p PushProvider)    { s.fcm = p }
+func (s *NotificationService) SetAPNSProvider(p PushProvider)   { s.apns = p }
+func (s *NotificationService) SetEmailProvider(p EmailProvider) { s.email = p }
+func (s *NotificationService) SetSMSProvider(p SMSProvider)     { s.sms = p }
+
+// Send notification to user
+func (s *NotificationService) Send(ctx context.Context, n *Notification) error {
+	user, err := s.repo.Get(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+
+	prefs := user.Notifications
+	if prefs == nil {
+		return ErrNotificationDisabled
+	}
+
+	// Check quiet hours
+	if s.isQuietHours(prefs) {
+		return ErrQuietHours
+	}
+
+	now := time.Now()
+	n.CreatedAt = now
+
+	var sendErr error
+
+	switch n.Type {
+	case NotificationTypePush:
+		if prefs.PushEnabled {
+			if prefs.FcmToken != "" && s.fcm != nil {
+				sendErr = s.fcm.Send(ctx, prefs.FcmToken, n)
+			} else if prefs.ApnsToken != "" && s.apns != nil {
+				sendErr = s.apns.Send(ctx, prefs.ApnsToken, n)
+			} else {
+				sendErr = ErrNoToken
+			}
+		} else {
+			sendErr = ErrNotificationDisabled
+		}
+
+	case NotificationTypeEmail:
+		if prefs.EmailEnabled && s.email != nil {
+			email := user.Email
+			if prefs.Email != "" {
+				email = prefs.Email
+			}
+			sendErr = s.email.Send(ctx, email, n.Title, n.Body, n.Body)
+		} else {
+			sendErr = ErrNotificationDisabled
+		}
+
+	case NotificationTypeSMS:
+		if prefs.SmsEnabled && s.sms != nil {
+			phone := ""
+			if prefs.Phone != "" {
+				phone = prefs.Phone
+			}
+			if phone != "" {
+				sendErr = s.sms.Send(ctx, phone, n.Body)
+			} else {
+				sendErr = ErrNoToken
+			}
+		} else {
+			sendErr = ErrNotificationDisabled
+		}
+	}
+
+	if sendErr == nil {
+		n.SentAt = &now
+	}
+
+	// Store notification
+	s.mu.Lock()
+	s.notifications[n.UserID] = append(s.notifications[n.UserID], n)
+	s.mu.Unlock()
+
+	return sendErr
+}
+
+// SendMulti sends to multiple users
+func (s *NotificationService) SendMulti(ctx context.Context, userIDs []string, n *Notification) map[string]error {
+	results := make(map[string]error)
+	for _, uid := range userIDs {
+		notification := *n
+		notification.UserID = uid
+		notification.ID = fmt.Sprintf("%s-%d", uid, time.Now().UnixNano())
+		results[uid] = s.Send(ctx, &notification)
+	}
+	return results
+}
+
+// SendBroadcast sends to all users
+func (s *NotificationService) SendBroadcast(ctx context.Context, n *Notification) (sent int, failed int) {
+	users, err := s.repo.List(ctx, 0)
+	if err != nil {
+		return 0, 0
+	}
+
+	for _, user := range users {
+		notification := *n
+		notification.UserID = user.Id
+		notification.ID = fmt.Sprintf("%s-%d", user.Id, time.Now().UnixNano())
+		if s.Send(ctx, &notification) == nil {
+			sent++
+		} else {
+			failed++
+		}
+	}
+	return sent, failed
+}
+
+// SendTemplate sends using a template
+func (s *NotificationService) SendTemplate(ctx context.Context, userID, templateID string, data map[string]interface{}) error {
+	title, body, err := s.templates.Render(templateID, data)
+	if err != nil {
+		return err
+	}
+
+	return s.Send(ctx, &Notification{
+		ID:     fmt.Sprintf("%s-%d", userID, time.Now().UnixNano()),
+		UserID: userID,
+		Type:   NotificationTypePush,
+		Title:  title,
+		Body:   body,
+	})
+}
+
+// GetUserNotifications returns notifications for a user
+func (s *NotificationService) GetUserNotifications(ctx context.Context, userID string, limit int) []*Notification {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	notifs := s.notifications[userID]
+	if limit > 0 && len(notifs) > limit {
+		return notifs[len(notifs)-limit:]
+	}
+	return notifs
+}
+
+// MarkAsRead marks notification as read
+func (s *NotificationService) MarkAsRead(ctx context.Context, userID, notificationID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, n := range s.notifications[userID] {
+		if n.ID == notificationID {
+			now := time.Now()
+			n.ReadAt = &now
+			return nil
+		}
+	}
+	return errors.New("notification not found")
+}
+
+// GetUnreadCount returns unread notification count
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, n := range s.notifications[userID] {
+		if n.ReadAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// UpdateToken updates device token
+func (s *NotificationService) UpdateFCMToken(ctx context.Context, userID, token string) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Notifications == nil {
+		user.Notifications = &NotificationPrefs{}
+	}
+	user.Notifications.FcmToken = token
+
+	return s.repo.Update(ctx, user)
+}
+
+func (s *NotificationService) UpdateAPNSToken(ctx context.Context, userID, token string) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Notifications == nil {
+		user.Notifications = &NotificationPrefs{}
+	}
+	user.Notifications.ApnsToken = token
+
+	return s.repo.Update(ctx, user)
+}
+
+// UpdatePreferences updates notification preferences
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID string, prefs *NotificationPrefs) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.Notifications = prefs
+	return s.repo.Update(ctx, user)
+}
+
+func (s *NotificationService) isQuietHours(prefs *NotificationPrefs) bool {
+	if prefs.QuietHoursStart == "" || prefs.QuietHoursEnd == "" {
+		return false
+	}
+
+	now := time.Now()
+	hour := now.Hour()
+
+	start, _ := time.Parse("15:04", prefs.QuietHoursStart)
+	end, _ := time.Parse("15:04", prefs.QuietHoursEnd)
+
+	startHour := start.Hour()
+	endHour := end.Hour()
+
+	if startHour < endHour {
+		return hour >= startHour && hour < endHour
+	}
+	// Overnight quiet hours
+	return hour >= startHour || hour < endHour
+}
+
+// Template Engine
+type NotificationTemplate struct {
+	ID    string
+	Title string
+	Body  string
+	Type  NotificationType
+}
+
+type TemplateEngine struct {
+	templates map[string]*NotificationTemplate
+}
+
+func NewTemplateEngine() *TemplateEngine {
+	e := &TemplateEngine{templates: make(map[string]*NotificationTemplate)}
+
+	// Default templates
+	e.Register(&NotificationTemplate{
+		ID:    "welcome",
+		Title: "Welcome to {{.AppName}}!",
+		Body:  "Hi {{.Name}}, thanks for joining us!",
+		Type:  NotificationTypePush,
+	})
+	e.Register(&NotificationTemplate{
+		ID:    "order_confirmed",
+		Title: "Order Confirmed",
+		Body:  "Your order #{{.OrderID}} has been confirmed.",
+		Type:  NotificationTypePush,
+	})
+	e.Register(&NotificationTemplate{
+		ID:    "password_reset",
+		Title: "Password Reset",
+		Body:  "Click here to reset your password: {{.ResetURL}}",
+		Type:  NotificationTypeEmail,
+	})
+
+	return e
+}
+
+func (e *TemplateEngine) Register(t *NotificationTemplate) {
+	e.templates[t.ID] = t
+}
+
+func (e *TemplateEngine) Render(templateID string, data map[string]interface{}) (title, body string, err error) {
+	t, ok := e.templates[templateID]
+	if !ok {
+		return "", "", errors.New("template not found")
+	}
+
+	title = e.interpolate(t.Title, data)
+	body = e.interpolate(t.Body, data)
+	return title, body, nil
+}
+
+func (e *TemplateEngine) interpolate(s string, data map[string]interface{}) string {
+	result := s
+	for k, v := range data {
+		result = strings.ReplaceAll(result, "{{."+k+"}}", fmt.Sprintf("%v", v))
+	}
+	return result
+}
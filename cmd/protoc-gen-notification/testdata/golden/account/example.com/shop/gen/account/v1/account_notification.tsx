@@ -0,0 +1,189 @@
+// notification.tsx
+import React, { createContext, useContext, useState, useEffect, useCallback } from 'react';
+
+interface Notification {
+  id: string;
+  type: 'push' | 'email' | 'sms';
+  title: string;
+  body: string;
+  createdAt: string;
+  readAt?: string;
+}
+
+interface NotificationPrefs {
+  fcmToken?: string;
+  apnsToken?: string;
+  pushEnabled: boolean;
+  emailEnabled: boolean;
+  smsEnabled: boolean;
+  quietHoursStart?: string;
+  quietHoursEnd?: string;
+}
+
+interface NotificationContextType {
+  notifications: Notification[];
+  unreadCount: number;
+  prefs: NotificationPrefs | null;
+  markAsRead: (id: string) => Promise<void>;
+  markAllAsRead: () => Promise<void>;
+  updatePrefs: (prefs: Partial<NotificationPrefs>) => Promise<void>;
+  requestPermission: () => Promise<boolean>;
+  refresh: () => Promise<void>;
+}
+
+const NotificationContext = createContext<NotificationContextType | null>(null);
+
+export function NotificationProvider({ children, client }: { children: React.ReactNode; client: any }) {
+  const [notifications, setNotifications] = useState<Notification[]>([]);
+  const [prefs, setPrefs] = useState<NotificationPrefs | null>(null);
+
+  const refresh = useCallback(async () => {
+    try {
+      const [notifs, p] = await Promise.all([
+        client.getNotifications({}),
+        client.getPreferences({})
+      ]);
+      setNotifications(notifs.notifications || []);
+      setPrefs(p.prefs);
+    } catch {}
+  }, [client]);
+
+  useEffect(() => { refresh(); }, [refresh]);
+
+  const unreadCount = notifications.filter(n => !n.readAt).length;
+
+  const markAsRead = useCallback(async (id: string) => {
+    await client.markAsRead({ id });
+    setNotifications(ns => ns.map(n => n.id === id ? { ...n, readAt: new Date().toISOString() } : n));
+  }, [client]);
+
+  const markAllAsRead = useCallback(async () => {
+    await client.markAllAsRead({});
+    setNotifications(ns => ns.map(n => ({ ...n, readAt: new Date().toISOString() })));
+  }, [client]);
+
+  const updatePrefs = useCallback(async (updates: Partial<NotificationPrefs>) => {
+    const newPrefs = { ...prefs, ...updates } as NotificationPrefs;
+    await client.updatePreferences({ prefs: newPrefs });
+    setPrefs(newPrefs);
+  }, [client, prefs]);
+
+  const requestPermission = useCallback(async () => {
+    if (!('Notification' in window)) return false;
+    const permission = await Notification.requestPermission();
+    if (permission === 'granted') {
+      // Get FCM token and update
+      // This requires Firebase setup
+      return true;
+    }
+    return false;
+  }, []);
+
+  return (
+    <NotificationContext.Provider value={{ notifications, unreadCount, prefs, markAsRead, markAllAsRead, updatePrefs, requestPermission, refresh }}>
+      {children}
+    </NotificationContext.Provider>
+  );
+}
+
+export function useNotifications() {
+  const ctx = useContext(NotificationContext);
+  if (!ctx) throw new Error('useNotifications requires NotificationProvider');
+  return ctx;
+}
+
+// Components
+export function NotificationBell() {
+  const { unreadCount } = useNotifications();
+  const [open, setOpen] = useState(false);
+
+  return (
+    <div className="relative">
+      <button onClick={() => setOpen(!open)} className="p-2 rounded-full hover:bg-gray-100">
+        🔔
+        {unreadCount > 0 && (
+          <span className="absolute -top-1 -right-1 bg-red-500 text-white text-xs w-5 h-5 rounded-full flex items-center justify-center">
+            {unreadCount > 9 ? '9+' : unreadCount}
+          </span>
+        )}
+      </button>
+      {open && <NotificationDropdown onClose={() => setOpen(false)} />}
+    </div>
+  );
+}
+
+function NotificationDropdown({ onClose }: { onClose: () => void }) {
+  const { notifications, markAsRead, markAllAsRead } = useNotifications();
+
+  return (
+    <div className="absolute right-0 mt-2 w-80 bg-white rounded-lg shadow-lg border z-50">
+      <div className="p-3 border-b flex justify-between items-center">
+        <span className="font-medium">Notifications</span>
+        <button onClick={markAllAsRead} className="text-sm text-blue-600">Mark all read</button>
+      </div>
+      <div className="max-h-96 overflow-y-auto">
+        {notifications.length === 0 ? (
+          <div className="p-4 text-center text-gray-500">No notifications</div>
+        ) : (
+          notifications.map(n => (
+            <div key={n.id} onClick={() => markAsRead(n.id)}
+              className={"p-3 border-b cursor-pointer hover:bg-gray-50 " + (!n.readAt ? "bg-blue-50" : "")}>
+              <div className="font-medium">{n.title}</div>
+              <div className="text-sm text-gray-600">{n.body}</div>
+              <div className="text-xs text-gray-400 mt-1">{new Date(n.createdAt).toLocaleString()}</div>
+            </div>
+          ))
+        )}
+      </div>
+    </div>
+  );
+}
+
+export function NotificationPreferences() {
+  const { prefs, updatePrefs, requestPermission } = useNotifications();
+  
+  if (!prefs) return <div className="animate-pulse h-48 bg-gray-100 rounded" />;
+
+  return (
+    <div className="space-y-4">
+      <h3 className="font-medium text-lg">Notification Preferences</h3>
+      
+      <div className="space-y-3">
+        <label className="flex items-center justify-between">
+          <span>Push Notifications</span>
+          <input type="checkbox" checked={prefs.pushEnabled} onChange={e => {
+            if (e.target.checked) requestPermission();
+            updatePrefs({ pushEnabled: e.target.checked });
+          }} className="toggle" />
+        </label>
+        
+        <label className="flex items-center justify-between">
+          <span>Email Notifications</span>
+          <input type="checkbox" checked={prefs.emailEnabled} onChange={e => updatePrefs({ emailEnabled: e.target.checked })} className="toggle" />
+        </label>
+        
+        <label className="flex items-center justify-between">
+          <span>SMS Notifications</span>
+          <input type="checkbox" checked={prefs.smsEnabled} onChange={e => updatePrefs({ smsEnabled: e.target.checked })} className="toggle" />
+        </label>
+      </div>
+      
+      <div className="pt-4 border-t">
+        <h4 className="font-medium mb-2">Quiet Hours</h4>
+        <div className="flex gap-4">
+          <label className="flex-1">
+            <span className="text-sm text-gray-600">Start</span>
+            <input type="time" value={prefs.quietHoursStart || ''} onChange={e => updatePrefs({ quietHoursStart: e.target.value })}
+              className="w-full px-3 py-2 border rounded" />
+          </label>
+          <label className="flex-1">
+            <span className="text-sm text-gray-600">End</span>
+            <input type="time" value={prefs.quietHoursEnd || ''} onChange={e => updatePrefs({ quietHoursEnd: e.target.value })}
+              className="w-full px-3 py-2 border rounded" />
+          </label>
+        </div>
+      </div>
+    </div>
+  );
+}
+
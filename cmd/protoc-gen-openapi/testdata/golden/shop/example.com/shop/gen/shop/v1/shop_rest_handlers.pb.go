@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-openapi. DO NOT EDIT.
+package shopv1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type RESTHandler struct {
+	UserRepo              UserRepository
+	StoreRepo             StoreRepository
+	CreateUserRequestRepo CreateUserRequestRepository
+	GetUserRequestRepo    GetUserRequestRepository
+	UpdateUserRequestRepo UpdateUserRequestRepository
+	DeleteUserRequestRepo DeleteUserRequestRepository
+	ListUsersRequestRepo  ListUsersRequestRepository
+	ListUsersResponseRepo ListUsersResponseRepository
+}
+
+func (h *RESTHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/users", h.handleUsers)
+	mux.HandleFunc("/users/", h.handleUser)
+	mux.HandleFunc("/stores", h.handleStores)
+	mux.HandleFunc("/stores/", h.handleStore)
+	mux.HandleFunc("/createuserrequests", h.handleCreateUserRequests)
+	mux.HandleFunc("/createuserrequests/", h.handleCreateUserRequest)
+	mux.HandleFunc("/getuserrequests", h.handleGetUserRequests)
+	mux.HandleFunc("/getuserrequests/", h.handleGetUserRequest)
+	mux.HandleFunc("/updateuserrequests", h.handleUpdateUserRequests)
+	mux.HandleFunc("/updateuserrequests/", h.handleUpdateUserRequest)
+	mux.HandleFunc("/deleteuserrequests", h.handleDeleteUserRequests)
+	mux.HandleFunc("/deleteuserrequests/", h.handleDeleteUserRequest)
+	mux.HandleFunc("/listusersrequests", h.handleListUsersRequests)
+	mux.HandleFunc("/listusersrequests/", h.handleListUsersRequest)
+	mux.HandleFunc("/listusersresponses", h.handleListUsersResponses)
+	mux.HandleFunc("/listusersresponses/", h.handleListUsersResponse)
+}
+
+// User collection handler (GET list, POST create)
+func (h *RESTHandler) handleUsers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.UserRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input User
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.UserRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// User single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleUser(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/users/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.UserRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input User
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.UserRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.UserRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// Store collection handler (GET list, POST create)
+func (h *RESTHandler) handleStores(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.StoreRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input Store
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.StoreRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// Store single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleStore(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/stores/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.StoreRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input Store
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.StoreRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.StoreRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// CreateUserRequest collection handler (GET list, POST create)
+func (h *RESTHandler) handleCreateUserRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.CreateUserRequestRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input CreateUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.CreateUserRequestRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// CreateUserRequest single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleCreateUserRequest(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/createuserrequests/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.CreateUserRequestRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input CreateUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.CreateUserRequestRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.CreateUserRequestRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// GetUserRequest collection handler (GET list, POST create)
+func (h *RESTHandler) handleGetUserRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.GetUserRequestRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input GetUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.GetUserRequestRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// GetUserRequest single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleGetUserRequest(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/getuserrequests/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.GetUserRequestRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input GetUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.GetUserRequestRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.GetUserRequestRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// UpdateUserRequest collection handler (GET list, POST create)
+func (h *RESTHandler) handleUpdateUserRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.UpdateUserRequestRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input UpdateUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.UpdateUserRequestRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// UpdateUserRequest single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleUpdateUserRequest(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/updateuserrequests/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.UpdateUserRequestRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input UpdateUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.UpdateUserRequestRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.UpdateUserRequestRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// DeleteUserRequest collection handler (GET list, POST create)
+func (h *RESTHandler) handleDeleteUserRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.DeleteUserRequestRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input DeleteUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.DeleteUserRequestRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// DeleteUserRequest single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleDeleteUserRequest(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/deleteuserrequests/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.DeleteUserRequestRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input DeleteUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.DeleteUserRequestRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.DeleteUserRequestRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// ListUsersRequest collection handler (GET list, POST create)
+func (h *RESTHandler) handleListUsersRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.ListUsersRequestRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input ListUsersRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.ListUsersRequestRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// ListUsersRequest single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleListUsersRequest(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/listusersrequests/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.ListUsersRequestRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input ListUsersRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.ListUsersRequestRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.ListUsersRequestRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// ListUsersResponse collection handler (GET list, POST create)
+func (h *RESTHandler) handleListUsersResponses(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		items, err := h.ListUsersResponseRepo.List(r.Context(), 0)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+
+		// Pagination
+		limit := 100
+		offset := 0
+		if l := r.URL.Query().Get("limit"); l != "" {
+			limit, _ = strconv.Atoi(l)
+		}
+		if o := r.URL.Query().Get("offset"); o != "" {
+			offset, _ = strconv.Atoi(o)
+		}
+
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > len(items) {
+			offset = len(items)
+		}
+
+		writeJSON(w, 200, items[offset:end])
+
+	case http.MethodPost:
+		var input ListUsersResponse
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+
+		id, err := h.ListUsersResponseRepo.Create(r.Context(), &input)
+		if err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		input.Id = id
+
+		writeJSON(w, 201, input)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+// ListUsersResponse single resource handler (GET, PUT, PATCH, DELETE)
+func (h *RESTHandler) handleListUsersResponse(w http.ResponseWriter, r *http.Request) {
+	id := getIDFromPath(r.URL.Path, "/listusersresponses/")
+	if id == "" {
+		writeError(w, 400, "missing id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := h.ListUsersResponseRepo.Get(r.Context(), id)
+		if err != nil {
+			writeError(w, 404, "not found")
+			return
+		}
+		writeJSON(w, 200, item)
+
+	case http.MethodPut, http.MethodPatch:
+		var input ListUsersResponse
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, 400, "invalid JSON")
+			return
+		}
+		input.Id = id
+
+		if err := h.ListUsersResponseRepo.Update(r.Context(), &input); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		writeJSON(w, 200, input)
+
+	case http.MethodDelete:
+		if err := h.ListUsersResponseRepo.Delete(r.Context(), id); err != nil {
+			writeError(w, 500, err.Error())
+			return
+		}
+		w.WriteHeader(204)
+
+	default:
+		writeError(w, 405, "method not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func getIDFromPath(path, prefix string) string {
+	return strings.TrimPrefix(path, prefix)
+}
@@ -0,0 +1,52 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { createBrowserRouter, RouterProvider, Outlet, Link } from "react-router-dom";
+import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
+
+// Import pages
+import { StoreListPage } from "./pages/StoreListPage";
+import { StoreDetailPage } from "./pages/StoreDetailPage";
+import { StoreCreatePage } from "./pages/StoreCreatePage";
+import { StoreEditPage } from "./pages/StoreEditPage";
+
+const queryClient = new QueryClient();
+
+function Layout() {
+  return (
+    <div className="min-h-screen bg-gray-100">
+      <nav className="bg-white shadow">
+        <div className="max-w-7xl mx-auto px-4 py-3">
+          <div className="flex space-x-8">
+            <Link to="/stores" className="text-gray-700 hover:text-gray-900">Stores</Link>
+          </div>
+        </div>
+      </nav>
+      <main className="max-w-7xl mx-auto">
+        <Outlet />
+      </main>
+    </div>
+  );
+}
+
+const router = createBrowserRouter([
+  {
+    path: "/",
+    element: <Layout />,
+    children: [
+      { path: "stores", element: <StoreListPage /> },
+      { path: "stores/new", element: <StoreCreatePage /> },
+      { path: "stores/:id", element: <StoreDetailPage /> },
+      { path: "stores/:id/edit", element: <StoreEditPage /> },
+    ],
+  },
+]);
+
+export function App() {
+  return (
+    <QueryClientProvider client={queryClient}>
+      <RouterProvider router={router} />
+    </QueryClientProvider>
+  );
+}
+
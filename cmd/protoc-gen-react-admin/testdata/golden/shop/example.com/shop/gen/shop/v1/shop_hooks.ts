@@ -0,0 +1,62 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { createClient } from "@connectrpc/connect";
+import { createConnectTransport } from "@connectrpc/connect-web";
+import { StoreService } from "./gen/shopv1/service_connect";
+import type { Store } from "./gen/shopv1/models_pb";
+
+const transport = createConnectTransport({
+  baseUrl: import.meta.env.VITE_API_URL || "http://localhost:8080",
+});
+
+// =============================================================================
+// Store Hooks
+// =============================================================================
+
+const storeClient = createClient(StoreService, transport);
+
+export function useStores(pageSize = 20) {
+  return useQuery({
+    queryKey: ["stores"],
+    queryFn: () => storeClient.listStores({ pageSize }),
+  });
+}
+
+export function useStore(id: string | undefined) {
+  return useQuery({
+    queryKey: ["store", id],
+    queryFn: () => storeClient.getStore({ id: id! }),
+    enabled: !!id,
+  });
+}
+
+export function useCreateStore() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (store: Partial<Store>) => storeClient.createStore({ store }),
+    onSuccess: () => queryClient.invalidateQueries({ queryKey: ["stores"] }),
+  });
+}
+
+export function useUpdateStore() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (store: Store) => storeClient.updateStore({ store }),
+    onSuccess: (_, vars) => {
+      queryClient.invalidateQueries({ queryKey: ["stores"] });
+      queryClient.invalidateQueries({ queryKey: ["store", vars.id] });
+    },
+  });
+}
+
+export function useDeleteStore() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) => storeClient.deleteStore({ id }),
+    onSuccess: () => queryClient.invalidateQueries({ queryKey: ["stores"] }),
+  });
+}
+
+
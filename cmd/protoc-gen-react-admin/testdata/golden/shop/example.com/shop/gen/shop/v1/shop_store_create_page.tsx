@@ -0,0 +1,35 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { useNavigate } from "react-router-dom";
+import { useCreateStore } from "../hooks";
+import { StoreForm } from "../components/StoreForm";
+
+export function StoreCreatePage() {
+  const navigate = useNavigate();
+  const mutation = useCreateStore();
+
+  const handleSubmit = async (store: any) => {
+    const result = await mutation.mutateAsync(store);
+    navigate(`/${result.store?.id}`);
+  };
+
+  return (
+    <div className="p-8 max-w-2xl">
+      <h1 className="text-2xl font-bold mb-6">New Store</h1>
+
+      <StoreForm
+        onSubmit={handleSubmit}
+        onCancel={() => navigate("/")}
+        isLoading={mutation.isPending}
+      />
+
+      {mutation.error && (
+        <div className="mt-4 p-4 bg-red-50 text-red-600 rounded">
+          {mutation.error.message}
+        </div>
+      )}
+    </div>
+  );
+}
+
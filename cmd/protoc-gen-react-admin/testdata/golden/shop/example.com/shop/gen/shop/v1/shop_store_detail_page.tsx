@@ -0,0 +1,71 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { useParams, Link, useNavigate } from "react-router-dom";
+import { useStore, useDeleteStore } from "../hooks";
+
+export function StoreDetailPage() {
+  const { id } = useParams<{ id: string }>();
+  const navigate = useNavigate();
+  const { data, isLoading, error } = useStore(id);
+  const deleteMutation = useDeleteStore();
+
+  const handleDelete = async () => {
+    if (!id || !confirm("Are you sure?")) return;
+    await deleteMutation.mutateAsync(id);
+    navigate("/");
+  };
+
+  if (isLoading) return <div className="p-8 text-center">Loading...</div>;
+  if (error) return <div className="p-8 text-center text-red-600">Error: {error.message}</div>;
+  if (!data?.store) return <div className="p-8 text-center">Not found</div>;
+
+  const store = data.store;
+
+  return (
+    <div className="p-8 max-w-2xl">
+      <div className="flex justify-between items-center mb-6">
+        <h1 className="text-2xl font-bold">Store Details</h1>
+        <div className="space-x-2">
+          <Link
+            to={`/${id}/edit`}
+            className="px-4 py-2 bg-green-600 text-white rounded hover:bg-green-700"
+          >
+            Edit
+          </Link>
+          <button
+            onClick={handleDelete}
+            disabled={deleteMutation.isPending}
+            className="px-4 py-2 bg-red-600 text-white rounded hover:bg-red-700 disabled:opacity-50"
+          >
+            Delete
+          </button>
+        </div>
+      </div>
+
+      <dl className="divide-y divide-gray-200">
+        <div className="py-3 grid grid-cols-3 gap-4">
+          <dt className="text-sm font-medium text-gray-500">Id</dt>
+          <dd className="text-sm text-gray-900 col-span-2">{store.id ?? "-"}</dd>
+        </div>
+        <div className="py-3 grid grid-cols-3 gap-4">
+          <dt className="text-sm font-medium text-gray-500">Name</dt>
+          <dd className="text-sm text-gray-900 col-span-2">{store.name ?? "-"}</dd>
+        </div>
+        <div className="py-3 grid grid-cols-3 gap-4">
+          <dt className="text-sm font-medium text-gray-500">Latitude</dt>
+          <dd className="text-sm text-gray-900 col-span-2">{store.latitude ?? "-"}</dd>
+        </div>
+        <div className="py-3 grid grid-cols-3 gap-4">
+          <dt className="text-sm font-medium text-gray-500">Longitude</dt>
+          <dd className="text-sm text-gray-900 col-span-2">{store.longitude ?? "-"}</dd>
+        </div>
+      </dl>
+
+      <div className="mt-6">
+        <Link to="/" className="text-blue-600 hover:text-blue-800">← Back to list</Link>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,40 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { useParams, useNavigate } from "react-router-dom";
+import { useStore, useUpdateStore } from "../hooks";
+import { StoreForm } from "../components/StoreForm";
+
+export function StoreEditPage() {
+  const { id } = useParams<{ id: string }>();
+  const navigate = useNavigate();
+  const { data, isLoading } = useStore(id);
+  const mutation = useUpdateStore();
+
+  const handleSubmit = async (store: any) => {
+    await mutation.mutateAsync({ ...store, id });
+    navigate(`/${id}`);
+  };
+
+  if (isLoading) return <div className="p-8 text-center">Loading...</div>;
+
+  return (
+    <div className="p-8 max-w-2xl">
+      <h1 className="text-2xl font-bold mb-6">Edit Store</h1>
+
+      <StoreForm
+        initial={data?.store}
+        onSubmit={handleSubmit}
+        onCancel={() => navigate(`/${id}`)}
+        isLoading={mutation.isPending}
+      />
+
+      {mutation.error && (
+        <div className="mt-4 p-4 bg-red-50 text-red-600 rounded">
+          {mutation.error.message}
+        </div>
+      )}
+    </div>
+  );
+}
+
@@ -0,0 +1,81 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { useState } from "react";
+import type { Store } from "../types";
+
+interface StoreFormProps {
+  initial?: Partial<Store>;
+  onSubmit: (data: Partial<Store>) => void;
+  onCancel?: () => void;
+  isLoading?: boolean;
+}
+
+export function StoreForm({ initial, onSubmit, onCancel, isLoading }: StoreFormProps) {
+  const [form, setForm] = useState<Partial<Store>>(initial ?? {});
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault();
+    onSubmit(form);
+  };
+
+  const update = <K extends keyof typeof form>(key: K, value: typeof form[K]) => {
+    setForm((prev) => ({ ...prev, [key]: value }));
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+
+      <div>
+        <label className="block text-sm font-medium text-gray-700">Name</label>
+        <input
+          type="text"
+          value={form.name ?? ""}
+          onChange={(e) => update("name", e.target.value)}
+          required
+          className="mt-1 block w-full rounded border-gray-300 shadow-sm focus:border-blue-500 focus:ring-blue-500"
+        />
+      </div>
+
+      <div>
+        <label className="block text-sm font-medium text-gray-700">Latitude</label>
+        <input
+          type="number"
+          value={form.latitude ?? ""}
+          onChange={(e) => update("latitude", e.target.valueAsNumber)}
+          className="mt-1 block w-full rounded border-gray-300 shadow-sm focus:border-blue-500 focus:ring-blue-500"
+        />
+      </div>
+
+      <div>
+        <label className="block text-sm font-medium text-gray-700">Longitude</label>
+        <input
+          type="number"
+          value={form.longitude ?? ""}
+          onChange={(e) => update("longitude", e.target.valueAsNumber)}
+          className="mt-1 block w-full rounded border-gray-300 shadow-sm focus:border-blue-500 focus:ring-blue-500"
+        />
+      </div>
+
+      <div className="flex gap-2 pt-4">
+        <button
+          type="submit"
+          disabled={isLoading}
+          className="px-4 py-2 bg-blue-600 text-white rounded hover:bg-blue-700 disabled:opacity-50"
+        >
+          {isLoading ? "Saving..." : "Save"}
+        </button>
+        {onCancel && (
+          <button
+            type="button"
+            onClick={onCancel}
+            className="px-4 py-2 bg-gray-200 rounded hover:bg-gray-300"
+          >
+            Cancel
+          </button>
+        )}
+      </div>
+    </form>
+  );
+}
+
@@ -0,0 +1,40 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { Link } from "react-router-dom";
+import { useStores, useDeleteStore } from "../hooks";
+import { StoreTable } from "../components/StoreTable";
+
+export function StoreListPage() {
+  const { data, isLoading, error } = useStores();
+  const deleteMutation = useDeleteStore();
+
+  if (isLoading) {
+    return <div className="p-8 text-center">Loading...</div>;
+  }
+
+  if (error) {
+    return <div className="p-8 text-center text-red-600">Error: {error.message}</div>;
+  }
+
+  return (
+    <div className="p-8">
+      <div className="flex justify-between items-center mb-6">
+        <h1 className="text-2xl font-bold">Stores</h1>
+        <Link
+          to="/new"
+          className="px-4 py-2 bg-blue-600 text-white rounded hover:bg-blue-700"
+        >
+          New Store
+        </Link>
+      </div>
+
+      <StoreTable
+        data={data?.stores ?? []}
+        onDelete={(id) => deleteMutation.mutate(id)}
+        isDeleting={deleteMutation.isPending}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,73 @@
+// Code generated by protoc-gen-react-admin. DO NOT EDIT.
+// Generated using Category Theory: Monoid + Functor + Fold
+
+import { Link } from "react-router-dom";
+import type { Store } from "../types";
+
+interface StoreTableProps {
+  data: Store[];
+  onDelete?: (id: string) => void;
+  isDeleting?: boolean;
+}
+
+export function StoreTable({ data, onDelete, isDeleting }: StoreTableProps) {
+  if (data.length === 0) {
+    return (
+      <div className="text-center py-12 text-gray-500">
+        No stores found
+      </div>
+    );
+  }
+
+  return (
+    <div className="overflow-x-auto">
+      <table className="min-w-full divide-y divide-gray-200">
+        <thead className="bg-gray-50">
+          <tr>
+            <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Id</th>
+            <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Name</th>
+            <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Latitude</th>
+            <th className="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Longitude</th>
+            <th className="px-6 py-3 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Actions</th>
+          </tr>
+        </thead>
+        <tbody className="bg-white divide-y divide-gray-200">
+          {data.map((item) => (
+            <tr key={item.id} className="hover:bg-gray-50">
+              <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
+                {item.id ?? "-"}
+              </td>
+              <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
+                {item.name ?? "-"}
+              </td>
+              <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
+                {item.latitude ?? "-"}
+              </td>
+              <td className="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
+                {item.longitude ?? "-"}
+              </td>
+              <td className="px-6 py-4 text-right text-sm font-medium space-x-2">
+                <Link to={`/${item.id}`} className="text-blue-600 hover:text-blue-900">
+                  View
+                </Link>
+                <Link to={`/${item.id}/edit`} className="text-green-600 hover:text-green-900">
+                  Edit
+                </Link>
+                {onDelete && (
+                  <button
+                    onClick={() => onDelete(item.id)}
+                    disabled={isDeleting}
+                    className="text-red-600 hover:text-red-900 disabled:opacity-50"
+                  >
+                    Delete
+                  </button>
+                )}
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </table>
+    </div>
+  );
+}
+
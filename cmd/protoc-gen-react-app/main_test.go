@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/reactapp"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, reactapp.Plugin,
+		plugintest.Case{Name: "account", Files: []string{"account/v1/account.proto"}},
+		plugintest.Case{Name: "base_path", Files: []string{"account/v1/account.proto"}, Param: "base_path=web"},
+		plugintest.Case{Name: "cross_file", Files: []string{"crm/v1/contact.proto"}},
+	)
+}
@@ -0,0 +1,78 @@
+// Generated by protoc-gen-react-app
+import { BrowserRouter, Routes, Route, Navigate } from 'react-router-dom';
+import { Suspense, lazy } from 'react';
+import { AuthProvider, useAuth } from './context/AuthContext';
+import { ToastProvider } from './components/ui/Toast';
+import { Loading } from './components/ui/Loading';
+import { Layout } from './components/Layout';
+
+// Lazy load pages
+const DashboardPage = lazy(() => import('./pages/DashboardPage'));
+const LoginPage = lazy(() => import('./pages/LoginPage'));
+const SignupPage = lazy(() => import('./pages/SignupPage'));
+const UserListPage = lazy(() => import('./pages/UserListPage'));
+const UserDetailPage = lazy(() => import('./pages/UserDetailPage'));
+const UserCreatePage = lazy(() => import('./pages/UserCreatePage'));
+const UserEditPage = lazy(() => import('./pages/UserEditPage'));
+const GetUserRequestListPage = lazy(() => import('./pages/GetUserRequestListPage'));
+const GetUserRequestDetailPage = lazy(() => import('./pages/GetUserRequestDetailPage'));
+const GetUserRequestCreatePage = lazy(() => import('./pages/GetUserRequestCreatePage'));
+const GetUserRequestEditPage = lazy(() => import('./pages/GetUserRequestEditPage'));
+const ForgotPasswordPage = lazy(() => import('./pages/ForgotPasswordPage'));
+const ResetPasswordPage = lazy(() => import('./pages/ResetPasswordPage'));
+const VerifyEmailPage = lazy(() => import('./pages/VerifyEmailPage'));
+const OAuthCallbackPage = lazy(() => import('./pages/OAuthCallbackPage'));
+const LinkedAccountsPage = lazy(() => import('./pages/LinkedAccountsPage'));
+const PricingPage = lazy(() => import('./pages/PricingPage'));
+const BillingPage = lazy(() => import('./pages/BillingPage'));
+const CheckoutSuccessPage = lazy(() => import('./pages/CheckoutSuccessPage'));
+const NotificationsPage = lazy(() => import('./pages/NotificationsPage'));
+const NotificationSettingsPage = lazy(() => import('./pages/NotificationSettingsPage'));
+const SettingsPage = lazy(() => import('./pages/SettingsPage'));
+const ProfilePage = lazy(() => import('./pages/ProfilePage'));
+
+function ProtectedRoute({ children }: { children: React.ReactNode }) {
+  const { isAuthenticated, isLoading } = useAuth();
+  if (isLoading) return <Loading fullScreen />;
+  if (!isAuthenticated) return <Navigate to="/login" />;
+  return <>{children}</>;
+}
+
+export default function App() {
+  return (
+    <AuthProvider>
+      <ToastProvider>
+        <BrowserRouter>
+          <Routes>
+            <Route path="/login" element={<Suspense fallback={<Loading fullScreen />}><LoginPage /></Suspense>} />
+            <Route path="/signup" element={<Suspense fallback={<Loading fullScreen />}><SignupPage /></Suspense>} />
+        <Route path="/forgot-password" element={<Suspense fallback={<Loading fullScreen />}><ForgotPasswordPage /></Suspense>} />
+        <Route path="/reset-password" element={<Suspense fallback={<Loading fullScreen />}><ResetPasswordPage /></Suspense>} />
+        <Route path="/verify-email" element={<Suspense fallback={<Loading fullScreen />}><VerifyEmailPage /></Suspense>} />
+        <Route path="/auth/callback/:provider" element={<Suspense fallback={<Loading fullScreen />}><OAuthCallbackPage /></Suspense>} />
+        <Route path="/settings/linked-accounts" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><LinkedAccountsPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/pricing" element={<Suspense fallback={<Loading fullScreen />}><PricingPage /></Suspense>} />
+        <Route path="/settings/billing" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><BillingPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/checkout/success" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><CheckoutSuccessPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/notifications" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><NotificationsPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/settings/notifications" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><NotificationSettingsPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/settings" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><SettingsPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/profile" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ProfilePage /></Suspense></Layout></ProtectedRoute>} />
+            <Route path="/" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><DashboardPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/user" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><UserListPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/user/new" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><UserCreatePage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/user/:id" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><UserDetailPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/user/:id/edit" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><UserEditPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/getuserrequest" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><GetUserRequestListPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/getuserrequest/new" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><GetUserRequestCreatePage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/getuserrequest/:id" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><GetUserRequestDetailPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/getuserrequest/:id/edit" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><GetUserRequestEditPage /></Suspense></Layout></ProtectedRoute>} />
+
+            <Route path="*" element={<Navigate to="/" />} />
+          </Routes>
+        </BrowserRouter>
+      </ToastProvider>
+    </AuthProvider>
+  );
+}
+
@@ -0,0 +1,117 @@
+// Generated by protoc-gen-react-app
+const API_BASE = import.meta.env.VITE_API_URL || '/api';
+
+async function request<T>(url: string, options?: RequestInit): Promise<T> {
+  const token = localStorage.getItem('token');
+  const res = await fetch(API_BASE + url, {
+    ...options,
+    headers: {
+      'Content-Type': 'application/json',
+      ...(token ? { Authorization: 'Bearer ' + token } : {}),
+      ...options?.headers,
+    },
+  });
+  if (!res.ok) {
+    const error = await res.json().catch(() => ({ message: res.statusText }));
+    throw Object.assign(new Error(error.message || 'Request failed'), { code: error.code, status: res.status });
+  }
+  return res.json();
+}
+
+// Auth API
+export const authApi = {
+  login: (email: string, password: string) => 
+    request<{ token: string; user: any }>('/auth/login', { method: 'POST', body: JSON.stringify({ email, password }) }),
+  signup: (name: string, email: string, password: string) => 
+    request<{ token: string; user: any }>('/auth/signup', { method: 'POST', body: JSON.stringify({ name, email, password }) }),
+  logout: () => request<void>('/auth/logout', { method: 'POST' }),
+  me: () => request<any>('/auth/me'),
+};
+
+// User API
+export const userApi = {
+  list: (params?: { page?: number; pageSize?: number; search?: string }) => 
+    request<User[]>('/users?' + new URLSearchParams(params as any).toString()),
+  get: (id: string) => request<User>('/users/' + id),
+  create: (data: UserInput) => request<User>('/users', { method: 'POST', body: JSON.stringify(data) }),
+  update: (id: string, data: UserInput) => request<User>('/users/' + id, { method: 'PUT', body: JSON.stringify(data) }),
+  delete: (id: string) => request<void>('/users/' + id, { method: 'DELETE' }),
+};
+
+// GetUserRequest API
+export const getuserrequestApi = {
+  list: (params?: { page?: number; pageSize?: number; search?: string }) => 
+    request<GetUserRequest[]>('/getuserrequests?' + new URLSearchParams(params as any).toString()),
+  get: (id: string) => request<GetUserRequest>('/getuserrequests/' + id),
+  create: (data: GetUserRequestInput) => request<GetUserRequest>('/getuserrequests', { method: 'POST', body: JSON.stringify(data) }),
+  update: (id: string, data: GetUserRequestInput) => request<GetUserRequest>('/getuserrequests/' + id, { method: 'PUT', body: JSON.stringify(data) }),
+  delete: (id: string) => request<void>('/getuserrequests/' + id, { method: 'DELETE' }),
+};
+
+// Auth Email API
+export const authEmailApi = {
+  forgotPassword: (email: string) => 
+    request<void>('/auth/forgot-password', { method: 'POST', body: JSON.stringify({ email }) }),
+  resetPassword: (token: string, password: string) => 
+    request<void>('/auth/reset-password', { method: 'POST', body: JSON.stringify({ token, password }) }),
+  verifyEmail: (token: string) => 
+    request<void>('/auth/verify-email', { method: 'POST', body: JSON.stringify({ token }) }),
+  resendVerification: () => 
+    request<void>('/auth/resend-verification', { method: 'POST' }),
+  refreshToken: (refreshToken: string) => 
+    request<{ token: string; refreshToken: string }>('/auth/refresh', { method: 'POST', body: JSON.stringify({ refreshToken }) }),
+};
+
+// OAuth API
+export const oauthApi = {
+  startAuth: (provider: string) => 
+    request<{ url: string }>('/auth/oauth/' + provider + '/start'),
+  callback: (provider: string, code: string, state: string) => 
+    request<{ token: string; user: any }>('/auth/oauth/' + provider + '/callback', { method: 'POST', body: JSON.stringify({ code, state }) }),
+  getLinkedProviders: () => 
+    request<{ providers: string[] }>('/auth/oauth/linked'),
+  linkProvider: (provider: string) => 
+    request<{ url: string }>('/auth/oauth/' + provider + '/link'),
+  unlinkProvider: (provider: string) => 
+    request<void>('/auth/oauth/' + provider + '/unlink', { method: 'DELETE' }),
+};
+
+// Stripe API
+export const stripeApi = {
+  createCheckoutSession: (priceId: string, mode?: string) => 
+    request<{ sessionId: string; url: string }>('/stripe/checkout', { method: 'POST', body: JSON.stringify({ priceId, mode }) }),
+  createPortalSession: () => 
+    request<{ url: string }>('/stripe/portal', { method: 'POST' }),
+  getSubscription: () => 
+    request<any>('/stripe/subscription'),
+  cancelSubscription: () => 
+    request<void>('/stripe/subscription/cancel', { method: 'POST' }),
+  reactivateSubscription: () => 
+    request<void>('/stripe/subscription/reactivate', { method: 'POST' }),
+  getInvoices: () => 
+    request<any[]>('/stripe/invoices'),
+  getPaymentMethods: () => 
+    request<any[]>('/stripe/payment-methods'),
+};
+
+// Notification API
+export const notificationApi = {
+  list: (params?: { limit?: number; unreadOnly?: boolean }) => 
+    request<any[]>('/notifications?' + new URLSearchParams(params as any).toString()),
+  markAsRead: (id: string) => 
+    request<void>('/notifications/' + id + '/read', { method: 'POST' }),
+  markAllAsRead: () => 
+    request<void>('/notifications/read-all', { method: 'POST' }),
+  getUnreadCount: () => 
+    request<{ count: number }>('/notifications/unread-count'),
+  getPreferences: () => 
+    request<any>('/notifications/preferences'),
+  updatePreferences: (prefs: any) => 
+    request<void>('/notifications/preferences', { method: 'PUT', body: JSON.stringify(prefs) }),
+  updateFcmToken: (token: string) => 
+    request<void>('/notifications/fcm-token', { method: 'PUT', body: JSON.stringify({ token }) }),
+  testNotification: () => 
+    request<void>('/notifications/test', { method: 'POST' }),
+};
+
+
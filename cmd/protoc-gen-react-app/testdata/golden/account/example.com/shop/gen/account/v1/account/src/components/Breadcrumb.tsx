@@ -0,0 +1,34 @@
+// Generated by protoc-gen-react-app
+import { Link, useLocation } from 'react-router-dom';
+
+export function Breadcrumb() {
+  const location = useLocation();
+  const paths = location.pathname.split('/').filter(Boolean);
+
+  if (paths.length === 0) {
+    return <h1 className="text-lg font-semibold">Dashboard</h1>;
+  }
+
+  return (
+    <nav className="flex items-center gap-2 text-sm">
+      <Link to="/" className="text-gray-500 hover:text-gray-700">Home</Link>
+      {paths.map((path, i) => {
+        const href = '/' + paths.slice(0, i + 1).join('/');
+        const isLast = i === paths.length - 1;
+        const label = path.charAt(0).toUpperCase() + path.slice(1);
+
+        return (
+          <span key={path} className="flex items-center gap-2">
+            <span className="text-gray-300">/</span>
+            {isLast ? (
+              <span className="text-gray-900 font-medium">{label}</span>
+            ) : (
+              <Link to={href} className="text-gray-500 hover:text-gray-700">{label}</Link>
+            )}
+          </span>
+        );
+      })}
+    </nav>
+  );
+}
+
@@ -0,0 +1,33 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Input, Select, Checkbox } from './ui/Input';
+import { Button } from './ui/Button';
+import type { GetUserRequest, GetUserRequestInput } from '../types';
+
+interface GetUserRequestFormProps {
+  initialData?: GetUserRequest;
+  onSubmit: (data: GetUserRequestInput) => Promise<void>;
+  loading?: boolean;
+}
+
+export function GetUserRequestForm({ initialData, onSubmit, loading }: GetUserRequestFormProps) {
+  const [form, setForm] = useState<GetUserRequestInput>({
+  });
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    await onSubmit(form);
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+
+      <div className="flex gap-3 pt-4">
+        <Button type="submit" loading={loading}>
+          {initialData ? 'Save Changes' : 'Create GetUserRequest'}
+        </Button>
+      </div>
+    </form>
+  );
+}
+
@@ -0,0 +1,22 @@
+// Generated by protoc-gen-react-app
+import { Sidebar } from './Sidebar';
+import { Topbar } from './Topbar';
+
+interface LayoutProps {
+  children: React.ReactNode;
+}
+
+export function Layout({ children }: LayoutProps) {
+  return (
+    <div className="min-h-screen flex">
+      <Sidebar />
+      <div className="flex-1 flex flex-col ml-64">
+        <Topbar />
+        <main className="flex-1 p-6 overflow-auto">
+          {children}
+        </main>
+      </div>
+    </div>
+  );
+}
+
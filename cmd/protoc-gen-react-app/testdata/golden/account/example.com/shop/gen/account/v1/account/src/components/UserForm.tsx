@@ -0,0 +1,97 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Input, Select, Checkbox } from './ui/Input';
+import { Button } from './ui/Button';
+import type { User, UserInput } from '../types';
+
+interface UserFormProps {
+  initialData?: User;
+  onSubmit: (data: UserInput) => Promise<void>;
+  loading?: boolean;
+}
+
+export function UserForm({ initialData, onSubmit, loading }: UserFormProps) {
+  const [form, setForm] = useState<UserInput>({
+    email: initialData?.email ?? '',
+    name: initialData?.name ?? '',
+    phone: initialData?.phone ?? '',
+    auth_email: initialData?.auth_email ?? '',
+    auth_oauth: initialData?.auth_oauth ?? '',
+    stripe: initialData?.stripe ?? '',
+    notifications: initialData?.notifications ?? '',
+    created_at: initialData?.created_at ?? '',
+    updated_at: initialData?.updated_at ?? '',
+    etag: initialData?.etag ?? '',
+  });
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    await onSubmit(form);
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <Input
+        label="Email"
+        type="text"
+        value={form.email}
+        onChange={e => setForm(f => ({ ...f, email: e.target.value }))}
+      />
+      <Input
+        label="Name"
+        type="text"
+        value={form.name}
+        onChange={e => setForm(f => ({ ...f, name: e.target.value }))}
+      />
+      <Input
+        label="Phone"
+        type="text"
+        value={form.phone}
+        onChange={e => setForm(f => ({ ...f, phone: e.target.value }))}
+      />
+      <Input
+        label="AuthEmail"
+        type="text"
+        value={form.auth_email}
+        onChange={e => setForm(f => ({ ...f, auth_email: e.target.value }))}
+      />
+      <Input
+        label="AuthOauth"
+        type="text"
+        value={form.auth_oauth}
+        onChange={e => setForm(f => ({ ...f, auth_oauth: e.target.value }))}
+      />
+      <Input
+        label="Stripe"
+        type="text"
+        value={form.stripe}
+        onChange={e => setForm(f => ({ ...f, stripe: e.target.value }))}
+      />
+      <Input
+        label="Notifications"
+        type="text"
+        value={form.notifications}
+        onChange={e => setForm(f => ({ ...f, notifications: e.target.value }))}
+      />
+      <Input
+        label="CreatedAt"
+        type="datetime-local"
+        value={form.created_at}
+        onChange={e => setForm(f => ({ ...f, created_at: e.target.value }))}
+      />
+      <Input
+        label="UpdatedAt"
+        type="datetime-local"
+        value={form.updated_at}
+        onChange={e => setForm(f => ({ ...f, updated_at: e.target.value }))}
+      />
+
+      <div className="flex gap-3 pt-4">
+        <Button type="submit" loading={loading}>
+          {initialData ? 'Save Changes' : 'Create User'}
+        </Button>
+      </div>
+    </form>
+  );
+}
+
@@ -0,0 +1,27 @@
+// Generated by protoc-gen-react-app
+interface LoadingProps {
+  fullScreen?: boolean;
+  size?: 'sm' | 'md' | 'lg';
+}
+
+export function Loading({ fullScreen, size = 'md' }: LoadingProps) {
+  const sizes = { sm: 'w-4 h-4', md: 'w-8 h-8', lg: 'w-12 h-12' };
+
+  const spinner = (
+    <svg className={"animate-spin text-indigo-600 " + sizes[size]} fill="none" viewBox="0 0 24 24">
+      <circle className="opacity-25" cx="12" cy="12" r="10" stroke="currentColor" strokeWidth="4" />
+      <path className="opacity-75" fill="currentColor" d="M4 12a8 8 0 018-8V0C5.373 0 0 5.373 0 12h4z" />
+    </svg>
+  );
+
+  if (fullScreen) {
+    return (
+      <div className="fixed inset-0 bg-white flex items-center justify-center">
+        {spinner}
+      </div>
+    );
+  }
+
+  return <div className="flex items-center justify-center p-8">{spinner}</div>;
+}
+
@@ -0,0 +1,37 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+
+interface SearchInputProps {
+  placeholder?: string;
+  className?: string;
+  onSearch: (query: string) => void;
+  debounce?: number;
+}
+
+export function SearchInput({ placeholder = 'Search...', className = '', onSearch, debounce = 300 }: SearchInputProps) {
+  const [value, setValue] = useState('');
+
+  useEffect(() => {
+    const timer = setTimeout(() => onSearch(value), debounce);
+    return () => clearTimeout(timer);
+  }, [value, debounce, onSearch]);
+
+  return (
+    <div className={"relative " + className}>
+      <span className="absolute left-3 top-1/2 -translate-y-1/2 text-gray-400">🔍</span>
+      <input
+        type="text"
+        value={value}
+        onChange={e => setValue(e.target.value)}
+        placeholder={placeholder}
+        className="input pl-10"
+      />
+      {value && (
+        <button onClick={() => setValue('')} className="absolute right-3 top-1/2 -translate-y-1/2 text-gray-400 hover:text-gray-600">
+          ✕
+        </button>
+      )}
+    </div>
+  );
+}
+
@@ -0,0 +1,69 @@
+// Generated by protoc-gen-react-app
+interface Column<T> {
+  key: keyof T | string;
+  header: string;
+  render?: (item: T) => React.ReactNode;
+  className?: string;
+}
+
+interface TableProps<T> {
+  columns: Column<T>[];
+  data: T[];
+  onRowClick?: (item: T) => void;
+  loading?: boolean;
+  emptyMessage?: string;
+}
+
+export function Table<T extends { id: string }>({ columns, data, onRowClick, loading, emptyMessage = 'No data found' }: TableProps<T>) {
+  if (loading) {
+    return (
+      <div className="card">
+        <div className="animate-pulse p-4 space-y-3">
+          {[1, 2, 3, 4, 5].map(i => (
+            <div key={i} className="h-12 bg-gray-100 rounded" />
+          ))}
+        </div>
+      </div>
+    );
+  }
+
+  if (data.length === 0) {
+    return (
+      <div className="card p-8 text-center text-gray-500">
+        {emptyMessage}
+      </div>
+    );
+  }
+
+  return (
+    <div className="card overflow-hidden">
+      <table className="w-full">
+        <thead className="bg-gray-50 border-b border-gray-200">
+          <tr>
+            {columns.map(col => (
+              <th key={String(col.key)} className={"px-4 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider " + (col.className || '')}>
+                {col.header}
+              </th>
+            ))}
+          </tr>
+        </thead>
+        <tbody className="divide-y divide-gray-200">
+          {data.map(item => (
+            <tr 
+              key={item.id} 
+              onClick={() => onRowClick?.(item)}
+              className={"hover:bg-gray-50 " + (onRowClick ? 'cursor-pointer' : '')}
+            >
+              {columns.map(col => (
+                <td key={String(col.key)} className={"px-4 py-3 text-sm text-gray-900 " + (col.className || '')}>
+                  {col.render ? col.render(item) : String((item as any)[col.key] ?? '-')}
+                </td>
+              ))}
+            </tr>
+          ))}
+        </tbody>
+      </table>
+    </div>
+  );
+}
+
@@ -0,0 +1,195 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect, useCallback } from 'react';
+import { useToast } from './components/ui/Toast';
+
+import { userApi } from './api';
+import type { User, UserInput } from './types';
+
+export function useUserList() {
+  const [items, setItems] = useState<User[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const { showToast } = useToast();
+
+  const fetch = useCallback(async (params?: { search?: string }) => {
+    setLoading(true);
+    try {
+      const data = await userApi.list(params);
+      setItems(data);
+      setError(null);
+    } catch (e: any) {
+      setError(e.message);
+      showToast(e.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  }, [showToast]);
+
+  useEffect(() => { fetch(); }, [fetch]);
+
+  return { items, loading, error, refetch: fetch };
+}
+
+export function useUser(id: string | undefined) {
+  const [item, setItem] = useState<User | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!id) return;
+    setLoading(true);
+    userApi.get(id)
+      .then(setItem)
+      .catch(e => setError(e.message))
+      .finally(() => setLoading(false));
+  }, [id]);
+
+  return { item, loading, error };
+}
+
+export function useUserMutations() {
+  const [loading, setLoading] = useState(false);
+  const { showToast } = useToast();
+
+  const create = async (data: UserInput) => {
+    setLoading(true);
+    try {
+      const result = await userApi.create(data);
+      showToast('User created successfully', 'success');
+      return result;
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const update = async (id: string, data: UserInput) => {
+    setLoading(true);
+    try {
+      const result = await userApi.update(id, data);
+      showToast('User updated successfully', 'success');
+      return result;
+    } catch (e: any) {
+      // aborted: the user changed since it was loaded
+      const conflict = e.code === 'aborted' || e.status === 409;
+      showToast(conflict ? 'User was changed by someone else. Reload it and try again.' : e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const remove = async (id: string) => {
+    setLoading(true);
+    try {
+      await userApi.delete(id);
+      showToast('User deleted successfully', 'success');
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return { create, update, remove, loading };
+}
+
+import { getuserrequestApi } from './api';
+import type { GetUserRequest, GetUserRequestInput } from './types';
+
+export function useGetUserRequestList() {
+  const [items, setItems] = useState<GetUserRequest[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const { showToast } = useToast();
+
+  const fetch = useCallback(async (params?: { search?: string }) => {
+    setLoading(true);
+    try {
+      const data = await getuserrequestApi.list(params);
+      setItems(data);
+      setError(null);
+    } catch (e: any) {
+      setError(e.message);
+      showToast(e.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  }, [showToast]);
+
+  useEffect(() => { fetch(); }, [fetch]);
+
+  return { items, loading, error, refetch: fetch };
+}
+
+export function useGetUserRequest(id: string | undefined) {
+  const [item, setItem] = useState<GetUserRequest | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!id) return;
+    setLoading(true);
+    getuserrequestApi.get(id)
+      .then(setItem)
+      .catch(e => setError(e.message))
+      .finally(() => setLoading(false));
+  }, [id]);
+
+  return { item, loading, error };
+}
+
+export function useGetUserRequestMutations() {
+  const [loading, setLoading] = useState(false);
+  const { showToast } = useToast();
+
+  const create = async (data: GetUserRequestInput) => {
+    setLoading(true);
+    try {
+      const result = await getuserrequestApi.create(data);
+      showToast('GetUserRequest created successfully', 'success');
+      return result;
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const update = async (id: string, data: GetUserRequestInput) => {
+    setLoading(true);
+    try {
+      const result = await getuserrequestApi.update(id, data);
+      showToast('GetUserRequest updated successfully', 'success');
+      return result;
+    } catch (e: any) {
+      // aborted: the getuserrequest changed since it was loaded
+      const conflict = e.code === 'aborted' || e.status === 409;
+      showToast(conflict ? 'GetUserRequest was changed by someone else. Reload it and try again.' : e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const remove = async (id: string) => {
+    setLoading(true);
+    try {
+      await getuserrequestApi.delete(id);
+      showToast('GetUserRequest deleted successfully', 'success');
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return { create, update, remove, loading };
+}
+
+
@@ -0,0 +1,108 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Card, CardHeader } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Badge } from '../components/ui/Badge';
+import { Loading } from '../components/ui/Loading';
+import { useToast } from '../components/ui/Toast';
+import { stripeApi } from '../api';
+
+export default function BillingPage() {
+  const [subscription, setSubscription] = useState<any>(null);
+  const [invoices, setInvoices] = useState<any[]>([]);
+  const [loading, setLoading] = useState(true);
+  const { showToast } = useToast();
+
+  useEffect(() => {
+    Promise.all([stripeApi.getSubscription(), stripeApi.getInvoices()])
+      .then(([sub, inv]) => { setSubscription(sub); setInvoices(inv); })
+      .finally(() => setLoading(false));
+  }, []);
+
+  const handleManage = async () => {
+    const { url } = await stripeApi.createPortalSession();
+    window.location.href = url;
+  };
+
+  const handleCancel = async () => {
+    if (!confirm('Are you sure you want to cancel?')) return;
+    try {
+      await stripeApi.cancelSubscription();
+      showToast('Subscription will be cancelled at period end', 'success');
+      setSubscription((s: any) => ({ ...s, cancelAtPeriodEnd: true }));
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    }
+  };
+
+  const handleReactivate = async () => {
+    try {
+      await stripeApi.reactivateSubscription();
+      showToast('Subscription reactivated', 'success');
+      setSubscription((s: any) => ({ ...s, cancelAtPeriodEnd: false }));
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    }
+  };
+
+  if (loading) return <Loading />;
+
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Billing</h1>
+        <p className="page-subtitle">Manage your subscription and billing</p>
+      </div>
+      <div className="grid gap-6 max-w-4xl">
+        <Card>
+          <CardHeader title="Current Plan" action={<Button variant="secondary" onClick={handleManage}>Manage Billing</Button>} />
+          {subscription ? (
+            <div className="space-y-4">
+              <div className="flex items-center gap-3">
+                <Badge variant={subscription.status === 'active' ? 'success' : 'warning'}>{subscription.status}</Badge>
+                {subscription.cancelAtPeriodEnd && <Badge variant="warning">Cancels at period end</Badge>}
+              </div>
+              {subscription.currentPeriodEnd && <p className="text-gray-600">Next billing: {new Date(subscription.currentPeriodEnd).toLocaleDateString()}</p>}
+              <div className="flex gap-2">
+                {subscription.cancelAtPeriodEnd ? (
+                  <Button onClick={handleReactivate}>Reactivate</Button>
+                ) : (
+                  <Button variant="danger" onClick={handleCancel}>Cancel Subscription</Button>
+                )}
+              </div>
+            </div>
+          ) : (
+            <div className="text-center py-8">
+              <p className="text-gray-500 mb-4">No active subscription</p>
+              <Button onClick={() => window.location.href = '/pricing'}>View Plans</Button>
+            </div>
+          )}
+        </Card>
+        <Card>
+          <CardHeader title="Billing History" />
+          {invoices.length === 0 ? <p className="text-gray-500 text-center py-4">No invoices yet</p> : (
+            <table className="w-full">
+              <thead className="bg-gray-50"><tr>
+                <th className="px-4 py-2 text-left text-sm font-medium text-gray-500">Date</th>
+                <th className="px-4 py-2 text-left text-sm font-medium text-gray-500">Amount</th>
+                <th className="px-4 py-2 text-left text-sm font-medium text-gray-500">Status</th>
+                <th className="px-4 py-2 text-right text-sm font-medium text-gray-500">Invoice</th>
+              </tr></thead>
+              <tbody className="divide-y">
+                {invoices.map((inv: any) => (
+                  <tr key={inv.id}>
+                    <td className="px-4 py-3 text-sm">{new Date(inv.created * 1000).toLocaleDateString()}</td>
+                    <td className="px-4 py-3 text-sm">${(inv.amount_paid / 100).toFixed(2)}</td>
+                    <td className="px-4 py-3"><Badge variant={inv.status === 'paid' ? 'success' : 'warning'}>{inv.status}</Badge></td>
+                    <td className="px-4 py-3 text-right"><a href={inv.invoice_pdf} className="text-indigo-600 hover:underline text-sm">Download</a></td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </Card>
+      </div>
+    </div>
+  );
+}
+
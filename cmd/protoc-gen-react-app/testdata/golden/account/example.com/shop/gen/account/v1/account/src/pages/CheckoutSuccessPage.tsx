@@ -0,0 +1,21 @@
+// Generated by protoc-gen-react-app
+import { Link } from 'react-router-dom';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+
+export default function CheckoutSuccessPage() {
+  return (
+    <div className="max-w-md mx-auto mt-16">
+      <Card className="text-center p-8">
+        <div className="text-6xl mb-4">🎉</div>
+        <h1 className="text-2xl font-bold mb-2">Thank you!</h1>
+        <p className="text-gray-500 mb-6">Your subscription is now active. You can start using all premium features.</p>
+        <div className="flex gap-3 justify-center">
+          <Link to="/"><Button>Go to Dashboard</Button></Link>
+          <Link to="/settings/billing"><Button variant="secondary">View Billing</Button></Link>
+        </div>
+      </Card>
+    </div>
+  );
+}
+
@@ -0,0 +1,56 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useParams, useNavigate } from 'react-router-dom';
+import { useGetUserRequest, useGetUserRequestMutations } from '../hooks';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import { Badge } from '../components/ui/Badge';
+import { ConfirmModal } from '../components/ui/Modal';
+
+export default function GetUserRequestDetailPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading, error } = useGetUserRequest(id);
+  const { remove, loading: deleting } = useGetUserRequestMutations();
+  const [showDelete, setShowDelete] = useState(false);
+
+  if (loading) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleDelete = async () => {
+    await remove(item.id);
+    navigate('/getuserrequest');
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">GetUserRequest Details</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <div className="flex gap-2">
+          <Button variant="secondary" onClick={() => navigate('/getuserrequest')}>← Back</Button>
+          <Button variant="secondary" onClick={() => navigate('/getuserrequest/' + item.id + '/edit')}>Edit</Button>
+          <Button variant="danger" onClick={() => setShowDelete(true)}>Delete</Button>
+        </div>
+      </div>
+
+      <Card>
+        <dl className="grid grid-cols-1 md:grid-cols-2 gap-6">
+        </dl>
+      </Card>
+
+      <ConfirmModal
+        isOpen={showDelete}
+        onClose={() => setShowDelete(false)}
+        onConfirm={handleDelete}
+        title="Delete GetUserRequest"
+        message="Are you sure? This cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,72 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { useGetUserRequestList, useGetUserRequestMutations } from '../hooks';
+import { GetUserRequestTable } from '../components/GetUserRequestTable';
+import { Button } from '../components/ui/Button';
+import { SearchInput } from '../components/ui/SearchInput';
+import { ConfirmModal } from '../components/ui/Modal';
+import { EmptyState } from '../components/ui/EmptyState';
+
+export default function GetUserRequestListPage() {
+  const navigate = useNavigate();
+  const { items, loading, refetch } = useGetUserRequestList();
+  const { remove, loading: deleting } = useGetUserRequestMutations();
+  const [deleteId, setDeleteId] = useState<string | null>(null);
+  const [search, setSearch] = useState('');
+
+  const filtered = items.filter(item => 
+    JSON.stringify(item).toLowerCase().includes(search.toLowerCase())
+  );
+
+  const handleDelete = async () => {
+    if (deleteId) {
+      await remove(deleteId);
+      setDeleteId(null);
+      refetch();
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">GetUserRequests</h1>
+          <p className="page-subtitle">{items.length} total getuserrequests</p>
+        </div>
+        <Button onClick={() => navigate('/getuserrequest/new')}>+ Add GetUserRequest</Button>
+      </div>
+
+      <div className="mb-6">
+        <SearchInput onSearch={setSearch} placeholder="Search getuserrequests..." className="max-w-md" />
+      </div>
+
+      {!loading && filtered.length === 0 ? (
+        <EmptyState
+          icon="📋"
+          title="No GetUserRequests yet"
+          description="Get started by creating your first getuserrequest."
+          action={<Button onClick={() => navigate('/getuserrequest/new')}>Create GetUserRequest</Button>}
+        />
+      ) : (
+        <GetUserRequestTable
+          data={filtered}
+          loading={loading}
+          onView={(item) => navigate('/getuserrequest/' + item.id)}
+          onEdit={(item) => navigate('/getuserrequest/' + item.id + '/edit')}
+          onDelete={(item) => setDeleteId(item.id)}
+        />
+      )}
+
+      <ConfirmModal
+        isOpen={!!deleteId}
+        onClose={() => setDeleteId(null)}
+        onConfirm={handleDelete}
+        title="Delete GetUserRequest"
+        message="Are you sure you want to delete this getuserrequest? This action cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,57 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Card, CardHeader } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Checkbox } from '../components/ui/Input';
+import { Loading } from '../components/ui/Loading';
+import { useToast } from '../components/ui/Toast';
+import { notificationApi } from '../api';
+
+export default function NotificationSettingsPage() {
+  const [prefs, setPrefs] = useState<any>(null);
+  const [loading, setLoading] = useState(true);
+  const [saving, setSaving] = useState(false);
+  const { showToast } = useToast();
+
+  useEffect(() => { notificationApi.getPreferences().then(setPrefs).finally(() => setLoading(false)); }, []);
+
+  const handleSave = async () => {
+    setSaving(true);
+    try { await notificationApi.updatePreferences(prefs); showToast('Preferences saved', 'success'); }
+    catch (err: any) { showToast(err.message, 'error'); }
+    finally { setSaving(false); }
+  };
+
+  const handleTest = async () => {
+    try { await notificationApi.testNotification(); showToast('Test notification sent', 'success'); }
+    catch (err: any) { showToast(err.message, 'error'); }
+  };
+
+  if (loading) return <Loading />;
+
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Notification Settings</h1>
+        <p className="page-subtitle">Manage how you receive notifications</p>
+      </div>
+      <div className="max-w-2xl space-y-6">
+        <Card><CardHeader title="Push Notifications" /><Checkbox label="Enable push notifications" checked={prefs?.pushEnabled} onChange={e => setPrefs((p: any) => ({ ...p, pushEnabled: e.target.checked }))} /></Card>
+        <Card><CardHeader title="Email Notifications" /><Checkbox label="Enable email notifications" checked={prefs?.emailEnabled} onChange={e => setPrefs((p: any) => ({ ...p, emailEnabled: e.target.checked }))} /></Card>
+        <Card><CardHeader title="SMS Notifications" /><Checkbox label="Enable SMS notifications" checked={prefs?.smsEnabled} onChange={e => setPrefs((p: any) => ({ ...p, smsEnabled: e.target.checked }))} /></Card>
+        <Card>
+          <CardHeader title="Quiet Hours" subtitle="Do not send notifications during these hours" />
+          <div className="flex gap-4">
+            <div className="flex-1"><label className="label">Start</label><input type="time" className="input" value={prefs?.quietHoursStart || ''} onChange={e => setPrefs((p: any) => ({ ...p, quietHoursStart: e.target.value }))} /></div>
+            <div className="flex-1"><label className="label">End</label><input type="time" className="input" value={prefs?.quietHoursEnd || ''} onChange={e => setPrefs((p: any) => ({ ...p, quietHoursEnd: e.target.value }))} /></div>
+          </div>
+        </Card>
+        <div className="flex gap-3">
+          <Button onClick={handleSave} loading={saving}>Save Preferences</Button>
+          <Button variant="secondary" onClick={handleTest}>Send Test Notification</Button>
+        </div>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,60 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import { EmptyState } from '../components/ui/EmptyState';
+import { notificationApi } from '../api';
+
+export default function NotificationsPage() {
+  const [notifications, setNotifications] = useState<any[]>([]);
+  const [loading, setLoading] = useState(true);
+
+  useEffect(() => {
+    notificationApi.list({ limit: 50 }).then(setNotifications).finally(() => setLoading(false));
+  }, []);
+
+  const handleMarkAsRead = async (id: string) => {
+    await notificationApi.markAsRead(id);
+    setNotifications(n => n.map(x => x.id === id ? { ...x, readAt: new Date().toISOString() } : x));
+  };
+
+  const handleMarkAllAsRead = async () => {
+    await notificationApi.markAllAsRead();
+    setNotifications(n => n.map(x => ({ ...x, readAt: new Date().toISOString() })));
+  };
+
+  if (loading) return <Loading />;
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Notifications</h1>
+          <p className="page-subtitle">{notifications.filter(n => !n.readAt).length} unread</p>
+        </div>
+        <Button variant="secondary" onClick={handleMarkAllAsRead}>Mark all as read</Button>
+      </div>
+      {notifications.length === 0 ? (
+        <EmptyState icon="🔔" title="No notifications" description="You are all caught up!" />
+      ) : (
+        <Card padding={false}>
+          <div className="divide-y">
+            {notifications.map(n => (
+              <div key={n.id} onClick={() => !n.readAt && handleMarkAsRead(n.id)} className={"p-4 cursor-pointer hover:bg-gray-50 " + (!n.readAt ? 'bg-blue-50' : '')}>
+                <div className="flex justify-between items-start">
+                  <div>
+                    <p className="font-medium">{n.title}</p>
+                    <p className="text-sm text-gray-600 mt-1">{n.body}</p>
+                  </div>
+                  <span className="text-xs text-gray-400">{new Date(n.createdAt).toLocaleString()}</span>
+                </div>
+              </div>
+            ))}
+          </div>
+        </Card>
+      )}
+    </div>
+  );
+}
+
@@ -0,0 +1,82 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Link, useNavigate } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { Input } from '../components/ui/Input';
+import { Button } from '../components/ui/Button';
+
+const API_BASE = import.meta.env.VITE_API_URL || '/api';
+
+export default function SignupPage() {
+  const navigate = useNavigate();
+  const { signup } = useAuth();
+  const [form, setForm] = useState({ name: '', email: '', password: '', confirm: '' });
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const handleOAuth = async (provider: string) => {
+    try {
+      const res = await fetch(API_BASE + '/auth/oauth/' + provider + '/start');
+      const { url } = await res.json();
+      window.location.href = url;
+    } catch (err: any) {
+      setError(err.message);
+    }
+  };
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (form.password !== form.confirm) { setError('Passwords do not match'); return; }
+    setError('');
+    setLoading(true);
+    try {
+      await signup(form.name, form.email, form.password);
+      navigate('/');
+    } catch (err: any) {
+      setError(err.message || 'Signup failed');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold">Create account</h1>
+          <p className="text-gray-500 mt-2">Get started with your free account</p>
+        </div>
+
+        <div className="card p-8">
+          {error && <div className="mb-4 p-3 bg-red-50 text-red-700 rounded-lg text-sm">{error}</div>}
+
+          <form onSubmit={handleSubmit} className="space-y-4">
+            <Input label="Name" value={form.name} onChange={e => setForm(f => ({ ...f, name: e.target.value }))} required />
+            <Input label="Email" type="email" value={form.email} onChange={e => setForm(f => ({ ...f, email: e.target.value }))} required />
+            <Input label="Password" type="password" value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} required minLength={8} />
+            <Input label="Confirm Password" type="password" value={form.confirm} onChange={e => setForm(f => ({ ...f, confirm: e.target.value }))} required />
+            <Button type="submit" className="w-full" loading={loading}>Create Account</Button>
+          </form>
+
+          <div className="relative my-6">
+            <div className="absolute inset-0 flex items-center"><div className="w-full border-t border-gray-300" /></div>
+            <div className="relative flex justify-center text-sm"><span className="px-2 bg-white text-gray-500">Or continue with</span></div>
+          </div>
+          
+          <div className="grid grid-cols-2 gap-3">
+            <button onClick={() => handleOAuth('google')} type="button" className="btn btn-secondary">
+              <span className="mr-2">G</span> Google
+            </button>
+            <button onClick={() => handleOAuth('github')} type="button" className="btn btn-secondary">
+              <span className="mr-2">🐙</span> GitHub
+            </button>
+          </div>
+          <p className="mt-6 text-center text-sm text-gray-500">
+            Already have an account? <Link to="/login" className="text-indigo-600 hover:underline">Sign in</Link>
+          </p>
+        </div>
+      </div>
+    </div>
+  );
+}
+
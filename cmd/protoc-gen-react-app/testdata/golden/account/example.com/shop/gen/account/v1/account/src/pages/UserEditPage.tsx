@@ -0,0 +1,40 @@
+// Generated by protoc-gen-react-app
+import { useParams, useNavigate } from 'react-router-dom';
+import { useUser, useUserMutations } from '../hooks';
+import { UserForm } from '../components/UserForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import type { UserInput } from '../types';
+
+export default function UserEditPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading: fetching, error } = useUser(id);
+  const { update, loading } = useUserMutations();
+
+  if (fetching) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleSubmit = async (data: UserInput) => {
+    await update(item.id, data);
+    navigate('/user/' + item.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Edit User</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/user/' + item.id)}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <UserForm initialData={item} onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
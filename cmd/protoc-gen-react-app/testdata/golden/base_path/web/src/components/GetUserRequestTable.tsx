@@ -0,0 +1,38 @@
+// Generated by protoc-gen-react-app
+import { Table } from './ui/Table';
+import { Button } from './ui/Button';
+import { Dropdown } from './ui/Dropdown';
+import type { GetUserRequest } from '../types';
+
+interface GetUserRequestTableProps {
+  data: GetUserRequest[];
+  loading?: boolean;
+  onView?: (item: GetUserRequest) => void;
+  onEdit?: (item: GetUserRequest) => void;
+  onDelete?: (item: GetUserRequest) => void;
+}
+
+export function GetUserRequestTable({ data, loading, onView, onEdit, onDelete }: GetUserRequestTableProps) {
+  const columns = [
+    { key: 'id', header: 'Id' },
+    {
+      key: 'actions',
+      header: '',
+      className: 'w-12',
+      render: (item: GetUserRequest) => (
+        <Dropdown
+          trigger={<button className="p-1 hover:bg-gray-100 rounded">⋯</button>}
+          items={[
+            { label: 'View', onClick: () => onView?.(item) },
+            { label: 'Edit', onClick: () => onEdit?.(item) },
+            { type: 'divider' },
+            { label: 'Delete', onClick: () => onDelete?.(item), className: 'text-red-600' },
+          ]}
+        />
+      ),
+    },
+  ];
+
+  return <Table columns={columns} data={data} loading={loading} onRowClick={onView} />;
+}
+
@@ -0,0 +1,52 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect, useRef } from 'react';
+import { Link } from 'react-router-dom';
+import { notificationApi } from '../api';
+
+export function NotificationBell() {
+  const [notifications, setNotifications] = useState<any[]>([]);
+  const [unreadCount, setUnreadCount] = useState(0);
+  const [open, setOpen] = useState(false);
+  const ref = useRef<HTMLDivElement>(null);
+
+  useEffect(() => { notificationApi.getUnreadCount().then(({ count }) => setUnreadCount(count)); }, []);
+  useEffect(() => { if (open) notificationApi.list({ limit: 5 }).then(setNotifications); }, [open]);
+  useEffect(() => {
+    const handleClick = (e: MouseEvent) => { if (ref.current && !ref.current.contains(e.target as Node)) setOpen(false); };
+    document.addEventListener('mousedown', handleClick);
+    return () => document.removeEventListener('mousedown', handleClick);
+  }, []);
+
+  const handleMarkAsRead = async (id: string) => {
+    await notificationApi.markAsRead(id);
+    setNotifications(n => n.map(x => x.id === id ? { ...x, readAt: new Date().toISOString() } : x));
+    setUnreadCount(c => Math.max(0, c - 1));
+  };
+
+  return (
+    <div ref={ref} className="relative">
+      <button onClick={() => setOpen(!open)} className="p-2 text-gray-500 hover:text-gray-700 hover:bg-gray-100 rounded-lg relative">
+        🔔
+        {unreadCount > 0 && <span className="absolute -top-1 -right-1 w-5 h-5 bg-red-500 text-white text-xs rounded-full flex items-center justify-center">{unreadCount > 9 ? '9+' : unreadCount}</span>}
+      </button>
+      {open && (
+        <div className="absolute right-0 mt-2 w-80 bg-white rounded-lg shadow-lg border z-50">
+          <div className="p-3 border-b flex justify-between items-center">
+            <span className="font-medium">Notifications</span>
+            <Link to="/notifications" className="text-sm text-indigo-600" onClick={() => setOpen(false)}>View all</Link>
+          </div>
+          <div className="max-h-96 overflow-y-auto">
+            {notifications.length === 0 ? <div className="p-4 text-center text-gray-500">No notifications</div> : notifications.map(n => (
+              <div key={n.id} onClick={() => !n.readAt && handleMarkAsRead(n.id)} className={"p-3 border-b cursor-pointer hover:bg-gray-50 " + (!n.readAt ? "bg-blue-50" : "")}>
+                <p className="font-medium text-sm">{n.title}</p>
+                <p className="text-xs text-gray-600 mt-1">{n.body}</p>
+                <p className="text-xs text-gray-400 mt-1">{new Date(n.createdAt).toLocaleString()}</p>
+              </div>
+            ))}
+          </div>
+        </div>
+      )}
+    </div>
+  );
+}
+
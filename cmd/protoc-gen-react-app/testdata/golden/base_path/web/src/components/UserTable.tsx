@@ -0,0 +1,43 @@
+// Generated by protoc-gen-react-app
+import { Table } from './ui/Table';
+import { Button } from './ui/Button';
+import { Dropdown } from './ui/Dropdown';
+import type { User } from '../types';
+
+interface UserTableProps {
+  data: User[];
+  loading?: boolean;
+  onView?: (item: User) => void;
+  onEdit?: (item: User) => void;
+  onDelete?: (item: User) => void;
+}
+
+export function UserTable({ data, loading, onView, onEdit, onDelete }: UserTableProps) {
+  const columns = [
+    { key: 'id', header: 'Id' },
+    { key: 'email', header: 'Email' },
+    { key: 'name', header: 'Name' },
+    { key: 'phone', header: 'Phone' },
+    { key: 'auth_email', header: 'AuthEmail' },
+    { key: 'auth_oauth', header: 'AuthOauth' },
+    {
+      key: 'actions',
+      header: '',
+      className: 'w-12',
+      render: (item: User) => (
+        <Dropdown
+          trigger={<button className="p-1 hover:bg-gray-100 rounded">⋯</button>}
+          items={[
+            { label: 'View', onClick: () => onView?.(item) },
+            { label: 'Edit', onClick: () => onEdit?.(item) },
+            { type: 'divider' },
+            { label: 'Delete', onClick: () => onDelete?.(item), className: 'text-red-600' },
+          ]}
+        />
+      ),
+    },
+  ];
+
+  return <Table columns={columns} data={data} loading={loading} onRowClick={onView} />;
+}
+
@@ -0,0 +1,59 @@
+// Generated by protoc-gen-react-app
+interface CardProps {
+  children: React.ReactNode;
+  className?: string;
+  padding?: boolean;
+}
+
+export function Card({ children, className = '', padding = true }: CardProps) {
+  return (
+    <div className={"card " + (padding ? 'p-6' : '') + " " + className}>
+      {children}
+    </div>
+  );
+}
+
+interface CardHeaderProps {
+  title: string;
+  subtitle?: string;
+  action?: React.ReactNode;
+}
+
+export function CardHeader({ title, subtitle, action }: CardHeaderProps) {
+  return (
+    <div className="flex items-center justify-between mb-4">
+      <div>
+        <h3 className="text-lg font-semibold">{title}</h3>
+        {subtitle && <p className="text-sm text-gray-500">{subtitle}</p>}
+      </div>
+      {action}
+    </div>
+  );
+}
+
+interface StatCardProps {
+  title: string;
+  value: string | number;
+  change?: { value: number; positive: boolean };
+  icon?: string;
+}
+
+export function StatCard({ title, value, change, icon }: StatCardProps) {
+  return (
+    <Card>
+      <div className="flex items-center justify-between">
+        <div>
+          <p className="text-sm text-gray-500">{title}</p>
+          <p className="text-2xl font-bold mt-1">{value}</p>
+          {change && (
+            <p className={"text-sm mt-1 " + (change.positive ? 'text-green-600' : 'text-red-600')}>
+              {change.positive ? '↑' : '↓'} {Math.abs(change.value)}%
+            </p>
+          )}
+        </div>
+        {icon && <span className="text-3xl opacity-50">{icon}</span>}
+      </div>
+    </Card>
+  );
+}
+
@@ -0,0 +1,85 @@
+// Generated by protoc-gen-react-app
+import { forwardRef } from 'react';
+
+interface InputProps extends React.InputHTMLAttributes<HTMLInputElement> {
+  label?: string;
+  error?: string;
+  hint?: string;
+}
+
+export const Input = forwardRef<HTMLInputElement, InputProps>(
+  ({ label, error, hint, className = '', ...props }, ref) => {
+    return (
+      <div className="w-full">
+        {label && <label className="label">{label}</label>}
+        <input
+          ref={ref}
+          className={"input " + (error ? 'border-red-500 focus:ring-red-500' : '') + " " + className}
+          {...props}
+        />
+        {hint && !error && <p className="mt-1 text-sm text-gray-500">{hint}</p>}
+        {error && <p className="mt-1 text-sm text-red-600">{error}</p>}
+      </div>
+    );
+  }
+);
+
+interface TextareaProps extends React.TextareaHTMLAttributes<HTMLTextAreaElement> {
+  label?: string;
+  error?: string;
+}
+
+export const Textarea = forwardRef<HTMLTextAreaElement, TextareaProps>(
+  ({ label, error, className = '', ...props }, ref) => {
+    return (
+      <div className="w-full">
+        {label && <label className="label">{label}</label>}
+        <textarea
+          ref={ref}
+          className={"input min-h-[100px] " + (error ? 'border-red-500' : '') + " " + className}
+          {...props}
+        />
+        {error && <p className="mt-1 text-sm text-red-600">{error}</p>}
+      </div>
+    );
+  }
+);
+
+interface SelectProps extends React.SelectHTMLAttributes<HTMLSelectElement> {
+  label?: string;
+  error?: string;
+  options: { value: string; label: string }[];
+}
+
+export const Select = forwardRef<HTMLSelectElement, SelectProps>(
+  ({ label, error, options, className = '', ...props }, ref) => {
+    return (
+      <div className="w-full">
+        {label && <label className="label">{label}</label>}
+        <select ref={ref} className={"input " + className} {...props}>
+          <option value="">Select...</option>
+          {options.map(opt => (
+            <option key={opt.value} value={opt.value}>{opt.label}</option>
+          ))}
+        </select>
+        {error && <p className="mt-1 text-sm text-red-600">{error}</p>}
+      </div>
+    );
+  }
+);
+
+interface CheckboxProps extends Omit<React.InputHTMLAttributes<HTMLInputElement>, 'type'> {
+  label: string;
+}
+
+export const Checkbox = forwardRef<HTMLInputElement, CheckboxProps>(
+  ({ label, className = '', ...props }, ref) => {
+    return (
+      <label className="flex items-center gap-2 cursor-pointer">
+        <input ref={ref} type="checkbox" className={"w-4 h-4 text-indigo-600 rounded " + className} {...props} />
+        <span className="text-sm text-gray-700">{label}</span>
+      </label>
+    );
+  }
+);
+
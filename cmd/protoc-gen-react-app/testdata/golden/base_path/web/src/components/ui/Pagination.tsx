@@ -0,0 +1,52 @@
+// Generated by protoc-gen-react-app
+interface PaginationProps {
+  currentPage: number;
+  totalPages: number;
+  onPageChange: (page: number) => void;
+}
+
+export function Pagination({ currentPage, totalPages, onPageChange }: PaginationProps) {
+  if (totalPages <= 1) return null;
+
+  const pages = [];
+  for (let i = 1; i <= totalPages; i++) {
+    if (i === 1 || i === totalPages || (i >= currentPage - 1 && i <= currentPage + 1)) {
+      pages.push(i);
+    } else if (pages[pages.length - 1] !== '...') {
+      pages.push('...');
+    }
+  }
+
+  return (
+    <div className="flex items-center justify-center gap-1 mt-6">
+      <button
+        onClick={() => onPageChange(currentPage - 1)}
+        disabled={currentPage === 1}
+        className="px-3 py-2 rounded-lg text-sm disabled:opacity-50 hover:bg-gray-100"
+      >
+        ← Prev
+      </button>
+      {pages.map((page, i) => 
+        page === '...' ? (
+          <span key={i} className="px-3 py-2">...</span>
+        ) : (
+          <button
+            key={i}
+            onClick={() => onPageChange(page as number)}
+            className={"px-3 py-2 rounded-lg text-sm " + (currentPage === page ? 'bg-indigo-600 text-white' : 'hover:bg-gray-100')}
+          >
+            {page}
+          </button>
+        )
+      )}
+      <button
+        onClick={() => onPageChange(currentPage + 1)}
+        disabled={currentPage === totalPages}
+        className="px-3 py-2 rounded-lg text-sm disabled:opacity-50 hover:bg-gray-100"
+      >
+        Next →
+      </button>
+    </div>
+  );
+}
+
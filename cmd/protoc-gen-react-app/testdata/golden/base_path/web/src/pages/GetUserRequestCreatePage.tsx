@@ -0,0 +1,34 @@
+// Generated by protoc-gen-react-app
+import { useNavigate } from 'react-router-dom';
+import { useGetUserRequestMutations } from '../hooks';
+import { GetUserRequestForm } from '../components/GetUserRequestForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import type { GetUserRequestInput } from '../types';
+
+export default function GetUserRequestCreatePage() {
+  const navigate = useNavigate();
+  const { create, loading } = useGetUserRequestMutations();
+
+  const handleSubmit = async (data: GetUserRequestInput) => {
+    const result = await create(data);
+    navigate('/getuserrequest/' + result.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Create GetUserRequest</h1>
+          <p className="page-subtitle">Add a new getuserrequest to the system</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/getuserrequest')}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <GetUserRequestForm onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
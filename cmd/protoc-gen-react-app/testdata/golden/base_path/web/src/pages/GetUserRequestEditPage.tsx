@@ -0,0 +1,40 @@
+// Generated by protoc-gen-react-app
+import { useParams, useNavigate } from 'react-router-dom';
+import { useGetUserRequest, useGetUserRequestMutations } from '../hooks';
+import { GetUserRequestForm } from '../components/GetUserRequestForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import type { GetUserRequestInput } from '../types';
+
+export default function GetUserRequestEditPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading: fetching, error } = useGetUserRequest(id);
+  const { update, loading } = useGetUserRequestMutations();
+
+  if (fetching) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleSubmit = async (data: GetUserRequestInput) => {
+    await update(item.id, data);
+    navigate('/getuserrequest/' + item.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Edit GetUserRequest</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/getuserrequest/' + item.id)}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <GetUserRequestForm initialData={item} onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
@@ -0,0 +1,76 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { useToast } from '../components/ui/Toast';
+import { oauthApi } from '../api';
+
+const providers = [
+  { id: 'google', name: 'Google', icon: 'G', color: 'bg-red-500' },
+  { id: 'github', name: 'GitHub', icon: '🐙', color: 'bg-gray-800' },
+  { id: 'apple', name: 'Apple', icon: '', color: 'bg-black' },
+];
+
+export default function LinkedAccountsPage() {
+  const [linked, setLinked] = useState<string[]>([]);
+  const [loading, setLoading] = useState(true);
+  const { showToast } = useToast();
+
+  useEffect(() => {
+    oauthApi.getLinkedProviders()
+      .then(({ providers }) => setLinked(providers))
+      .finally(() => setLoading(false));
+  }, []);
+
+  const handleLink = async (provider: string) => {
+    try {
+      const { url } = await oauthApi.linkProvider(provider);
+      window.location.href = url;
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    }
+  };
+
+  const handleUnlink = async (provider: string) => {
+    try {
+      await oauthApi.unlinkProvider(provider);
+      setLinked(l => l.filter(p => p !== provider));
+      showToast('Account unlinked', 'success');
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Linked Accounts</h1>
+        <p className="page-subtitle">Manage your connected social accounts</p>
+      </div>
+      <Card className="max-w-2xl">
+        <div className="divide-y">
+          {providers.map(p => {
+            const isLinked = linked.includes(p.id);
+            return (
+              <div key={p.id} className="flex items-center justify-between p-4">
+                <div className="flex items-center gap-3">
+                  <div className={"w-10 h-10 rounded-full flex items-center justify-center text-white " + p.color}>{p.icon}</div>
+                  <div>
+                    <p className="font-medium">{p.name}</p>
+                    <p className="text-sm text-gray-500">{isLinked ? 'Connected' : 'Not connected'}</p>
+                  </div>
+                </div>
+                {isLinked ? (
+                  <Button variant="danger" size="sm" onClick={() => handleUnlink(p.id)}>Unlink</Button>
+                ) : (
+                  <Button variant="secondary" size="sm" onClick={() => handleLink(p.id)}>Link</Button>
+                )}
+              </div>
+            );
+          })}
+        </div>
+      </Card>
+    </div>
+  );
+}
+
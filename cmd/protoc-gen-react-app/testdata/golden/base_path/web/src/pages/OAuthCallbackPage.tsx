@@ -0,0 +1,44 @@
+// Generated by protoc-gen-react-app
+import { useEffect, useState } from 'react';
+import { useParams, useSearchParams, useNavigate } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { Loading } from '../components/ui/Loading';
+import { oauthApi } from '../api';
+
+export default function OAuthCallbackPage() {
+  const { provider } = useParams();
+  const [searchParams] = useSearchParams();
+  const navigate = useNavigate();
+  const { setAuth } = useAuth();
+  const [error, setError] = useState('');
+
+  useEffect(() => {
+    const code = searchParams.get('code');
+    const state = searchParams.get('state');
+    if (!code || !state || !provider) { setError('Invalid OAuth callback'); return; }
+
+    oauthApi.callback(provider, code, state)
+      .then(({ token, user }) => {
+        localStorage.setItem('token', token);
+        localStorage.setItem('user', JSON.stringify(user));
+        if (setAuth) setAuth({ token, user });
+        navigate('/');
+      })
+      .catch(err => setError(err.message));
+  }, [provider, searchParams, navigate, setAuth]);
+
+  if (error) {
+    return (
+      <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+        <div className="card p-8 max-w-md w-full text-center">
+          <div className="text-5xl mb-4">❌</div>
+          <h1 className="text-2xl font-bold mb-2">Authentication failed</h1>
+          <p className="text-gray-500 mb-6">{error}</p>
+          <a href="/login" className="btn btn-primary">Back to Login</a>
+        </div>
+      </div>
+    );
+  }
+  return <Loading fullScreen />;
+}
+
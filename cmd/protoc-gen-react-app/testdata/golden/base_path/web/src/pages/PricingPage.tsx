@@ -0,0 +1,59 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { Button } from '../components/ui/Button';
+import { useAuth } from '../context/AuthContext';
+import { stripeApi } from '../api';
+
+const plans = [
+  { id: 'free', name: 'Free', price: 0, priceId: '', features: ['5 projects', '1 GB storage', 'Community support'] },
+  { id: 'pro', name: 'Pro', price: 29, priceId: 'price_pro_monthly', features: ['Unlimited projects', '100 GB storage', 'Priority support', 'Advanced analytics'], popular: true },
+  { id: 'enterprise', name: 'Enterprise', price: 99, priceId: 'price_enterprise_monthly', features: ['Everything in Pro', 'Unlimited storage', 'Dedicated support', 'Custom integrations', 'SLA'] },
+];
+
+export default function PricingPage() {
+  const navigate = useNavigate();
+  const { isAuthenticated } = useAuth();
+  const [loading, setLoading] = useState<string | null>(null);
+
+  const handleSelect = async (plan: typeof plans[0]) => {
+    if (!plan.priceId) return;
+    if (!isAuthenticated) { navigate('/signup?plan=' + plan.id); return; }
+    setLoading(plan.id);
+    try {
+      const { url } = await stripeApi.createCheckoutSession(plan.priceId);
+      window.location.href = url;
+    } catch (err) {
+      console.error(err);
+    } finally {
+      setLoading(null);
+    }
+  };
+
+  return (
+    <div className="min-h-screen bg-gray-50 py-16 px-4">
+      <div className="max-w-5xl mx-auto">
+        <div className="text-center mb-12">
+          <h1 className="text-4xl font-bold">Simple, transparent pricing</h1>
+          <p className="text-gray-500 mt-4 text-lg">Choose the plan that is right for you</p>
+        </div>
+        <div className="grid md:grid-cols-3 gap-8">
+          {plans.map(plan => (
+            <div key={plan.id} className={"card p-8 relative " + (plan.popular ? 'ring-2 ring-indigo-600' : '')}>
+              {plan.popular && <span className="absolute -top-3 left-1/2 -translate-x-1/2 bg-indigo-600 text-white text-xs px-3 py-1 rounded-full">Most Popular</span>}
+              <h2 className="text-xl font-bold">{plan.name}</h2>
+              <div className="mt-4"><span className="text-4xl font-bold">${plan.price}</span><span className="text-gray-500">/month</span></div>
+              <ul className="mt-6 space-y-3">
+                {plan.features.map(f => <li key={f} className="flex items-center gap-2 text-sm"><span className="text-green-500">✓</span> {f}</li>)}
+              </ul>
+              <Button onClick={() => handleSelect(plan)} loading={loading === plan.id} variant={plan.popular ? 'primary' : 'secondary'} className="w-full mt-8">
+                {plan.price === 0 ? 'Get Started' : 'Subscribe'}
+              </Button>
+            </div>
+          ))}
+        </div>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,83 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useAuth } from '../context/AuthContext';
+import { Card, CardHeader } from '../components/ui/Card';
+import { Input } from '../components/ui/Input';
+import { Button } from '../components/ui/Button';
+import { useToast } from '../components/ui/Toast';
+
+export default function ProfilePage() {
+  const { user } = useAuth();
+  const { showToast } = useToast();
+  const [form, setForm] = useState({ name: user?.name || '', email: user?.email || '' });
+  const [passwordForm, setPasswordForm] = useState({ current: '', newPassword: '', confirm: '' });
+  const [loading, setLoading] = useState(false);
+
+  const handleUpdateProfile = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setLoading(true);
+    try {
+      // await authApi.updateProfile(form);
+      showToast('Profile updated', 'success');
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleChangePassword = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (passwordForm.newPassword !== passwordForm.confirm) {
+      showToast('Passwords do not match', 'error');
+      return;
+    }
+    setLoading(true);
+    try {
+      // await authApi.changePassword(passwordForm);
+      showToast('Password changed', 'success');
+      setPasswordForm({ current: '', newPassword: '', confirm: '' });
+    } catch (err: any) {
+      showToast(err.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Profile</h1>
+        <p className="page-subtitle">Manage your account information</p>
+      </div>
+
+      <div className="max-w-2xl space-y-6">
+        <Card>
+          <CardHeader title="Personal Information" />
+          <form onSubmit={handleUpdateProfile} className="space-y-4">
+            <Input label="Name" value={form.name} onChange={e => setForm(f => ({ ...f, name: e.target.value }))} />
+            <Input label="Email" type="email" value={form.email} onChange={e => setForm(f => ({ ...f, email: e.target.value }))} />
+            <Button type="submit" loading={loading}>Save Changes</Button>
+          </form>
+        </Card>
+
+        <Card>
+          <CardHeader title="Change Password" />
+          <form onSubmit={handleChangePassword} className="space-y-4">
+            <Input label="Current Password" type="password" value={passwordForm.current} onChange={e => setPasswordForm(f => ({ ...f, current: e.target.value }))} />
+            <Input label="New Password" type="password" value={passwordForm.newPassword} onChange={e => setPasswordForm(f => ({ ...f, newPassword: e.target.value }))} />
+            <Input label="Confirm New Password" type="password" value={passwordForm.confirm} onChange={e => setPasswordForm(f => ({ ...f, confirm: e.target.value }))} />
+            <Button type="submit" loading={loading}>Change Password</Button>
+          </form>
+        </Card>
+
+        <Card>
+          <CardHeader title="Danger Zone" />
+          <p className="text-gray-500 text-sm mb-4">Once you delete your account, there is no going back.</p>
+          <Button variant="danger">Delete Account</Button>
+        </Card>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,55 @@
+// Generated by protoc-gen-react-app
+import { Link } from 'react-router-dom';
+import { Card, CardHeader } from '../components/ui/Card';
+
+export default function SettingsPage() {
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Settings</h1>
+        <p className="page-subtitle">Manage your account settings</p>
+      </div>
+
+      <div className="max-w-2xl space-y-4">
+        <Link to="/profile" className="flex items-center gap-4 p-4 rounded-lg hover:bg-gray-50 border bg-white">
+          <span className="text-2xl">👤</span>
+          <div>
+            <p className="font-medium">Profile</p>
+            <p className="text-sm text-gray-500">Update your personal information</p>
+          </div>
+        </Link>
+        
+        <Link to="/profile" className="flex items-center gap-4 p-4 rounded-lg hover:bg-gray-50 border bg-white">
+          <span className="text-2xl">🔒</span>
+          <div>
+            <p className="font-medium">Security</p>
+            <p className="text-sm text-gray-500">Change password and security settings</p>
+          </div>
+        </Link>
+
+        <Link to="/settings/billing" className="flex items-center gap-4 p-4 rounded-lg hover:bg-gray-50 border">
+          <span className="text-2xl">💳</span>
+          <div>
+            <p className="font-medium">Billing</p>
+            <p className="text-sm text-gray-500">Manage subscription and payment methods</p>
+          </div>
+        </Link>
+        <Link to="/settings/notifications" className="flex items-center gap-4 p-4 rounded-lg hover:bg-gray-50 border">
+          <span className="text-2xl">🔔</span>
+          <div>
+            <p className="font-medium">Notifications</p>
+            <p className="text-sm text-gray-500">Configure how you receive notifications</p>
+          </div>
+        </Link>
+        <Link to="/settings/linked-accounts" className="flex items-center gap-4 p-4 rounded-lg hover:bg-gray-50 border">
+          <span className="text-2xl">🔗</span>
+          <div>
+            <p className="font-medium">Linked Accounts</p>
+            <p className="text-sm text-gray-500">Manage connected social accounts</p>
+          </div>
+        </Link>
+      </div>
+    </div>
+  );
+}
+
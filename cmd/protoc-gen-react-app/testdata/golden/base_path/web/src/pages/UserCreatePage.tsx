@@ -0,0 +1,34 @@
+// Generated by protoc-gen-react-app
+import { useNavigate } from 'react-router-dom';
+import { useUserMutations } from '../hooks';
+import { UserForm } from '../components/UserForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import type { UserInput } from '../types';
+
+export default function UserCreatePage() {
+  const navigate = useNavigate();
+  const { create, loading } = useUserMutations();
+
+  const handleSubmit = async (data: UserInput) => {
+    const result = await create(data);
+    navigate('/user/' + result.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Create User</h1>
+          <p className="page-subtitle">Add a new user to the system</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/user')}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <UserForm onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
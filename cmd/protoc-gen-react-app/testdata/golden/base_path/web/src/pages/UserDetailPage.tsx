@@ -0,0 +1,96 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useParams, useNavigate } from 'react-router-dom';
+import { useUser, useUserMutations } from '../hooks';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import { Badge } from '../components/ui/Badge';
+import { ConfirmModal } from '../components/ui/Modal';
+
+export default function UserDetailPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading, error } = useUser(id);
+  const { remove, loading: deleting } = useUserMutations();
+  const [showDelete, setShowDelete] = useState(false);
+
+  if (loading) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleDelete = async () => {
+    await remove(item.id);
+    navigate('/user');
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">User Details</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <div className="flex gap-2">
+          <Button variant="secondary" onClick={() => navigate('/user')}>← Back</Button>
+          <Button variant="secondary" onClick={() => navigate('/user/' + item.id + '/edit')}>Edit</Button>
+          <Button variant="danger" onClick={() => setShowDelete(true)}>Delete</Button>
+        </div>
+      </div>
+
+      <Card>
+        <dl className="grid grid-cols-1 md:grid-cols-2 gap-6">
+          <div>
+            <dt className="text-sm text-gray-500">Email</dt>
+            <dd className="mt-1 text-gray-900">{item.email ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Name</dt>
+            <dd className="mt-1 text-gray-900">{item.name ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Phone</dt>
+            <dd className="mt-1 text-gray-900">{item.phone ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">AuthEmail</dt>
+            <dd className="mt-1 text-gray-900">{item.auth_email ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">AuthOauth</dt>
+            <dd className="mt-1 text-gray-900">{item.auth_oauth ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Stripe</dt>
+            <dd className="mt-1 text-gray-900">{item.stripe ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Notifications</dt>
+            <dd className="mt-1 text-gray-900">{item.notifications ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">CreatedAt</dt>
+            <dd className="mt-1 text-gray-900">{item.created_at ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">UpdatedAt</dt>
+            <dd className="mt-1 text-gray-900">{item.updated_at ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Etag</dt>
+            <dd className="mt-1 text-gray-900">{item.etag ?? '-'}</dd>
+          </div>
+        </dl>
+      </Card>
+
+      <ConfirmModal
+        isOpen={showDelete}
+        onClose={() => setShowDelete(false)}
+        onConfirm={handleDelete}
+        title="Delete User"
+        message="Are you sure? This cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,72 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { useUserList, useUserMutations } from '../hooks';
+import { UserTable } from '../components/UserTable';
+import { Button } from '../components/ui/Button';
+import { SearchInput } from '../components/ui/SearchInput';
+import { ConfirmModal } from '../components/ui/Modal';
+import { EmptyState } from '../components/ui/EmptyState';
+
+export default function UserListPage() {
+  const navigate = useNavigate();
+  const { items, loading, refetch } = useUserList();
+  const { remove, loading: deleting } = useUserMutations();
+  const [deleteId, setDeleteId] = useState<string | null>(null);
+  const [search, setSearch] = useState('');
+
+  const filtered = items.filter(item => 
+    JSON.stringify(item).toLowerCase().includes(search.toLowerCase())
+  );
+
+  const handleDelete = async () => {
+    if (deleteId) {
+      await remove(deleteId);
+      setDeleteId(null);
+      refetch();
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Users</h1>
+          <p className="page-subtitle">{items.length} total users</p>
+        </div>
+        <Button onClick={() => navigate('/user/new')}>+ Add User</Button>
+      </div>
+
+      <div className="mb-6">
+        <SearchInput onSearch={setSearch} placeholder="Search users..." className="max-w-md" />
+      </div>
+
+      {!loading && filtered.length === 0 ? (
+        <EmptyState
+          icon="👤"
+          title="No Users yet"
+          description="Get started by creating your first user."
+          action={<Button onClick={() => navigate('/user/new')}>Create User</Button>}
+        />
+      ) : (
+        <UserTable
+          data={filtered}
+          loading={loading}
+          onView={(item) => navigate('/user/' + item.id)}
+          onEdit={(item) => navigate('/user/' + item.id + '/edit')}
+          onDelete={(item) => setDeleteId(item.id)}
+        />
+      )}
+
+      <ConfirmModal
+        isOpen={!!deleteId}
+        onClose={() => setDeleteId(null)}
+        onConfirm={handleDelete}
+        title="Delete User"
+        message="Are you sure you want to delete this user? This action cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
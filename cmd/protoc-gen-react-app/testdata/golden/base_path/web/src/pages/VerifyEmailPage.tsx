@@ -0,0 +1,43 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import { Loading } from '../components/ui/Loading';
+import { authEmailApi } from '../api';
+
+export default function VerifyEmailPage() {
+  const [searchParams] = useSearchParams();
+  const [status, setStatus] = useState<'loading' | 'success' | 'error'>('loading');
+  const [error, setError] = useState('');
+  const token = searchParams.get('token');
+
+  useEffect(() => {
+    if (!token) { setStatus('error'); setError('Invalid verification link'); return; }
+    authEmailApi.verifyEmail(token)
+      .then(() => setStatus('success'))
+      .catch(err => { setStatus('error'); setError(err.message); });
+  }, [token]);
+
+  if (status === 'loading') return <Loading fullScreen />;
+
+  return (
+    <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+      <div className="card p-8 max-w-md w-full text-center">
+        {status === 'success' ? (
+          <>
+            <div className="text-5xl mb-4">✅</div>
+            <h1 className="text-2xl font-bold mb-2">Email verified!</h1>
+            <p className="text-gray-500 mb-6">Your email has been successfully verified.</p>
+          </>
+        ) : (
+          <>
+            <div className="text-5xl mb-4">❌</div>
+            <h1 className="text-2xl font-bold mb-2">Verification failed</h1>
+            <p className="text-gray-500 mb-6">{error}</p>
+          </>
+        )}
+        <Link to="/login" className="btn btn-primary">Go to Login</Link>
+      </div>
+    </div>
+  );
+}
+
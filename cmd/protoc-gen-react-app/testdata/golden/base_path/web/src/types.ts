@@ -0,0 +1,48 @@
+// Generated by protoc-gen-react-app
+
+export interface User {
+  id: string;
+  email?: string;
+  name?: string;
+  phone?: string;
+  auth_email?: AuthEmail;
+  auth_oauth?: AuthOAuth;
+  stripe?: StripeCustomer;
+  notifications?: NotificationPrefs;
+  created_at?: string;
+  updated_at?: string;
+  etag?: string;
+}
+
+export interface UserInput {
+  email?: string;
+  name?: string;
+  phone?: string;
+  auth_email?: AuthEmail;
+  auth_oauth?: AuthOAuth;
+  stripe?: StripeCustomer;
+  notifications?: NotificationPrefs;
+  created_at?: string;
+  updated_at?: string;
+  etag?: string;
+}
+
+export interface GetUserRequest {
+  id: string;
+}
+
+export interface GetUserRequestInput {
+}
+
+export interface PaginatedResponse<T> {
+  items: T[];
+  total: number;
+  page: number;
+  pageSize: number;
+}
+
+export interface ApiError {
+  message: string;
+  code?: string;
+}
+
@@ -0,0 +1,64 @@
+// Generated by protoc-gen-react-app
+import { BrowserRouter, Routes, Route, Navigate } from 'react-router-dom';
+import { Suspense, lazy } from 'react';
+import { AuthProvider, useAuth } from './context/AuthContext';
+import { ToastProvider } from './components/ui/Toast';
+import { Loading } from './components/ui/Loading';
+import { Layout } from './components/Layout';
+
+// Lazy load pages
+const DashboardPage = lazy(() => import('./pages/DashboardPage'));
+const LoginPage = lazy(() => import('./pages/LoginPage'));
+const SignupPage = lazy(() => import('./pages/SignupPage'));
+const ContactListPage = lazy(() => import('./pages/ContactListPage'));
+const ContactDetailPage = lazy(() => import('./pages/ContactDetailPage'));
+const ContactCreatePage = lazy(() => import('./pages/ContactCreatePage'));
+const ContactEditPage = lazy(() => import('./pages/ContactEditPage'));
+const MemberListPage = lazy(() => import('./pages/MemberListPage'));
+const MemberDetailPage = lazy(() => import('./pages/MemberDetailPage'));
+const MemberCreatePage = lazy(() => import('./pages/MemberCreatePage'));
+const MemberEditPage = lazy(() => import('./pages/MemberEditPage'));
+const ForgotPasswordPage = lazy(() => import('./pages/ForgotPasswordPage'));
+const ResetPasswordPage = lazy(() => import('./pages/ResetPasswordPage'));
+const VerifyEmailPage = lazy(() => import('./pages/VerifyEmailPage'));
+const SettingsPage = lazy(() => import('./pages/SettingsPage'));
+const ProfilePage = lazy(() => import('./pages/ProfilePage'));
+
+function ProtectedRoute({ children }: { children: React.ReactNode }) {
+  const { isAuthenticated, isLoading } = useAuth();
+  if (isLoading) return <Loading fullScreen />;
+  if (!isAuthenticated) return <Navigate to="/login" />;
+  return <>{children}</>;
+}
+
+export default function App() {
+  return (
+    <AuthProvider>
+      <ToastProvider>
+        <BrowserRouter>
+          <Routes>
+            <Route path="/login" element={<Suspense fallback={<Loading fullScreen />}><LoginPage /></Suspense>} />
+            <Route path="/signup" element={<Suspense fallback={<Loading fullScreen />}><SignupPage /></Suspense>} />
+        <Route path="/forgot-password" element={<Suspense fallback={<Loading fullScreen />}><ForgotPasswordPage /></Suspense>} />
+        <Route path="/reset-password" element={<Suspense fallback={<Loading fullScreen />}><ResetPasswordPage /></Suspense>} />
+        <Route path="/verify-email" element={<Suspense fallback={<Loading fullScreen />}><VerifyEmailPage /></Suspense>} />
+        <Route path="/settings" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><SettingsPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/profile" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ProfilePage /></Suspense></Layout></ProtectedRoute>} />
+            <Route path="/" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><DashboardPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/contact" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ContactListPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/contact/new" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ContactCreatePage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/contact/:id" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ContactDetailPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/contact/:id/edit" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><ContactEditPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/member" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><MemberListPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/member/new" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><MemberCreatePage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/member/:id" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><MemberDetailPage /></Suspense></Layout></ProtectedRoute>} />
+        <Route path="/member/:id/edit" element={<ProtectedRoute><Layout><Suspense fallback={<Loading />}><MemberEditPage /></Suspense></Layout></ProtectedRoute>} />
+
+            <Route path="*" element={<Navigate to="/" />} />
+          </Routes>
+        </BrowserRouter>
+      </ToastProvider>
+    </AuthProvider>
+  );
+}
+
@@ -0,0 +1,65 @@
+// Generated by protoc-gen-react-app
+const API_BASE = import.meta.env.VITE_API_URL || '/api';
+
+async function request<T>(url: string, options?: RequestInit): Promise<T> {
+  const token = localStorage.getItem('token');
+  const res = await fetch(API_BASE + url, {
+    ...options,
+    headers: {
+      'Content-Type': 'application/json',
+      ...(token ? { Authorization: 'Bearer ' + token } : {}),
+      ...options?.headers,
+    },
+  });
+  if (!res.ok) {
+    const error = await res.json().catch(() => ({ message: res.statusText }));
+    throw Object.assign(new Error(error.message || 'Request failed'), { code: error.code, status: res.status });
+  }
+  return res.json();
+}
+
+// Auth API
+export const authApi = {
+  login: (email: string, password: string) => 
+    request<{ token: string; user: any }>('/auth/login', { method: 'POST', body: JSON.stringify({ email, password }) }),
+  signup: (name: string, email: string, password: string) => 
+    request<{ token: string; user: any }>('/auth/signup', { method: 'POST', body: JSON.stringify({ name, email, password }) }),
+  logout: () => request<void>('/auth/logout', { method: 'POST' }),
+  me: () => request<any>('/auth/me'),
+};
+
+// Contact API
+export const contactApi = {
+  list: (params?: { page?: number; pageSize?: number; search?: string }) => 
+    request<Contact[]>('/contacts?' + new URLSearchParams(params as any).toString()),
+  get: (id: string) => request<Contact>('/contacts/' + id),
+  create: (data: ContactInput) => request<Contact>('/contacts', { method: 'POST', body: JSON.stringify(data) }),
+  update: (id: string, data: ContactInput) => request<Contact>('/contacts/' + id, { method: 'PUT', body: JSON.stringify(data) }),
+  delete: (id: string) => request<void>('/contacts/' + id, { method: 'DELETE' }),
+};
+
+// Member API
+export const memberApi = {
+  list: (params?: { page?: number; pageSize?: number; search?: string }) => 
+    request<Member[]>('/members?' + new URLSearchParams(params as any).toString()),
+  get: (id: string) => request<Member>('/members/' + id),
+  create: (data: MemberInput) => request<Member>('/members', { method: 'POST', body: JSON.stringify(data) }),
+  update: (id: string, data: MemberInput) => request<Member>('/members/' + id, { method: 'PUT', body: JSON.stringify(data) }),
+  delete: (id: string) => request<void>('/members/' + id, { method: 'DELETE' }),
+};
+
+// Auth Email API
+export const authEmailApi = {
+  forgotPassword: (email: string) => 
+    request<void>('/auth/forgot-password', { method: 'POST', body: JSON.stringify({ email }) }),
+  resetPassword: (token: string, password: string) => 
+    request<void>('/auth/reset-password', { method: 'POST', body: JSON.stringify({ token, password }) }),
+  verifyEmail: (token: string) => 
+    request<void>('/auth/verify-email', { method: 'POST', body: JSON.stringify({ token }) }),
+  resendVerification: () => 
+    request<void>('/auth/resend-verification', { method: 'POST' }),
+  refreshToken: (refreshToken: string) => 
+    request<{ token: string; refreshToken: string }>('/auth/refresh', { method: 'POST', body: JSON.stringify({ refreshToken }) }),
+};
+
+
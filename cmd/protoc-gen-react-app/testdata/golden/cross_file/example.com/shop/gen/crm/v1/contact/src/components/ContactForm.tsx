@@ -0,0 +1,75 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Input, Select, Checkbox } from './ui/Input';
+import { Button } from './ui/Button';
+import type { Contact, ContactInput } from '../types';
+
+interface ContactFormProps {
+  initialData?: Contact;
+  onSubmit: (data: ContactInput) => Promise<void>;
+  loading?: boolean;
+}
+
+export function ContactForm({ initialData, onSubmit, loading }: ContactFormProps) {
+  const [form, setForm] = useState<ContactInput>({
+    contact_id: initialData?.contact_id ?? '',
+    email: initialData?.email ?? '',
+    name: initialData?.name ?? '',
+    org_id: initialData?.org_id ?? '',
+    created_at: initialData?.created_at ?? '',
+    updated_at: initialData?.updated_at ?? '',
+  });
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    await onSubmit(form);
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <Input
+        label="ContactId"
+        type="text"
+        value={form.contact_id}
+        onChange={e => setForm(f => ({ ...f, contact_id: e.target.value }))}
+      />
+      <Input
+        label="Email"
+        type="text"
+        value={form.email}
+        onChange={e => setForm(f => ({ ...f, email: e.target.value }))}
+      />
+      <Input
+        label="Name"
+        type="text"
+        value={form.name}
+        onChange={e => setForm(f => ({ ...f, name: e.target.value }))}
+      />
+      <Input
+        label="OrgId"
+        type="text"
+        value={form.org_id}
+        onChange={e => setForm(f => ({ ...f, org_id: e.target.value }))}
+      />
+      <Input
+        label="CreatedAt"
+        type="datetime-local"
+        value={form.created_at}
+        onChange={e => setForm(f => ({ ...f, created_at: e.target.value }))}
+      />
+      <Input
+        label="UpdatedAt"
+        type="datetime-local"
+        value={form.updated_at}
+        onChange={e => setForm(f => ({ ...f, updated_at: e.target.value }))}
+      />
+
+      <div className="flex gap-3 pt-4">
+        <Button type="submit" loading={loading}>
+          {initialData ? 'Save Changes' : 'Create Contact'}
+        </Button>
+      </div>
+    </form>
+  );
+}
+
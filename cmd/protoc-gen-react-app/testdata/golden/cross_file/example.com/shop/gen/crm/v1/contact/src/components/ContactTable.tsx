@@ -0,0 +1,43 @@
+// Generated by protoc-gen-react-app
+import { Table } from './ui/Table';
+import { Button } from './ui/Button';
+import { Dropdown } from './ui/Dropdown';
+import type { Contact } from '../types';
+
+interface ContactTableProps {
+  data: Contact[];
+  loading?: boolean;
+  onView?: (item: Contact) => void;
+  onEdit?: (item: Contact) => void;
+  onDelete?: (item: Contact) => void;
+}
+
+export function ContactTable({ data, loading, onView, onEdit, onDelete }: ContactTableProps) {
+  const columns = [
+    { key: 'contact_id', header: 'ContactId' },
+    { key: 'email', header: 'Email' },
+    { key: 'name', header: 'Name' },
+    { key: 'org_id', header: 'OrgId' },
+    { key: 'created_at', header: 'CreatedAt' },
+    { key: 'updated_at', header: 'UpdatedAt' },
+    {
+      key: 'actions',
+      header: '',
+      className: 'w-12',
+      render: (item: Contact) => (
+        <Dropdown
+          trigger={<button className="p-1 hover:bg-gray-100 rounded">⋯</button>}
+          items={[
+            { label: 'View', onClick: () => onView?.(item) },
+            { label: 'Edit', onClick: () => onEdit?.(item) },
+            { type: 'divider' },
+            { label: 'Delete', onClick: () => onDelete?.(item), className: 'text-red-600' },
+          ]}
+        />
+      ),
+    },
+  ];
+
+  return <Table columns={columns} data={data} loading={loading} onRowClick={onView} />;
+}
+
@@ -0,0 +1,54 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Input, Select, Checkbox } from './ui/Input';
+import { Button } from './ui/Button';
+import type { Member, MemberInput } from '../types';
+
+interface MemberFormProps {
+  initialData?: Member;
+  onSubmit: (data: MemberInput) => Promise<void>;
+  loading?: boolean;
+}
+
+export function MemberForm({ initialData, onSubmit, loading }: MemberFormProps) {
+  const [form, setForm] = useState<MemberInput>({
+    email: initialData?.email ?? '',
+    name: initialData?.name ?? '',
+    auth: initialData?.auth ?? '',
+  });
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    await onSubmit(form);
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-4">
+      <Input
+        label="Email"
+        type="text"
+        value={form.email}
+        onChange={e => setForm(f => ({ ...f, email: e.target.value }))}
+      />
+      <Input
+        label="Name"
+        type="text"
+        value={form.name}
+        onChange={e => setForm(f => ({ ...f, name: e.target.value }))}
+      />
+      <Input
+        label="Auth"
+        type="text"
+        value={form.auth}
+        onChange={e => setForm(f => ({ ...f, auth: e.target.value }))}
+      />
+
+      <div className="flex gap-3 pt-4">
+        <Button type="submit" loading={loading}>
+          {initialData ? 'Save Changes' : 'Create Member'}
+        </Button>
+      </div>
+    </form>
+  );
+}
+
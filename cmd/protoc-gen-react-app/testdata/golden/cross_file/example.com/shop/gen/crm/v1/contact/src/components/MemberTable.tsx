@@ -0,0 +1,41 @@
+// Generated by protoc-gen-react-app
+import { Table } from './ui/Table';
+import { Button } from './ui/Button';
+import { Dropdown } from './ui/Dropdown';
+import type { Member } from '../types';
+
+interface MemberTableProps {
+  data: Member[];
+  loading?: boolean;
+  onView?: (item: Member) => void;
+  onEdit?: (item: Member) => void;
+  onDelete?: (item: Member) => void;
+}
+
+export function MemberTable({ data, loading, onView, onEdit, onDelete }: MemberTableProps) {
+  const columns = [
+    { key: 'id', header: 'Id' },
+    { key: 'email', header: 'Email' },
+    { key: 'name', header: 'Name' },
+    { key: 'auth', header: 'Auth' },
+    {
+      key: 'actions',
+      header: '',
+      className: 'w-12',
+      render: (item: Member) => (
+        <Dropdown
+          trigger={<button className="p-1 hover:bg-gray-100 rounded">⋯</button>}
+          items={[
+            { label: 'View', onClick: () => onView?.(item) },
+            { label: 'Edit', onClick: () => onEdit?.(item) },
+            { type: 'divider' },
+            { label: 'Delete', onClick: () => onDelete?.(item), className: 'text-red-600' },
+          ]}
+        />
+      ),
+    },
+  ];
+
+  return <Table columns={columns} data={data} loading={loading} onRowClick={onView} />;
+}
+
@@ -0,0 +1,79 @@
+// Generated by protoc-gen-react-app
+import { Link, useLocation } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+
+const mainNavigation = [
+  { name: 'Dashboard', href: '/', icon: '📊' },
+    { name: 'Contacts', href: '/contact', icon: '📋' },
+    { name: 'Members', href: '/member', icon: '📋' },
+];
+
+const settingsNavigation = [
+  { name: 'Profile', href: '/profile', icon: '👤' },
+];
+
+const publicNavigation = [
+];
+
+export function Sidebar() {
+  const location = useLocation();
+  const { user } = useAuth();
+
+  const isActive = (href: string) => 
+    location.pathname === href || (href !== '/' && location.pathname.startsWith(href));
+
+  const NavLink = ({ item }: { item: { name: string; href: string; icon: string } }) => (
+    <Link
+      to={item.href}
+      className={"flex items-center px-3 py-2 rounded-lg text-sm font-medium transition-colors " +
+        (isActive(item.href) 
+          ? "bg-indigo-600 text-white" 
+          : "text-gray-300 hover:bg-gray-800 hover:text-white"
+        )}
+    >
+      <span className="mr-3 text-lg">{item.icon}</span>
+      {item.name}
+    </Link>
+  );
+
+  return (
+    <aside className="fixed inset-y-0 left-0 w-64 bg-gray-900 text-white flex flex-col">
+      <div className="flex items-center h-16 px-6 border-b border-gray-800">
+        <span className="text-xl font-bold">Admin</span>
+      </div>
+      
+      <nav className="flex-1 overflow-y-auto py-4 px-3">
+        <div className="space-y-1">
+          {mainNavigation.map((item) => <NavLink key={item.href} item={item} />)}
+        </div>
+        
+        {publicNavigation.length > 0 && (
+          <>
+            <div className="mt-8 mb-2 px-3 text-xs font-semibold text-gray-400 uppercase">Public</div>
+            <div className="space-y-1">
+              {publicNavigation.map((item) => <NavLink key={item.href} item={item} />)}
+            </div>
+          </>
+        )}
+        
+        <div className="mt-8 mb-2 px-3 text-xs font-semibold text-gray-400 uppercase">Settings</div>
+        <div className="space-y-1">
+          {settingsNavigation.map((item) => <NavLink key={item.href} item={item} />)}
+        </div>
+      </nav>
+      
+      <div className="p-4 border-t border-gray-800">
+        <div className="flex items-center">
+          <div className="w-10 h-10 bg-indigo-600 rounded-full flex items-center justify-center text-sm font-medium">
+            {user?.name?.[0]?.toUpperCase() || 'A'}
+          </div>
+          <div className="ml-3 overflow-hidden">
+            <p className="text-sm font-medium truncate">{user?.name || 'Admin User'}</p>
+            <p className="text-xs text-gray-400 truncate">{user?.email || 'admin@example.com'}</p>
+          </div>
+        </div>
+      </div>
+    </aside>
+  );
+}
+
@@ -0,0 +1,53 @@
+// Generated by protoc-gen-react-app
+import { useNavigate } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { Breadcrumb } from './Breadcrumb';
+import { Dropdown } from './ui/Dropdown';
+import { SearchInput } from './ui/SearchInput';
+
+export function Topbar() {
+  const { logout, user } = useAuth();
+  const navigate = useNavigate();
+
+  const handleLogout = () => {
+    logout();
+    navigate('/login');
+  };
+
+  return (
+    <header className="h-16 bg-white border-b border-gray-200 flex items-center justify-between px-6">
+      <div className="flex items-center gap-4">
+        <Breadcrumb />
+      </div>
+      
+      <div className="flex items-center gap-4">
+        <SearchInput 
+          placeholder="Search..." 
+          className="w-64"
+          onSearch={(q) => console.log('Search:', q)}
+        />
+        
+        <button className="p-2 text-gray-500 hover:text-gray-700 hover:bg-gray-100 rounded-lg relative">
+          🔔
+        </button>
+        
+        <Dropdown
+          trigger={
+            <button className="flex items-center gap-2 p-2 hover:bg-gray-100 rounded-lg">
+              <div className="w-8 h-8 bg-indigo-600 rounded-full flex items-center justify-center text-white text-sm font-medium">
+                {user?.name?.[0] || 'A'}
+              </div>
+            </button>
+          }
+          items={[
+            { label: 'Profile', onClick: () => navigate('/profile') },
+            { label: 'Settings', onClick: () => navigate('/settings') },
+            { type: 'divider' },
+            { label: 'Logout', onClick: handleLogout, className: 'text-red-600' },
+          ]}
+        />
+      </div>
+    </header>
+  );
+}
+
@@ -0,0 +1,51 @@
+// Generated by protoc-gen-react-app
+import { useState, useRef, useEffect } from 'react';
+
+interface DropdownItem {
+  label?: string;
+  onClick?: () => void;
+  type?: 'divider';
+  className?: string;
+}
+
+interface DropdownProps {
+  trigger: React.ReactNode;
+  items: DropdownItem[];
+}
+
+export function Dropdown({ trigger, items }: DropdownProps) {
+  const [open, setOpen] = useState(false);
+  const ref = useRef<HTMLDivElement>(null);
+
+  useEffect(() => {
+    const handleClick = (e: MouseEvent) => {
+      if (ref.current && !ref.current.contains(e.target as Node)) setOpen(false);
+    };
+    document.addEventListener('mousedown', handleClick);
+    return () => document.removeEventListener('mousedown', handleClick);
+  }, []);
+
+  return (
+    <div ref={ref} className="relative">
+      <div onClick={() => setOpen(!open)}>{trigger}</div>
+      {open && (
+        <div className="absolute right-0 mt-2 w-48 bg-white rounded-lg shadow-lg border py-1 z-50">
+          {items.map((item, i) => 
+            item.type === 'divider' ? (
+              <div key={i} className="border-t my-1" />
+            ) : (
+              <button
+                key={i}
+                onClick={() => { item.onClick?.(); setOpen(false); }}
+                className={"w-full px-4 py-2 text-left text-sm hover:bg-gray-50 " + (item.className || '')}
+              >
+                {item.label}
+              </button>
+            )
+          )}
+        </div>
+      )}
+    </div>
+  );
+}
+
@@ -0,0 +1,19 @@
+// Generated by protoc-gen-react-app
+interface EmptyStateProps {
+  icon?: string;
+  title: string;
+  description?: string;
+  action?: React.ReactNode;
+}
+
+export function EmptyState({ icon = '📭', title, description, action }: EmptyStateProps) {
+  return (
+    <div className="text-center py-12">
+      <span className="text-5xl">{icon}</span>
+      <h3 className="mt-4 text-lg font-medium text-gray-900">{title}</h3>
+      {description && <p className="mt-2 text-gray-500">{description}</p>}
+      {action && <div className="mt-6">{action}</div>}
+    </div>
+  );
+}
+
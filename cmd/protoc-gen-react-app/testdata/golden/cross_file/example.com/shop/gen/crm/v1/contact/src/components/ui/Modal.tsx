@@ -0,0 +1,78 @@
+// Generated by protoc-gen-react-app
+import { useEffect } from 'react';
+
+interface ModalProps {
+  isOpen: boolean;
+  onClose: () => void;
+  title?: string;
+  children: React.ReactNode;
+  size?: 'sm' | 'md' | 'lg' | 'xl';
+}
+
+export function Modal({ isOpen, onClose, title, children, size = 'md' }: ModalProps) {
+  useEffect(() => {
+    const handleEsc = (e: KeyboardEvent) => e.key === 'Escape' && onClose();
+    if (isOpen) {
+      document.addEventListener('keydown', handleEsc);
+      document.body.style.overflow = 'hidden';
+    }
+    return () => {
+      document.removeEventListener('keydown', handleEsc);
+      document.body.style.overflow = '';
+    };
+  }, [isOpen, onClose]);
+
+  if (!isOpen) return null;
+
+  const sizes = {
+    sm: 'max-w-md',
+    md: 'max-w-lg',
+    lg: 'max-w-2xl',
+    xl: 'max-w-4xl',
+  };
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center p-4">
+      <div className="fixed inset-0 bg-black/50" onClick={onClose} />
+      <div className={"relative bg-white rounded-xl shadow-xl w-full " + sizes[size]}>
+        {title && (
+          <div className="flex items-center justify-between px-6 py-4 border-b">
+            <h2 className="text-lg font-semibold">{title}</h2>
+            <button onClick={onClose} className="text-gray-400 hover:text-gray-600">✕</button>
+          </div>
+        )}
+        <div className="p-6">{children}</div>
+      </div>
+    </div>
+  );
+}
+
+interface ConfirmModalProps {
+  isOpen: boolean;
+  onClose: () => void;
+  onConfirm: () => void;
+  title: string;
+  message: string;
+  confirmText?: string;
+  cancelText?: string;
+  variant?: 'danger' | 'warning';
+}
+
+export function ConfirmModal({ isOpen, onClose, onConfirm, title, message, confirmText = 'Confirm', cancelText = 'Cancel', variant = 'danger' }: ConfirmModalProps) {
+  return (
+    <Modal isOpen={isOpen} onClose={onClose} size="sm">
+      <div className="text-center">
+        <div className={"mx-auto w-12 h-12 rounded-full flex items-center justify-center mb-4 " + (variant === 'danger' ? 'bg-red-100' : 'bg-yellow-100')}>
+          {variant === 'danger' ? '⚠️' : '❓'}
+        </div>
+        <h3 className="text-lg font-semibold mb-2">{title}</h3>
+        <p className="text-gray-500 mb-6">{message}</p>
+        <div className="flex gap-3 justify-center">
+          <button onClick={onClose} className="btn btn-secondary">{cancelText}</button>
+          <button onClick={() => { onConfirm(); onClose(); }} className={"btn " + (variant === 'danger' ? 'btn-danger' : 'btn-primary')}>{confirmText}</button>
+        </div>
+      </div>
+    </Modal>
+  );
+}
+
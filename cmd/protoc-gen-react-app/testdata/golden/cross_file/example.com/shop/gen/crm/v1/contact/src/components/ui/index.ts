@@ -0,0 +1,14 @@
+// Generated by protoc-gen-react-app
+export * from './Button';
+export * from './Input';
+export * from './Table';
+export * from './Modal';
+export * from './Card';
+export * from './Badge';
+export * from './Dropdown';
+export * from './Toast';
+export * from './Loading';
+export * from './EmptyState';
+export * from './Pagination';
+export * from './SearchInput';
+
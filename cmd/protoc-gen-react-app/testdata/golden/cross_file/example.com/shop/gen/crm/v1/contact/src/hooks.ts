@@ -0,0 +1,195 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect, useCallback } from 'react';
+import { useToast } from './components/ui/Toast';
+
+import { contactApi } from './api';
+import type { Contact, ContactInput } from './types';
+
+export function useContactList() {
+  const [items, setItems] = useState<Contact[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const { showToast } = useToast();
+
+  const fetch = useCallback(async (params?: { search?: string }) => {
+    setLoading(true);
+    try {
+      const data = await contactApi.list(params);
+      setItems(data);
+      setError(null);
+    } catch (e: any) {
+      setError(e.message);
+      showToast(e.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  }, [showToast]);
+
+  useEffect(() => { fetch(); }, [fetch]);
+
+  return { items, loading, error, refetch: fetch };
+}
+
+export function useContact(id: string | undefined) {
+  const [item, setItem] = useState<Contact | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!id) return;
+    setLoading(true);
+    contactApi.get(id)
+      .then(setItem)
+      .catch(e => setError(e.message))
+      .finally(() => setLoading(false));
+  }, [id]);
+
+  return { item, loading, error };
+}
+
+export function useContactMutations() {
+  const [loading, setLoading] = useState(false);
+  const { showToast } = useToast();
+
+  const create = async (data: ContactInput) => {
+    setLoading(true);
+    try {
+      const result = await contactApi.create(data);
+      showToast('Contact created successfully', 'success');
+      return result;
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const update = async (id: string, data: ContactInput) => {
+    setLoading(true);
+    try {
+      const result = await contactApi.update(id, data);
+      showToast('Contact updated successfully', 'success');
+      return result;
+    } catch (e: any) {
+      // aborted: the contact changed since it was loaded
+      const conflict = e.code === 'aborted' || e.status === 409;
+      showToast(conflict ? 'Contact was changed by someone else. Reload it and try again.' : e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const remove = async (id: string) => {
+    setLoading(true);
+    try {
+      await contactApi.delete(id);
+      showToast('Contact deleted successfully', 'success');
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return { create, update, remove, loading };
+}
+
+import { memberApi } from './api';
+import type { Member, MemberInput } from './types';
+
+export function useMemberList() {
+  const [items, setItems] = useState<Member[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const { showToast } = useToast();
+
+  const fetch = useCallback(async (params?: { search?: string }) => {
+    setLoading(true);
+    try {
+      const data = await memberApi.list(params);
+      setItems(data);
+      setError(null);
+    } catch (e: any) {
+      setError(e.message);
+      showToast(e.message, 'error');
+    } finally {
+      setLoading(false);
+    }
+  }, [showToast]);
+
+  useEffect(() => { fetch(); }, [fetch]);
+
+  return { items, loading, error, refetch: fetch };
+}
+
+export function useMember(id: string | undefined) {
+  const [item, setItem] = useState<Member | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!id) return;
+    setLoading(true);
+    memberApi.get(id)
+      .then(setItem)
+      .catch(e => setError(e.message))
+      .finally(() => setLoading(false));
+  }, [id]);
+
+  return { item, loading, error };
+}
+
+export function useMemberMutations() {
+  const [loading, setLoading] = useState(false);
+  const { showToast } = useToast();
+
+  const create = async (data: MemberInput) => {
+    setLoading(true);
+    try {
+      const result = await memberApi.create(data);
+      showToast('Member created successfully', 'success');
+      return result;
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const update = async (id: string, data: MemberInput) => {
+    setLoading(true);
+    try {
+      const result = await memberApi.update(id, data);
+      showToast('Member updated successfully', 'success');
+      return result;
+    } catch (e: any) {
+      // aborted: the member changed since it was loaded
+      const conflict = e.code === 'aborted' || e.status === 409;
+      showToast(conflict ? 'Member was changed by someone else. Reload it and try again.' : e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const remove = async (id: string) => {
+    setLoading(true);
+    try {
+      await memberApi.delete(id);
+      showToast('Member deleted successfully', 'success');
+    } catch (e: any) {
+      showToast(e.message, 'error');
+      throw e;
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return { create, update, remove, loading };
+}
+
+
@@ -0,0 +1,34 @@
+// Generated by protoc-gen-react-app
+import { useNavigate } from 'react-router-dom';
+import { useContactMutations } from '../hooks';
+import { ContactForm } from '../components/ContactForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import type { ContactInput } from '../types';
+
+export default function ContactCreatePage() {
+  const navigate = useNavigate();
+  const { create, loading } = useContactMutations();
+
+  const handleSubmit = async (data: ContactInput) => {
+    const result = await create(data);
+    navigate('/contact/' + result.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Create Contact</h1>
+          <p className="page-subtitle">Add a new contact to the system</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/contact')}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <ContactForm onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
@@ -0,0 +1,80 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useParams, useNavigate } from 'react-router-dom';
+import { useContact, useContactMutations } from '../hooks';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import { Badge } from '../components/ui/Badge';
+import { ConfirmModal } from '../components/ui/Modal';
+
+export default function ContactDetailPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading, error } = useContact(id);
+  const { remove, loading: deleting } = useContactMutations();
+  const [showDelete, setShowDelete] = useState(false);
+
+  if (loading) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleDelete = async () => {
+    await remove(item.id);
+    navigate('/contact');
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Contact Details</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <div className="flex gap-2">
+          <Button variant="secondary" onClick={() => navigate('/contact')}>← Back</Button>
+          <Button variant="secondary" onClick={() => navigate('/contact/' + item.id + '/edit')}>Edit</Button>
+          <Button variant="danger" onClick={() => setShowDelete(true)}>Delete</Button>
+        </div>
+      </div>
+
+      <Card>
+        <dl className="grid grid-cols-1 md:grid-cols-2 gap-6">
+          <div>
+            <dt className="text-sm text-gray-500">ContactId</dt>
+            <dd className="mt-1 text-gray-900">{item.contact_id ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Email</dt>
+            <dd className="mt-1 text-gray-900">{item.email ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Name</dt>
+            <dd className="mt-1 text-gray-900">{item.name ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">OrgId</dt>
+            <dd className="mt-1 text-gray-900">{item.org_id ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">CreatedAt</dt>
+            <dd className="mt-1 text-gray-900">{item.created_at ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">UpdatedAt</dt>
+            <dd className="mt-1 text-gray-900">{item.updated_at ?? '-'}</dd>
+          </div>
+        </dl>
+      </Card>
+
+      <ConfirmModal
+        isOpen={showDelete}
+        onClose={() => setShowDelete(false)}
+        onConfirm={handleDelete}
+        title="Delete Contact"
+        message="Are you sure? This cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,40 @@
+// Generated by protoc-gen-react-app
+import { useParams, useNavigate } from 'react-router-dom';
+import { useContact, useContactMutations } from '../hooks';
+import { ContactForm } from '../components/ContactForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import type { ContactInput } from '../types';
+
+export default function ContactEditPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading: fetching, error } = useContact(id);
+  const { update, loading } = useContactMutations();
+
+  if (fetching) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleSubmit = async (data: ContactInput) => {
+    await update(item.id, data);
+    navigate('/contact/' + item.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Edit Contact</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/contact/' + item.id)}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <ContactForm initialData={item} onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
@@ -0,0 +1,72 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { useContactList, useContactMutations } from '../hooks';
+import { ContactTable } from '../components/ContactTable';
+import { Button } from '../components/ui/Button';
+import { SearchInput } from '../components/ui/SearchInput';
+import { ConfirmModal } from '../components/ui/Modal';
+import { EmptyState } from '../components/ui/EmptyState';
+
+export default function ContactListPage() {
+  const navigate = useNavigate();
+  const { items, loading, refetch } = useContactList();
+  const { remove, loading: deleting } = useContactMutations();
+  const [deleteId, setDeleteId] = useState<string | null>(null);
+  const [search, setSearch] = useState('');
+
+  const filtered = items.filter(item => 
+    JSON.stringify(item).toLowerCase().includes(search.toLowerCase())
+  );
+
+  const handleDelete = async () => {
+    if (deleteId) {
+      await remove(deleteId);
+      setDeleteId(null);
+      refetch();
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Contacts</h1>
+          <p className="page-subtitle">{items.length} total contacts</p>
+        </div>
+        <Button onClick={() => navigate('/contact/new')}>+ Add Contact</Button>
+      </div>
+
+      <div className="mb-6">
+        <SearchInput onSearch={setSearch} placeholder="Search contacts..." className="max-w-md" />
+      </div>
+
+      {!loading && filtered.length === 0 ? (
+        <EmptyState
+          icon="📋"
+          title="No Contacts yet"
+          description="Get started by creating your first contact."
+          action={<Button onClick={() => navigate('/contact/new')}>Create Contact</Button>}
+        />
+      ) : (
+        <ContactTable
+          data={filtered}
+          loading={loading}
+          onView={(item) => navigate('/contact/' + item.id)}
+          onEdit={(item) => navigate('/contact/' + item.id + '/edit')}
+          onDelete={(item) => setDeleteId(item.id)}
+        />
+      )}
+
+      <ConfirmModal
+        isOpen={!!deleteId}
+        onClose={() => setDeleteId(null)}
+        onConfirm={handleDelete}
+        title="Delete Contact"
+        message="Are you sure you want to delete this contact? This action cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,46 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Link } from 'react-router-dom';
+import { StatCard, Card, CardHeader } from '../components/ui/Card';
+
+export default function DashboardPage() {
+  const [stats, setStats] = useState({
+    contact: 0,
+    member: 0,
+  });
+
+  useEffect(() => {
+    // TODO: Fetch real stats from API
+  }, []);
+
+  return (
+    <div>
+      <div className="page-header">
+        <h1 className="page-title">Dashboard</h1>
+        <p className="page-subtitle">Welcome back! Here's an overview of your data.</p>
+      </div>
+
+      <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-4 gap-6 mb-8">
+      <StatCard title="Total Contacts" value={stats.contact} icon="📋" />
+      <StatCard title="Total Members" value={stats.member} icon="📋" />
+      </div>
+
+      <div className="grid grid-cols-1 lg:grid-cols-2 gap-6">
+        <Card>
+          <CardHeader title="Recent Activity" subtitle="Latest changes in the system" />
+          <div className="text-gray-500 text-center py-8">No recent activity</div>
+        </Card>
+        
+        <Card>
+          <CardHeader title="Quick Actions" />
+          <div className="space-y-2">
+            <Link to="/users/new" className="block p-3 rounded-lg hover:bg-gray-50 border">
+              + Create new user
+            </Link>
+          </div>
+        </Card>
+      </div>
+    </div>
+  );
+}
+
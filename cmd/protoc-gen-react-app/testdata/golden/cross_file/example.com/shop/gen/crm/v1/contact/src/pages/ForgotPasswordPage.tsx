@@ -0,0 +1,63 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Link } from 'react-router-dom';
+import { Input } from '../components/ui/Input';
+import { Button } from '../components/ui/Button';
+import { authEmailApi } from '../api';
+
+export default function ForgotPasswordPage() {
+  const [email, setEmail] = useState('');
+  const [sent, setSent] = useState(false);
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setLoading(true);
+    try {
+      await authEmailApi.forgotPassword(email);
+      setSent(true);
+    } catch (err: any) {
+      setError(err.message);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  if (sent) {
+    return (
+      <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+        <div className="card p-8 max-w-md w-full text-center">
+          <div className="text-5xl mb-4">📧</div>
+          <h1 className="text-2xl font-bold mb-2">Check your email</h1>
+          <p className="text-gray-500 mb-6">We sent a password reset link to {email}</p>
+          <Link to="/login" className="text-indigo-600 hover:underline">Back to login</Link>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold">Forgot password?</h1>
+          <p className="text-gray-500 mt-2">Enter your email to reset your password</p>
+        </div>
+
+        <div className="card p-8">
+          {error && <div className="mb-4 p-3 bg-red-50 text-red-700 rounded-lg text-sm">{error}</div>}
+          <form onSubmit={handleSubmit} className="space-y-4">
+            <Input label="Email" type="email" value={email} onChange={e => setEmail(e.target.value)} required />
+            <Button type="submit" className="w-full" loading={loading}>Send Reset Link</Button>
+          </form>
+          <p className="mt-6 text-center text-sm text-gray-500">
+            <Link to="/login" className="text-indigo-600 hover:underline">Back to login</Link>
+          </p>
+        </div>
+      </div>
+    </div>
+  );
+}
+
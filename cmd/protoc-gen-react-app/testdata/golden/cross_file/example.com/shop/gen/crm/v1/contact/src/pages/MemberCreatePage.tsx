@@ -0,0 +1,34 @@
+// Generated by protoc-gen-react-app
+import { useNavigate } from 'react-router-dom';
+import { useMemberMutations } from '../hooks';
+import { MemberForm } from '../components/MemberForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import type { MemberInput } from '../types';
+
+export default function MemberCreatePage() {
+  const navigate = useNavigate();
+  const { create, loading } = useMemberMutations();
+
+  const handleSubmit = async (data: MemberInput) => {
+    const result = await create(data);
+    navigate('/member/' + result.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Create Member</h1>
+          <p className="page-subtitle">Add a new member to the system</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/member')}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <MemberForm onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
@@ -0,0 +1,68 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useParams, useNavigate } from 'react-router-dom';
+import { useMember, useMemberMutations } from '../hooks';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import { Badge } from '../components/ui/Badge';
+import { ConfirmModal } from '../components/ui/Modal';
+
+export default function MemberDetailPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading, error } = useMember(id);
+  const { remove, loading: deleting } = useMemberMutations();
+  const [showDelete, setShowDelete] = useState(false);
+
+  if (loading) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleDelete = async () => {
+    await remove(item.id);
+    navigate('/member');
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Member Details</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <div className="flex gap-2">
+          <Button variant="secondary" onClick={() => navigate('/member')}>← Back</Button>
+          <Button variant="secondary" onClick={() => navigate('/member/' + item.id + '/edit')}>Edit</Button>
+          <Button variant="danger" onClick={() => setShowDelete(true)}>Delete</Button>
+        </div>
+      </div>
+
+      <Card>
+        <dl className="grid grid-cols-1 md:grid-cols-2 gap-6">
+          <div>
+            <dt className="text-sm text-gray-500">Email</dt>
+            <dd className="mt-1 text-gray-900">{item.email ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Name</dt>
+            <dd className="mt-1 text-gray-900">{item.name ?? '-'}</dd>
+          </div>
+          <div>
+            <dt className="text-sm text-gray-500">Auth</dt>
+            <dd className="mt-1 text-gray-900">{item.auth ?? '-'}</dd>
+          </div>
+        </dl>
+      </Card>
+
+      <ConfirmModal
+        isOpen={showDelete}
+        onClose={() => setShowDelete(false)}
+        onConfirm={handleDelete}
+        title="Delete Member"
+        message="Are you sure? This cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
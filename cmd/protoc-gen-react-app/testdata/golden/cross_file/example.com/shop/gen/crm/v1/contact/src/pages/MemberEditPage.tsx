@@ -0,0 +1,40 @@
+// Generated by protoc-gen-react-app
+import { useParams, useNavigate } from 'react-router-dom';
+import { useMember, useMemberMutations } from '../hooks';
+import { MemberForm } from '../components/MemberForm';
+import { Card } from '../components/ui/Card';
+import { Button } from '../components/ui/Button';
+import { Loading } from '../components/ui/Loading';
+import type { MemberInput } from '../types';
+
+export default function MemberEditPage() {
+  const { id } = useParams();
+  const navigate = useNavigate();
+  const { item, loading: fetching, error } = useMember(id);
+  const { update, loading } = useMemberMutations();
+
+  if (fetching) return <Loading />;
+  if (error || !item) return <div className="text-red-500">Error: {error || 'Not found'}</div>;
+
+  const handleSubmit = async (data: MemberInput) => {
+    await update(item.id, data);
+    navigate('/member/' + item.id);
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Edit Member</h1>
+          <p className="page-subtitle">ID: {item.id}</p>
+        </div>
+        <Button variant="secondary" onClick={() => navigate('/member/' + item.id)}>← Back</Button>
+      </div>
+
+      <Card className="max-w-2xl">
+        <MemberForm initialData={item} onSubmit={handleSubmit} loading={loading} />
+      </Card>
+    </div>
+  );
+}
+
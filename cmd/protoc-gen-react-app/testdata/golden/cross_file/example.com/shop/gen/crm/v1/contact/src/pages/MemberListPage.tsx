@@ -0,0 +1,72 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { useNavigate } from 'react-router-dom';
+import { useMemberList, useMemberMutations } from '../hooks';
+import { MemberTable } from '../components/MemberTable';
+import { Button } from '../components/ui/Button';
+import { SearchInput } from '../components/ui/SearchInput';
+import { ConfirmModal } from '../components/ui/Modal';
+import { EmptyState } from '../components/ui/EmptyState';
+
+export default function MemberListPage() {
+  const navigate = useNavigate();
+  const { items, loading, refetch } = useMemberList();
+  const { remove, loading: deleting } = useMemberMutations();
+  const [deleteId, setDeleteId] = useState<string | null>(null);
+  const [search, setSearch] = useState('');
+
+  const filtered = items.filter(item => 
+    JSON.stringify(item).toLowerCase().includes(search.toLowerCase())
+  );
+
+  const handleDelete = async () => {
+    if (deleteId) {
+      await remove(deleteId);
+      setDeleteId(null);
+      refetch();
+    }
+  };
+
+  return (
+    <div>
+      <div className="page-header flex items-center justify-between">
+        <div>
+          <h1 className="page-title">Members</h1>
+          <p className="page-subtitle">{items.length} total members</p>
+        </div>
+        <Button onClick={() => navigate('/member/new')}>+ Add Member</Button>
+      </div>
+
+      <div className="mb-6">
+        <SearchInput onSearch={setSearch} placeholder="Search members..." className="max-w-md" />
+      </div>
+
+      {!loading && filtered.length === 0 ? (
+        <EmptyState
+          icon="📋"
+          title="No Members yet"
+          description="Get started by creating your first member."
+          action={<Button onClick={() => navigate('/member/new')}>Create Member</Button>}
+        />
+      ) : (
+        <MemberTable
+          data={filtered}
+          loading={loading}
+          onView={(item) => navigate('/member/' + item.id)}
+          onEdit={(item) => navigate('/member/' + item.id + '/edit')}
+          onDelete={(item) => setDeleteId(item.id)}
+        />
+      )}
+
+      <ConfirmModal
+        isOpen={!!deleteId}
+        onClose={() => setDeleteId(null)}
+        onConfirm={handleDelete}
+        title="Delete Member"
+        message="Are you sure you want to delete this member? This action cannot be undone."
+        confirmText={deleting ? 'Deleting...' : 'Delete'}
+      />
+    </div>
+  );
+}
+
@@ -0,0 +1,56 @@
+// Generated by protoc-gen-react-app
+import { useState, useEffect } from 'react';
+import { Link, useSearchParams, useNavigate } from 'react-router-dom';
+import { Input } from '../components/ui/Input';
+import { Button } from '../components/ui/Button';
+import { authEmailApi } from '../api';
+
+export default function ResetPasswordPage() {
+  const [searchParams] = useSearchParams();
+  const navigate = useNavigate();
+  const [form, setForm] = useState({ password: '', confirm: '' });
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+  const token = searchParams.get('token');
+
+  useEffect(() => { if (!token) setError('Invalid reset link'); }, [token]);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (form.password !== form.confirm) { setError('Passwords do not match'); return; }
+    if (!token) { setError('Invalid reset link'); return; }
+    setError('');
+    setLoading(true);
+    try {
+      await authEmailApi.resetPassword(token, form.password);
+      navigate('/login?reset=success');
+    } catch (err: any) {
+      setError(err.message);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold">Reset password</h1>
+          <p className="text-gray-500 mt-2">Enter your new password</p>
+        </div>
+        <div className="card p-8">
+          {error && <div className="mb-4 p-3 bg-red-50 text-red-700 rounded-lg text-sm">{error}</div>}
+          <form onSubmit={handleSubmit} className="space-y-4">
+            <Input label="New Password" type="password" value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} required minLength={8} />
+            <Input label="Confirm Password" type="password" value={form.confirm} onChange={e => setForm(f => ({ ...f, confirm: e.target.value }))} required />
+            <Button type="submit" className="w-full" loading={loading} disabled={!token}>Reset Password</Button>
+          </form>
+          <p className="mt-6 text-center text-sm text-gray-500">
+            <Link to="/login" className="text-indigo-600 hover:underline">Back to login</Link>
+          </p>
+        </div>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,59 @@
+// Generated by protoc-gen-react-app
+import { useState } from 'react';
+import { Link, useNavigate } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { Input } from '../components/ui/Input';
+import { Button } from '../components/ui/Button';
+
+const API_BASE = import.meta.env.VITE_API_URL || '/api';
+
+export default function SignupPage() {
+  const navigate = useNavigate();
+  const { signup } = useAuth();
+  const [form, setForm] = useState({ name: '', email: '', password: '', confirm: '' });
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (form.password !== form.confirm) { setError('Passwords do not match'); return; }
+    setError('');
+    setLoading(true);
+    try {
+      await signup(form.name, form.email, form.password);
+      navigate('/');
+    } catch (err: any) {
+      setError(err.message || 'Signup failed');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="min-h-screen flex items-center justify-center bg-gray-50 px-4">
+      <div className="w-full max-w-md">
+        <div className="text-center mb-8">
+          <h1 className="text-3xl font-bold">Create account</h1>
+          <p className="text-gray-500 mt-2">Get started with your free account</p>
+        </div>
+
+        <div className="card p-8">
+          {error && <div className="mb-4 p-3 bg-red-50 text-red-700 rounded-lg text-sm">{error}</div>}
+
+          <form onSubmit={handleSubmit} className="space-y-4">
+            <Input label="Name" value={form.name} onChange={e => setForm(f => ({ ...f, name: e.target.value }))} required />
+            <Input label="Email" type="email" value={form.email} onChange={e => setForm(f => ({ ...f, email: e.target.value }))} required />
+            <Input label="Password" type="password" value={form.password} onChange={e => setForm(f => ({ ...f, password: e.target.value }))} required minLength={8} />
+            <Input label="Confirm Password" type="password" value={form.confirm} onChange={e => setForm(f => ({ ...f, confirm: e.target.value }))} required />
+            <Button type="submit" className="w-full" loading={loading}>Create Account</Button>
+          </form>
+
+          <p className="mt-6 text-center text-sm text-gray-500">
+            Already have an account? <Link to="/login" className="text-indigo-600 hover:underline">Sign in</Link>
+          </p>
+        </div>
+      </div>
+    </div>
+  );
+}
+
@@ -0,0 +1,45 @@
+// Generated by protoc-gen-react-app
+
+export interface Contact {
+  contact_id?: string;
+  email?: string;
+  name?: string;
+  org_id?: string;
+  created_at?: string;
+  updated_at?: string;
+}
+
+export interface ContactInput {
+  contact_id?: string;
+  email?: string;
+  name?: string;
+  org_id?: string;
+  created_at?: string;
+  updated_at?: string;
+}
+
+export interface Member {
+  id: string;
+  email?: string;
+  name?: string;
+  auth?: AuthEmail;
+}
+
+export interface MemberInput {
+  email?: string;
+  name?: string;
+  auth?: AuthEmail;
+}
+
+export interface PaginatedResponse<T> {
+  items: T[];
+  total: number;
+  page: number;
+  pageSize: number;
+}
+
+export interface ApiError {
+  message: string;
+  code?: string;
+}
+
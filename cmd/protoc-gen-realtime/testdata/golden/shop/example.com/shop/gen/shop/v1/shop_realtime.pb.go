@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-realtime. DO NOT EDIT.
+
+package shopv1
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// WebSocket configuration
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true // Configure for production
+	},
+}
+
+// Event types
+type EventType string
+
+const (
+	EventTypeCreate EventType = "create"
+	EventTypeUpdate EventType = "update"
+	EventTypeDelete EventType = "delete"
+)
+
+// Event represents a real-time event
+type Event struct {
+	Type      EventType   `json:"type"`
+	Entity    string      `json:"entity"`
+	ID        string      `json:"id"`
+	Data      interface{} `json:"data,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// Subscription represents a client subscription
+type Subscription struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id,omitempty"` // empty = all entities of type
+}
+
+// ClientMessage from client to server
+type ClientMessage struct {
+	Action       string       `json:"action"` // subscribe, unsubscribe
+	Subscription Subscription `json:"subscription"`
+}
+
+// Hub manages all WebSocket connections and subscriptions
+type Hub struct {
+	clients    map[*Client]bool
+	broadcast  chan Event
+	register   chan *Client
+	unregister chan *Client
+	mu         sync.RWMutex
+}
+
+// Global hub instance
+var hub *Hub
+
+// InitHub initializes the global hub
+func InitHub() *Hub {
+	hub = &Hub{
+		clients:    make(map[*Client]bool),
+		broadcast:  make(chan Event, 256),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+	}
+	go hub.run()
+	return hub
+}
+
+// GetHub returns the global hub instance
+func GetHub() *Hub {
+	return hub
+}
+
+func (h *Hub) run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.mu.Lock()
+			h.clients[client] = true
+			h.mu.Unlock()
+			log.Printf("Client connected: %s", client.id)
+
+		case client := <-h.unregister:
+			h.mu.Lock()
+			if _, ok := h.clients[client]; ok {
+				delete(h.clients, client)
+				close(client.send)
+			}
+			h.mu.Unlock()
+			log.Printf("Client disconnected: %s", client.id)
+
+		case event := <-h.broadcast:
+			h.mu.RLock()
+			for client := range h.clients {
+				if client.isSubscribed(event.Entity, event.ID) {
+					select {
+					case client.send <- event:
+					default:
+						// Client buffer full, skip
+					}
+				}
+			}
+			h.mu.RUnlock()
+		}
+	}
+}
+
+// Publish sends an event to all subscribed clients
+func (h *Hub) Publish(event Event) {
+	event.Timestamp = time.Now()
+	h.broadcast <- event
+}
+
+// Watched is a change seen by a database listener, such as the Change values
+// that the Watch and WatchDoc methods of protoc-gen-firestore repositories send.
+type Watched interface {
+	EventType() string // create, update or delete; "" for none
+	EntityID() string
+	EntityData() interface{}
+}
+
+// PublishWatched publishes the changes of a listener on the given entity type
+// until changes is closed, so that subscribers see writes that bypass the
+// RepositoryWithEvents wrappers: other services, Cloud Functions, the console.
+//
+//	go PublishWatched(EntityUser, repo.Watch(ctx, nil))
+func PublishWatched[C Watched](entity string, changes <-chan C) {
+	for c := range changes {
+		if hub == nil || c.EventType() == "" {
+			continue
+		}
+		hub.Publish(Event{
+			Type:   EventType(c.EventType()),
+			Entity: entity,
+			ID:     c.EntityID(),
+			Data:   c.EntityData(),
+		})
+	}
+}
+
+// Client represents a WebSocket client
+type Client struct {
+	id            string
+	hub           *Hub
+	conn          *websocket.Conn
+	send          chan Event
+	subscriptions map[string]bool // entity:id or entity:*
+	mu            sync.RWMutex
+}
+
+func (c *Client) isSubscribed(entity, id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Check specific subscription
+	if c.subscriptions[entity+":"+id] {
+		return true
+	}
+	// Check wildcard subscription
+	if c.subscriptions[entity+":*"] {
+		return true
+	}
+	return false
+}
+
+func (c *Client) subscribe(sub Subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sub.Entity
+	if sub.ID != "" {
+		key += ":" + sub.ID
+	} else {
+		key += ":*"
+	}
+	c.subscriptions[key] = true
+	log.Printf("Client %s subscribed to %s", c.id, key)
+}
+
+func (c *Client) unsubscribe(sub Subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sub.Entity
+	if sub.ID != "" {
+		key += ":" + sub.ID
+	} else {
+		key += ":*"
+	}
+	delete(c.subscriptions, key)
+	log.Printf("Client %s unsubscribed from %s", c.id, key)
+}
+
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(512)
+	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		return nil
+	})
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			break
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			continue
+		}
+
+		switch msg.Action {
+		case "subscribe":
+			c.subscribe(msg.Subscription)
+		case "unsubscribe":
+			c.unsubscribe(msg.Subscription)
+		}
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case event, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+
+			data, _ := json.Marshal(event)
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Entity types
+const (
+	EntityUser  = "user"
+	EntityStore = "store"
+)
+
+// PublishUserCreate publishes a create event
+func PublishUserCreate(entity *User) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeCreate,
+		Entity: EntityUser,
+		ID:     entity.UserId,
+		Data:   entity,
+	})
+}
+
+// PublishUserUpdate publishes an update event
+func PublishUserUpdate(entity *User) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeUpdate,
+		Entity: EntityUser,
+		ID:     entity.UserId,
+		Data:   entity,
+	})
+}
+
+// PublishUserDelete publishes a delete event
+func PublishUserDelete(id string) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeDelete,
+		Entity: EntityUser,
+		ID:     id,
+	})
+}
+
+// UserRepositoryWithEvents wraps repository with event publishing
+type UserRepositoryWithEvents struct {
+	repo UserRepository
+}
+
+func NewUserRepositoryWithEvents(repo UserRepository) *UserRepositoryWithEvents {
+	return &UserRepositoryWithEvents{repo: repo}
+}
+
+func (r *UserRepositoryWithEvents) Create(ctx context.Context, entity *User) (string, error) {
+	id, err := r.repo.Create(ctx, entity)
+	if err == nil {
+		PublishUserCreate(entity)
+	}
+	return id, err
+}
+
+func (r *UserRepositoryWithEvents) Update(ctx context.Context, entity *User) error {
+	err := r.repo.Update(ctx, entity)
+	if err == nil {
+		PublishUserUpdate(entity)
+	}
+	return err
+}
+
+func (r *UserRepositoryWithEvents) Patch(ctx context.Context, id string, entity *User, mask *fieldmaskpb.FieldMask) error {
+	if err := r.repo.Patch(ctx, id, entity, mask); err != nil {
+		return err
+	}
+	// Subscribers get the whole entity, not the patch
+	patched, err := r.repo.Get(ctx, id)
+	if err == nil {
+		PublishUserUpdate(patched)
+	}
+	return nil
+}
+
+func (r *UserRepositoryWithEvents) Delete(ctx context.Context, id string) error {
+	err := r.repo.Delete(ctx, id)
+	if err == nil {
+		PublishUserDelete(id)
+	}
+	return err
+}
+
+// Delegate other methods to underlying repo
+func (r *UserRepositoryWithEvents) Get(ctx context.Context, id string) (*User, error) {
+	return r.repo.Get(ctx, id)
+}
+func (r *UserRepositoryWithEvents) List(ctx context.Context, limit int) ([]*User, error) {
+	return r.repo.List(ctx, limit)
+}
+func (r *UserRepositoryWithEvents) Exists(ctx context.Context, id string) (bool, error) {
+	return r.repo.Exists(ctx, id)
+}
+func (r *UserRepositoryWithEvents) Count(ctx context.Context) (int64, error) {
+	return r.repo.Count(ctx)
+}
+func (r *UserRepositoryWithEvents) Query() UserQuery { return r.repo.Query() }
+
+// Ensure interface compliance
+var _ UserRepository = (*UserRepositoryWithEvents)(nil)
+
+// PublishStoreCreate publishes a create event
+func PublishStoreCreate(entity *Store) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeCreate,
+		Entity: EntityStore,
+		ID:     entity.Id,
+		Data:   entity,
+	})
+}
+
+// PublishStoreUpdate publishes an update event
+func PublishStoreUpdate(entity *Store) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeUpdate,
+		Entity: EntityStore,
+		ID:     entity.Id,
+		Data:   entity,
+	})
+}
+
+// PublishStoreDelete publishes a delete event
+func PublishStoreDelete(id string) {
+	if hub == nil {
+		return
+	}
+	hub.Publish(Event{
+		Type:   EventTypeDelete,
+		Entity: EntityStore,
+		ID:     id,
+	})
+}
+
+// StoreRepositoryWithEvents wraps repository with event publishing
+type StoreRepositoryWithEvents struct {
+	repo StoreRepository
+}
+
+func NewStoreRepositoryWithEvents(repo StoreRepository) *StoreRepositoryWithEvents {
+	return &StoreRepositoryWithEvents{repo: repo}
+}
+
+func (r *StoreRepositoryWithEvents) Create(ctx context.Context, entity *Store) (string, error) {
+	id, err := r.repo.Create(ctx, entity)
+	if err == nil {
+		PublishStoreCreate(entity)
+	}
+	return id, err
+}
+
+func (r *StoreRepositoryWithEvents) Update(ctx context.Context, entity *Store) error {
+	err := r.repo.Update(ctx, entity)
+	if err == nil {
+		PublishStoreUpdate(entity)
+	}
+	return err
+}
+
+func (r *StoreRepositoryWithEvents) Patch(ctx context.Context, id string, entity *Store, mask *fieldmaskpb.FieldMask) error {
+	if err := r.repo.Patch(ctx, id, entity, mask); err != nil {
+		return err
+	}
+	// Subscribers get the whole entity, not the patch
+	patched, err := r.repo.Get(ctx, id)
+	if err == nil {
+		PublishStoreUpdate(patched)
+	}
+	return nil
+}
+
+func (r *StoreRepositoryWithEvents) Delete(ctx context.Context, id string) error {
+	err := r.repo.Delete(ctx, id)
+	if err == nil {
+		PublishStoreDelete(id)
+	}
+	return err
+}
+
+// Delegate other methods to underlying repo
+func (r *StoreRepositoryWithEvents) Get(ctx context.Context, id string) (*Store, error) {
+	return r.repo.Get(ctx, id)
+}
+func (r *StoreRepositoryWithEvents) List(ctx context.Context, limit int) ([]*Store, error) {
+	return r.repo.List(ctx, limit)
+}
+func (r *StoreRepositoryWithEvents) Exists(ctx context.Context, id string) (bool, error) {
+	return r.repo.Exists(ctx, id)
+}
+func (r *StoreRepositoryWithEvents) Count(ctx context.Context) (int64, error) {
+	return r.repo.Count(ctx)
+}
+func (r *StoreRepositoryWithEvents) Query() StoreQuery { return r.repo.Query() }
+
+// Ensure interface compliance
+var _ StoreRepository = (*StoreRepositoryWithEvents)(nil)
+
+// WebSocketHandler handles WebSocket connections
+func WebSocketHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+
+	client := &Client{
+		id:            r.RemoteAddr,
+		hub:           hub,
+		conn:          conn,
+		send:          make(chan Event, 64),
+		subscriptions: make(map[string]bool),
+	}
+
+	client.hub.register <- client
+
+	go client.writePump()
+	go client.readPump()
+}
+
+// RegisterWebSocket registers the WebSocket handler
+func RegisterWebSocket(mux *http.ServeMux) {
+	mux.HandleFunc("/ws", WebSocketHandler)
+}
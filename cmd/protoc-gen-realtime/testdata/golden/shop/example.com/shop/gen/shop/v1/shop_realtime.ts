@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-realtime. DO NOT EDIT.
+
+import { useEffect, useRef, useState, useCallback } from "react";
+import { useQueryClient } from "@tanstack/react-query";
+
+// Types
+type EventType = 'create' | 'update' | 'delete';
+
+interface RealtimeEvent<T = unknown> {
+  type: EventType;
+  entity: string;
+  id: string;
+  data?: T;
+  timestamp: string;
+}
+
+interface Subscription {
+  entity: string;
+  id?: string;
+}
+
+type ConnectionStatus = 'connecting' | 'connected' | 'disconnected' | 'error';
+
+// WebSocket URL
+const WS_URL = (import.meta.env.VITE_WS_URL || 'ws://localhost:8080') + '/ws';
+
+// Global WebSocket instance
+let ws: WebSocket | null = null;
+let reconnectTimeout: ReturnType<typeof setTimeout> | null = null;
+const listeners = new Map<string, Set<(event: RealtimeEvent) => void>>();
+
+function connect() {
+  if (ws?.readyState === WebSocket.OPEN) return;
+
+  ws = new WebSocket(WS_URL);
+
+  ws.onopen = () => {
+    console.log('WebSocket connected');
+    // Resubscribe to all active subscriptions
+    listeners.forEach((_, key) => {
+      const [entity, id] = key.split(':');
+      ws?.send(JSON.stringify({ action: 'subscribe', subscription: { entity, id: id === '*' ? undefined : id } }));
+    });
+  };
+
+  ws.onmessage = (msg) => {
+    try {
+      const event: RealtimeEvent = JSON.parse(msg.data);
+      // Notify specific listeners
+      const specificKey = `${event.entity}:${event.id}`;
+      listeners.get(specificKey)?.forEach(cb => cb(event));
+      // Notify wildcard listeners
+      const wildcardKey = `${event.entity}:*`;
+      listeners.get(wildcardKey)?.forEach(cb => cb(event));
+    } catch (e) {
+      console.error('Failed to parse WebSocket message:', e);
+    }
+  };
+
+  ws.onclose = () => {
+    console.log('WebSocket disconnected, reconnecting...');
+    reconnectTimeout = setTimeout(connect, 3000);
+  };
+
+  ws.onerror = (err) => {
+    console.error('WebSocket error:', err);
+  };
+}
+
+function subscribe(entity: string, id: string | undefined, callback: (event: RealtimeEvent) => void) {
+  const key = `${entity}:${id || '*'}`;
+
+  if (!listeners.has(key)) {
+    listeners.set(key, new Set());
+    // Send subscribe message if connected
+    if (ws?.readyState === WebSocket.OPEN) {
+      ws.send(JSON.stringify({ action: 'subscribe', subscription: { entity, id } }));
+    }
+  }
+
+  listeners.get(key)!.add(callback);
+
+  // Ensure connection
+  connect();
+
+  // Return unsubscribe function
+  return () => {
+    listeners.get(key)?.delete(callback);
+    if (listeners.get(key)?.size === 0) {
+      listeners.delete(key);
+      if (ws?.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ action: 'unsubscribe', subscription: { entity, id } }));
+      }
+    }
+  };
+}
+
+// Base subscription hook
+export function useSubscription<T>(
+  entity: string,
+  id?: string,
+  onEvent?: (event: RealtimeEvent<T>) => void
+) {
+  const queryClient = useQueryClient();
+
+  useEffect(() => {
+    const handleEvent = (event: RealtimeEvent<T>) => {
+      // Call custom handler
+      onEvent?.(event);
+
+      // Auto-invalidate React Query cache
+      if (event.type === 'create' || event.type === 'delete') {
+        queryClient.invalidateQueries({ queryKey: [entity + 's'] });
+      }
+      if (event.type === 'update' || event.type === 'delete') {
+        queryClient.invalidateQueries({ queryKey: [entity, event.id] });
+      }
+    };
+
+    const unsubscribe = subscribe(entity, id, handleEvent as (event: RealtimeEvent) => void);
+    return unsubscribe;
+  }, [entity, id, onEvent, queryClient]);
+}
+
+// Connection status hook
+export function useConnectionStatus(): ConnectionStatus {
+  const [status, setStatus] = useState<ConnectionStatus>('disconnected');
+
+  useEffect(() => {
+    const checkStatus = () => {
+      if (!ws) setStatus('disconnected');
+      else if (ws.readyState === WebSocket.CONNECTING) setStatus('connecting');
+      else if (ws.readyState === WebSocket.OPEN) setStatus('connected');
+      else setStatus('disconnected');
+    };
+
+    checkStatus();
+    const interval = setInterval(checkStatus, 1000);
+    return () => clearInterval(interval);
+  }, []);
+
+  return status;
+}
+
+// =============================================================================
+// User Subscriptions
+// =============================================================================
+
+// Subscribe to all User changes
+export function useUserSubscription(onEvent?: (event: RealtimeEvent<User>) => void) {
+  useSubscription<User>("user", undefined, onEvent);
+}
+
+// Subscribe to a specific User
+export function useUserItemSubscription(id: string, onEvent?: (event: RealtimeEvent<User>) => void) {
+  useSubscription<User>("user", id, onEvent);
+}
+
+// User list with real-time updates
+export function useUsersRealtime() {
+  useUserSubscription();
+  // The useSubscription hook auto-invalidates the React Query cache
+  // so useUsers() will automatically refetch
+}
+
+// Single User with real-time updates
+export function useUserRealtime(id: string) {
+  useUserItemSubscription(id);
+  // The useSubscription hook auto-invalidates the React Query cache
+  // so useUser(id) will automatically refetch
+}
+
+// =============================================================================
+// Store Subscriptions
+// =============================================================================
+
+// Subscribe to all Store changes
+export function useStoreSubscription(onEvent?: (event: RealtimeEvent<Store>) => void) {
+  useSubscription<Store>("store", undefined, onEvent);
+}
+
+// Subscribe to a specific Store
+export function useStoreItemSubscription(id: string, onEvent?: (event: RealtimeEvent<Store>) => void) {
+  useSubscription<Store>("store", id, onEvent);
+}
+
+// Store list with real-time updates
+export function useStoresRealtime() {
+  useStoreSubscription();
+  // The useSubscription hook auto-invalidates the React Query cache
+  // so useStores() will automatically refetch
+}
+
+// Single Store with real-time updates
+export function useStoreRealtime(id: string) {
+  useStoreItemSubscription(id);
+  // The useSubscription hook auto-invalidates the React Query cache
+  // so useStore(id) will automatically refetch
+}
+
+
@@ -0,0 +1,13 @@
+// protoc-gen-repository runs the generator of internal/plugins/repository as a
+// standalone protoc plugin; protoc-gen-fullstack runs it alongside the others.
+package main
+
+import (
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/repository"
+)
+
+func main() {
+	var flags params.Set
+	flags.Options().Run(repository.Plugin(&flags))
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/servicestubs"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+)
+
+func TestGolden(t *testing.T) {
+	plugintest.Golden(t, servicestubs.Plugin,
+		plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+		plugintest.Case{Name: "explain", Files: []string{"shop/v1/shop.proto"}, Param: "explain=true"},
+		plugintest.Case{Name: "cross_file", Files: []string{"crm/v1/service.proto"}},
+	)
+}
@@ -0,0 +1,96 @@
+// Code generated by protoc-gen-service-stubs. DO NOT EDIT.
+// Service implementations wired to Firestore repositories.
+// Override methods as needed for custom business logic.
+
+package shopv1
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"example.com/shop/gen/shop/v1/shopv1connect"
+	"github.com/google/wire"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// =============================================================================
+// USERSERVICE
+// =============================================================================
+
+type UserService struct {
+	shopv1connect.UnimplementedUserServiceHandler
+	repos *Repositories
+}
+
+func NewUserService(repos *Repositories) *UserService {
+	return &UserService{repos: repos}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req *connect.Request[CreateUserRequest]) (*connect.Response[User], error) {
+	// TODO: Implement create logic
+	// - Validate input
+	// - Set defaults (CreatedAt, UpdatedAt)
+	// - Call s.xxxRepo.Create(ctx, entity)
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))
+}
+
+func (s *UserService) GetUser(ctx context.Context, req *connect.Request[GetUserRequest]) (*connect.Response[User], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+	entity, err := s.repos.User.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(entity), nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, req *connect.Request[UpdateUserRequest]) (*connect.Response[User], error) {
+	// TODO: Implement update logic
+	// - Get existing entity
+	// - Apply updates from request
+	// - Set UpdatedAt
+	// - Call s.xxxRepo.Update(ctx, entity)
+	// - Map ErrConflict (stale version field) to connect.CodeAborted
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, req *connect.Request[DeleteUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	id := req.Msg.GetUserId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))
+	}
+	if err := s.repos.User.Delete(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (s *UserService) ListUsers(ctx context.Context, req *connect.Request[ListUsersRequest]) (*connect.Response[ListUsersResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	entities, err := s.repos.User.List(ctx, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&ListUsersResponse{Users: entities}), nil
+}
+
+// =============================================================================
+// WIRE PROVIDERS
+// =============================================================================
+
+// ServiceSet provides all service constructors for Wire.
+var ServiceSet = wire.NewSet(
+	NewUserService,
+)
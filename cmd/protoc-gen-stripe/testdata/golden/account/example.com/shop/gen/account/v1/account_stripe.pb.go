@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-stripe. DO NOT EDIT.
+// Stripe payments for User.Stripe
+
+package accountv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/stripe/stripe-go/v76"
+	billingportal "github.com/stripe/stripe-go/v76/billingportal/session"
+	"github.com/stripe/stripe-go/v76/checkout/session"
+	"github.com/stripe/stripe-go/v76/customer"
+	"github.com/stripe/stripe-go/v76/subscription"
+	"github.com/stripe/stripe-go/v76/webhook"
+)
+
+var (
+	ErrStripeCustomerNotFound = errors.New("stripe customer not found")
+	ErrStripeCheckoutFailed   = errors.New("checkout failed")
+	ErrStripeWebhookInvalid   = errors.New("invalid webhook")
+)
+
+type StripeServiceConfig struct {
+	SecretKey       string
+	WebhookSecret   string
+	SuccessURL      string
+	CancelURL       string
+	PortalReturnURL string
+}
+
+func InitStripe(cfg StripeServiceConfig) { stripe.Key = cfg.SecretKey }
+
+type StripeService struct {
+	repo   UserRepository
+	config StripeServiceConfig
+}
+
+func NewStripeService(repo UserRepository, cfg StripeServiceConfig) *StripeService {
+	return &StripeService{repo: repo, config: cfg}
+}
+
+func (s *StripeService) GetOrCreateCustomer(ctx context.Context, userID string) (string, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Stripe != nil && user.Stripe.CustomerId != "" {
+		return user.Stripe.CustomerId, nil
+	}
+
+	// Create Stripe customer
+	params := &stripe.CustomerParams{
+		Email:    stripe.String(user.Email),
+		Name:     stripe.String(user.Name),
+		Metadata: map[string]string{"user_id": userID},
+	}
+	cust, err := customer.New(params)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Stripe == nil {
+		user.Stripe = &StripeCustomer{}
+	}
+	user.Stripe.CustomerId = cust.ID
+	s.repo.Update(ctx, user)
+
+	return cust.ID, nil
+}
+
+type CheckoutRequest struct {
+	UserID    string
+	PriceID   string
+	Mode      string // subscription or payment
+	TrialDays int64
+}
+
+type CheckoutResult struct{ SessionID, URL string }
+
+func (s *StripeService) CreateCheckoutSession(ctx context.Context, req CheckoutRequest) (*CheckoutResult, error) {
+	custID, err := s.GetOrCreateCustomer(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := stripe.CheckoutSessionModeSubscription
+	if req.Mode == "payment" {
+		mode = stripe.CheckoutSessionModePayment
+	}
+
+	params := &stripe.CheckoutSessionParams{
+		Customer: stripe.String(custID),
+		Mode:     stripe.String(string(mode)),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{{
+			Price:    stripe.String(req.PriceID),
+			Quantity: stripe.Int64(1),
+		}},
+		SuccessURL: stripe.String(s.config.SuccessURL + "?session_id={CHECKOUT_SESSION_ID}"),
+		CancelURL:  stripe.String(s.config.CancelURL),
+	}
+
+	if mode == stripe.CheckoutSessionModeSubscription && req.TrialDays > 0 {
+		params.SubscriptionData = &stripe.CheckoutSessionSubscriptionDataParams{
+			TrialPeriodDays: stripe.Int64(req.TrialDays),
+		}
+	}
+
+	sess, err := session.New(params)
+	if err != nil {
+		return nil, ErrStripeCheckoutFailed
+	}
+
+	return &CheckoutResult{SessionID: sess.ID, URL: sess.URL}, nil
+}
+
+func (s *StripeService) CreatePortalSession(ctx context.Context, userID string) (string, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.Stripe == nil || user.Stripe.CustomerId == "" {
+		return "", ErrStripeCustomerNotFound
+	}
+
+	params := &stripe.BillingPortalSessionParams{
+		Customer:  stripe.String(user.Stripe.CustomerId),
+		ReturnURL: stripe.String(s.config.PortalReturnURL),
+	}
+	sess, err := billingportal.New(params)
+	if err != nil {
+		return "", err
+	}
+	return sess.URL, nil
+}
+
+func (s *StripeService) CancelSubscription(ctx context.Context, userID string) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Stripe == nil || user.Stripe.SubscriptionId == "" {
+		return nil
+	}
+
+	_, err = subscription.Update(user.Stripe.SubscriptionId, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	user.Stripe.CancelAtPeriodEnd = true
+	return s.repo.Update(ctx, user)
+}
+
+func (s *StripeService) ReactivateSubscription(ctx context.Context, userID string) error {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Stripe == nil || user.Stripe.SubscriptionId == "" {
+		return nil
+	}
+
+	_, err = subscription.Update(user.Stripe.SubscriptionId, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(false),
+	})
+	if err != nil {
+		return err
+	}
+
+	user.Stripe.CancelAtPeriodEnd = false
+	return s.repo.Update(ctx, user)
+}
+
+func (s *StripeService) GetSubscriptionStatus(ctx context.Context, userID string) (*StripeCustomer, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Stripe, nil
+}
+
+type StripeWebhookHandler struct {
+	repo          UserRepository
+	webhookSecret string
+}
+
+func NewStripeWebhookHandler(repo UserRepository, secret string) *StripeWebhookHandler {
+	return &StripeWebhookHandler{repo: repo, webhookSecret: secret}
+}
+
+func (h *StripeWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "read error", 400)
+		return
+	}
+
+	event, err := webhook.ConstructEvent(body, r.Header.Get("Stripe-Signature"), h.webhookSecret)
+	if err != nil {
+		http.Error(w, "invalid signature", 400)
+		return
+	}
+
+	ctx := r.Context()
+
+	switch event.Type {
+	case "customer.subscription.created", "customer.subscription.updated":
+		var sub stripe.Subscription
+		json.Unmarshal(event.Data.Raw, &sub)
+		h.handleSubscriptionUpdate(ctx, &sub)
+	case "customer.subscription.deleted":
+		var sub stripe.Subscription
+		json.Unmarshal(event.Data.Raw, &sub)
+		h.handleSubscriptionDeleted(ctx, &sub)
+	}
+
+	w.WriteHeader(200)
+}
+
+func (h *StripeWebhookHandler) handleSubscriptionUpdate(ctx context.Context, sub *stripe.Subscription) {
+	users, _ := h.repo.List(ctx, 0)
+	for _, user := range users {
+		if user.Stripe != nil && user.Stripe.CustomerId == sub.Customer.ID {
+			user.Stripe.SubscriptionId = sub.ID
+			user.Stripe.SubscriptionStatus = string(sub.Status)
+			if len(sub.Items.Data) > 0 {
+				user.Stripe.PriceId = sub.Items.Data[0].Price.ID
+			}
+			t := time.Unix(sub.CurrentPeriodEnd, 0)
+			user.Stripe.CurrentPeriodEnd = &t
+			user.Stripe.CancelAtPeriodEnd = sub.CancelAtPeriodEnd
+			h.repo.Update(ctx, user)
+			return
+		}
+	}
+}
+
+func (h *StripeWebhookHandler) handleSubscriptionDeleted(ctx context.Context, sub *stripe.Subscription) {
+	users, _ := h.repo.List(ctx, 0)
+	for _, user := range users {
+		if user.Stripe != nil && user.Stripe.SubscriptionId == sub.ID {
+			user.Stripe.SubscriptionId = ""
+			user.Stripe.SubscriptionStatus = "canceled"
+			user.Stripe.PriceId = ""
+			h.repo.Update(ctx, user)
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// stripe.tsx
+import React, { createContext, useContext, useState, useEffect, useCallback } from 'react';
+
+interface StripeContextType {
+  subscription: StripeSubscription | null;
+  isLoading: boolean;
+  createCheckout: (priceId: string, mode?: 'subscription' | 'payment') => Promise<void>;
+  openPortal: () => Promise<void>;
+  cancelSubscription: () => Promise<void>;
+  reactivateSubscription: () => Promise<void>;
+  refresh: () => Promise<void>;
+}
+
+interface StripeSubscription {
+  subscriptionId: string;
+  subscriptionStatus: string;
+  priceId: string;
+  currentPeriodEnd: string;
+  cancelAtPeriodEnd: boolean;
+}
+
+const StripeContext = createContext<StripeContextType | null>(null);
+
+export function StripeProvider({ children, client }: { children: React.ReactNode; client: any }) {
+  const [subscription, setSubscription] = useState<StripeSubscription | null>(null);
+  const [isLoading, setIsLoading] = useState(true);
+
+  const refresh = useCallback(async () => {
+    try {
+      const { subscription: sub } = await client.getSubscriptionStatus({});
+      setSubscription(sub);
+    } catch { setSubscription(null); }
+  }, [client]);
+
+  useEffect(() => { refresh().finally(() => setIsLoading(false)); }, [refresh]);
+
+  const createCheckout = useCallback(async (priceId: string, mode = 'subscription') => {
+    const { url } = await client.createCheckoutSession({ priceId, mode });
+    window.location.href = url;
+  }, [client]);
+
+  const openPortal = useCallback(async () => {
+    const { url } = await client.createPortalSession({});
+    window.location.href = url;
+  }, [client]);
+
+  const cancelSubscription = useCallback(async () => {
+    await client.cancelSubscription({});
+    refresh();
+  }, [client, refresh]);
+
+  const reactivateSubscription = useCallback(async () => {
+    await client.reactivateSubscription({});
+    refresh();
+  }, [client, refresh]);
+
+  return (
+    <StripeContext.Provider value={{ subscription, isLoading, createCheckout, openPortal, cancelSubscription, reactivateSubscription, refresh }}>
+      {children}
+    </StripeContext.Provider>
+  );
+}
+
+export function useStripe() {
+  const ctx = useContext(StripeContext);
+  if (!ctx) throw new Error('useStripe requires StripeProvider');
+  return ctx;
+}
+
+interface Plan { id: string; priceId: string; name: string; price: number; interval: string; features: string[] }
+
+export function PricingTable({ plans, highlight }: { plans: Plan[]; highlight?: string }) {
+  const { subscription, createCheckout } = useStripe();
+
+  const fmt = (n: number) => new Intl.NumberFormat('en-US', { style: 'currency', currency: 'USD' }).format(n / 100);
+
+  return (
+    <div className="grid gap-6 md:grid-cols-3">
+      {plans.map(plan => {
+        const isCurrent = subscription?.priceId === plan.priceId;
+        const isHighlight = plan.id === highlight;
+        return (
+          <div key={plan.id} className={"rounded-lg border p-6 " + (isHighlight ? "border-blue-500 ring-2 ring-blue-500" : "") + (isCurrent ? " bg-blue-50" : "")}>
+            <h3 className="text-xl font-bold">{plan.name}</h3>
+            <div className="mt-4"><span className="text-4xl font-bold">{fmt(plan.price)}</span><span className="text-gray-500">/{plan.interval}</span></div>
+            <ul className="mt-6 space-y-2">{plan.features.map((f, i) => <li key={i} className="flex items-center gap-2">✓ {f}</li>)}</ul>
+            <button onClick={() => createCheckout(plan.priceId)} disabled={isCurrent}
+              className={"mt-6 w-full py-2 rounded " + (isCurrent ? "bg-gray-200 text-gray-500" : "bg-blue-600 text-white hover:bg-blue-700")}>
+              {isCurrent ? 'Current Plan' : 'Select'}
+            </button>
+          </div>
+        );
+      })}
+    </div>
+  );
+}
+
+export function SubscriptionStatus() {
+  const { subscription, isLoading, cancelSubscription, reactivateSubscription, openPortal } = useStripe();
+
+  if (isLoading) return <div className="animate-pulse h-24 bg-gray-100 rounded" />;
+  if (!subscription) return <div className="p-4 bg-gray-50 rounded">No active subscription</div>;
+
+  const statusColors: Record<string, string> = {
+    active: 'bg-green-100 text-green-800',
+    trialing: 'bg-blue-100 text-blue-800',
+    past_due: 'bg-yellow-100 text-yellow-800',
+    canceled: 'bg-gray-100 text-gray-800',
+  };
+
+  return (
+    <div className="p-6 border rounded-lg">
+      <div className="flex justify-between items-start">
+        <div>
+          <span className={"px-2 py-1 text-xs rounded-full " + (statusColors[subscription.subscriptionStatus] || 'bg-gray-100')}>
+            {subscription.subscriptionStatus}
+          </span>
+          {subscription.currentPeriodEnd && (
+            <p className="mt-2 text-sm text-gray-600">
+              Renews {new Date(subscription.currentPeriodEnd).toLocaleDateString()}
+            </p>
+          )}
+          {subscription.cancelAtPeriodEnd && (
+            <p className="text-sm text-yellow-600">Cancels at period end</p>
+          )}
+        </div>
+      </div>
+      <div className="mt-4 flex gap-2">
+        {subscription.cancelAtPeriodEnd ? (
+          <button onClick={reactivateSubscription} className="px-4 py-2 bg-blue-600 text-white rounded">Reactivate</button>
+        ) : (
+          <button onClick={cancelSubscription} className="px-4 py-2 border rounded">Cancel</button>
+        )}
+        <button onClick={openPortal} className="px-4 py-2 border rounded">Manage Billing</button>
+      </div>
+    </div>
+  );
+}
+
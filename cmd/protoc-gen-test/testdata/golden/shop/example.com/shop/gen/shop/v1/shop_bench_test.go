@@ -0,0 +1,59 @@
+// Code generated by protoc-gen-test. DO NOT EDIT.
+// Benchmarks for service operations
+
+package shopv1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	pb "example.com/shop/gen/shop/v1"
+)
+
+func BenchmarkUserService_Create(b *testing.B) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+
+	repo := pb.NewInMemoryUserRepository()
+	server := pb.NewUserServiceServer(repo)
+	path, handler := pb.NewUserServiceHandler(server)
+	mux.Handle(path, handler)
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := pb.NewUserServiceClient(http.DefaultClient, ts.URL)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fake := pb.FakeUser()
+		req := connect.NewRequest(&pb.CreateUserRequest{User: fake})
+		_, _ = client.CreateUser(ctx, req)
+	}
+}
+
+func BenchmarkUserService_List(b *testing.B) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+
+	repo := pb.NewInMemoryUserRepository()
+	pb.SeedUsers(ctx, repo, 100) // Pre-seed
+
+	server := pb.NewUserServiceServer(repo)
+	path, handler := pb.NewUserServiceHandler(server)
+	mux.Handle(path, handler)
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := pb.NewUserServiceClient(http.DefaultClient, ts.URL)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := connect.NewRequest(&pb.ListUsersRequest{})
+		_, _ = client.ListUsers(ctx, req)
+	}
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-wire. DO NOT EDIT.
+// Wire dependency injection providers for proto-generated services.
+
+package shopv1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/rs/cors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// =============================================================================
+// REPOSITORY PROVIDERS
+// =============================================================================
+
+// RepositorySet provides all Firestore repositories, bound to the
+// backend-agnostic repository interfaces.
+var RepositorySet = wire.NewSet(
+	NewFirestoreUserRepository,
+	wire.Bind(new(UserRepository), new(*FirestoreUserRepository)),
+	NewFirestoreStoreRepository,
+	wire.Bind(new(StoreRepository), new(*FirestoreStoreRepository)),
+	NewRepositories,
+)
+
+// Repositories holds all repository instances. Fields are interfaces so
+// any generated backend (Firestore, in-memory) can be plugged in.
+type Repositories struct {
+	User  UserRepository
+	Store StoreRepository
+}
+
+// NewRepositories creates a Repositories container.
+func NewRepositories(
+	user UserRepository,
+	store StoreRepository,
+) *Repositories {
+	return &Repositories{
+		User:  user,
+		Store: store,
+	}
+}
+
+// =============================================================================
+// SERVICE PROVIDERS
+// =============================================================================
+
+// ServiceSet provides all service implementations.
+// Note: Custom services (TokenService, UserService, etc.) should be
+// provided separately as they require custom logic.
+var ServiceSet = wire.NewSet(
+// Add custom service providers here
+)
+
+// =============================================================================
+// HANDLER REGISTRATION HELPERS
+// =============================================================================
+
+// RegisterHandlers registers all Connect handlers with the mux.
+// Call this from main.go after creating your services.
+// Example:
+//   mux := NewServerMux()
+//   mux.Handle(purecertsv1connect.NewUserServiceHandler(userSvc))
+//   mux.Handle(purecertsv1connect.NewTokenServiceHandler(tokenSvc))
+//   ...
+
+// =============================================================================
+// SERVER SET
+// =============================================================================
+
+// ServerSet wires repositories + handlers into a server.
+var ServerSet = wire.NewSet(
+	RepositorySet,
+	// ServiceSet, // Uncomment when custom services added
+	// HandlerSet, // Uncomment when handlers wired
+	NewServerMux,
+	NewHTTPServer,
+)
+
+// =============================================================================
+// SERVER HELPERS
+// =============================================================================
+
+// ServerConfig holds server configuration.
+type ServerConfig struct {
+	Port           string
+	AllowedOrigins []string
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+}
+
+// DefaultServerConfig returns sensible defaults.
+func DefaultServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Port:           "8080",
+		AllowedOrigins: []string{"http://localhost:3000", "http://localhost:5173"},
+		ReadTimeout:    30 * time.Second,
+		WriteTimeout:   30 * time.Second,
+	}
+}
+
+// NewServerMux creates a new HTTP mux.
+func NewServerMux() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	// Health check
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	return mux
+}
+
+// RegisterHandler registers a Connect handler with the mux.
+func RegisterHandler(mux *http.ServeMux, path string, handler http.Handler) {
+	mux.Handle(path, handler)
+}
+
+// NewHTTPServer creates an HTTP server with CORS and HTTP/2.
+func NewHTTPServer(mux *http.ServeMux, cfg *ServerConfig) *http.Server {
+	if cfg == nil {
+		cfg = DefaultServerConfig()
+	}
+
+	corsHandler := cors.New(cors.Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Connect-Protocol-Version"},
+		ExposedHeaders:   []string{"Grpc-Status", "Grpc-Message"},
+		AllowCredentials: true,
+	}).Handler(mux)
+
+	return &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      h2c.NewHandler(corsHandler, &http2.Server{}),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+}
+
+// =============================================================================
+// WIRE INJECTOR EXAMPLE
+// =============================================================================
+
+/*
+Copy this to cmd/server/wire.go:
+
+//go:build wireinject
+
+package main
+
+import (
+	"cloud.google.com/go/firestore"
+	"github.com/google/wire"
+	pb "example.com/shop/gen/shop/v1"
+)
+
+func InitializeServer(client *firestore.Client, cfg *pb.ServerConfig) (*http.Server, error) {
+	wire.Build(pb.ServerSet)
+	return nil, nil
+}
+
+Then run: wire ./cmd/server
+*/
@@ -2,4 +2,10 @@ module github.com/vinodhalaharvi/buf-go-plugins
 
 go 1.25.0
 
-require google.golang.org/protobuf v1.36.11
+require (
+	github.com/bufbuild/protocompile v0.14.1
+	github.com/google/uuid v1.6.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require golang.org/x/sync v0.8.0 // indirect
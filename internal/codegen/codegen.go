@@ -0,0 +1,228 @@
+// Package codegen is the code builder shared by every plugin.
+//
+// Generated source is a Code value: a deferred string built from Lines and
+// combined with the Code monoid. Plugins dot-import the package so that
+// generators read like the code they emit:
+//
+//	Func("NewUserRepository", "client *firestore.Client", "*UserRepository",
+//		Return("&UserRepository{client: client}"),
+//	)
+//
+// Imports of generated Go files are tracked through protogen.GoIdent with an
+// ImportSet; gosrc prunes whatever a file ends up not using.
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// CATEGORY THEORY FOUNDATIONS
+// =============================================================================
+
+// Monoid is an associative Append with an identity Empty. Concat, when set,
+// combines many values at once and must agree with folding Append.
+type Monoid[A any] struct {
+	Empty  func() A
+	Append func(A, A) A
+	Concat func([]A) A
+}
+
+// Code is a deferred piece of source text.
+type Code struct{ Run func() string }
+
+// CodeMonoid concatenates source text.
+var CodeMonoid = Monoid[Code]{
+	Empty:  Empty,
+	Append: func(a, b Code) Code { return Code{Run: func() string { return a.Run() + b.Run() }} },
+	Concat: func(cs []Code) Code { return Join(cs...) },
+}
+
+// Empty is the Code that emits nothing.
+func Empty() Code { return Code{Run: func() string { return "" }} }
+
+// FoldLeft combines xs from the left: f(f(f(z, x0), x1), x2).
+func FoldLeft[A, B any](xs []A, z B, f func(B, A) B) B {
+	acc := z
+	for _, x := range xs {
+		acc = f(acc, x)
+	}
+	return acc
+}
+
+// FoldRight combines xs from the right: f(x0, f(x1, f(x2, z))). It loops
+// rather than recursing, so the stack does not grow with len(xs).
+func FoldRight[A, B any](xs []A, z B, f func(A, B) B) B {
+	acc := z
+	for i := len(xs) - 1; i >= 0; i-- {
+		acc = f(xs[i], acc)
+	}
+	return acc
+}
+
+// Concat combines xs with m, in order.
+func Concat[A any](m Monoid[A], xs []A) A {
+	if m.Concat != nil {
+		return m.Concat(xs)
+	}
+	return FoldLeft(xs, m.Empty(), m.Append)
+}
+
+// Map applies f to every element of xs.
+func Map[A, B any](xs []A, f func(A) B) []B {
+	out := make([]B, len(xs))
+	for i, x := range xs {
+		out[i] = f(x)
+	}
+	return out
+}
+
+// FoldMap maps xs with f and combines the results with m.
+func FoldMap[A, B any](xs []A, m Monoid[B], f func(A) B) B { return Concat(m, Map(xs, f)) }
+
+// Filter returns the elements of xs that satisfy pred.
+func Filter[A any](xs []A, pred func(A) bool) []A {
+	var out []A
+	for _, x := range xs {
+		if pred(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Join concatenates codes. Running the result takes constant stack depth
+// however many codes there are.
+func Join(codes ...Code) Code {
+	return Code{Run: func() string {
+		var b strings.Builder
+		for _, c := range codes {
+			b.WriteString(c.Run())
+		}
+		return b.String()
+	}}
+}
+
+// When returns c if cond holds and Empty otherwise.
+func When(cond bool, c Code) Code {
+	if cond {
+		return c
+	}
+	return Empty()
+}
+
+// =============================================================================
+// CODE PRIMITIVES
+// =============================================================================
+
+func Raw(s string) Code                                { return Code{Run: func() string { return s }} }
+func Line(s string) Code                               { return Code{Run: func() string { return s + "\n" }} }
+func Linef(format string, args ...interface{}) Code    { return Line(fmt.Sprintf(format, args...)) }
+func Blank() Code                                      { return Line("") }
+func Comment(text string) Code                         { return Line("// " + text) }
+func Commentf(format string, args ...interface{}) Code { return Comment(fmt.Sprintf(format, args...)) }
+
+// Doc emits text as a // comment, one comment line per line of text.
+func Doc(text string) Code {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	return Join(Map(lines, func(l string) Code {
+		if l == "" {
+			return Line("//")
+		}
+		return Comment(l)
+	})...)
+}
+
+// Docf is Doc with fmt.Sprintf formatting.
+func Docf(format string, args ...interface{}) Code { return Doc(fmt.Sprintf(format, args...)) }
+
+// Indent prefixes every non-empty line of c with a tab.
+func Indent(c Code) Code {
+	return Code{Run: func() string {
+		lines := strings.Split(c.Run(), "\n")
+		for i, l := range lines {
+			if l != "" {
+				lines[i] = "\t" + l
+			}
+		}
+		return strings.Join(lines, "\n")
+	}}
+}
+
+// =============================================================================
+// GO CODE COMBINATORS
+// =============================================================================
+
+// Block emits "header {", the indented body and "}".
+func Block(header string, body ...Code) Code {
+	return Join(Line(header+" {"), Indent(Join(body...)), Line("}"))
+}
+
+func Package(name string) Code { return Line("package " + name) }
+
+func Import(path string) Code {
+	if path == "" {
+		return Line("")
+	}
+	return Linef("\t%q", path)
+}
+
+// Imports emits an import block; an empty path separates groups.
+func Imports(paths ...string) Code {
+	return Join(Blank(), Line("import ("), FoldMap(paths, CodeMonoid, Import), Line(")"))
+}
+
+func Struct(name string, fields Code) Code { return Block("type "+name+" struct", fields) }
+
+func Field(name, typ string) Code { return Linef("%s %s", name, typ) }
+
+func Func(name, params, returns string, body Code) Code {
+	return Block(signature(fmt.Sprintf("func %s(%s)", name, params), returns), body)
+}
+
+func Method(receiver, name, params, returns string, body Code) Code {
+	return Block(signature(fmt.Sprintf("func (%s) %s(%s)", receiver, name, params), returns), body)
+}
+
+func signature(sig, returns string) string {
+	if returns != "" {
+		return sig + " " + returns
+	}
+	return sig
+}
+
+func VarBlock(vars Code) Code {
+	return Join(Line("var ("), Indent(vars), Line(")"))
+}
+
+func If(cond string, body Code) Code { return Block("if "+cond, body) }
+
+func IfElse(cond string, ifBody, elseBody Code) Code {
+	return Join(Linef("if %s {", cond), Indent(ifBody), Line("} else {"), Indent(elseBody), Line("}"))
+}
+
+// Switch emits a switch statement over tag (which may be empty) with the
+// given Case and Default clauses.
+func Switch(tag string, clauses ...Code) Code {
+	header := "switch"
+	if tag != "" {
+		header += " " + tag
+	}
+	return Join(Line(header+" {"), Join(clauses...), Line("}"))
+}
+
+// Case is a switch clause matching any of exprs.
+func Case(exprs string, body ...Code) Code {
+	return Join(Linef("case %s:", exprs), Indent(Join(body...)))
+}
+
+// Default is the default clause of a switch.
+func Default(body ...Code) Code { return Join(Line("default:"), Indent(Join(body...))) }
+
+func Return(values ...string) Code {
+	if len(values) == 0 {
+		return Line("return")
+	}
+	return Linef("return %s", strings.Join(values, ", "))
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestConcatLargeInput(t *testing.T) {
+	lines := make([]Code, 1_000_000)
+	for i := range lines {
+		lines[i] = Line("x")
+	}
+	if got := Concat(CodeMonoid, lines).Run(); len(got) != 2*len(lines) {
+		t.Errorf("len = %d, want %d", len(got), 2*len(lines))
+	}
+	if got := FoldMap(lines, CodeMonoid, Indent).Run(); len(got) != 3*len(lines) {
+		t.Errorf("len = %d, want %d", len(got), 3*len(lines))
+	}
+}
+
+func TestConcatWithoutBatchConcat(t *testing.T) {
+	sum := Monoid[int]{Empty: func() int { return 0 }, Append: func(a, b int) int { return a + b }}
+	if got := Concat(sum, []int{1, 2, 3, 4}); got != 10 {
+		t.Errorf("Concat = %d, want 10", got)
+	}
+	digits := FoldRight([]string{"1", "2", "3"}, "", func(a, acc string) string { return a + acc })
+	if digits != "123" {
+		t.Errorf("FoldRight = %q, want %q", digits, "123")
+	}
+}
+
+func TestGoCombinators(t *testing.T) {
+	code := Join(
+		Doc("Kind names k.\n\nUnknown kinds are empty."),
+		Func("Kind", "k int", "string", Switch("k",
+			Case("0", Return(`"zero"`)),
+			Case("1, 2", Block("if k == 1", Return(`"one"`)), Return(`"two"`)),
+			Default(Return(`""`)),
+		)),
+	)
+	want := `// Kind names k.
+//
+// Unknown kinds are empty.
+func Kind(k int) string {
+	switch k {
+	case 0:
+		return "zero"
+	case 1, 2:
+		if k == 1 {
+			return "one"
+		}
+		return "two"
+	default:
+		return ""
+	}
+}
+`
+	if got := code.Run(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestImportSet(t *testing.T) {
+	imports := NewImportSet("example.com/app/store")
+	body := Join(
+		Linef("var _ %s", imports.Ident(protogen.GoIdent{GoName: "Context", GoImportPath: "context"})),
+		Linef("var _ %s", imports.Ident(protogen.GoIdent{GoName: "User", GoImportPath: "example.com/app/store"})),
+		Linef("var _ %s", imports.Ident(protogen.GoIdent{GoName: "Client", GoImportPath: "cloud.google.com/go/firestore"})),
+		Linef("var _ %s", imports.Ident(protogen.GoIdent{GoName: "Client", GoImportPath: "example.com/legacy/firestore"})),
+	)
+	pb := imports.UseAs("example.com/app/gen/v1", "pb")
+	got := File(Line("package store"), imports, body).Run()
+
+	want := `package store
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	pb "example.com/app/gen/v1"
+	firestore1 "example.com/legacy/firestore"
+)
+
+var _ context.Context
+var _ User
+var _ firestore.Client
+var _ firestore1.Client
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if pb != "pb" || imports.Use("example.com/app/gen/v1") != "pb" {
+		t.Errorf("UseAs name not kept")
+	}
+	if !strings.Contains(got, "firestore1") {
+		t.Errorf("conflicting import not renamed")
+	}
+}
@@ -0,0 +1,118 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// ImportSet tracks the packages a generated Go file refers to and the local
+// name each one is imported as.
+type ImportSet struct {
+	self  protogen.GoImportPath
+	names map[protogen.GoImportPath]string
+	taken map[string]bool
+}
+
+// NewImportSet starts an empty set for a file in package self. Identifiers
+// from self are emitted unqualified.
+func NewImportSet(self protogen.GoImportPath) *ImportSet {
+	return &ImportSet{
+		self:  self,
+		names: make(map[protogen.GoImportPath]string),
+		taken: make(map[string]bool),
+	}
+}
+
+// Ident returns id qualified for use in the file, importing its package.
+func (s *ImportSet) Ident(id protogen.GoIdent) string {
+	if id.GoImportPath == s.self {
+		return id.GoName
+	}
+	return s.Use(id.GoImportPath) + "." + id.GoName
+}
+
+// Use imports path and returns its local name. The name is the one goimports
+// would assume, numbered when another import already has it.
+func (s *ImportSet) Use(path protogen.GoImportPath) string {
+	if name, ok := s.names[path]; ok {
+		return name
+	}
+	base := gosrc.AssumedName(string(path))
+	name := base
+	for i := 1; s.taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	s.names[path] = name
+	s.taken[name] = true
+	return name
+}
+
+// UseAs imports path under an explicit local name.
+func (s *ImportSet) UseAs(path protogen.GoImportPath, name string) string {
+	if existing, ok := s.names[path]; ok {
+		return existing
+	}
+	s.names[path] = name
+	s.taken[name] = true
+	return name
+}
+
+// Code emits the import block: the standard library first, then everything
+// else, each group sorted. It lists the imports recorded when it runs, so
+// run it after the code that uses them (File does).
+func (s *ImportSet) Code() Code {
+	return Code{Run: func() string {
+		if len(s.names) == 0 {
+			return ""
+		}
+		var std, other []protogen.GoImportPath
+		for path := range s.names {
+			if isStd(string(path)) {
+				std = append(std, path)
+			} else {
+				other = append(other, path)
+			}
+		}
+		sort.Slice(std, func(i, j int) bool { return std[i] < std[j] })
+		sort.Slice(other, func(i, j int) bool { return other[i] < other[j] })
+
+		spec := func(path protogen.GoImportPath) Code {
+			if name := s.names[path]; name != gosrc.AssumedName(string(path)) {
+				return Linef("\t%s %q", name, string(path))
+			}
+			return Linef("\t%q", string(path))
+		}
+		return Join(
+			Line("import ("),
+			FoldMap(std, CodeMonoid, spec),
+			When(len(std) > 0 && len(other) > 0, Blank()),
+			FoldMap(other, CodeMonoid, spec),
+			Line(")"),
+		).Run()
+	}}
+}
+
+// File assembles a Go file from its header (package clause and leading
+// comments), its imports and its body. The body runs first so every import
+// it records makes it into the import block.
+func File(header Code, imports *ImportSet, body Code) Code {
+	return Code{Run: func() string {
+		b := body.Run()
+		return fmt.Sprintf("%s\n%s\n%s", header.Run(), imports.Code().Run(), b)
+	}}
+}
+
+// isStd reports whether path looks like a standard library package: its
+// first element has no dot.
+func isStd(path string) bool {
+	for i := 0; i < len(path) && path[i] != '/'; i++ {
+		if path[i] == '.' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+// Package diag collects diagnostics tied to proto source locations.
+//
+// Plugins report shapes they cannot generate for, rather than skipping them
+// silently or emitting code that does not compile. A diagnostic points at the
+// offending declaration as file:line:column, taken from the request's
+// SourceCodeInfo:
+//
+//	shop/v1/shop.proto:42:3: warning: User embeds StripeCustomer in field billing but has no email field; stripe code is not generated
+//
+// Errors fail generation through CodeGeneratorResponse.Error. Warnings are
+// written to <plugin>.warnings.txt next to the generated code, or fail
+// generation like errors with strict=true.
+package diag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Severity says whether a diagnostic fails generation.
+type Severity int
+
+const (
+	Warning Severity = iota
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// Position is a place in a proto file. Line and Column are 1-based, and zero
+// when the request carries no source info for the declaration.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Pos returns the position of loc, a location within file.
+func Pos(file protoreflect.FileDescriptor, loc protogen.Location) Position {
+	p := Position{File: loc.SourceFile}
+	if file == nil {
+		return p
+	}
+	if l := file.SourceLocations().ByPath(loc.Path); l.Path != nil {
+		p.Line, p.Column = l.StartLine+1, l.StartColumn+1
+	}
+	return p
+}
+
+// Diagnostic is one problem found in the input. It is an error so that code
+// outside a plugin's generator, such as the entities registry, can return it.
+type Diagnostic struct {
+	Severity Severity
+	Pos      Position
+	Message  string
+}
+
+func (d *Diagnostic) Error() string { return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message) }
+
+// Errorf returns an error diagnostic at loc, a location within file.
+func Errorf(file protoreflect.FileDescriptor, loc protogen.Location, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{Severity: Error, Pos: Pos(file, loc), Message: fmt.Sprintf(format, args...)}
+}
+
+// Settings holds the diagnostics parameters of one plugin.
+type Settings struct {
+	strict *bool
+}
+
+// Declare adds the strict parameter to flags.
+func Declare(flags *params.Set) *Settings {
+	return &Settings{strict: flags.Bool("strict", false, "fail generation on any warning")}
+}
+
+// New returns an empty set for one run of the generator on gen.
+func (s *Settings) New(gen *protogen.Plugin) *Set {
+	return &Set{gen: gen, strict: *s.strict}
+}
+
+// Set collects the diagnostics of one run. A nil Set discards what is
+// recorded, for callers that only want a plugin's detection.
+type Set struct {
+	gen    *protogen.Plugin
+	strict bool
+	list   []*Diagnostic
+}
+
+// Warnf records a warning at loc.
+func (s *Set) Warnf(loc protogen.Location, format string, args ...interface{}) {
+	s.add(Warning, loc, format, args...)
+}
+
+// Errorf records an error at loc.
+func (s *Set) Errorf(loc protogen.Location, format string, args ...interface{}) {
+	s.add(Error, loc, format, args...)
+}
+
+func (s *Set) add(sev Severity, loc protogen.Location, format string, args ...interface{}) {
+	if s == nil {
+		return
+	}
+	var file protoreflect.FileDescriptor
+	if f := s.gen.FilesByPath[loc.SourceFile]; f != nil {
+		file = f.Desc
+	}
+	s.list = append(s.list, &Diagnostic{Severity: sev, Pos: Pos(file, loc), Message: fmt.Sprintf(format, args...)})
+}
+
+// Diagnostics returns what was recorded, in order.
+func (s *Set) Diagnostics() []*Diagnostic { return s.list }
+
+// Finish surfaces the diagnostics of a run. It returns an error listing
+// every diagnostic when one is an error, or when one is a warning and the
+// plugin runs strict; otherwise it writes the warnings, if any, to
+// <plugin>.warnings.txt.
+func (s *Set) Finish(plugin string) error {
+	if len(s.list) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fail := s.strict
+	for _, d := range s.list {
+		if d.Severity == Error {
+			fail = true
+		}
+		b.WriteString(d.Error())
+		b.WriteByte('\n')
+	}
+	if fail {
+		return errors.New(strings.TrimSuffix(b.String(), "\n"))
+	}
+	_, err := s.gen.NewGeneratedFile(plugin+".warnings.txt", "").Write([]byte(b.String()))
+	return err
+}
@@ -0,0 +1,60 @@
+package diag
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func newSet(t *testing.T, param string) (*protogen.Plugin, *Set) {
+	t.Helper()
+	var flags params.Set
+	s := Declare(&flags)
+	req := plugintest.Request(t, plugintest.Case{Files: []string{"shop/v1/shop.proto"}, Param: param})
+	gen, err := flags.Options().New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen, s.New(gen)
+}
+
+func TestFinish(t *testing.T) {
+	gen, diags := newSet(t, "")
+	if err := diags.Finish("protoc-gen-x"); err != nil || len(gen.Response().File) != 0 {
+		t.Fatalf("no diagnostics: err = %v, files = %v", err, gen.Response().File)
+	}
+
+	user := gen.FilesByPath["shop/v1/shop.proto"].Messages[0]
+	diags.Warnf(user.Location, "careful")
+	if err := diags.Finish("protoc-gen-x"); err != nil {
+		t.Fatal(err)
+	}
+	files := gen.Response().File
+	if len(files) != 1 || files[0].GetName() != "protoc-gen-x.warnings.txt" {
+		t.Fatalf("files = %v, want the warnings file", files)
+	}
+	if got, want := files[0].GetContent(), "shop/v1/shop.proto:19:1: warning: careful\n"; got != want {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+
+	diags.Errorf(user.Fields[0].Location, "broken")
+	if err := diags.Finish("protoc-gen-x"); err == nil {
+		t.Error("Finish succeeded with an error diagnostic")
+	}
+}
+
+func TestStrict(t *testing.T) {
+	gen, diags := newSet(t, "strict=true")
+	diags.Warnf(gen.FilesByPath["shop/v1/shop.proto"].Messages[0].Location, "careful")
+	if err := diags.Finish("protoc-gen-x"); err == nil || err.Error() != "shop/v1/shop.proto:19:1: warning: careful" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestPosWithoutSourceInfo(t *testing.T) {
+	if got := Pos(nil, protogen.Location{SourceFile: "a.proto"}).String(); got != "a.proto" {
+		t.Errorf("Pos = %q, want a.proto", got)
+	}
+}
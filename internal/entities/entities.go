@@ -0,0 +1,423 @@
+// Package entities resolves the (entity.entity) message option into the
+// configuration shared by the repository plugins.
+//
+// Every plugin that generates per-entity code resolves entities through a
+// Registry so that custom collection names, ID fields and constraints apply
+// consistently across the Firestore, in-memory and server generators, and
+// across the files of a request.
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/proto/entity"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Config is the resolved entity configuration for a message.
+type Config struct {
+	Collection string
+	IDField    string // proto field name of the primary key
+	IDGoName   string // Go field name of the primary key
+	Indexes    []string
+	Unique     []string
+	Orders     []Order // declared sort orders, from order_by
+	Access     *Access // client access through security rules; nil for none
+	Version    string  // proto field name of the optimistic concurrency field; "" for none
+	ETag       bool    // Version is a string etag rather than an integer counter
+	SoftDelete bool    // deleted_at is managed by the repository
+	Timestamps bool    // created_at/updated_at are managed by the repository
+
+	IDReason string   // why IDField was chosen
+	Notes    []string // how the other settings were decided, for explain reports
+}
+
+// Order is a sort order an entity is listed in.
+type Order struct {
+	Field string // proto field name
+	Desc  bool
+}
+
+// Access is who may read and write an entity's documents directly, from the
+// access option. Field names are proto field names.
+type Access struct {
+	OwnerField  string
+	TenantField string
+	Read        []string // roles; "*" is any signed-in user
+	Create      []string
+	Update      []string
+	Delete      []string
+	Immutable   []string // includes the owner and tenant fields
+}
+
+// IsUnique reports whether the named field carries a unique constraint.
+func (c *Config) IsUnique(name string) bool { return containsFold(c.Unique, name) }
+
+// IsIndexed reports whether the named field gets lookup methods.
+func (c *Config) IsIndexed(name string) bool {
+	return containsFold(c.Indexes, name) || containsFold(c.Unique, name)
+}
+
+// Options returns the raw entity options declared on msg, or nil.
+func Options(msg *protogen.Message) *entity.EntityOptions {
+	opts, ok := msg.Desc.Options().(*descriptorpb.MessageOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+	if proto.HasExtension(opts, entity.E_Entity) {
+		if eo, ok := proto.GetExtension(opts, entity.E_Entity).(*entity.EntityOptions); ok {
+			return eo
+		}
+	}
+	// The options may hold the extension as unknown fields (or as a dynamic
+	// message) when they were decoded without our registry; re-decode them.
+	b, err := proto.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+	decoded := &descriptorpb.MessageOptions{}
+	if err := proto.Unmarshal(b, decoded); err != nil || !proto.HasExtension(decoded, entity.E_Entity) {
+		return nil
+	}
+	eo, _ := proto.GetExtension(decoded, entity.E_Entity).(*entity.EntityOptions)
+	return eo
+}
+
+// IsEntity reports whether msg declares the entity option.
+func IsEntity(msg *protogen.Message) bool { return Options(msg) != nil }
+
+// Defaults are plugin-wide settings applied to entities that leave the
+// corresponding option unset.
+type Defaults struct {
+	SoftDelete bool // infer soft delete from a deleted_at field
+	Timestamps bool // infer timestamps from created_at/updated_at fields
+}
+
+// Inferred enables every presence-based default.
+var Inferred = Defaults{SoftDelete: true, Timestamps: true}
+
+// Lookup resolves msg with the Inferred defaults.
+func Lookup(msg *protogen.Message) (*Config, error) { return Inferred.Lookup(msg) }
+
+// Infer resolves msg with the Inferred defaults, whether or not it declares
+// the entity option.
+func Infer(msg *protogen.Message) (*Config, error) { return Inferred.Infer(msg) }
+
+// Lookup resolves the entity configuration for msg. It returns nil, nil when
+// msg does not declare the entity option, and an error when the option
+// refers to fields the message does not have.
+func (d Defaults) Lookup(msg *protogen.Message) (*Config, error) {
+	opts := Options(msg)
+	if opts == nil {
+		return nil, nil
+	}
+	return d.resolve(msg, opts)
+}
+
+// Infer resolves a configuration for msg from its declared option or, when
+// the option is absent, from the defaults alone.
+func (d Defaults) Infer(msg *protogen.Message) (*Config, error) {
+	opts := Options(msg)
+	if opts == nil {
+		opts = &entity.EntityOptions{}
+	}
+	return d.resolve(msg, opts)
+}
+
+func (d Defaults) resolve(msg *protogen.Message, opts *entity.EntityOptions) (*Config, error) {
+	cfg := &Config{Collection: opts.GetCollection()}
+	if cfg.Collection == "" {
+		cfg.Collection = toSnakeCase(string(msg.Desc.Name())) + "s"
+		cfg.note("collection %s: default, snake_case(name) + \"s\"", cfg.Collection)
+	} else {
+		cfg.note("collection %s: collection option", cfg.Collection)
+	}
+
+	var idField *protogen.Field
+	if opts.GetIdField() != "" {
+		if idField = fieldByName(msg, opts.GetIdField()); idField == nil {
+			return nil, invalid(msg, "id_field %q is not a field of the message", opts.GetIdField())
+		}
+		cfg.IDReason = "id_field option"
+	} else {
+		idField, cfg.IDReason = findIDField(msg)
+	}
+	if idField == nil {
+		return nil, invalid(msg, "no id, *_id or string field to use as ID; set id_field")
+	}
+	cfg.IDField, cfg.IDGoName = string(idField.Desc.Name()), idField.GoName
+
+	for _, n := range opts.GetUnique() {
+		if fieldByName(msg, n) == nil {
+			return nil, invalid(msg, "unique field %q is not a field of the message", n)
+		}
+	}
+	for _, n := range opts.GetIndexes() {
+		if fieldByName(msg, n) == nil {
+			return nil, invalid(msg, "indexed field %q is not a field of the message", n)
+		}
+	}
+	for _, o := range opts.GetOrderBy() {
+		order, err := parseOrder(o)
+		if err != nil {
+			return nil, invalid(msg, "order_by %q: %v", o, err)
+		}
+		if fieldByName(msg, order.Field) == nil {
+			return nil, invalid(msg, "order_by field %q is not a field of the message", order.Field)
+		}
+		cfg.Orders = append(cfg.Orders, order)
+	}
+	if len(cfg.Orders) > 0 {
+		cfg.note("order by %v: order_by option", opts.GetOrderBy())
+	}
+	if a := opts.GetAccess(); a != nil {
+		access, err := resolveAccess(msg, a)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Access = access
+		cfg.note("access: owner %q, tenant %q, read %v, create %v, update %v, delete %v", access.OwnerField, access.TenantField, access.Read, access.Create, access.Update, access.Delete)
+	}
+	if err := cfg.resolveVersion(msg, opts.GetVersionField()); err != nil {
+		return nil, err
+	}
+	cfg.Unique = opts.GetUnique()
+	if len(cfg.Unique) == 0 {
+		cfg.Unique = defaultUnique(msg)
+		cfg.note("unique %v: default, fields named email, slug or username", cfg.Unique)
+	} else {
+		cfg.note("unique %v: unique option", cfg.Unique)
+	}
+	cfg.Indexes = opts.GetIndexes()
+	if len(cfg.Indexes) == 0 {
+		cfg.Indexes = defaultIndexes(msg)
+		cfg.note("indexes %v: default, enum, *_id, status and role fields", cfg.Indexes)
+	} else {
+		cfg.note("indexes %v: indexes option", cfg.Indexes)
+	}
+
+	hasDeletedAt := isTimestamp(fieldByName(msg, "deleted_at"))
+	cfg.SoftDelete = hasDeletedAt && d.SoftDelete
+	if opts.SoftDelete != nil {
+		if opts.GetSoftDelete() && !hasDeletedAt {
+			return nil, invalid(msg, "soft_delete requires a google.protobuf.Timestamp deleted_at field")
+		}
+		cfg.SoftDelete = opts.GetSoftDelete()
+		cfg.note("soft delete %t: soft_delete option", cfg.SoftDelete)
+	} else {
+		cfg.note("soft delete %t: deleted_at timestamp %t, plugin default %t", cfg.SoftDelete, hasDeletedAt, d.SoftDelete)
+	}
+
+	hasTimestamps := isTimestamp(fieldByName(msg, "created_at")) || isTimestamp(fieldByName(msg, "updated_at"))
+	cfg.Timestamps = hasTimestamps && d.Timestamps
+	if opts.Timestamps != nil {
+		if opts.GetTimestamps() && !hasTimestamps {
+			return nil, invalid(msg, "timestamps requires google.protobuf.Timestamp created_at/updated_at fields")
+		}
+		cfg.Timestamps = opts.GetTimestamps()
+		cfg.note("timestamps %t: timestamps option", cfg.Timestamps)
+	} else {
+		cfg.note("timestamps %t: created_at/updated_at timestamps %t, plugin default %t", cfg.Timestamps, hasTimestamps, d.Timestamps)
+	}
+	return cfg, nil
+}
+
+func resolveAccess(msg *protogen.Message, a *entity.AccessOptions) (*Access, error) {
+	access := &Access{
+		OwnerField: a.GetOwnerField(), TenantField: a.GetTenantField(),
+		Read: a.GetRead(), Create: a.GetCreate(), Update: a.GetUpdate(), Delete: a.GetDelete(),
+	}
+	for _, f := range []struct{ option, name string }{{"owner_field", access.OwnerField}, {"tenant_field", access.TenantField}} {
+		if f.name == "" {
+			continue
+		}
+		field := fieldByName(msg, f.name)
+		if field == nil {
+			return nil, invalid(msg, "access %s %q is not a field of the message", f.option, f.name)
+		}
+		if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList() {
+			return nil, invalid(msg, "access %s %q must be a string field", f.option, f.name)
+		}
+		access.Immutable = append(access.Immutable, f.name)
+	}
+	for _, n := range a.GetImmutable() {
+		if fieldByName(msg, n) == nil {
+			return nil, invalid(msg, "immutable field %q is not a field of the message", n)
+		}
+		if !containsFold(access.Immutable, n) {
+			access.Immutable = append(access.Immutable, n)
+		}
+	}
+	return access, nil
+}
+
+// resolveVersion picks the optimistic concurrency field: the version_field
+// option, else a field named version or etag.
+func (c *Config) resolveVersion(msg *protogen.Message, name string) error {
+	why := "version_field option"
+	if name == "" {
+		for _, n := range []string{"version", "etag"} {
+			if f := fieldByName(msg, n); f != nil && versionKind(f) != "" {
+				name, why = n, "default, field named version or etag"
+				break
+			}
+		}
+		if name == "" {
+			return nil
+		}
+	}
+	f := fieldByName(msg, name)
+	if f == nil {
+		return invalid(msg, "version_field %q is not a field of the message", name)
+	}
+	if string(f.Desc.Name()) == c.IDField {
+		return invalid(msg, "version_field %q is the ID field", name)
+	}
+	kind := versionKind(f)
+	if kind == "" {
+		return invalid(msg, "version_field %q must be an int32/int64 counter or a string etag", name)
+	}
+	c.Version, c.ETag = string(f.Desc.Name()), kind == "etag"
+	c.note("%s %s guards updates: %s", kind, c.Version, why)
+	return nil
+}
+
+// versionKind returns "version" for a field that can count versions,
+// "etag" for one that can hold an etag, and "" otherwise.
+func versionKind(f *protogen.Field) string {
+	if f.Desc.IsList() || f.Desc.IsMap() {
+		return ""
+	}
+	switch f.Desc.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return "version"
+	case protoreflect.StringKind:
+		return "etag"
+	}
+	return ""
+}
+
+// parseOrder parses an order_by entry: a field name, optionally followed by
+// asc or desc.
+func parseOrder(s string) (Order, error) {
+	parts := strings.Fields(s)
+	switch {
+	case len(parts) == 1:
+		return Order{Field: parts[0]}, nil
+	case len(parts) == 2 && strings.EqualFold(parts[1], "asc"):
+		return Order{Field: parts[0]}, nil
+	case len(parts) == 2 && strings.EqualFold(parts[1], "desc"):
+		return Order{Field: parts[0], Desc: true}, nil
+	default:
+		return Order{}, fmt.Errorf(`want "field", "field asc" or "field desc"`)
+	}
+}
+
+// invalid reports an entity option msg cannot satisfy, at msg's declaration.
+func invalid(msg *protogen.Message, format string, args ...interface{}) error {
+	return diag.Errorf(msg.Desc.ParentFile(), msg.Location, "%s: %s", msg.Desc.FullName(), fmt.Sprintf(format, args...))
+}
+
+func (c *Config) note(format string, args ...interface{}) {
+	c.Notes = append(c.Notes, fmt.Sprintf(format, args...))
+}
+
+// FindIDField picks the primary key of msg: a field named id, then the first
+// field ending in _id, then the first string field. It returns nil when the
+// message has none of these.
+func FindIDField(msg *protogen.Message) *protogen.Field {
+	f, _ := findIDField(msg)
+	return f
+}
+
+// findIDField is FindIDField, also returning which rule picked the field.
+func findIDField(msg *protogen.Message) (*protogen.Field, string) {
+	for _, f := range msg.Fields {
+		if strings.EqualFold(string(f.Desc.Name()), "id") {
+			return f, "field named id"
+		}
+	}
+	for _, f := range msg.Fields {
+		if strings.HasSuffix(strings.ToLower(string(f.Desc.Name())), "_id") {
+			return f, "first field ending in _id"
+		}
+	}
+	for _, f := range msg.Fields {
+		if f.Desc.Kind() == protoreflect.StringKind {
+			return f, "first string field (fallback: no id or *_id field)"
+		}
+	}
+	return nil, ""
+}
+
+func defaultUnique(msg *protogen.Message) []string {
+	var names []string
+	for _, f := range msg.Fields {
+		name := string(f.Desc.Name())
+		if strings.EqualFold(name, "email") || strings.EqualFold(name, "slug") || strings.EqualFold(name, "username") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func defaultIndexes(msg *protogen.Message) []string {
+	var names []string
+	for _, f := range msg.Fields {
+		name := string(f.Desc.Name())
+		if f.Desc.Kind() == protoreflect.EnumKind ||
+			strings.HasSuffix(strings.ToLower(name), "_id") ||
+			strings.EqualFold(name, "status") || strings.EqualFold(name, "role") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func hasIDField(msg *protogen.Message) bool {
+	for _, f := range msg.Fields {
+		if strings.EqualFold(string(f.Desc.Name()), "id") {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldByName(msg *protogen.Message, name string) *protogen.Field {
+	for _, f := range msg.Fields {
+		if string(f.Desc.Name()) == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func isTimestamp(f *protogen.Field) bool {
+	return f != nil && f.Message != nil && f.Message.Desc.FullName() == "google.protobuf.Timestamp"
+}
+
+func containsFold(xs []string, s string) bool {
+	for _, x := range xs {
+		if strings.EqualFold(x, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSnakeCase(s string) string {
+	var result []rune
+	for i, r := range s {
+		if i > 0 && unicode.IsUpper(r) {
+			result = append(result, '_')
+		}
+		result = append(result, unicode.ToLower(r))
+	}
+	return string(result)
+}
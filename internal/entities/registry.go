@@ -0,0 +1,246 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Registry resolves entities, feature messages and services across every file
+// of a request, including files that are only imported. Plugins build one per
+// protogen.Plugin, so a service in one file sees the entities declared in
+// another and a message embedding an imported AuthEmail is still found.
+//
+// The Go package, not the proto file, is the unit of resolution: generated
+// code that refers to an entity by its bare name (the Repositories struct,
+// servers, stubs) can only use entities of its own package.
+type Registry struct {
+	messages map[protoreflect.FullName]*protogen.Message
+	configs  map[protoreflect.FullName]*Config
+	declared map[protoreflect.FullName]bool
+	packages []protogen.GoImportPath // packages with files to generate, in request order
+	byPath   map[protogen.GoImportPath][]*protogen.File
+}
+
+// NewRegistry indexes gen with the Inferred defaults.
+func NewRegistry(gen *protogen.Plugin) (*Registry, error) { return Inferred.Registry(gen) }
+
+// registries holds the registry built for each request and set of defaults.
+// Generators that protoc-gen-fullstack runs over one request thereby share a
+// single analysis instead of each re-deriving it.
+var registries sync.Map // registryKey -> *Registry
+
+type registryKey struct {
+	gen *protogen.Plugin
+	d   Defaults
+}
+
+// Registry indexes every file of gen and resolves its entities with d. It
+// fails when an entity option, in any file, refers to fields its message does
+// not have. Repeated calls for the same gen and defaults return the same
+// registry.
+func (d Defaults) Registry(gen *protogen.Plugin) (*Registry, error) {
+	key := registryKey{gen, d}
+	if r, ok := registries.Load(key); ok {
+		return r.(*Registry), nil
+	}
+	r, err := d.build(gen)
+	if err != nil {
+		return nil, err
+	}
+	registries.Store(key, r)
+	return r, nil
+}
+
+func (d Defaults) build(gen *protogen.Plugin) (*Registry, error) {
+	r := &Registry{
+		messages: make(map[protoreflect.FullName]*protogen.Message),
+		configs:  make(map[protoreflect.FullName]*Config),
+		declared: make(map[protoreflect.FullName]bool),
+		byPath:   make(map[protogen.GoImportPath][]*protogen.File),
+	}
+	hasDeclared := make(map[protogen.GoImportPath]bool)
+	for _, f := range gen.Files {
+		if f.Generate && !r.generates(f.GoImportPath) {
+			r.packages = append(r.packages, f.GoImportPath)
+		}
+		r.byPath[f.GoImportPath] = append(r.byPath[f.GoImportPath], f)
+		r.index(f.Messages)
+		for _, msg := range f.Messages {
+			config, err := d.Lookup(msg)
+			if err != nil {
+				return nil, err
+			}
+			if config != nil {
+				r.configs[msg.Desc.FullName()] = config
+				r.declared[msg.Desc.FullName()] = true
+				hasDeclared[f.GoImportPath] = true
+			}
+		}
+	}
+
+	// Packages that declare no entity option fall back to treating every
+	// message with an id field as an entity (see Entities).
+	for _, f := range gen.Files {
+		if hasDeclared[f.GoImportPath] {
+			continue
+		}
+		for _, msg := range f.Messages {
+			if !hasIDField(msg) {
+				continue
+			}
+			config, err := d.Infer(msg)
+			if err != nil {
+				return nil, err
+			}
+			r.configs[msg.Desc.FullName()] = config
+		}
+	}
+	return r, nil
+}
+
+func (r *Registry) index(msgs []*protogen.Message) {
+	for _, msg := range msgs {
+		r.messages[msg.Desc.FullName()] = msg
+		r.index(msg.Messages)
+	}
+}
+
+func (r *Registry) generates(path protogen.GoImportPath) bool {
+	for _, p := range r.packages {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Message returns the message, nested or not, with the given full name.
+func (r *Registry) Message(name protoreflect.FullName) *protogen.Message { return r.messages[name] }
+
+// Config returns the entity configuration of msg, or nil when msg is not an
+// entity.
+func (r *Registry) Config(msg *protogen.Message) *Config { return r.configs[msg.Desc.FullName()] }
+
+// Entities returns the entities declared in f, in declaration order. When
+// inferIDs is set and no file of f's Go package declares an entity option,
+// every message of f with an id field is an entity.
+func (r *Registry) Entities(f *protogen.File, inferIDs bool) []*protogen.Message {
+	var msgs []*protogen.Message
+	for _, msg := range f.Messages {
+		name := msg.Desc.FullName()
+		if r.declared[name] || inferIDs && r.configs[name] != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// Why says why msg is not among Entities(f, inferIDs) for its file, or
+// returns "" when it is.
+func (r *Registry) Why(msg *protogen.Message, inferIDs bool) string {
+	name := msg.Desc.FullName()
+	switch {
+	case r.declared[name]:
+		return ""
+	case !inferIDs:
+		return "no (entity.entity) option"
+	case r.configs[name] != nil:
+		return ""
+	case !hasIDField(msg):
+		return "no (entity.entity) option and no id field"
+	default:
+		return "no (entity.entity) option; id fields only count when the Go package declares no entity options"
+	}
+}
+
+// Package returns the entities of every file in the Go package at path,
+// whether or not the file is being generated, in request order.
+func (r *Registry) Package(path protogen.GoImportPath, inferIDs bool) []*protogen.Message {
+	var msgs []*protogen.Message
+	for _, f := range r.byPath[path] {
+		msgs = append(msgs, r.Entities(f, inferIDs)...)
+	}
+	return msgs
+}
+
+// Services returns the services of every file in the Go package at path.
+func (r *Registry) Services(path protogen.GoImportPath) []*protogen.Service {
+	var svcs []*protogen.Service
+	for _, f := range r.byPath[path] {
+		svcs = append(svcs, f.Services...)
+	}
+	return svcs
+}
+
+// Packages returns the Go packages that have files to generate, in request
+// order. Plugins that emit one file per Go package iterate these.
+func (r *Registry) Packages() []protogen.GoImportPath { return r.packages }
+
+// Anchor returns the file a per-package output is named after: the package's
+// first file by path. It does not depend on which of the package's files a
+// run generates, so repeated runs overwrite the same output.
+func (r *Registry) Anchor(path protogen.GoImportPath) *protogen.File {
+	files := append([]*protogen.File(nil), r.byPath[path]...)
+	if len(files) == 0 {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Desc.Path() < files[j].Desc.Path() })
+	return files[0]
+}
+
+// Feature returns the message named name that f declares or that a message of
+// f embeds as a field, possibly from an imported file. It returns nil when f
+// neither declares nor embeds one.
+func (r *Registry) Feature(f *protogen.File, name string) *protogen.Message {
+	for _, msg := range f.Messages {
+		if msg.GoIdent.GoName == name {
+			return msg
+		}
+	}
+	for _, msg := range f.Messages {
+		for _, field := range msg.Fields {
+			if field.Message != nil && field.Message.GoIdent.GoName == name {
+				return field.Message
+			}
+		}
+	}
+	return nil
+}
+
+// Embedders returns the messages of f with a field of type feature, paired
+// with the first such field.
+func (r *Registry) Embedders(f *protogen.File, feature *protogen.Message) []Embedding {
+	var out []Embedding
+	for _, msg := range f.Messages {
+		for _, field := range msg.Fields {
+			if field.Message != nil && field.Message.Desc.FullName() == feature.Desc.FullName() {
+				out = append(out, Embedding{Parent: msg, Field: field})
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Embedding is a message field whose type is a feature message.
+type Embedding struct {
+	Parent *protogen.Message
+	Field  *protogen.Field
+}
+
+// Alias returns what a file in the Go package path needs in order to refer to
+// msg by its bare Go name: an import spec and a type alias declaration. Both
+// are empty when msg is declared in path itself.
+func (r *Registry) Alias(path protogen.GoImportPath, msg *protogen.Message) (importSpec, alias string) {
+	from := msg.GoIdent.GoImportPath
+	if from == path || len(r.byPath[from]) == 0 {
+		return "", ""
+	}
+	pkg := r.byPath[from][0].GoPackageName
+	return fmt.Sprintf("%s %q", pkg, string(from)),
+		fmt.Sprintf("type %s = %s.%s", msg.GoIdent.GoName, pkg, msg.GoIdent.GoName)
+}
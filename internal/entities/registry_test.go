@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestRegistryResolvesAcrossFiles(t *testing.T) {
+	// Only service.proto is generated; the entities come from its import.
+	req := plugintest.Request(t, plugintest.Case{Files: []string{"crm/v1/service.proto"}})
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := NewRegistry(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const pkg = protogen.GoImportPath("example.com/shop/gen/crm/v1")
+	if got := reg.Packages(); len(got) != 1 || got[0] != pkg {
+		t.Fatalf("Packages = %v, want [%s]", got, pkg)
+	}
+	if got := names(reg.Package(pkg, false)); got != "Contact,Member" {
+		t.Errorf("Package = %s, want Contact,Member", got)
+	}
+	if svcs := reg.Services(pkg); len(svcs) != 1 || svcs[0].GoName != "ContactService" {
+		t.Errorf("Services = %v, want [ContactService]", svcs)
+	}
+	if got := reg.Anchor(pkg).Desc.Path(); got != "crm/v1/contact.proto" {
+		t.Errorf("Anchor = %s, want crm/v1/contact.proto", got)
+	}
+
+	contact := reg.Message("crm.v1.Contact")
+	if contact == nil || reg.Config(contact).IDGoName != "ContactId" {
+		t.Fatalf("Contact not resolved: %v", contact)
+	}
+
+	// Member embeds account.v1.AuthEmail from another Go package.
+	contactFile := reg.Anchor(pkg)
+	authEmail := reg.Feature(contactFile, "AuthEmail")
+	if authEmail == nil || authEmail.Desc.FullName() != "account.v1.AuthEmail" {
+		t.Fatalf("Feature(AuthEmail) = %v", authEmail)
+	}
+	if e := reg.Embedders(contactFile, authEmail); len(e) != 1 || e[0].Parent.GoIdent.GoName != "Member" || e[0].Field.GoName != "Auth" {
+		t.Errorf("Embedders = %v, want Member.Auth", e)
+	}
+	spec, alias := reg.Alias(pkg, authEmail)
+	if spec != `accountv1 "example.com/shop/gen/account/v1"` || alias != "type AuthEmail = accountv1.AuthEmail" {
+		t.Errorf("Alias = %q, %q", spec, alias)
+	}
+	if spec, alias := reg.Alias("example.com/shop/gen/account/v1", authEmail); spec != "" || alias != "" {
+		t.Errorf("Alias in own package = %q, %q, want empty", spec, alias)
+	}
+}
+
+func names(msgs []*protogen.Message) string {
+	var s string
+	for i, m := range msgs {
+		if i > 0 {
+			s += ","
+		}
+		s += m.GoIdent.GoName
+	}
+	return s
+}
@@ -0,0 +1,292 @@
+// Package explain reports what a plugin detected and why.
+//
+// Plugin detection is heuristic: ID fields fall back to the first string
+// field, geo fields match on name fragments, validation rules are guessed
+// from field names. Every plugin declares the shared explain parameters
+// through Declare; with explain=true it runs its detection as usual but, in
+// place of code, writes one report listing every message and method of the
+// files to generate, the pattern it detected (or that it detected none), the
+// field chosen as ID, the rules it inferred and the outputs it would have
+// written, and the diagnostics it raised:
+//
+//	opt:
+//	  - explain=true
+//	  - explain_format=json   # markdown (default) or json
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Settings holds the explain parameters of one plugin.
+type Settings struct {
+	plugin  string
+	enabled *bool
+	format  *string
+	diag    *diag.Settings
+}
+
+// Declare adds the parameters every plugin shares to flags: explain,
+// explain_format and the diagnostics' strict. plugin names the report
+// (protoc-gen-firestore writes protoc-gen-firestore.explain.md) and the
+// warnings file.
+func Declare(flags *params.Set, plugin string) *Settings {
+	return &Settings{
+		plugin:  plugin,
+		enabled: flags.Bool("explain", false, "write a report of what was detected and why instead of code"),
+		format:  flags.String("explain_format", "markdown", "format of the explain report: markdown or json"),
+		diag:    diag.Declare(flags),
+	}
+}
+
+// Wrap turns generate into a protogen generator. generate records its
+// decisions on the report it is handed; the report is nil, and recording a
+// no-op, unless explain is set. It records the problems it finds in the
+// input on diags, which are surfaced once it returns (see diag.Set.Finish).
+//
+// With explain set, generate runs against a copy of the request so that the
+// code it produces is dropped, and the report is written in its place. A
+// generation error and the diagnostics are part of the report rather than a
+// failure.
+func (s *Settings) Wrap(generate func(*protogen.Plugin, *Report, *diag.Set) error) func(*protogen.Plugin) error {
+	return func(gen *protogen.Plugin) error {
+		if !*s.enabled {
+			diags := s.diag.New(gen)
+			if err := generate(gen, nil, diags); err != nil {
+				return err
+			}
+			return diags.Finish(s.plugin)
+		}
+		if *s.format != "markdown" && *s.format != "json" {
+			return fmt.Errorf("invalid value %q for parameter \"explain_format\": want markdown or json", *s.format)
+		}
+
+		// The parameters were validated when gen was built.
+		shadow, err := protogen.Options{ParamFunc: func(string, string) error { return nil }}.New(gen.Request)
+		if err != nil {
+			return err
+		}
+		rep := newReport(s.plugin, shadow)
+		diags := s.diag.New(shadow)
+		if err := generate(shadow, rep, diags); err != nil {
+			rep.Error = err.Error()
+		}
+		for _, d := range diags.Diagnostics() {
+			rep.Diagnostics = append(rep.Diagnostics, d.Error())
+		}
+		if resp := shadow.Response(); resp.Error != nil {
+			rep.Error = resp.GetError()
+		} else {
+			for _, f := range resp.File {
+				rep.Outputs = append(rep.Outputs, f.GetName())
+			}
+		}
+		gen.SupportedFeatures = shadow.SupportedFeatures
+
+		if *s.format == "json" {
+			b, err := json.MarshalIndent(rep, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = gen.NewGeneratedFile(s.plugin+".explain.json", "").Write(append(b, '\n'))
+			return err
+		}
+		_, err = gen.NewGeneratedFile(s.plugin+".explain.md", "").Write([]byte(rep.Markdown()))
+		return err
+	}
+}
+
+// Report is what a plugin detected in one request.
+type Report struct {
+	Plugin      string   `json:"plugin"`
+	Files       []*File  `json:"files"`
+	Outputs     []string `json:"outputs"` // files the plugin would have written
+	Diagnostics []string `json:"diagnostics,omitempty"`
+	Error       string   `json:"error,omitempty"`
+
+	entries map[protoreflect.FullName]*Entry
+}
+
+// File lists the messages and methods of one file to generate.
+type File struct {
+	Path     string   `json:"path"`
+	Messages []*Entry `json:"messages"`
+	Methods  []*Entry `json:"methods"`
+}
+
+// Entry is what the plugin made of one message or method.
+type Entry struct {
+	Name     string   `json:"name"`               // full proto name
+	Detected []string `json:"detected,omitempty"` // patterns matched; empty when none did
+	ID       string   `json:"id,omitempty"`       // field chosen as ID
+	Rules    []string `json:"rules,omitempty"`    // rules inferred, one per field and rule
+	Reasons  []string `json:"reasons,omitempty"`  // why
+}
+
+func newReport(plugin string, gen *protogen.Plugin) *Report {
+	r := &Report{Plugin: plugin, Outputs: []string{}, entries: make(map[protoreflect.FullName]*Entry)}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		file := &File{Path: f.Desc.Path(), Messages: []*Entry{}, Methods: []*Entry{}}
+		var walk func([]*protogen.Message)
+		walk = func(msgs []*protogen.Message) {
+			for _, msg := range msgs {
+				if msg.Desc.IsMapEntry() {
+					continue
+				}
+				file.Messages = append(file.Messages, r.entry(msg.Desc.FullName()))
+				walk(msg.Messages)
+			}
+		}
+		walk(f.Messages)
+		for _, svc := range f.Services {
+			for _, m := range svc.Methods {
+				file.Methods = append(file.Methods, r.entry(m.Desc.FullName()))
+			}
+		}
+		r.Files = append(r.Files, file)
+	}
+	return r
+}
+
+func (r *Report) entry(name protoreflect.FullName) *Entry {
+	e := &Entry{Name: string(name)}
+	r.entries[name] = e
+	return e
+}
+
+// Message returns the entry of msg. It returns nil on a nil report, and for
+// messages outside the files to generate.
+func (r *Report) Message(msg *protogen.Message) *Entry {
+	if r == nil {
+		return nil
+	}
+	return r.entries[msg.Desc.FullName()]
+}
+
+// Method returns the entry of m, like Message.
+func (r *Report) Method(m *protogen.Method) *Entry {
+	if r == nil {
+		return nil
+	}
+	return r.entries[m.Desc.FullName()]
+}
+
+// Detect records that pattern matched, and why.
+func (e *Entry) Detect(pattern string, reasons ...string) {
+	if e == nil {
+		return
+	}
+	e.Detected = append(e.Detected, pattern)
+	e.Reasons = append(e.Reasons, reasons...)
+}
+
+// Because records why something was, or was not, detected.
+func (e *Entry) Because(format string, args ...interface{}) {
+	if e == nil {
+		return
+	}
+	e.Reasons = append(e.Reasons, fmt.Sprintf(format, args...))
+}
+
+// Rule records a rule inferred for field.
+func (e *Entry) Rule(field, rule, reason string) {
+	if e == nil {
+		return
+	}
+	r := field + ": " + rule
+	if reason != "" {
+		r += " (" + reason + ")"
+	}
+	e.Rules = append(e.Rules, r)
+}
+
+// Entity records that the message is an entity resolved as config.
+func (e *Entry) Entity(config *entities.Config) {
+	if e == nil {
+		return
+	}
+	e.Detected = append(e.Detected, "entity")
+	e.ID = config.IDField + " (" + config.IDReason + ")"
+	e.Reasons = append(e.Reasons, config.Notes...)
+}
+
+// Entities records, for every message of f, whether reg resolves it as an
+// entity (see Registry.Entities) and why.
+func (r *Report) Entities(reg *entities.Registry, f *protogen.File, inferIDs bool) {
+	if r == nil {
+		return
+	}
+	for _, msg := range f.Messages {
+		if why := reg.Why(msg, inferIDs); why != "" {
+			r.Message(msg).Because("not an entity: %s", why)
+		} else {
+			r.Message(msg).Entity(reg.Config(msg))
+		}
+	}
+}
+
+// Markdown renders the report for reading.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s explain report\n", r.Plugin)
+	if r.Error != "" {
+		fmt.Fprintf(&b, "\nGeneration failed: %s\n", r.Error)
+	}
+	if len(r.Diagnostics) > 0 {
+		b.WriteString("\n## Diagnostics\n\n")
+		for _, d := range r.Diagnostics {
+			fmt.Fprintf(&b, "- %s\n", d)
+		}
+	}
+	b.WriteString("\n## Outputs\n\n")
+	if len(r.Outputs) == 0 {
+		b.WriteString("Nothing would be generated.\n")
+	}
+	outputs := append([]string(nil), r.Outputs...)
+	sort.Strings(outputs)
+	for _, o := range outputs {
+		fmt.Fprintf(&b, "- `%s`\n", o)
+	}
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "\n## %s\n", f.Path)
+		writeEntries(&b, "Messages", f.Messages)
+		writeEntries(&b, "Methods", f.Methods)
+	}
+	return b.String()
+}
+
+func writeEntries(b *strings.Builder, title string, entries []*Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n", title)
+	for _, e := range entries {
+		fmt.Fprintf(b, "\n#### `%s`\n\n", e.Name)
+		if len(e.Detected) == 0 {
+			b.WriteString("- detected: nothing\n")
+		} else {
+			fmt.Fprintf(b, "- detected: %s\n", strings.Join(e.Detected, ", "))
+		}
+		if e.ID != "" {
+			fmt.Fprintf(b, "- id: %s\n", e.ID)
+		}
+		for _, rule := range e.Rules {
+			fmt.Fprintf(b, "- rule: %s\n", rule)
+		}
+		for _, reason := range e.Reasons {
+			fmt.Fprintf(b, "- why: %s\n", reason)
+		}
+	}
+}
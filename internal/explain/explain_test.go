@@ -0,0 +1,72 @@
+package explain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func run(t *testing.T, param string, generate func(*protogen.Plugin, *Report, *diag.Set) error) *protogen.Plugin {
+	t.Helper()
+	var flags params.Set
+	ex := Declare(&flags, "protoc-gen-x")
+	req := plugintest.Request(t, plugintest.Case{Files: []string{"shop/v1/shop.proto"}, Param: param})
+	gen, err := flags.Options().New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.Wrap(generate)(gen); err != nil {
+		gen.Error(err)
+	}
+	return gen
+}
+
+func TestWrapOff(t *testing.T) {
+	gen := run(t, "", func(gen *protogen.Plugin, rep *Report, _ *diag.Set) error {
+		if rep != nil {
+			t.Error("report handed out with explain unset")
+		}
+		// Recording on a nil report is a no-op.
+		rep.Message(gen.Files[0].Messages[0]).Detect("entity", "because")
+		gen.NewGeneratedFile("out.ts", "")
+		return nil
+	})
+	if files := gen.Response().File; len(files) != 1 || files[0].GetName() != "out.ts" {
+		t.Errorf("files = %v, want [out.ts]", files)
+	}
+}
+
+func TestWrapReplacesOutput(t *testing.T) {
+	gen := run(t, "explain=true", func(gen *protogen.Plugin, rep *Report, _ *diag.Set) error {
+		var user *protogen.Message
+		for _, f := range gen.Files {
+			if f.Generate {
+				user = f.Messages[0]
+			}
+		}
+		rep.Message(user).Detect("entity", "has an id")
+		gen.NewGeneratedFile("out.ts", "")
+		return nil
+	})
+	resp := gen.Response()
+	if resp.Error != nil || len(resp.File) != 1 || resp.File[0].GetName() != "protoc-gen-x.explain.md" {
+		t.Fatalf("response = %v", resp)
+	}
+	md := resp.File[0].GetContent()
+	for _, want := range []string{"- `out.ts`", "#### `shop.v1.User`\n\n- detected: entity\n- why: has an id"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report lacks %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestWrapBadFormat(t *testing.T) {
+	gen := run(t, "explain=true,explain_format=yaml", func(*protogen.Plugin, *Report, *diag.Set) error { return nil })
+	if got := gen.Response().GetError(); !strings.Contains(got, `invalid value "yaml"`) {
+		t.Errorf("error = %q", got)
+	}
+}
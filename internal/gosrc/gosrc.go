@@ -0,0 +1,197 @@
+// Package gosrc is the last step of every Go-emitting plugin: it formats the
+// generated source with go/format and prunes the imports it does not use, the
+// way goimports would, so generators can declare imports unconditionally.
+//
+// Source that does not parse is never written. The error names the file and
+// the offending line so protoc/buf can report it in place of broken output.
+package gosrc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Generate formats src and writes it to a new generated file. It creates no
+// file when src does not parse.
+func Generate(gen *protogen.Plugin, filename string, importPath protogen.GoImportPath, src string) error {
+	out, err := Format(filename, []byte(src))
+	if err != nil {
+		return err
+	}
+	gen.NewGeneratedFile(filename, importPath).P(string(out))
+	return nil
+}
+
+// Format parses src, removes unused imports and returns the gofmt-ed result.
+func Format(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, parseError(filename, src, err)
+	}
+	// Cutting the unused specs out of the text, rather than the AST, keeps
+	// the remaining imports grouped exactly as the generator wrote them.
+	if cuts := unusedImports(fset, file, src); len(cuts) > 0 {
+		src = remove(src, cuts)
+		fset = token.NewFileSet()
+		if file, err = parser.ParseFile(fset, filename, src, parser.ParseComments); err != nil {
+			return nil, fmt.Errorf("%s: pruning imports: %v", filename, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseError reports the first syntax error with the source line it is on.
+func parseError(filename string, src []byte, err error) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) == 0 {
+		return fmt.Errorf("%s: generated invalid Go: %v", filename, err)
+	}
+	first := list[0]
+	msg := fmt.Sprintf("%s:%d:%d: generated invalid Go: %s", filename, first.Pos.Line, first.Pos.Column, first.Msg)
+	if len(list) > 1 {
+		msg += fmt.Sprintf(" (and %d more errors)", len(list)-1)
+	}
+	lines := strings.Split(string(src), "\n")
+	if n := first.Pos.Line; n >= 1 && n <= len(lines) {
+		msg += fmt.Sprintf("\n\t%d: %s", n, strings.TrimRight(lines[n-1], " \t"))
+	}
+	return errors.New(msg)
+}
+
+// span is a byte range [start, end) of the source.
+type span struct{ start, end int }
+
+// unusedImports returns the source ranges of the import specs no selector
+// expression refers to, or of the whole declaration when none is used.
+// Blank, dot and cgo imports are always kept.
+func unusedImports(fset *token.FileSet, file *ast.File, src []byte) []span {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	offset := func(p token.Pos) int { return fset.Position(p).Offset }
+	var cuts []span
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		var unused []span
+		for _, spec := range gd.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if keep(imp, used) {
+				continue
+			}
+			start, end := imp.Pos(), imp.End()
+			if imp.Doc != nil {
+				start = imp.Doc.Pos()
+			}
+			if imp.Comment != nil {
+				end = imp.Comment.End()
+			}
+			unused = append(unused, wholeLines(src, offset(start), offset(end)))
+		}
+		if len(unused) == 0 {
+			continue
+		}
+		if len(unused) < len(gd.Specs) {
+			cuts = append(cuts, unused...)
+			continue
+		}
+		start := gd.Pos()
+		if gd.Doc != nil {
+			start = gd.Doc.Pos()
+		}
+		cuts = append(cuts, wholeLines(src, offset(start), offset(gd.End())))
+	}
+	return cuts
+}
+
+// wholeLines widens [start, end) to full lines when nothing else shares them.
+func wholeLines(src []byte, start, end int) span {
+	s, e := start, end
+	for s > 0 && (src[s-1] == ' ' || src[s-1] == '\t') {
+		s--
+	}
+	for e < len(src) && (src[e] == ' ' || src[e] == '\t' || src[e] == '\r') {
+		e++
+	}
+	if (s == 0 || src[s-1] == '\n') && (e == len(src) || src[e] == '\n') {
+		if e < len(src) {
+			e++
+		}
+		return span{s, e}
+	}
+	return span{start, end}
+}
+
+// remove deletes the ascending, non-overlapping cuts from src.
+func remove(src []byte, cuts []span) []byte {
+	var out []byte
+	last := 0
+	for _, c := range cuts {
+		out = append(out, src[last:c.start]...)
+		last = c.end
+	}
+	return append(out, src[last:]...)
+}
+
+func keep(imp *ast.ImportSpec, used map[string]bool) bool {
+	importPath, err := strconv.Unquote(imp.Path.Value)
+	if err != nil || importPath == "C" {
+		return true
+	}
+	name := AssumedName(importPath)
+	if imp.Name != nil {
+		if imp.Name.Name == "_" || imp.Name.Name == "." {
+			return true
+		}
+		name = imp.Name.Name
+	}
+	return used[name]
+}
+
+// AssumedName is the package name goimports assumes for an import path: the
+// last element, skipping a major-version suffix and a "go-" prefix, cut at
+// the first character that cannot appear in an identifier.
+func AssumedName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
@@ -0,0 +1,98 @@
+package gosrc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPrunesUnusedImports(t *testing.T) {
+	src := `package x
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	_ "embed"
+
+	"github.com/google/wire"
+	stripe "github.com/stripe/stripe-go/v76"
+	"github.com/brianvoe/gofakeit/v6"
+	pb "example.com/gen/shop/v1"
+)
+
+func F(ctx context.Context) (*pb.User, error) {
+  if ctx == nil { return nil, errors.New("nil") }
+	_ = gofakeit.Name()
+    return nil, nil
+}
+`
+	want := `package x
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+
+	pb "example.com/gen/shop/v1"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+func F(ctx context.Context) (*pb.User, error) {
+	if ctx == nil {
+		return nil, errors.New("nil")
+	}
+	_ = gofakeit.Name()
+	return nil, nil
+}
+`
+	got, err := Format("x.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatDropsEmptyImportDecl(t *testing.T) {
+	src := "package x\n\nimport \"fmt\"\n\nimport (\n\t\"errors\"\n)\n\nvar X = 1\n"
+	got, err := Format("x.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package x\n\nvar X = 1\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatReportsBadLine(t *testing.T) {
+	src := "package x\n\nfunc F() {\n\tx := T{A: 1\n\t, B: 2}\n}\n"
+	_, err := Format("gen/x.pb.go", []byte(src))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	msg := err.Error()
+	for _, want := range []string{"gen/x.pb.go:4:", "4: \tx := T{A: 1"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q does not contain %q", msg, want)
+		}
+	}
+}
+
+func TestAssumedName(t *testing.T) {
+	for path, want := range map[string]string{
+		"context":                                            "context",
+		"net/http":                                           "http",
+		"github.com/stripe/stripe-go/v76":                    "stripe",
+		"github.com/golang-jwt/jwt/v5":                       "jwt",
+		"github.com/go-chi/chi":                              "chi",
+		"connectrpc.com/connect":                             "connect",
+		"gopkg.in/yaml.v3":                                   "yaml",
+		"example.com/gen/shop/v1/shopv1connect":              "shopv1connect",
+		"google.golang.org/protobuf/types/known/timestamppb": "timestamppb",
+	} {
+		if got := AssumedName(path); got != want {
+			t.Errorf("AssumedName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package params parses plugin parameters (the "opt:" entries in
+// buf.gen.yaml, or --<plugin>_opt for protoc) into typed settings.
+//
+// Every plugin declares its settings on a Set and runs through
+// Set.Options, so unknown keys fail generation instead of being ignored:
+//
+//	var flags params.Set
+//	region := flags.String("region", "us-central1", "Cloud Run region")
+//	flags.Options().Run(func(gen *protogen.Plugin) error { ... })
+//
+// The standard protogen parameters (paths, module, M...) are handled by
+// protogen itself and never reach the Set.
+package params
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Set is a collection of typed plugin parameters. The zero value is ready
+// to use.
+type Set struct {
+	fs *flag.FlagSet
+}
+
+func (s *Set) flags() *flag.FlagSet {
+	if s.fs == nil {
+		s.fs = flag.NewFlagSet("params", flag.ContinueOnError)
+		s.fs.SetOutput(io.Discard)
+	}
+	return s.fs
+}
+
+// Bool declares a boolean parameter. A bare key ("opt: cors") sets it to true.
+func (s *Set) Bool(name string, value bool, usage string) *bool {
+	return s.flags().Bool(name, value, usage)
+}
+
+// String declares a string parameter.
+func (s *Set) String(name, value, usage string) *string {
+	return s.flags().String(name, value, usage)
+}
+
+// Int declares an integer parameter.
+func (s *Set) Int(name string, value int, usage string) *int {
+	return s.flags().Int(name, value, usage)
+}
+
+// Func declares a parameter that calls fn with each value it is given. A
+// bare key passes the empty string.
+func (s *Set) Func(name, usage string, fn func(string) error) {
+	s.flags().Func(name, usage, fn)
+}
+
+// Set assigns a parameter by name. It has the signature of
+// protogen.Options.ParamFunc and rejects keys that were not declared.
+func (s *Set) Set(name, value string) error {
+	f := s.flags().Lookup(name)
+	if f == nil {
+		supported := s.Names()
+		if len(supported) == 0 {
+			return fmt.Errorf("unknown parameter %q (this plugin takes no parameters)", name)
+		}
+		return fmt.Errorf("unknown parameter %q (supported: %s)", name, strings.Join(supported, ", "))
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() && value == "" {
+		value = "true"
+	}
+	if err := s.flags().Set(name, value); err != nil {
+		return fmt.Errorf("invalid value %q for parameter %q: %s", value, name, f.Usage)
+	}
+	return nil
+}
+
+// Names returns the declared parameter names in sorted order.
+func (s *Set) Names() []string {
+	var names []string
+	s.flags().VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	sort.Strings(names)
+	return names
+}
+
+// Options returns protogen options that route parameters through s.
+func (s *Set) Options() protogen.Options {
+	return protogen.Options{ParamFunc: s.Set}
+}
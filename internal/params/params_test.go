@@ -0,0 +1,110 @@
+package params
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// values is what the parameters declared by declare hold.
+type values struct {
+	Cors   bool
+	Region string
+	Port   int
+	Enums  []string
+}
+
+// declare declares the parameters of a plugin on s and returns the function
+// that reads them.
+func declare(s *Set) func() values {
+	var enums []string
+	cors := s.Bool("cors", false, "wrap handlers in a CORS middleware")
+	region := s.String("region", "us-central1", "Cloud Run region")
+	port := s.Int("port", 8080, "port to listen on")
+	s.Func("enums", "enum storage: name or number", func(value string) error {
+		if value != "name" && value != "number" {
+			return errors.New("want name or number")
+		}
+		enums = append(enums, value)
+		return nil
+	})
+	return func() values {
+		return values{Cors: *cors, Region: *region, Port: *port, Enums: enums}
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		params [][2]string
+		want   values
+		err    string
+	}{
+		{name: "defaults", want: values{Region: "us-central1", Port: 8080}},
+		{name: "bare bool", params: [][2]string{{"cors", ""}}, want: values{Cors: true, Region: "us-central1", Port: 8080}},
+		{name: "bool", params: [][2]string{{"cors", "false"}}, want: values{Region: "us-central1", Port: 8080}},
+		{name: "string and int", params: [][2]string{{"region", "europe-west1"}, {"port", "9090"}}, want: values{Region: "europe-west1", Port: 9090}},
+		{name: "func hook", params: [][2]string{{"enums", "name"}, {"enums", "number"}}, want: values{Region: "us-central1", Port: 8080, Enums: []string{"name", "number"}}},
+		{name: "unknown key", params: [][2]string{{"color", "red"}}, err: `unknown parameter "color" (supported: cors, enums, port, region)`},
+		{name: "malformed bool", params: [][2]string{{"cors", "maybe"}}, err: `invalid value "maybe" for parameter "cors": wrap handlers in a CORS middleware`},
+		{name: "malformed int", params: [][2]string{{"port", "80x"}}, err: `invalid value "80x" for parameter "port": port to listen on`},
+		{name: "func hook error", params: [][2]string{{"enums", "ordinal"}}, err: `invalid value "ordinal" for parameter "enums": enum storage: name or number`},
+		{name: "bare func hook", params: [][2]string{{"enums", ""}}, err: `invalid value "" for parameter "enums"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Set
+			read := declare(&s)
+			var err error
+			for _, p := range tt.params {
+				if err = s.Set(p[0], p[1]); err != nil {
+					break
+				}
+			}
+			if tt.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := read(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoParameters(t *testing.T) {
+	var s Set
+	err := s.Set("cors", "true")
+	if err == nil || err.Error() != `unknown parameter "cors" (this plugin takes no parameters)` {
+		t.Errorf("err = %v", err)
+	}
+	if names := s.Names(); len(names) != 0 {
+		t.Errorf("Names = %v, want none", names)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	var s Set
+	read := declare(&s)
+	req := &pluginpb.CodeGeneratorRequest{Parameter: proto.String("cors,port=9090,paths=source_relative")}
+	if _, err := s.Options().New(req); err != nil {
+		t.Fatal(err)
+	}
+	if v := read(); !v.Cors || v.Port != 9090 {
+		t.Errorf("cors = %v, port = %d, want true and 9090", v.Cors, v.Port)
+	}
+
+	req.Parameter = proto.String("cors,colour=red")
+	if _, err := s.Options().New(req); err == nil || !strings.Contains(err.Error(), `unknown parameter "colour"`) {
+		t.Errorf("err = %v, want the unknown parameter", err)
+	}
+}
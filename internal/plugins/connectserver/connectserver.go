@@ -0,0 +1,652 @@
+// Package connectserver implements protoc-gen-connect-server, which generates Connect RPC service implementations
+// Fully generic - works with ANY proto file using proper proto reflection.
+// Uses Category Theory: Monoid, Functor (Map), Fold, Filter
+//
+// Pattern Detection (by TYPE signature, not name):
+//   - Get:    Input has ID field referencing entity → Output IS the entity
+//   - List:   Output has repeated entity field
+//   - Delete: Input has ID field referencing entity → Output is Empty
+//   - Update: Input carries the entity → Output IS the entity; Create has the
+//     same shape, so the method name must also start with Update
+//
+// Parameters:
+//   - cors: wrap the generated handlers in a CORS middleware
+//   - auth: wrap the generated handlers in pb.AuthMiddleware (protoc-gen-auth)
+package connectserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// ENTITY INFO - Extracted via proto reflection
+// =============================================================================
+
+type EntityInfo struct {
+	GoName    string
+	IDField   string
+	IDGoName  string
+	RepoField string
+}
+
+func ExtractEntityInfo(msg *protogen.Message, config *entities.Config) EntityInfo {
+	return EntityInfo{
+		GoName:    msg.GoIdent.GoName,
+		IDField:   config.IDField,
+		IDGoName:  config.IDGoName,
+		RepoField: msg.GoIdent.GoName,
+	}
+}
+
+// =============================================================================
+// METHOD PATTERN DETECTION - By type signature
+// =============================================================================
+
+type MethodPattern int
+
+const (
+	PatternUnknown MethodPattern = iota
+	PatternGet
+	PatternList
+	PatternDelete
+	PatternUpdate
+)
+
+type MethodInfo struct {
+	GoName      string
+	InputType   string
+	OutputType  string
+	Pattern     MethodPattern
+	Entity      *EntityInfo
+	ListField   string
+	IDFieldName string
+	EntityField string // input field carrying the entity, for Update
+	Reason      string // why Pattern was detected, for explain reports
+}
+
+// NoPattern says why DetectPattern returns nil, for explain reports.
+const NoPattern = "no pattern: Delete needs an Empty output and an entity ID in the input, " +
+	"Get an entity output and its ID in the input, List a repeated entity field in the output, " +
+	"Update an Update* name and an entity output carried by the input"
+
+func (p MethodPattern) String() string {
+	switch p {
+	case PatternGet:
+		return "get"
+	case PatternList:
+		return "list"
+	case PatternDelete:
+		return "delete"
+	case PatternUpdate:
+		return "update"
+	}
+	return "unknown"
+}
+
+func DetectPattern(m *protogen.Method, entities map[protoreflect.FullName]*EntityInfo) *MethodInfo {
+	inputMsg := m.Input
+	outputMsg := m.Output
+	inputName := inputMsg.GoIdent.GoName
+	outputName := outputMsg.GoIdent.GoName
+
+	// Pattern: Output is Empty AND Input has entity ID field → Delete
+	if outputName == "Empty" {
+		if entity, idField := findEntityIDField(inputMsg, entities); entity != nil {
+			return &MethodInfo{
+				GoName:      m.GoName,
+				InputType:   inputName,
+				OutputType:  "emptypb.Empty",
+				Pattern:     PatternDelete,
+				Entity:      entity,
+				IDFieldName: idField,
+				Reason:      fmt.Sprintf("output is Empty and input field %s is the ID of %s", idField, entity.GoName),
+			}
+		}
+	}
+
+	// Pattern: Output IS an entity AND Input carries it → Update
+	if entity, ok := entities[outputMsg.Desc.FullName()]; ok && strings.HasPrefix(m.GoName, "Update") {
+		if field := findEntityField(inputMsg, outputMsg); field != "" {
+			return &MethodInfo{
+				GoName:      m.GoName,
+				InputType:   inputName,
+				OutputType:  outputName,
+				Pattern:     PatternUpdate,
+				Entity:      entity,
+				EntityField: field,
+				Reason:      fmt.Sprintf("named Update*, output is the entity %s and input field %s carries it", entity.GoName, field),
+			}
+		}
+	}
+
+	// Pattern: Output IS an entity AND Input has that entity's ID field → Get
+	if entity, ok := entities[outputMsg.Desc.FullName()]; ok {
+		if idField := findMatchingIDField(inputMsg, entity); idField != "" {
+			return &MethodInfo{
+				GoName:      m.GoName,
+				InputType:   inputName,
+				OutputType:  outputName,
+				Pattern:     PatternGet,
+				Entity:      entity,
+				IDFieldName: idField,
+				Reason:      fmt.Sprintf("output is the entity %s and input field %s is its ID", entity.GoName, idField),
+			}
+		}
+	}
+
+	// Pattern: Output has repeated entity field → List
+	if entity, listField := findRepeatedEntityField(outputMsg, entities); entity != nil {
+		return &MethodInfo{
+			GoName:     m.GoName,
+			InputType:  fixEmptyType(inputName),
+			OutputType: outputName,
+			Pattern:    PatternList,
+			Entity:     entity,
+			ListField:  listField,
+			Reason:     fmt.Sprintf("output field %s is a repeated %s", listField, entity.GoName),
+		}
+	}
+
+	return nil
+}
+
+// findEntityIDField finds an ID field in the input message that references any entity
+func findEntityIDField(msg *protogen.Message, entities map[protoreflect.FullName]*EntityInfo) (*EntityInfo, string) {
+	for _, f := range msg.Fields {
+		if f.Desc.Kind() != protoreflect.StringKind {
+			continue
+		}
+		fieldName := string(f.Desc.Name())
+
+		// Check each entity, in name order, to see if this field is its ID
+		for _, name := range sortedNames(entities) {
+			entity := entities[name]
+			// Match: field name equals entity's ID field
+			if fieldName == entity.IDField {
+				return entity, f.GoName
+			}
+			// Match: field name is {lowercase_entity}_id
+			expectedID := strings.ToLower(entity.GoName) + "_id"
+			if fieldName == expectedID {
+				return entity, f.GoName
+			}
+		}
+	}
+	return nil, ""
+}
+
+// findMatchingIDField finds the ID field for a specific entity
+func findMatchingIDField(msg *protogen.Message, entity *EntityInfo) string {
+	for _, f := range msg.Fields {
+		if f.Desc.Kind() != protoreflect.StringKind {
+			continue
+		}
+		fieldName := string(f.Desc.Name())
+
+		if fieldName == entity.IDField {
+			return f.GoName
+		}
+		expectedID := strings.ToLower(entity.GoName) + "_id"
+		if fieldName == expectedID {
+			return f.GoName
+		}
+	}
+	return ""
+}
+
+// findEntityField finds a singular field of msg holding an entity message
+func findEntityField(msg, entity *protogen.Message) string {
+	for _, f := range msg.Fields {
+		if f.Desc.IsList() || f.Desc.IsMap() || f.Message == nil {
+			continue
+		}
+		if f.Message.Desc.FullName() == entity.Desc.FullName() {
+			return f.GoName
+		}
+	}
+	return ""
+}
+
+// findRepeatedEntityField finds a repeated field containing an entity type
+func findRepeatedEntityField(msg *protogen.Message, entities map[protoreflect.FullName]*EntityInfo) (*EntityInfo, string) {
+	for _, f := range msg.Fields {
+		if !f.Desc.IsList() || f.Desc.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		if entity, ok := entities[f.Message.Desc.FullName()]; ok {
+			return entity, f.GoName
+		}
+	}
+	return nil, ""
+}
+
+func sortedNames(entities map[protoreflect.FullName]*EntityInfo) []protoreflect.FullName {
+	names := make([]protoreflect.FullName, 0, len(entities))
+	for name := range entities {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func fixEmptyType(t string) string {
+	if t == "Empty" {
+		return "emptypb.Empty"
+	}
+	return t
+}
+
+// =============================================================================
+// CODE GENERATORS
+// =============================================================================
+
+func GenGet(svcName string, m *MethodInfo, baseAlias string) Code {
+	inputType := baseAlias + "." + m.InputType
+	outputType := baseAlias + "." + m.OutputType
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("func (s *%sServer) %s(ctx context.Context, req *connect.Request[%s]) (*connect.Response[%s], error) {",
+			svcName, m.GoName, inputType, outputType),
+		Indent(Concat(CodeMonoid, []Code{
+			Linef("id := req.Msg.Get%s()", m.IDFieldName),
+			Line(`if id == "" {`),
+			Line(`	return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))`),
+			Line(`}`),
+			Blank(),
+			Linef("entity, err := s.repos.%s.Get(ctx, id)", m.Entity.RepoField),
+			Line("if err != nil {"),
+			Linef("	if errors.Is(err, %s.ErrNotFound) {", baseAlias),
+			Line("		return nil, connect.NewError(connect.CodeNotFound, err)"),
+			Line("	}"),
+			Line("	return nil, connect.NewError(connect.CodeInternal, err)"),
+			Line("}"),
+			Blank(),
+			Line("return connect.NewResponse(entity), nil"),
+		})),
+		Line("}"),
+	})
+}
+
+func GenList(svcName string, m *MethodInfo, baseAlias string) Code {
+	inputType := m.InputType
+	if inputType != "emptypb.Empty" {
+		inputType = baseAlias + "." + inputType
+	}
+	outputType := baseAlias + "." + m.OutputType
+
+	var limitCode Code
+	if m.InputType == "emptypb.Empty" {
+		limitCode = Line("limit := 100")
+	} else {
+		limitCode = Concat(CodeMonoid, []Code{
+			Line("limit := int(req.Msg.GetLimit())"),
+			Line("if limit <= 0 || limit > 100 {"),
+			Line("	limit = 100"),
+			Line("}"),
+		})
+	}
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("func (s *%sServer) %s(ctx context.Context, req *connect.Request[%s]) (*connect.Response[%s], error) {",
+			svcName, m.GoName, inputType, outputType),
+		Indent(Concat(CodeMonoid, []Code{
+			limitCode,
+			Blank(),
+			Linef("entities, err := s.repos.%s.List(ctx, limit)", m.Entity.RepoField),
+			Line("if err != nil {"),
+			Line("	return nil, connect.NewError(connect.CodeInternal, err)"),
+			Line("}"),
+			Blank(),
+			Linef("return connect.NewResponse(&%s.%s{%s: entities}), nil", baseAlias, m.OutputType, m.ListField),
+		})),
+		Line("}"),
+	})
+}
+
+func GenDelete(svcName string, m *MethodInfo, baseAlias string) Code {
+	inputType := baseAlias + "." + m.InputType
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("func (s *%sServer) %s(ctx context.Context, req *connect.Request[%s]) (*connect.Response[emptypb.Empty], error) {",
+			svcName, m.GoName, inputType),
+		Indent(Concat(CodeMonoid, []Code{
+			Linef("id := req.Msg.Get%s()", m.IDFieldName),
+			Line(`if id == "" {`),
+			Line(`	return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))`),
+			Line(`}`),
+			Blank(),
+			Linef("if err := s.repos.%s.Delete(ctx, id); err != nil {", m.Entity.RepoField),
+			Linef("	if errors.Is(err, %s.ErrNotFound) {", baseAlias),
+			Line("		return nil, connect.NewError(connect.CodeNotFound, err)"),
+			Line("	}"),
+			Line("	return nil, connect.NewError(connect.CodeInternal, err)"),
+			Line("}"),
+			Blank(),
+			Line("return connect.NewResponse(&emptypb.Empty{}), nil"),
+		})),
+		Line("}"),
+	})
+}
+
+// GenUpdate stores the entity the request carries. A stale version field
+// fails the update with ErrConflict, which clients see as CodeAborted: read
+// the entity again and retry.
+func GenUpdate(svcName string, m *MethodInfo, baseAlias string) Code {
+	inputType := baseAlias + "." + m.InputType
+	outputType := baseAlias + "." + m.OutputType
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("func (s *%sServer) %s(ctx context.Context, req *connect.Request[%s]) (*connect.Response[%s], error) {",
+			svcName, m.GoName, inputType, outputType),
+		Indent(Concat(CodeMonoid, []Code{
+			Linef("entity := req.Msg.Get%s()", m.EntityField),
+			Linef(`if entity.Get%s() == "" {`, m.Entity.IDGoName),
+			Line(`	return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))`),
+			Line(`}`),
+			Blank(),
+			Linef("if err := s.repos.%s.Update(ctx, entity); err != nil {", m.Entity.RepoField),
+			Line("	switch {"),
+			Linef("	case errors.Is(err, %s.ErrNotFound):", baseAlias),
+			Line("		return nil, connect.NewError(connect.CodeNotFound, err)"),
+			Linef("	case errors.Is(err, %s.ErrConflict):", baseAlias),
+			Line("		return nil, connect.NewError(connect.CodeAborted, err)"),
+			Linef("	case errors.Is(err, %s.ErrAlreadyExists):", baseAlias),
+			Line("		return nil, connect.NewError(connect.CodeAlreadyExists, err)"),
+			Line("	}"),
+			Line("	return nil, connect.NewError(connect.CodeInternal, err)"),
+			Line("}"),
+			Blank(),
+			Line("return connect.NewResponse(entity), nil"),
+		})),
+		Line("}"),
+	})
+}
+
+func GenMethod(svcName string, m *MethodInfo, baseAlias string) Code {
+	switch m.Pattern {
+	case PatternGet:
+		return GenGet(svcName, m, baseAlias)
+	case PatternList:
+		return GenList(svcName, m, baseAlias)
+	case PatternDelete:
+		return GenDelete(svcName, m, baseAlias)
+	case PatternUpdate:
+		return GenUpdate(svcName, m, baseAlias)
+	default:
+		return CodeMonoid.Empty()
+	}
+}
+
+// =============================================================================
+// SERVICE GENERATION
+// =============================================================================
+
+type ServiceInfo struct {
+	GoName  string
+	Methods []*MethodInfo
+}
+
+// Settings are the plugin parameters that shape the generated handlers.
+type Settings struct {
+	CORS bool
+	Auth bool
+}
+
+// Middleware reports whether handlers need wrapping at all.
+func (s Settings) Middleware() bool { return s.CORS || s.Auth }
+
+func GenService(svc ServiceInfo, connectAlias string, baseAlias string) Code {
+	methods := FoldMap(svc.Methods, CodeMonoid, func(m *MethodInfo) Code {
+		return GenMethod(svc.GoName, m, baseAlias)
+	})
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Comment(fmt.Sprintf("%sServer implements %s", svc.GoName, svc.GoName)),
+		Linef("type %sServer struct {", svc.GoName),
+		Linef("	%s.Unimplemented%sHandler", connectAlias, svc.GoName),
+		Linef("	repos *%s.Repositories", baseAlias),
+		Line("}"),
+		Blank(),
+		Linef("func New%sServer(repos *%s.Repositories) *%sServer {", svc.GoName, baseAlias, svc.GoName),
+		Linef("	return &%sServer{repos: repos}", svc.GoName),
+		Line("}"),
+		methods,
+	})
+}
+
+func GenHandler(svc ServiceInfo, settings Settings, connectAlias string, baseAlias string) Code {
+	if !settings.Middleware() {
+		return CodeMonoid.Empty()
+	}
+
+	var auth, cors Code
+	if settings.Auth {
+		auth = Linef("handler = %s.AuthMiddleware(handler)", baseAlias)
+	} else {
+		auth = CodeMonoid.Empty()
+	}
+	if settings.CORS {
+		cors = Line("handler = withCORS(handler)")
+	} else {
+		cors = CodeMonoid.Empty()
+	}
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Comment(fmt.Sprintf("New%sHandler mounts srv with the configured middleware.", svc.GoName)),
+		Linef("func New%sHandler(srv *%sServer, opts ...connect.HandlerOption) (string, http.Handler) {", svc.GoName, svc.GoName),
+		Indent(Concat(CodeMonoid, []Code{
+			Linef("path, handler := %s.New%sHandler(srv, opts...)", connectAlias, svc.GoName),
+			auth,
+			cors,
+			Line("return path, handler"),
+		})),
+		Line("}"),
+	})
+}
+
+func GenCORS(settings Settings) Code {
+	if !settings.CORS {
+		return CodeMonoid.Empty()
+	}
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Comment("CORSAllowedOrigins lists the origins allowed by withCORS; none until set. \"*\""),
+		Comment("allows any origin, without credentials: only the origins listed by name get"),
+		Comment("Access-Control-Allow-Credentials."),
+		Line(`var CORSAllowedOrigins []string`),
+		Blank(),
+		Comment("withCORS answers preflight requests and sets the headers Connect clients need."),
+		Line("func withCORS(next http.Handler) http.Handler {"),
+		Line("	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {"),
+		Line(`		origin := r.Header.Get("Origin")`),
+		Line("		if allowed, named := corsAllowed(origin); origin != \"\" && allowed {"),
+		Line(`			h := w.Header()`),
+		Line(`			h.Add("Vary", "Origin")`),
+		Line(`			if named {`),
+		Line(`				h.Set("Access-Control-Allow-Origin", origin)`),
+		Line(`				h.Set("Access-Control-Allow-Credentials", "true")`),
+		Line(`			} else {`),
+		Line(`				h.Set("Access-Control-Allow-Origin", "*")`),
+		Line(`			}`),
+		Line(`			h.Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")`),
+		Line(`			if r.Method == http.MethodOptions {`),
+		Line(`				h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")`),
+		Line(`				h.Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Timeout, X-Grpc-Web, X-User-Agent")`),
+		Line(`				h.Set("Access-Control-Max-Age", "7200")`),
+		Line(`				w.WriteHeader(http.StatusNoContent)`),
+		Line(`				return`),
+		Line(`			}`),
+		Line("		}"),
+		Line("		next.ServeHTTP(w, r)"),
+		Line("	})"),
+		Line("}"),
+		Blank(),
+		Comment("corsAllowed reports whether CORSAllowedOrigins allows origin, and whether it"),
+		Comment("lists it by name rather than through \"*\"."),
+		Line("func corsAllowed(origin string) (allowed, named bool) {"),
+		Line("	for _, o := range CORSAllowedOrigins {"),
+		Line("		switch o {"),
+		Line("		case origin:"),
+		Line("			return true, true"),
+		Line(`		case "*":`),
+		Line("			allowed = true"),
+		Line("		}"),
+		Line("	}"),
+		Line("	return allowed, false"),
+		Line("}"),
+	})
+}
+
+func GenServiceSet(services []ServiceInfo) Code {
+	if len(services) == 0 {
+		return CodeMonoid.Empty()
+	}
+
+	providers := FoldMap(services, CodeMonoid, func(svc ServiceInfo) Code {
+		return Linef("	New%sServer,", svc.GoName)
+	})
+
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Comment("ServiceServerSet provides all generated service servers for Wire."),
+		Line("var ServiceServerSet = wire.NewSet("),
+		providers,
+		Line(")"),
+	})
+}
+
+func GenFile(pkgName, pkgPath string, services []ServiceInfo, settings Settings, connectPkg string, basePkg string) Code {
+	imports := NewImportSet(protogen.GoImportPath(pkgPath))
+	for _, path := range []protogen.GoImportPath{"context", "errors", "net/http", "connectrpc.com/connect", "github.com/google/wire", "google.golang.org/protobuf/types/known/emptypb"} {
+		imports.Use(path)
+	}
+	connectAlias := imports.Use(protogen.GoImportPath(connectPkg))
+	baseAlias := imports.UseAs(protogen.GoImportPath(basePkg), "pb")
+
+	svcCode := FoldMap(services, CodeMonoid, func(svc ServiceInfo) Code {
+		return Concat(CodeMonoid, []Code{
+			GenService(svc, connectAlias, baseAlias),
+			GenHandler(svc, settings, connectAlias, baseAlias),
+		})
+	})
+
+	header := Concat(CodeMonoid, []Code{
+		Comment("Code generated by protoc-gen-connect-server. DO NOT EDIT."),
+		Comment("Pattern-based generation using proto reflection."),
+		Blank(),
+		Linef("package %s", pkgName),
+	})
+	return File(header, imports, Concat(CodeMonoid, []Code{
+		svcCode,
+		GenCORS(settings),
+		GenServiceSet(services),
+	}))
+}
+
+// =============================================================================
+// MAIN - Pure functional pipeline
+// =============================================================================
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-connect-server")
+	cors := flags.Bool("cors", false, "wrap handlers in a CORS middleware")
+	auth := flags.Bool("auth", false, "wrap handlers in pb.AuthMiddleware from protoc-gen-auth")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		settings := Settings{CORS: *cors, Auth: *auth}
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		reg, err := entities.NewRegistry(gen)
+		if err != nil {
+			return err
+		}
+
+		// One servers package per Go package: its services may use the
+		// entities of any file in the package, imported or generated
+		for _, f := range gen.Files {
+			rep.Entities(reg, f, false)
+		}
+		for _, pkg := range reg.Packages() {
+			// Step 1: Extract entities (messages with entity option)
+			entityInfos := make(map[protoreflect.FullName]*EntityInfo)
+			for _, msg := range reg.Package(pkg, false) {
+				info := ExtractEntityInfo(msg, reg.Config(msg))
+				entityInfos[msg.Desc.FullName()] = &info
+			}
+
+			if len(entityInfos) == 0 {
+				continue
+			}
+
+			// Step 2: Analyze services - detect patterns by type signature
+			var services []ServiceInfo
+			for _, svc := range reg.Services(pkg) {
+				methods := Filter(
+					Map(svc.Methods, func(m *protogen.Method) *MethodInfo {
+						info := DetectPattern(m, entityInfos)
+						if info != nil {
+							rep.Method(m).Detect(info.Pattern.String(), info.Reason)
+						} else {
+							rep.Method(m).Because(NoPattern)
+							diags.Warnf(m.Location, "%s matches no Get, List or Delete pattern; the server returns Unimplemented for it", m.GoName)
+						}
+						return info
+					}),
+					func(m *MethodInfo) bool { return m != nil },
+				)
+
+				if len(methods) > 0 {
+					services = append(services, ServiceInfo{
+						GoName:  svc.GoName,
+						Methods: methods,
+					})
+				}
+			}
+
+			if len(services) == 0 {
+				continue
+			}
+
+			f := reg.Anchor(pkg)
+
+			// Compute packages
+			basePkg := string(f.GoImportPath)
+			connectPkg := basePkg + "/" + strings.ToLower(string(f.GoPackageName)) + "connect"
+			serversPkgName := "servers"
+			serversPkgPath := basePkg + "/" + serversPkgName
+
+			// Step 3: Generate code into servers subpackage
+			// f.GeneratedFilenamePrefix is like "path/to/pkg" - we want "path/to/servers/servers.pb.go"
+			parts := strings.Split(f.GeneratedFilenamePrefix, "/")
+			if len(parts) > 0 {
+				parts[len(parts)-1] = "servers"
+			}
+			outputPath := strings.Join(parts, "/") + "/servers.pb.go"
+
+			src := GenFile(serversPkgName, serversPkgPath, services, settings, connectPkg, basePkg).Run()
+			if err := gosrc.Generate(gen, outputPath, protogen.GoImportPath(serversPkgPath), src); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+// Package batches is the report of the generated Firestore repositories'
+// batch writes and the bookkeeping that fills it, less the BulkWriter itself.
+// protoc-gen-firestore embeds this file, without its package clause and
+// imports, in the helpers of every Go package it generates.
+package batches
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BatchReport is the outcome of a batch operation, item by item in the order
+// of its input.
+type BatchReport struct {
+	Items []BatchItem
+}
+
+// BatchItem is the outcome of one item of a batch operation.
+type BatchItem struct {
+	ID  string // document ID, assigned by CreateBatch to entities without one
+	Err error  // nil when the item was written
+}
+
+// Failed returns the items that weren't written.
+func (r *BatchReport) Failed() []BatchItem {
+	var failed []BatchItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of the items that weren't written, joined, or nil
+// when every item was.
+func (r *BatchReport) Err() error {
+	var errs []error
+	for _, item := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", item.ID, item.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// fillReport submits the write of every item of report that hasn't failed
+// yet, calls end once all are submitted, and then records the outcome of
+// each submitted write, which result waits for, in its item. An item whose
+// write can't be submitted fails with the error of submit.
+func fillReport[J any](report *BatchReport, submit func(i int) (J, error), end func(), result func(i int, job J) error) {
+	jobs := make([]J, len(report.Items))
+	submitted := make([]bool, len(report.Items))
+	for i := range report.Items {
+		if report.Items[i].Err == nil {
+			jobs[i], report.Items[i].Err = submit(i)
+			submitted[i] = report.Items[i].Err == nil
+		}
+	}
+	end()
+	for i, job := range jobs {
+		if submitted[i] {
+			report.Items[i].Err = result(i, job)
+		}
+	}
+}
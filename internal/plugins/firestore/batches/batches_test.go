@@ -0,0 +1,94 @@
+package batches
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFillReport(t *testing.T) {
+	invalid := errors.New("invalid")
+	rejected := errors.New("rejected")
+	failed := errors.New("failed")
+	report := &BatchReport{Items: []BatchItem{
+		{ID: "ok"},
+		{ID: "invalid", Err: invalid}, // failed before the batch
+		{ID: "rejected"},              // can't be submitted
+		{ID: "failed"},                // fails once written
+		{ID: "ok2"},
+	}}
+
+	var submitted, results []int
+	ended := false
+	fillReport(report,
+		func(i int) (string, error) {
+			if ended {
+				t.Errorf("item %d submitted after end", i)
+			}
+			submitted = append(submitted, i)
+			if report.Items[i].ID == "rejected" {
+				return "", rejected
+			}
+			return "job " + report.Items[i].ID, nil
+		},
+		func() { ended = true },
+		func(i int, job string) error {
+			if !ended {
+				t.Errorf("item %d waited for before end", i)
+			}
+			if job != "job "+report.Items[i].ID {
+				t.Errorf("item %d waited for %q", i, job)
+			}
+			results = append(results, i)
+			if report.Items[i].ID == "failed" {
+				return failed
+			}
+			return nil
+		})
+
+	if want := []int{0, 2, 3, 4}; !slices.Equal(submitted, want) {
+		t.Errorf("submitted items %v, want %v", submitted, want)
+	}
+	if want := []int{0, 3, 4}; !slices.Equal(results, want) {
+		t.Errorf("waited for items %v, want %v", results, want)
+	}
+	for i, want := range []error{nil, invalid, rejected, failed, nil} {
+		if report.Items[i].Err != want {
+			t.Errorf("item %s: err = %v, want %v", report.Items[i].ID, report.Items[i].Err, want)
+		}
+	}
+
+	var ids []string
+	for _, item := range report.Failed() {
+		ids = append(ids, item.ID)
+	}
+	if want := []string{"invalid", "rejected", "failed"}; !slices.Equal(ids, want) {
+		t.Errorf("Failed() = %v, want %v", ids, want)
+	}
+	err := report.Err()
+	if want := "invalid: invalid\nrejected: rejected\nfailed: failed"; err == nil || err.Error() != want {
+		t.Errorf("Err() = %v, want %q", err, want)
+	}
+	for _, want := range []error{invalid, rejected, failed} {
+		if !errors.Is(err, want) {
+			t.Errorf("Err() doesn't wrap %v", want)
+		}
+	}
+}
+
+func TestEmptyReport(t *testing.T) {
+	report := &BatchReport{Items: []BatchItem{{ID: "a", Err: errors.New("invalid")}}}
+	ended := false
+	fillReport(report,
+		func(i int) (int, error) { t.Errorf("item %d submitted", i); return 0, nil },
+		func() { ended = true },
+		func(i, _ int) error { t.Errorf("item %d waited for", i); return nil })
+	if !ended {
+		t.Error("end wasn't called")
+	}
+
+	report = &BatchReport{Items: []BatchItem{{ID: "a"}, {ID: "b"}}}
+	if report.Failed() != nil || report.Err() != nil {
+		t.Errorf("a report of written items has failures: %v", report.Err())
+	}
+}
@@ -0,0 +1,107 @@
+// Package changes is the change stream of the generated Firestore
+// repositories' Watch and WatchDoc, less the snapshot listeners themselves.
+// protoc-gen-firestore embeds this file, without its package clause and
+// imports, in the helpers of every Go package it generates.
+package changes
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ChangeKind says how a change changed an entity.
+type ChangeKind int
+
+const (
+	ChangeAdded    ChangeKind = iota + 1 // the entity was created, or came into view
+	ChangeModified                       // the entity was written
+	ChangeRemoved                        // the entity was deleted, or left the view
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeModified:
+		return "modified"
+	case ChangeRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a change to an entity seen by a snapshot listener. Entity is the
+// entity after the change, or before it for ChangeRemoved. A listener that
+// fails sends a last Change with only Err set.
+type Change[T any] struct {
+	Kind     ChangeKind
+	ID       string
+	Entity   T
+	ReadTime time.Time
+	Err      error
+}
+
+// EventType returns the kind of the change as the event types of
+// protoc-gen-realtime: create, update or delete; "" for errors.
+func (c Change[T]) EventType() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return "create"
+	case ChangeModified:
+		return "update"
+	case ChangeRemoved:
+		return "delete"
+	}
+	return ""
+}
+
+// EntityID returns the ID of the changed entity.
+func (c Change[T]) EntityID() string { return c.ID }
+
+// EntityData returns the changed entity.
+func (c Change[T]) EntityData() interface{} { return c.Entity }
+
+// docState turns the snapshots of a document listener into changes. A
+// document listener sends a snapshot of the document whether or not it holds
+// an entity: the first that holds one adds it, and later ones modify it until
+// one without removes it. Snapshots without an entity, while there is none,
+// change nothing.
+type docState[T any] struct {
+	last   T // the entity of the last snapshot
+	exists bool
+}
+
+// next returns the change of the snapshot whose entity decoded as entity and
+// err, when found, or false when the snapshot changes nothing.
+func (s *docState[T]) next(found bool, entity T, err error) (Change[T], bool) {
+	var c Change[T]
+	switch {
+	case !found:
+		if !s.exists {
+			return c, false
+		}
+		c.Kind, c.Entity = ChangeRemoved, s.last
+	case s.exists:
+		c.Kind, c.Entity, c.Err = ChangeModified, entity, err
+	default:
+		c.Kind, c.Entity, c.Err = ChangeAdded, entity, err
+	}
+	s.exists = found
+	s.last = entity
+	return c, true
+}
+
+// sendChange sends c unless ctx is done first, which it reports as false. A
+// listener stopped by ctx fails with its error, which isn't sent.
+func sendChange[T any](ctx context.Context, changes chan<- Change[T], c Change[T]) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case changes <- c:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package changes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDocState(t *testing.T) {
+	broken := errors.New("broken")
+	type snapshot struct {
+		found  bool
+		entity string
+		err    error
+	}
+	type change struct {
+		kind   ChangeKind
+		entity string
+		err    error
+	}
+	tests := []struct {
+		name      string
+		snapshots []snapshot
+		want      []change
+	}{
+		{
+			name:      "missing document",
+			snapshots: []snapshot{{}, {}},
+		},
+		{
+			name:      "created, written, deleted",
+			snapshots: []snapshot{{}, {true, "v1", nil}, {true, "v2", nil}, {}, {}},
+			want:      []change{{ChangeAdded, "v1", nil}, {ChangeModified, "v2", nil}, {ChangeRemoved, "v2", nil}},
+		},
+		{
+			name:      "recreated",
+			snapshots: []snapshot{{true, "v1", nil}, {}, {true, "v2", nil}},
+			want:      []change{{ChangeAdded, "v1", nil}, {ChangeRemoved, "v1", nil}, {ChangeAdded, "v2", nil}},
+		},
+		{
+			name:      "undecodable",
+			snapshots: []snapshot{{true, "", broken}, {true, "v1", nil}, {true, "", broken}, {}},
+			want:      []change{{ChangeAdded, "", broken}, {ChangeModified, "v1", nil}, {ChangeModified, "", broken}, {ChangeRemoved, "", nil}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s docState[string]
+			var got []change
+			for _, snap := range tt.snapshots {
+				if c, ok := s.next(snap.found, snap.entity, snap.err); ok {
+					got = append(got, change{c.Kind, c.Entity, c.Err})
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("changes = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("change %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChangeKinds(t *testing.T) {
+	for _, tt := range []struct {
+		kind            ChangeKind
+		name, eventType string
+	}{
+		{ChangeAdded, "added", "create"},
+		{ChangeModified, "modified", "update"},
+		{ChangeRemoved, "removed", "delete"},
+		{0, "ChangeKind(0)", ""},
+	} {
+		c := Change[string]{Kind: tt.kind, ID: "id", Entity: "entity"}
+		if got := tt.kind.String(); got != tt.name {
+			t.Errorf("String() = %q, want %q", got, tt.name)
+		}
+		if got := c.EventType(); got != tt.eventType {
+			t.Errorf("%v: EventType() = %q, want %q", tt.kind, got, tt.eventType)
+		}
+		if c.EntityID() != "id" || c.EntityData() != "entity" {
+			t.Errorf("%v: EntityID() = %q, EntityData() = %v", tt.kind, c.EntityID(), c.EntityData())
+		}
+	}
+}
+
+func TestSendChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan Change[string], 1)
+	if !sendChange(ctx, changes, Change[string]{ID: "a"}) {
+		t.Fatal("sendChange with a receiver failed")
+	}
+	if c := <-changes; c.ID != "a" {
+		t.Errorf("received %v", c)
+	}
+
+	// A full channel blocks until ctx is done
+	changes <- Change[string]{}
+	done := make(chan bool)
+	go func() { done <- sendChange(ctx, changes, Change[string]{ID: "b"}) }()
+	cancel()
+	if <-done {
+		t.Error("sendChange succeeded after the cancel")
+	}
+
+	// Once ctx is done nothing is sent, even with room for it
+	<-changes
+	if sendChange(ctx, changes, Change[string]{ID: "c"}) || len(changes) != 0 {
+		t.Error("sendChange sent on a done context")
+	}
+}
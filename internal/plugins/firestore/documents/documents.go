@@ -0,0 +1,474 @@
+// Package documents is the document codec of the generated Firestore
+// repositories. protoc-gen-firestore embeds this file, without its package
+// clause and imports, in the helpers of every Go package it generates; it is
+// a package of its own so that it is compiled and tested like any other code.
+package documents
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// documentCodec converts messages to the data of Firestore documents and
+// back, field by field through protobuf reflection. A field is stored under
+// its proto name in snake_case, as:
+//   - integers as int64 (uint64 bit for bit, so values above MaxInt64 are
+//     negative in the document and order accordingly), float and double as
+//     float64, string, bool and bytes as themselves;
+//   - enums as their number, or as their name with enumNames (numbers no
+//     value is declared for stay numbers);
+//   - google.protobuf.Timestamp as a timestamp, the wrappers as the value
+//     they wrap, Struct, Value and ListValue as the JSON-like map, value or
+//     array they stand for, and any other message, Duration and Any included,
+//     as a map of its fields;
+//   - repeated fields as arrays and maps as maps keyed by the key's decimal
+//     or string form.
+//
+// A field with presence, a message, a oneof member or an optional scalar,
+// is stored as null when unset, so that queries such as deleted_at == nil
+// find it and writes clear it. Decoding reverses all of this, and fails on a
+// value of the wrong type rather than drop it; the only loss is a
+// google.protobuf.Value field holding null, which reads back unset.
+type documentCodec struct {
+	enumNames bool
+}
+
+// encode returns the document data of m, leaving out the fields named skip.
+func (c documentCodec) encode(m protoreflect.Message, skip ...protoreflect.Name) map[string]interface{} {
+	data := make(map[string]interface{})
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if slices.Contains(skip, fd.Name()) {
+			continue
+		}
+		data[c.key(fd.Name())] = c.encodeField(m, fd)
+	}
+	return data
+}
+
+// encodeField returns the value of the field fd of m as stored.
+func (c documentCodec) encodeField(m protoreflect.Message, fd protoreflect.FieldDescriptor) interface{} {
+	switch {
+	case fd.IsList():
+		list := m.Get(fd).List()
+		items := make([]interface{}, list.Len())
+		for j := range items {
+			items[j] = c.encodeValue(fd, list.Get(j))
+		}
+		return items
+	case fd.IsMap():
+		entries := make(map[string]interface{})
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries[k.Value().String()] = c.encodeValue(fd.MapValue(), v)
+			return true
+		})
+		return entries
+	case fd.HasPresence() && !m.Has(fd):
+		return nil
+	default:
+		return c.encodeValue(fd, m.Get(fd))
+	}
+}
+
+// encodePath returns the document path of the field a field mask path names
+// in m, and its value as stored. The path names a field of m, or a field of
+// a message field of it with the names separated by dots, like
+// "ship_from.location.latitude"; every field but the last must be a singular
+// message stored as a map of its fields, not a timestamp, wrapper or Struct.
+// A field of an unset message is null.
+func (c documentCodec) encodePath(m protoreflect.Message, path string) (string, interface{}, error) {
+	var keys []string
+	set := true
+	for rest := path; ; {
+		name, tail, nested := strings.Cut(rest, ".")
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return "", nil, fmt.Errorf("%q: %s has no field %q", path, m.Descriptor().FullName(), name)
+		}
+		keys = append(keys, c.key(fd.Name()))
+		if !nested {
+			if !set {
+				return strings.Join(keys, "."), nil, nil
+			}
+			return strings.Join(keys, "."), c.encodeField(m, fd), nil
+		}
+		if !storedAsMap(fd) {
+			return "", nil, fmt.Errorf("%q: %s is not a message stored as a map", path, name)
+		}
+		set = set && m.Has(fd)
+		m, rest = m.Get(fd).Message(), tail
+	}
+}
+
+// storedAsMap reports whether fd is a singular message field stored as a map
+// of its fields, which a field mask path may name a field of.
+func storedAsMap(fd protoreflect.FieldDescriptor) bool {
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return false
+	}
+	switch fd.Message().FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Struct", "google.protobuf.Value", "google.protobuf.ListValue",
+		"google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return false
+	}
+	return true
+}
+
+// encodeValue returns v, a singular value of fd, as stored.
+func (c documentCodec) encodeValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return v.Bytes()
+	case protoreflect.EnumKind:
+		return c.encodeEnum(fd.Enum(), v.Enum())
+	default:
+		return c.encodeMessage(v.Message())
+	}
+}
+
+func (c documentCodec) encodeEnum(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) interface{} {
+	if c.enumNames {
+		if ev := ed.Values().ByNumber(n); ev != nil {
+			return string(ev.Name())
+		}
+	}
+	return int64(n)
+}
+
+func (c documentCodec) encodeMessage(m protoreflect.Message) interface{} {
+	fields := m.Descriptor().Fields()
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		return time.Unix(m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()).UTC()
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		fd := fields.ByName("value")
+		return c.encodeValue(fd, m.Get(fd))
+	case "google.protobuf.Struct":
+		fd := fields.ByName("fields")
+		entries := make(map[string]interface{})
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries[k.String()] = c.encodeMessage(v.Message())
+			return true
+		})
+		return entries
+	case "google.protobuf.ListValue":
+		list := m.Get(fields.ByName("values")).List()
+		items := make([]interface{}, list.Len())
+		for i := range items {
+			items[i] = c.encodeMessage(list.Get(i).Message())
+		}
+		return items
+	case "google.protobuf.Value":
+		fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("kind"))
+		if fd == nil || fd.Name() == "null_value" {
+			return nil
+		}
+		return c.encodeValue(fd, m.Get(fd))
+	}
+	return c.encode(m)
+}
+
+// decode sets the fields of m from the document data, leaving the fields it
+// holds no value or null for as they are.
+func (c documentCodec) decode(data map[string]interface{}, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		raw, ok := data[c.key(fd.Name())]
+		if !ok || raw == nil {
+			continue
+		}
+		if err := c.decodeField(m, fd, raw); err != nil {
+			return fmt.Errorf("%s: %w", fd.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (c documentCodec) decodeField(m protoreflect.Message, fd protoreflect.FieldDescriptor, raw interface{}) error {
+	switch {
+	case fd.IsList():
+		items, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("want an array, got %T", raw)
+		}
+		list := m.Mutable(fd).List()
+		for i, item := range items {
+			v, err := c.decodeValue(fd, item, list.NewElement)
+			if err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			list.Append(v)
+		}
+	case fd.IsMap():
+		entries, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("want a map, got %T", raw)
+		}
+		mp := m.Mutable(fd).Map()
+		for k, item := range entries {
+			key, err := c.decodeKey(fd.MapKey(), k)
+			if err != nil {
+				return err
+			}
+			v, err := c.decodeValue(fd.MapValue(), item, mp.NewValue)
+			if err != nil {
+				return fmt.Errorf("[%q]: %w", k, err)
+			}
+			mp.Set(key, v)
+		}
+	default:
+		v, err := c.decodeValue(fd, raw, func() protoreflect.Value { return m.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		m.Set(fd, v)
+	}
+	return nil
+}
+
+// decodeKey parses the stored form of a map key.
+func (documentCodec) decodeKey(fd protoreflect.FieldDescriptor, k string) (protoreflect.MapKey, error) {
+	var (
+		v   protoreflect.Value
+		err error
+	)
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(k)
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(k)
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int64
+		n, err = strconv.ParseInt(k, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var n int64
+		n, err = strconv.ParseInt(k, 10, 64)
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var n uint64
+		n, err = strconv.ParseUint(k, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(n))
+	default:
+		var n uint64
+		n, err = strconv.ParseUint(k, 10, 64)
+		v = protoreflect.ValueOfUint64(n)
+	}
+	if err != nil {
+		return protoreflect.MapKey{}, fmt.Errorf("map key %q: %w", k, err)
+	}
+	return v.MapKey(), nil
+}
+
+// decodeValue returns the singular value of fd stored as raw. newValue
+// returns an empty message to decode a message value into.
+func (c documentCodec) decodeValue(fd protoreflect.FieldDescriptor, raw interface{}, newValue func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		v := newValue()
+		if err := c.decodeMessage(raw, v.Message()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return v, nil
+	case protoreflect.EnumKind:
+		switch raw := raw.(type) {
+		case int64:
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(raw)), nil
+		case string:
+			ev := fd.Enum().Values().ByName(protoreflect.Name(raw))
+			if ev == nil {
+				return protoreflect.Value{}, fmt.Errorf("%s has no value %q", fd.Enum().FullName(), raw)
+			}
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+	case protoreflect.BoolKind:
+		if b, ok := raw.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, ok := raw.(int64); ok && n == int64(int32(n)) {
+			return protoreflect.ValueOfInt32(int32(n)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, ok := raw.(int64); ok {
+			return protoreflect.ValueOfInt64(n), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, ok := raw.(int64); ok && n == int64(uint32(n)) {
+			return protoreflect.ValueOfUint32(uint32(n)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, ok := raw.(int64); ok {
+			return protoreflect.ValueOfUint64(uint64(n)), nil
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var f float64
+		switch raw := raw.(type) {
+		case float64:
+			f = raw
+		case int64: // written by a client that stores whole numbers as integers
+			f = float64(raw)
+		default:
+			return protoreflect.Value{}, fmt.Errorf("want %s, got %T", fd.Kind(), raw)
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.StringKind:
+		if s, ok := raw.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+	case protoreflect.BytesKind:
+		if b, ok := raw.([]byte); ok {
+			return protoreflect.ValueOfBytes(b), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("want %s, got %T %v", fd.Kind(), raw, raw)
+}
+
+func (c documentCodec) decodeMessage(raw interface{}, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		t, ok := raw.(time.Time)
+		if !ok {
+			return fmt.Errorf("want a timestamp, got %T", raw)
+		}
+		m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		return nil
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		fd := fields.ByName("value")
+		v, err := c.decodeValue(fd, raw, nil)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, v)
+		return nil
+	case "google.protobuf.Struct":
+		entries, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("want a map, got %T", raw)
+		}
+		mp := m.Mutable(fields.ByName("fields")).Map()
+		for k, item := range entries {
+			v := mp.NewValue()
+			if err := c.decodeMessage(item, v.Message()); err != nil {
+				return fmt.Errorf("[%q]: %w", k, err)
+			}
+			mp.Set(protoreflect.ValueOfString(k).MapKey(), v)
+		}
+		return nil
+	case "google.protobuf.ListValue":
+		items, ok := raw.([]interface{})
+		if !ok {
+			return fmt.Errorf("want an array, got %T", raw)
+		}
+		list := m.Mutable(fields.ByName("values")).List()
+		for i, item := range items {
+			v := list.NewElement()
+			if err := c.decodeMessage(item, v.Message()); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			list.Append(v)
+		}
+		return nil
+	case "google.protobuf.Value":
+		switch raw := raw.(type) {
+		case nil:
+			m.Set(fields.ByName("null_value"), protoreflect.ValueOfEnum(0))
+		case float64:
+			m.Set(fields.ByName("number_value"), protoreflect.ValueOfFloat64(raw))
+		case int64:
+			m.Set(fields.ByName("number_value"), protoreflect.ValueOfFloat64(float64(raw)))
+		case string:
+			m.Set(fields.ByName("string_value"), protoreflect.ValueOfString(raw))
+		case bool:
+			m.Set(fields.ByName("bool_value"), protoreflect.ValueOfBool(raw))
+		case map[string]interface{}:
+			return c.decodeMessage(raw, m.Mutable(fields.ByName("struct_value")).Message())
+		case []interface{}:
+			return c.decodeMessage(raw, m.Mutable(fields.ByName("list_value")).Message())
+		default:
+			return fmt.Errorf("want a JSON value, got %T", raw)
+		}
+		return nil
+	}
+	if raw == nil {
+		return nil // an unset element of a repeated or map field
+	}
+	data, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("want a map for %s, got %T", m.Descriptor().FullName(), raw)
+	}
+	return c.decode(data, m)
+}
+
+// queryValue returns v, a value given to a query filter, in the form
+// documents store it: a generated enum as its number or name, a message such
+// as a timestamp as encoded, and a slice, for in and array-contains-any,
+// element by element. Other values are returned as they are.
+func (c documentCodec) queryValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, []byte:
+		return v
+	case protoreflect.Enum:
+		return c.encodeEnum(v.Descriptor(), v.Number())
+	case protoreflect.ProtoMessage:
+		return c.encodeMessage(v.ProtoReflect())
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return v
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = c.queryValue(rv.Index(i).Interface())
+	}
+	return items
+}
+
+// key returns the key a field is stored under, its name in
+// snake_case as the generated queries spell it.
+func (documentCodec) key(name protoreflect.Name) string {
+	var key []rune
+	for i, r := range string(name) {
+		if i > 0 && unicode.IsUpper(r) {
+			key = append(key, '_')
+		}
+		key = append(key, unicode.ToLower(r))
+	}
+	return string(key)
+}
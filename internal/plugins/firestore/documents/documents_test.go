@@ -0,0 +1,304 @@
+package documents
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugintest"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// listing returns the descriptor of catalog.v1.Listing, the fixture with
+// every shape of field.
+func listing(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	req := plugintest.Request(t, plugintest.Case{Files: []string{"catalog/v1/listing.proto"}})
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: req.ProtoFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := files.FindDescriptorByName("catalog.v1.Listing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d.(protoreflect.MessageDescriptor)
+}
+
+func TestRoundTrip(t *testing.T) {
+	desc := listing(t)
+	for _, c := range []documentCodec{{}, {enumNames: true}} {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 500; i++ {
+			want := dynamicpb.NewMessage(desc)
+			fill(r, want, 0)
+			data := c.encode(want)
+			checkStorable(t, "", data)
+			got := dynamicpb.NewMessage(desc)
+			if err := c.decode(data, got); err != nil {
+				t.Fatalf("enumNames=%t: decode(encode(%v)): %v", c.enumNames, prototext.Format(want), err)
+			}
+			if !proto.Equal(got, want) {
+				t.Fatalf("enumNames=%t: decode(encode(x)) != x\nx:\n%v\ngot:\n%v", c.enumNames, prototext.Format(want), prototext.Format(got))
+			}
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	desc := listing(t)
+	m := dynamicpb.NewMessage(desc)
+	field := func(name string) protoreflect.FieldDescriptor { return desc.Fields().ByName(protoreflect.Name(name)) }
+	m.Set(field("id"), protoreflect.ValueOfString("l1"))
+	m.Set(field("status"), protoreflect.ValueOfEnum(2))
+	m.Mutable(field("channels")).List().Append(protoreflect.ValueOfEnum(7))
+	subtitle := m.Mutable(field("subtitle")).Message()
+	subtitle.Set(subtitle.Descriptor().Fields().ByName("value"), protoreflect.ValueOfString("boxed"))
+	created := m.Mutable(field("created_at")).Message()
+	created.Set(created.Descriptor().Fields().ByName("seconds"), protoreflect.ValueOfInt64(1700000000))
+
+	data := documentCodec{enumNames: true}.encode(m, "id")
+	if _, ok := data["id"]; ok {
+		t.Error("skipped field id encoded")
+	}
+	for key, want := range map[string]interface{}{
+		"status":     "STATUS_PUBLISHED",
+		"subtitle":   "boxed",
+		"created_at": time.Unix(1700000000, 0).UTC(),
+		"price":      nil, // unset message
+		"fixed":      nil, // unset oneof member
+		"stock":      nil, // unset optional
+		"serial":     int64(0),
+	} {
+		if got, ok := data[key]; !ok || got != want {
+			t.Errorf("data[%q] = %#v, want %#v", key, got, want)
+		}
+	}
+	if got := data["channels"].([]interface{}); len(got) != 1 || got[0] != int64(7) {
+		t.Errorf("channels = %#v, want [7]: undeclared numbers stay numbers", got)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	desc := listing(t)
+	for _, data := range []map[string]interface{}{
+		{"status": "STATUS_SOLD"},
+		{"stock": "12"},
+		{"shelf": int64(-1)},
+		{"channels": "CHANNEL_WEB"},
+		{"labels": map[string]interface{}{"one": "1"}},
+		{"price": map[string]interface{}{"units": 1.5}},
+		{"created_at": "2024-01-01"},
+	} {
+		if err := (documentCodec{}).decode(data, dynamicpb.NewMessage(desc)); err == nil {
+			t.Errorf("decode(%v) = nil, want an error", data)
+		}
+	}
+}
+
+func TestEncodePath(t *testing.T) {
+	m := dynamicpb.NewMessage(listing(t))
+	err := prototext.Unmarshal([]byte(`
+		ship_from { lines: "Dam 1" country: "NL" location { latitude: 52.37 } }
+		tiers { currency: "EUR" }
+		status: STATUS_DRAFT
+	`), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		path, key string
+		want      interface{}
+	}{
+		{"status", "status", "STATUS_DRAFT"},
+		{"ship_from.country", "ship_from.country", "NL"},
+		{"ship_from.lines", "ship_from.lines", []interface{}{"Dam 1"}},
+		{"ship_from.location.latitude", "ship_from.location.latitude", 52.37},
+		{"ship_from.location", "ship_from.location", map[string]interface{}{"latitude": 52.37, "longitude": 0.0}},
+		{"price.currency", "price.currency", nil},               // unset message
+		{"auction.reserve.units", "auction.reserve.units", nil}, // unset oneof member
+		{"fixed", "fixed", nil},
+	} {
+		key, got, err := documentCodec{enumNames: true}.encodePath(m, tt.path)
+		if err != nil {
+			t.Errorf("encodePath(%q): %v", tt.path, err)
+			continue
+		}
+		if key != tt.key || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("encodePath(%q) = %q, %#v, want %q, %#v", tt.path, key, got, tt.key, tt.want)
+		}
+	}
+
+	for _, path := range []string{
+		"",
+		"colour",
+		"ship_from.zip",
+		"ship_from.",
+		"tiers.currency",      // repeated
+		"regional_prices.eur", // map
+		"status.name",         // not a message
+		"created_at.seconds",  // a timestamp
+		"subtitle.value",      // a wrapper
+		"metadata.fields",     // a Struct
+	} {
+		if _, _, err := (documentCodec{}).encodePath(m, path); err == nil {
+			t.Errorf("encodePath(%q) succeeded", path)
+		}
+	}
+}
+
+func TestQueryValue(t *testing.T) {
+	names, numbers := documentCodec{enumNames: true}, documentCodec{}
+	if got := names.queryValue(structpb.NullValue_NULL_VALUE); got != "NULL_VALUE" {
+		t.Errorf("names: enum = %#v, want NULL_VALUE", got)
+	}
+	if got := numbers.queryValue(structpb.NullValue_NULL_VALUE); got != int64(0) {
+		t.Errorf("numbers: enum = %#v, want 0", got)
+	}
+	if got := names.queryValue([]structpb.NullValue{0}); len(got.([]interface{})) != 1 || got.([]interface{})[0] != "NULL_VALUE" {
+		t.Errorf("names: enum slice = %#v, want [NULL_VALUE]", got)
+	}
+	at := time.Unix(1700000000, 5).UTC()
+	if got := names.queryValue(timestamppb.New(at)); got != at {
+		t.Errorf("timestamp = %#v, want %v", got, at)
+	}
+	if got := names.queryValue("x"); got != "x" {
+		t.Errorf("string = %#v, want x", got)
+	}
+}
+
+// checkStorable fails unless v is made of the types Firestore stores.
+func checkStorable(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	switch v := v.(type) {
+	case nil, bool, int64, float64, string, []byte, time.Time:
+	case []interface{}:
+		for _, item := range v {
+			checkStorable(t, path+"[]", item)
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			checkStorable(t, path+"."+k, item)
+		}
+	default:
+		t.Fatalf("%s: %T is not a Firestore value", path, v)
+	}
+}
+
+// fill sets a random selection of the fields of m to random values.
+func fill(r *rand.Rand, m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if r.Intn(3) == 0 {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for n := r.Intn(4); n > 0; n-- {
+				list.Append(randomValue(r, fd, list.NewElement, depth))
+			}
+		case fd.IsMap():
+			mp := m.Mutable(fd).Map()
+			for n := r.Intn(4); n > 0; n-- {
+				key := randomValue(r, fd.MapKey(), nil, depth)
+				mp.Set(key.MapKey(), randomValue(r, fd.MapValue(), mp.NewValue, depth))
+			}
+		default:
+			m.Set(fd, randomValue(r, fd, func() protoreflect.Value { return m.NewField(fd) }, depth))
+			// a Value field holding null reads back unset, see documentCodec
+			if fd.Message() != nil && fd.Message().FullName() == "google.protobuf.Value" && kind(m.Get(fd).Message()) == "null_value" {
+				m.Clear(fd)
+			}
+		}
+	}
+}
+
+func randomValue(r *rand.Rand, fd protoreflect.FieldDescriptor, newValue func() protoreflect.Value, depth int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(r.Intn(2) == 0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(r.Uint32()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(r.Uint64()))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(r.Uint32())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(r.Uint64())
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(r.NormFloat64() * 1e6))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(r.NormFloat64() * 1e12)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(randomString(r))
+	case protoreflect.BytesKind:
+		b := make([]byte, r.Intn(8))
+		r.Read(b)
+		return protoreflect.ValueOfBytes(b)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		if r.Intn(5) == 0 {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(100 + r.Intn(100))) // undeclared
+		}
+		return protoreflect.ValueOfEnum(values.Get(r.Intn(values.Len())).Number())
+	}
+
+	v := newValue()
+	m := v.Message()
+	fields := m.Descriptor().Fields()
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		// between years 1 and 9999, the range Firestore stores
+		m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(-62135596800+r.Int63n(315537897600)))
+		m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(r.Int31n(1e9)))
+	case "google.protobuf.Value":
+		fd := fields.ByName("null_value")
+		choices := []string{"null_value", "number_value", "string_value", "bool_value"}
+		if depth < 3 {
+			choices = append(choices, "struct_value", "list_value")
+		}
+		if name := choices[r.Intn(len(choices))]; name != "null_value" {
+			fd = fields.ByName(protoreflect.Name(name))
+		}
+		switch {
+		case fd.Name() == "null_value":
+			m.Set(fd, protoreflect.ValueOfEnum(0))
+		case fd.Message() != nil:
+			m.Set(fd, randomValue(r, fd, func() protoreflect.Value { return m.NewField(fd) }, depth+1))
+		default:
+			m.Set(fd, randomValue(r, fd, nil, depth))
+		}
+	default:
+		if depth < 3 {
+			fill(r, m, depth+1)
+		}
+	}
+	return v
+}
+
+func randomString(r *rand.Rand) string {
+	var b strings.Builder
+	for n := r.Intn(6); n > 0; n-- {
+		b.WriteRune([]rune("aZ0 _.é/世")[r.Intn(9)])
+	}
+	return b.String()
+}
+
+func kind(m protoreflect.Message) protoreflect.Name {
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("kind"))
+	if fd == nil {
+		return ""
+	}
+	return fd.Name()
+}
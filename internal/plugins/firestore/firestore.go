@@ -0,0 +1,1501 @@
+// Package firestore implements protoc-gen-firestore, which generates Firestore CRUD stubs using Category Theory composition
+// No string append - uses: Monoid, Functor (Map), Fold, When
+//
+// Usage: Add to your proto:
+//
+//	import "entity/options.proto";
+//
+//	message User {
+//	  option (entity.entity) = {
+//	    collection: "users"
+//	    id_field: "user_id"
+//	  };
+//	  string user_id = 1;
+//	  string email = 2;
+//	}
+//
+// Besides one <file>_firestore.pb.go per proto file with entities, it writes
+// firestore.indexes.json with the composite indexes the generated queries
+// need (see EntityIndexes), and, when entities declare the access option,
+// firestore.rules with an emulator test scaffold, firestore.rules.test.ts
+// (see GenerateRules).
+//
+// Parameters:
+//   - soft_delete: manage deleted_at when present (default true)
+//   - timestamps:  manage created_at/updated_at when present (default true)
+//   - enums:       store enum values by number or by name (default number)
+//
+// The first two only apply to entities that leave the corresponding option
+// unset.
+package firestore
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/repository"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// MESSAGE INFO (Pure data extraction)
+// =============================================================================
+
+type MessageInfo struct {
+	Name, GoName, Collection, IDField, IDGoName     string
+	Fields                                          []FieldInfo
+	HasID, HasCreatedAt, HasUpdatedAt, HasDeletedAt bool
+
+	// Version is the proto name of the field that guards updates, a counter
+	// or, when ETag, the document's update time; "" for none.
+	Version, VersionGoName string
+	ETag                   bool
+}
+
+type FieldInfo struct {
+	Name, GoName, GoType                          string
+	IsID, IsIndexed, IsUnique, IsEnum, IsRepeated bool
+
+	// Oneof is the name of the oneof the field is a member of, "" for none
+	// (proto3 optional fields included).
+	Oneof string
+}
+
+func ExtractMessageInfo(msg *protogen.Message, config *entities.Config) MessageInfo {
+	fields := Map(msg.Fields, func(f *protogen.Field) FieldInfo {
+		return ExtractFieldInfo(f, config)
+	})
+
+	hasID, hasCreatedAt, hasUpdatedAt, hasDeletedAt := false, false, false, false
+	var idGoName, versionGoName string
+	for _, f := range fields {
+		snake := toSnakeCase(f.Name)
+		switch {
+		case f.IsID:
+			hasID = true
+			idGoName = f.GoName
+		case f.Name == config.Version:
+			versionGoName = f.GoName
+		case snake == "created_at":
+			hasCreatedAt = config.Timestamps
+		case snake == "updated_at":
+			hasUpdatedAt = config.Timestamps
+		case snake == "deleted_at":
+			hasDeletedAt = config.SoftDelete
+		}
+	}
+
+	return MessageInfo{
+		Name:         string(msg.Desc.Name()),
+		GoName:       msg.GoIdent.GoName,
+		Collection:   config.Collection,
+		IDField:      config.IDField,
+		IDGoName:     idGoName,
+		Fields:       fields,
+		HasID:        hasID,
+		HasCreatedAt: hasCreatedAt,
+		HasUpdatedAt: hasUpdatedAt,
+		HasDeletedAt: hasDeletedAt,
+
+		Version:       config.Version,
+		VersionGoName: versionGoName,
+		ETag:          config.ETag,
+	}
+}
+
+func ExtractFieldInfo(field *protogen.Field, config *entities.Config) FieldInfo {
+	name := string(field.Desc.Name())
+	isID := strings.EqualFold(name, config.IDField)
+	isUnique := isID || config.IsUnique(name)
+	isIndexed := isUnique || config.IsIndexed(name)
+	isRepeated := field.Desc.IsList()
+	goType := fieldGoType(field)
+	if isRepeated {
+		goType = "[]" + goType
+	}
+	var oneof string
+	if o := field.Oneof; o != nil && !o.Desc.IsSynthetic() {
+		oneof = string(o.Desc.Name())
+	}
+	return FieldInfo{
+		Name: name, GoName: field.GoName, GoType: goType,
+		IsID: isID, IsIndexed: isIndexed, IsUnique: isUnique,
+		IsEnum: field.Desc.Kind() == protoreflect.EnumKind, IsRepeated: isRepeated,
+		Oneof: oneof,
+	}
+}
+
+func fieldGoType(field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	case protoreflect.EnumKind:
+		return field.Enum.GoIdent.GoName
+	case protoreflect.MessageKind:
+		if field.Message.GoIdent.GoName == "Timestamp" {
+			return "*timestamppb.Timestamp"
+		}
+		return "*" + field.Message.GoIdent.GoName
+	default:
+		return "interface{}"
+	}
+}
+
+// =============================================================================
+// FIRESTORE GENERATORS (Monoid composition)
+// =============================================================================
+
+func Header() Code {
+	return Comment("Code generated by protoc-gen-firestore. DO NOT EDIT.")
+}
+
+func RepositoryStruct(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Struct("Firestore"+m.GoName+"Repository", Field("client", "*firestore.Client")),
+		Blank(), Line(repository.Assertion(m.GoName, "Firestore"+m.GoName+"Repository")),
+	})
+}
+
+func Constructor(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Func("NewFirestore"+m.GoName+"Repository", "client *firestore.Client", "*Firestore"+m.GoName+"Repository",
+			Return("&Firestore"+m.GoName+"Repository{client: client}")),
+	})
+}
+
+func CollectionHelpers(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Method(recv, "Collection", "", "*firestore.CollectionRef", Return(fmt.Sprintf("r.client.Collection(%q)", m.Collection))),
+		Blank(), Method(recv, "Doc", "id string", "*firestore.DocumentRef", Return("r.Collection().Doc(id)")),
+	})
+}
+
+func CreateMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	// set creates the document ref and returns id, taking the etag from the
+	// write's update time
+	set := func(ref, id string) Code {
+		wr := "_"
+		if m.ETag {
+			wr = "wr"
+		}
+		return Concat(CodeMonoid, []Code{
+			Linef("%s, err := %s.Create(ctx, r.toFirestoreData(entity))", wr, ref),
+			If("status.Code(err) == codes.AlreadyExists", Return(`"", ErrAlreadyExists`)),
+			If("err != nil", Return(`"", err`)),
+			When(m.ETag, Linef("entity.%s = etagOf(wr.UpdateTime)", m.VersionGoName)),
+			Return(id + ", nil"),
+		})
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Create adds a new " + m.GoName + " to Firestore. It fails with ErrAlreadyExists when"),
+		Comment("a document has the entity's ID. Unlike the in-memory repository, it doesn't"),
+		Comment("check unique fields: Firestore has no unique constraints."),
+		Method(recv, "Create", "ctx context.Context, entity *"+m.GoName, "(string, error)",
+			Concat(CodeMonoid, []Code{
+				When(m.HasCreatedAt || m.HasUpdatedAt, Concat(CodeMonoid, []Code{
+					Line("now := timestamppb.Now()"),
+					When(m.HasCreatedAt, Line("entity.CreatedAt = now")),
+					When(m.HasUpdatedAt, Line("entity.UpdatedAt = now")),
+				})),
+				When(m.Version != "" && !m.ETag, Linef("entity.%s = 1", m.VersionGoName)),
+				IfElse(fmt.Sprintf("entity.%s == \"\"", m.IDGoName),
+					Concat(CodeMonoid, []Code{
+						Line("ref := r.Collection().NewDoc()"),
+						Linef("entity.%s = ref.ID", m.IDGoName),
+						set("ref", "ref.ID"),
+					}),
+					set("r.Doc(entity."+m.IDGoName+")", "entity."+m.IDGoName)),
+			})),
+	})
+}
+
+func GetMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Get retrieves a " + m.GoName + " by ID"),
+		Method(recv, "Get", "ctx context.Context, id string", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("doc, err := r.Doc(id).Get(ctx)"),
+				If("status.Code(err) == codes.NotFound", Return("nil, ErrNotFound")),
+				If("err != nil", Return("nil, err")),
+				Return("r.fromFirestoreDoc(doc)"),
+			})),
+	})
+}
+
+func UpdateMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	switch {
+	case m.ETag:
+		return Concat(CodeMonoid, []Code{
+			Blank(), Comment("Update modifies an existing " + m.GoName + ". The write is conditional on the document's"),
+			Comment("update time, which entity." + m.VersionGoName + " carries, so it fails with ErrConflict when the"),
+			Comment("document changed since entity was read."),
+			Method(recv, "Update", "ctx context.Context, entity *"+m.GoName, "error",
+				Concat(CodeMonoid, []Code{
+					If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+					Linef("readAt, err := parseEtag(entity.%s)", m.VersionGoName),
+					If("err != nil", Return("ErrConflict")),
+					When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+					Linef("wr, err := r.Doc(entity.%s).Update(ctx, fieldUpdates(r.toFirestoreData(entity)), firestore.LastUpdateTime(readAt))", m.IDGoName),
+					Line("switch status.Code(err) {"),
+					Line("case codes.NotFound:"),
+					Return("ErrNotFound"),
+					Line("case codes.FailedPrecondition:"),
+					Return("ErrConflict"),
+					Line("}"),
+					If("err != nil", Return("err")),
+					Linef("entity.%s = etagOf(wr.UpdateTime)", m.VersionGoName),
+					Return("nil"),
+				})),
+		})
+	case m.Version != "":
+		return Concat(CodeMonoid, []Code{
+			Blank(), Comment("Update modifies an existing " + m.GoName + " and increments its " + m.VersionGoName + ". It fails"),
+			Comment("with ErrConflict when the stored " + m.VersionGoName + " is not entity's, that is when the"),
+			Comment("document changed since entity was read."),
+			Method(recv, "Update", "ctx context.Context, entity *"+m.GoName, "error",
+				Concat(CodeMonoid, []Code{
+					If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+					When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+					Linef("ref := r.Doc(entity.%s)", m.IDGoName),
+					Line("data := r.toFirestoreData(entity)"),
+					Linef("data[%q] = entity.%s + 1", toSnakeCase(m.Version), m.VersionGoName),
+					Line("err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {"),
+					Line("\tdoc, err := tx.Get(ref)"),
+					If("status.Code(err) == codes.NotFound", Return("ErrNotFound")),
+					If("err != nil", Return("err")),
+					Line("\tstored, err := r.fromFirestoreDoc(doc)"),
+					If("err != nil", Return("err")),
+					If(fmt.Sprintf("stored.%s != entity.%s", m.VersionGoName, m.VersionGoName), Return("ErrConflict")),
+					Return("tx.Set(ref, data)"),
+					Line("})"),
+					If("err != nil", Return("err")),
+					Linef("entity.%s++", m.VersionGoName),
+					Return("nil"),
+				})),
+		})
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Update modifies an existing " + m.GoName),
+		Method(recv, "Update", "ctx context.Context, entity *"+m.GoName, "error",
+			Concat(CodeMonoid, []Code{
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				Linef("_, err := r.Doc(entity.%s).Set(ctx, r.toFirestoreData(entity))", m.IDGoName),
+				Return("err"),
+			})),
+	})
+}
+
+// PatchMethod generates Patch, which translates the mask's paths, proto field
+// names, to updates of the fields' document paths. As in the message, setting
+// a member of a oneof clears the other members.
+func PatchMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	patchableFields := Filter(m.Fields, func(f FieldInfo) bool { return patchable(m, f) })
+	hasOneofs := len(Filter(patchableFields, func(f FieldInfo) bool { return f.Oneof != "" })) > 0
+	nested := "_"
+	if hasOneofs {
+		nested = "nested"
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Patch sets the fields of the stored " + m.GoName + " that mask names to entity's. A path"),
+		Comment("names a field or, with dots, a field of a message field stored as a map. A"),
+		Comment("path naming no field, the ID or a field the repository manages fails with"),
+		Comment("ErrInvalidMask before anything is written."),
+		When(hasOneofs, Comment("Setting a member of a oneof clears the other members.")),
+		Method(recv, "Patch", "ctx context.Context, id string, entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Line("updates, err := r.patchUpdates(entity, mask)"),
+				If("err != nil", Return("err")),
+				Line("_, err = r.Doc(id).Update(ctx, updates)"),
+				If("status.Code(err) == codes.NotFound", Return("ErrNotFound")),
+				Return("err"),
+			})),
+		Blank(), Comment("patchUpdates returns the updates of Patch."),
+		Method(recv, "patchUpdates", "entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "([]firestore.Update, error)",
+			Concat(CodeMonoid, []Code{
+				If("len(mask.GetPaths()) == 0", Return(`nil, fmt.Errorf("%w: no paths", ErrInvalidMask)`)),
+				Line("fields := make(map[string]interface{}, len(mask.GetPaths())+2)"),
+				Line("for _, path := range mask.GetPaths() {"),
+				Line("key, value, err := documents.encodePath(entity.ProtoReflect(), path)"),
+				If("err != nil", Return(`nil, fmt.Errorf("%w: %v", ErrInvalidMask, err)`)),
+				Linef(`switch top, _, %s := strings.Cut(path, "."); top {`, nested),
+				FoldMap(patchableFields, CodeMonoid, func(f FieldInfo) Code {
+					others := Filter(m.Fields, func(o FieldInfo) bool { return f.Oneof != "" && o.Oneof == f.Oneof && o.Name != f.Name })
+					return Concat(CodeMonoid, []Code{
+						Linef("case %q:", f.Name),
+						When(len(others) > 0, Concat(CodeMonoid, []Code{
+							Comment("A path below the member sets it."),
+							Line("if nested || value != nil {"),
+							FoldMap(others, CodeMonoid, func(o FieldInfo) Code { return Linef("\tfields[%q] = nil", toSnakeCase(o.Name)) }),
+							Line("}"),
+						})),
+					})
+				}),
+				Line("default:"),
+				Linef(`return nil, fmt.Errorf("%%w: %%q is not a patchable field of %s", ErrInvalidMask, path)`, m.Name),
+				Line("}"),
+				Line("fields[key] = value"),
+				Line("}"),
+				When(m.HasUpdatedAt, Line(`fields["updated_at"] = timestamppb.Now()`)),
+				When(m.Version != "" && !m.ETag, Linef("fields[%q] = firestore.Increment(1)", toSnakeCase(m.Version))),
+				Return("fieldUpdates(fields), nil"),
+			})),
+	})
+}
+
+// patchable reports whether a Patch mask may name f: the ID, the timestamps
+// and the version are the repository's to set.
+func patchable(m MessageInfo, f FieldInfo) bool {
+	switch toSnakeCase(f.Name) {
+	case "created_at":
+		return !m.HasCreatedAt
+	case "updated_at":
+		return !m.HasUpdatedAt
+	case "deleted_at":
+		return !m.HasDeletedAt
+	}
+	return !f.IsID && f.Name != m.Version
+}
+
+func DeleteMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Delete removes a " + m.GoName + " by ID"),
+		Method(recv, "Delete", "ctx context.Context, id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Line("_, err := r.Doc(id).Delete(ctx)"),
+				Return("err"),
+			})),
+	})
+}
+
+func SoftDeleteMethods(m MessageInfo) Code {
+	if !m.HasDeletedAt {
+		return CodeMonoid.Empty()
+	}
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("SoftDelete marks entity as deleted without removing"),
+		Method(recv, "SoftDelete", "ctx context.Context, id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Linef("_, err := r.Doc(id).Update(ctx, []firestore.Update{{Path: \"deleted_at\", Value: timestamppb.Now()}%s})", versionBump(m)),
+				Return("err"),
+			})),
+		Blank(), Method(recv, "Restore", "ctx context.Context, id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Linef("_, err := r.Doc(id).Update(ctx, []firestore.Update{{Path: \"deleted_at\", Value: nil}%s})", versionBump(m)),
+				Return("err"),
+			})),
+	})
+}
+
+// versionBump returns the update that increments the version counter of a
+// partial update, so that updates based on earlier reads fail.
+func versionBump(m MessageInfo) string {
+	if m.Version == "" || m.ETag {
+		return ""
+	}
+	return fmt.Sprintf(", {Path: %q, Value: firestore.Increment(1)}", toSnakeCase(m.Version))
+}
+
+func ListMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("List retrieves all " + m.GoName + "s with optional limit"),
+		Method(recv, "List", "ctx context.Context, limit int", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("q := r.Collection().Query"),
+				When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+				If("limit > 0", Line("q = q.Limit(limit)")),
+				Line("iter := q.Documents(ctx)"),
+				Line("defer iter.Stop()"),
+				Linef("var results []*%s", m.GoName),
+				Line("for {"),
+				Line("\tdoc, err := iter.Next()"),
+				If("err == iterator.Done", Line("break")),
+				If("err != nil", Return("nil, err")),
+				Line("\te, err := r.fromFirestoreDoc(doc)"),
+				If("err != nil", Return("nil, err")),
+				Line("\tresults = append(results, e)"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+	})
+}
+
+// ListPageMethod pages through the collection in a stable order, created_at
+// then document ID, resuming after the last document of the previous page
+// rather than skipping over the documents before it.
+func ListPageMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	orderBy := "nil"
+	if m.HasCreatedAt {
+		orderBy = `[]string{"created_at"}`
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("ListPage returns up to pageSize " + m.GoName + "s after the position pageToken encodes,"),
+		Comment("and the token of the next page (empty on the last page). An empty pageToken"),
+		Comment("starts at the first page; pageSize <= 0 uses DefaultPageSize."),
+		Method(recv, "ListPage", "ctx context.Context, pageSize int, pageToken string", "([]*"+m.GoName+", string, error)",
+			Concat(CodeMonoid, []Code{
+				Line("q := r.Collection().Query"),
+				When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+				When(m.HasCreatedAt, Line("q = q.OrderBy(\"created_at\", firestore.Asc)")),
+				Linef("docs, next, err := pageDocuments(ctx, q, %q, %s, pageSize, pageToken)", m.Collection, orderBy),
+				If("err != nil", Return(`nil, "", err`)),
+				Linef("results := make([]*%s, 0, len(docs))", m.GoName),
+				Line("for _, doc := range docs {"),
+				Line("\te, err := r.fromFirestoreDoc(doc)"),
+				If("err != nil", Return(`nil, "", err`)),
+				Line("\tresults = append(results, e)"),
+				Line("}"),
+				Return("results, next, nil"),
+			})),
+	})
+}
+
+func ExistsMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Exists checks if a " + m.GoName + " exists"),
+		Method(recv, "Exists", "ctx context.Context, id string", "(bool, error)",
+			Concat(CodeMonoid, []Code{
+				Line("doc, err := r.Doc(id).Get(ctx)"),
+				If("status.Code(err) == codes.NotFound", Return("false, nil")),
+				If("err != nil", Return("false, err")),
+				Return("doc.Exists(), nil"),
+			})),
+	})
+}
+
+func CountMethod(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Count returns the number of " + m.GoName + "s, counted by the server"),
+		Method(recv, "Count", "ctx context.Context", "(int64, error)",
+			Concat(CodeMonoid, []Code{
+				Line("q := r.Collection().Query"),
+				When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+				Return("countOf(ctx, q)"),
+			})),
+		Blank(), Comment("CountWhere returns the number of " + m.GoName + "s whose field compares to value as op says"),
+		Method(recv, "CountWhere", "ctx context.Context, field, op string, value interface{}", "(int64, error)",
+			Return("r.Query().Where(field, op, value).Count(ctx)")),
+	})
+}
+
+// AggregateMethods generates Sum<Field> and Avg<Field> for the numeric fields,
+// which Firestore aggregates on the server without returning the documents.
+// Sums of integer fields are int64, of floating-point fields float64.
+func AggregateMethods(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return FoldMap(Filter(m.Fields, func(f FieldInfo) bool { return aggregatable(m, f) }), CodeMonoid, func(f FieldInfo) Code {
+		path := toSnakeCase(f.Name)
+		sum := "sumFloat"
+		sumType := "float64"
+		if isInteger(f.GoType) {
+			sum, sumType = "sumInt", "int64"
+		}
+		return Concat(CodeMonoid, []Code{
+			Blank(), Commentf("Sum%s returns the sum of %s over the %ss", f.GoName, f.Name, m.GoName),
+			Method(recv, "Sum"+f.GoName, "ctx context.Context", "("+sumType+", error)",
+				Concat(CodeMonoid, []Code{
+					Line("q := r.Collection().Query"),
+					When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+					Linef("return %s(ctx, q, %q)", sum, path),
+				})),
+			Blank(), Commentf("Avg%s returns the average of %s over the %ss, 0 when there are none", f.GoName, f.Name, m.GoName),
+			Method(recv, "Avg"+f.GoName, "ctx context.Context", "(float64, error)",
+				Concat(CodeMonoid, []Code{
+					Line("q := r.Collection().Query"),
+					When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+					Linef("return avgOf(ctx, q, %q)", path),
+				})),
+		})
+	})
+}
+
+// aggregatable reports whether Sum and Avg methods are generated for f: a
+// singular number that isn't the ID, the version counter or a oneof member.
+func aggregatable(m MessageInfo, f FieldInfo) bool {
+	if f.IsID || f.IsRepeated || f.Oneof != "" || f.Name == m.Version {
+		return false
+	}
+	return isInteger(f.GoType) || f.GoType == "float32" || f.GoType == "float64"
+}
+
+func isInteger(goType string) bool {
+	switch goType {
+	case "int32", "int64", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func FindMethods(m MessageInfo) Code {
+	indexedFields := Filter(m.Fields, func(f FieldInfo) bool { return f.IsIndexed && !f.IsID })
+	if len(indexedFields) == 0 {
+		return CodeMonoid.Empty()
+	}
+	recv := "r *Firestore" + m.GoName + "Repository"
+	return FoldMap(indexedFields, CodeMonoid, func(f FieldInfo) Code {
+		methodName := "FindBy" + f.GoName
+		paramType, op, value := f.GoType, "==", "value"
+		if f.IsRepeated {
+			paramType, op = strings.TrimPrefix(paramType, "[]"), "array-contains"
+		}
+		if f.IsEnum {
+			value = "documents.queryValue(value)" // by name or number, as stored
+		}
+		return Concat(CodeMonoid, []Code{
+			Blank(), Commentf("%s finds %ss by %s", methodName, m.GoName, f.Name),
+			Method(recv, methodName, "ctx context.Context, value "+paramType, "([]*"+m.GoName+", error)",
+				Concat(CodeMonoid, []Code{
+					Linef("q := r.Collection().Where(%q, %q, %s)", toSnakeCase(f.Name), op, value),
+					When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+					Line("iter := q.Documents(ctx)"),
+					Line("defer iter.Stop()"),
+					Linef("var results []*%s", m.GoName),
+					Line("for {"),
+					Line("\tdoc, err := iter.Next()"),
+					If("err == iterator.Done", Line("break")),
+					If("err != nil", Return("nil, err")),
+					Line("\te, err := r.fromFirestoreDoc(doc)"),
+					If("err != nil", Return("nil, err")),
+					Line("\tresults = append(results, e)"),
+					Line("}"),
+					Return("results, nil"),
+				})),
+		})
+	})
+}
+
+// BatchMethods generates CreateBatch, UpdateBatch and DeleteBatch, which write
+// any number of entities through a BulkWriter and report on each.
+func BatchMethods(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	// written records the etags of the written entities
+	written := "nil"
+	if m.ETag {
+		written = fmt.Sprintf("func(i int, wr *firestore.WriteResult) { entities[i].%s = etagOf(wr.UpdateTime) }", m.VersionGoName)
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Batch Operations ==="),
+		Blank(), Comment("CreateBatch stores entities, assigning IDs to those without one. The writes"),
+		Comment("aren't atomic: the report says which entities were stored, and which failed"),
+		Comment("with ErrAlreadyExists because a document had their ID."),
+		Method(recv, "CreateBatch", "ctx context.Context, entities []*"+m.GoName, "(*BatchReport, error)",
+			Concat(CodeMonoid, []Code{
+				Line("report := &BatchReport{Items: make([]BatchItem, len(entities))}"),
+				When(m.HasCreatedAt || m.HasUpdatedAt, Line("now := timestamppb.Now()")),
+				Line("for i, entity := range entities {"),
+				When(m.HasCreatedAt, Line("	entity.CreatedAt = now")),
+				When(m.HasUpdatedAt, Line("	entity.UpdatedAt = now")),
+				When(m.Version != "" && !m.ETag, Linef("	entity.%s = 1", m.VersionGoName)),
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Linef("entity.%s = r.Collection().NewDoc().ID", m.IDGoName)),
+				Linef("	report.Items[i].ID = entity.%s", m.IDGoName),
+				Line("}"),
+				Line("bulkWrite(ctx, r.client, report, func(bw *firestore.BulkWriter, i int) (*firestore.BulkWriterJob, error) {"),
+				Line("	return bw.Create(r.Doc(report.Items[i].ID), r.toFirestoreData(entities[i]))"),
+				Linef("}, %s)", written),
+				Return("report, report.Err()"),
+			})),
+		updateBatch(m, recv, written),
+		Blank(), Comment("DeleteBatch removes the entities with the given IDs. The deletes aren't"),
+		Comment("atomic: the report says which entities were removed."),
+		Method(recv, "DeleteBatch", "ctx context.Context, ids []string", "(*BatchReport, error)",
+			Concat(CodeMonoid, []Code{
+				Line("report := &BatchReport{Items: make([]BatchItem, len(ids))}"),
+				Line("for i, id := range ids {"),
+				Line("	report.Items[i].ID = id"),
+				If(`id == ""`, Line("report.Items[i].Err = ErrInvalidID")),
+				Line("}"),
+				Line("bulkWrite(ctx, r.client, report, func(bw *firestore.BulkWriter, i int) (*firestore.BulkWriterJob, error) {"),
+				Line("	return bw.Delete(r.Doc(ids[i]))"),
+				Line("}, nil)"),
+				Return("report, report.Err()"),
+			})),
+	})
+}
+
+// updateBatch generates UpdateBatch. Its updates fail for missing documents,
+// and for stale etags through the LastUpdateTime precondition. Version
+// counters can only be compared by reading the documents, so entities with
+// one are updated one transaction each.
+func updateBatch(m MessageInfo, recv, written string) Code {
+	doc := Concat(CodeMonoid, []Code{
+		Blank(), Comment("UpdateBatch modifies existing entities. The updates aren't atomic: the report"),
+		Comment("says which entities were modified, and which failed with ErrNotFound,"),
+		Comment("ErrConflict or another error."),
+	})
+	if m.Version != "" && !m.ETag {
+		return Concat(CodeMonoid, []Code{
+			doc,
+			Method(recv, "UpdateBatch", "ctx context.Context, entities []*"+m.GoName, "(*BatchReport, error)",
+				Concat(CodeMonoid, []Code{
+					Line("report := &BatchReport{Items: make([]BatchItem, len(entities))}"),
+					Line("for i, entity := range entities {"),
+					Linef("	report.Items[i] = BatchItem{ID: entity.%s, Err: r.Update(ctx, entity)}", m.IDGoName),
+					Line("}"),
+					Return("report, report.Err()"),
+				})),
+		})
+	}
+	write := Line("	return bw.Update(r.Doc(report.Items[i].ID), fieldUpdates(r.toFirestoreData(entities[i])))")
+	if m.ETag {
+		write = Concat(CodeMonoid, []Code{
+			Linef("	readAt, err := parseEtag(entities[i].%s)", m.VersionGoName),
+			If("err != nil", Return("nil, ErrConflict")),
+			Line("	return bw.Update(r.Doc(report.Items[i].ID), fieldUpdates(r.toFirestoreData(entities[i])), firestore.LastUpdateTime(readAt))"),
+		})
+	}
+	return Concat(CodeMonoid, []Code{
+		doc,
+		Method(recv, "UpdateBatch", "ctx context.Context, entities []*"+m.GoName, "(*BatchReport, error)",
+			Concat(CodeMonoid, []Code{
+				Line("report := &BatchReport{Items: make([]BatchItem, len(entities))}"),
+				When(m.HasUpdatedAt, Line("now := timestamppb.Now()")),
+				Line("for i, entity := range entities {"),
+				Linef("	report.Items[i].ID = entity.%s", m.IDGoName),
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Line("report.Items[i].Err = ErrInvalidID")),
+				When(m.HasUpdatedAt, Line("	entity.UpdatedAt = now")),
+				Line("}"),
+				Line("bulkWrite(ctx, r.client, report, func(bw *firestore.BulkWriter, i int) (*firestore.BulkWriterJob, error) {"),
+				write,
+				Linef("}, %s)", written),
+				Return("report, report.Err()"),
+			})),
+	})
+}
+
+// QueryBuilder generates Firestore<Entity>Query, the Firestore <Entity>Query of
+// the repository contract, and QueryPage, which pages through one.
+func QueryBuilder(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	qName := "Firestore" + m.GoName + "Query"
+	qRecv := "q *" + qName
+	query := repository.QueryInterfaceName(m.GoName)
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Query Builder ==="),
+		Blank(), Struct(qName, Concat(CodeMonoid, []Code{
+			Field("repo", "*Firestore"+m.GoName+"Repository"),
+			Field("query", "firestore.Query"),
+			Field("limitVal", "int"),
+			Field("offsetVal", "int"),
+			Field("orders", "[]string"),  // OrderBy fields, for the page cursor
+			Field("clauses", "[]string"), // Where and OrderBy calls, which page tokens are bound to
+		})),
+		Blank(), Line(repository.QueryAssertion(m.GoName, qName)),
+		Blank(), Method(recv, "Query", "", query,
+			Concat(CodeMonoid, []Code{
+				Line("baseQuery := r.Collection().Query"),
+				When(m.HasDeletedAt, Line("baseQuery = baseQuery.Where(\"deleted_at\", \"==\", nil)")),
+				Return("&" + qName + "{repo: r, query: baseQuery}"),
+			})),
+		Blank(), Commentf("firestoreQuery returns q as the %s it is, or the", qName),
+		Commentf("query of every %s when q is nil. Queries of other backends fail.", m.GoName),
+		Method(recv, "firestoreQuery", "q "+query, "(*"+qName+", error)",
+			Concat(CodeMonoid, []Code{
+				If("q == nil", Line("q = r.Query()")),
+				Linef("fq, ok := q.(*%s)", qName),
+				If("!ok", Return(`nil, fmt.Errorf("%T is not a Firestore query", q)`)),
+				Return("fq, nil"),
+			})),
+		Blank(), Comment("Where keeps the results whose field, a document path, compares to value as op"),
+		Comment("says. Enums and messages such as timestamps compare in the form documents store"),
+		Comment("them in."),
+		Method(qRecv, "Where", "field string, op string, value interface{}", query, Concat(CodeMonoid, []Code{
+			Line("value = documents.queryValue(value)"),
+			Line("q.query = q.query.Where(field, op, value)"),
+			Line("q.clauses = append(q.clauses, fmt.Sprintf(\"where %q %q %s\", field, op, scopeValue(value)))"),
+			Return("q"),
+		})),
+		Blank(), Method(qRecv, "OrderBy", "field string, dir Direction", query, Concat(CodeMonoid, []Code{
+			Line("q.query = q.query.OrderBy(field, firestore.Direction(dir))"),
+			Line("q.orders = append(q.orders, field)"),
+			Line("q.clauses = append(q.clauses, fmt.Sprintf(\"order %q %d\", field, dir))"),
+			Return("q"),
+		})),
+		Blank(), Method(qRecv, "Limit", "n int", query, Concat(CodeMonoid, []Code{Line("q.limitVal = n"), Return("q")})),
+		Blank(), Comment("Offset skips the first n results. Firestore bills every skipped document as a"),
+		Comment("read, so page through large result sets with QueryPage instead."),
+		Method(qRecv, "Offset", "n int", query, Concat(CodeMonoid, []Code{Line("q.offsetVal = n"), Return("q")})),
+		Blank(), Commentf("QueryPage returns up to pageSize results of q, or of every %s when q is nil,", m.GoName),
+		Comment("after the position pageToken encodes, and the token of the next page (empty on"),
+		Comment("the last page). Results are ordered by the OrderBy fields, then document ID;"),
+		Comment("Limit and Offset do not apply. A token only resumes a query with the same Where"),
+		Comment("and OrderBy calls."),
+		Method(recv, "QueryPage", "ctx context.Context, q "+query+", pageSize int, pageToken string", "([]*"+m.GoName+", string, error)",
+			Concat(CodeMonoid, []Code{
+				Line("fq, err := r.firestoreQuery(q)"),
+				If("err != nil", Return(`nil, "", err`)),
+				Linef("scope := %q + \"\\n\" + strings.Join(fq.clauses, \"\\n\")", m.Collection),
+				Line("docs, next, err := pageDocuments(ctx, fq.query, scope, fq.orders, pageSize, pageToken)"),
+				If("err != nil", Return(`nil, "", err`)),
+				Linef("results := make([]*%s, 0, len(docs))", m.GoName),
+				Line("for _, doc := range docs {"),
+				Line("\te, err := r.fromFirestoreDoc(doc)"),
+				If("err != nil", Return(`nil, "", err`)),
+				Line("\tresults = append(results, e)"),
+				Line("}"),
+				Return("results, next, nil"),
+			})),
+		Blank(), Method(qRecv, "Get", "ctx context.Context", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("finalQuery := q.query"),
+				If("q.limitVal > 0", Line("finalQuery = finalQuery.Limit(q.limitVal)")),
+				If("q.offsetVal > 0", Line("finalQuery = finalQuery.Offset(q.offsetVal)")),
+				Line("iter := finalQuery.Documents(ctx)"),
+				Line("defer iter.Stop()"),
+				Linef("var results []*%s", m.GoName),
+				Line("for {"),
+				Line("\tdoc, err := iter.Next()"),
+				If("err == iterator.Done", Line("break")),
+				If("err != nil", Return("nil, err")),
+				Line("\te, err := q.repo.fromFirestoreDoc(doc)"),
+				If("err != nil", Return("nil, err")),
+				Line("\tresults = append(results, e)"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Comment("Count returns the number of results, counted by the server; Limit and Offset"),
+		Comment("do not apply."),
+		Method(qRecv, "Count", "ctx context.Context", "(int64, error)", Return("countOf(ctx, q.query)")),
+		Blank(), Method(qRecv, "First", "ctx context.Context", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("q.limitVal = 1"),
+				Line("results, err := q.Get(ctx)"),
+				If("err != nil", Return("nil, err")),
+				If("len(results) == 0", Return("nil, ErrNotFound")),
+				Return("results[0], nil"),
+			})),
+	})
+}
+
+// WatchMethods generates Watch and WatchDoc, which stream the changes snapshot
+// listeners see, whichever client wrote them.
+func WatchMethods(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	decode := "r.fromFirestoreDoc"
+	if m.HasDeletedAt {
+		// a soft delete removes the entity, like it removes it from Watch's query
+		decode = fmt.Sprintf("func(doc *firestore.DocumentSnapshot) (*%s, error) {\n"+
+			"\tentity, err := r.fromFirestoreDoc(doc)\n"+
+			"\tif err == nil && entity.DeletedAt != nil {\n"+
+			"\t\treturn nil, ErrNotFound\n"+
+			"\t}\n"+
+			"\treturn entity, err\n"+
+			"}", m.GoName)
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Watch ==="),
+		Blank(), Commentf("Watch streams the changes to the %ss q matches, all of them when q is nil:", m.GoName),
+		Comment("first one ChangeAdded per match, then the changes as they happen. Limit applies,"),
+		Comment("Offset doesn't. The channel is closed when ctx is done, or after a change with"),
+		Comment("Err set when the listener fails or q isn't a Firestore query."),
+		Method(recv, "Watch", "ctx context.Context, q "+repository.QueryInterfaceName(m.GoName), "<-chan Change[*"+m.GoName+"]",
+			Concat(CodeMonoid, []Code{
+				Line("fq, err := r.firestoreQuery(q)"),
+				If("err != nil", Concat(CodeMonoid, []Code{
+					Linef("changes := make(chan Change[*%s], 1)", m.GoName),
+					Linef("changes <- Change[*%s]{Err: err}", m.GoName),
+					Line("close(changes)"),
+					Return("changes"),
+				})),
+				Line("query := fq.query"),
+				If("fq.limitVal > 0", Line("query = query.Limit(fq.limitVal)")),
+				Return("watch(ctx, query, r.fromFirestoreDoc)"),
+			})),
+		Blank(), Commentf("WatchDoc streams the changes to the %s with the given ID: ChangeAdded when it", m.GoName),
+		Comment("exists or comes to, ChangeModified on every write, ChangeRemoved when it is"),
+		Comment("deleted. The channel is closed like Watch's."),
+		Method(recv, "WatchDoc", "ctx context.Context, id string", "<-chan Change[*"+m.GoName+"]",
+			Linef("return watchDoc(ctx, r.Doc(id), %s)", decode)),
+	})
+}
+
+// TransactionHelpers generates the repository's side of a transaction,
+// Firestore<Entity>Tx, and RunTransaction, which runs one over the entity
+// alone. RunFirestoreTransaction runs them over the entities of the package.
+func TransactionHelpers(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	txName := "Firestore" + m.GoName + "Tx"
+	tRecv := "t *" + txName
+	// live skips a soft-deleted entity e inside a loop over documents
+	live := When(m.HasDeletedAt, If("e.DeletedAt != nil", Line("continue")))
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Transaction Support ==="),
+		Blank(), Comment("RunTransaction runs fn in a transaction over the " + m.GoName + "s; see RunFirestoreTransaction."),
+		Method(recv, "RunTransaction", "ctx context.Context, fn func(context.Context, "+repository.TxInterfaceName(m.GoName)+") error", "error",
+			Concat(CodeMonoid, []Code{
+				Line("err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {"),
+				Linef("	return fn(ctx, &%s{repo: r, tx: tx})", txName),
+				Line("})"),
+				Return("transactionError(err)"),
+			})),
+		Blank(), Commentf("%s is the %s side of a Firestore transaction.", txName, m.GoName),
+		Struct(txName, Concat(CodeMonoid, []Code{Field("repo", "*Firestore"+m.GoName+"Repository"), Field("tx", "*firestore.Transaction")})),
+		Blank(), Line(repository.TxAssertion(m.GoName, txName)),
+		Blank(), Method(tRecv, "Get", "id string", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("nil, ErrInvalidID")),
+				Line("doc, err := t.tx.Get(t.repo.Doc(id))"),
+				If("status.Code(err) == codes.NotFound", Return("nil, ErrNotFound")),
+				If("err != nil", Return("nil, err")),
+				When(!m.HasDeletedAt, Return("t.repo.fromFirestoreDoc(doc)")),
+				When(m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					Line("e, err := t.repo.fromFirestoreDoc(doc)"),
+					If("err != nil", Return("nil, err")),
+					If("e.DeletedAt != nil", Return("nil, ErrNotFound")),
+					Return("e, nil"),
+				})),
+			})),
+		Blank(), Method(tRecv, "GetAll", "ids []string", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("refs := make([]*firestore.DocumentRef, len(ids))"),
+				Line("for i, id := range ids {"),
+				If(`id == ""`, Return("nil, ErrInvalidID")),
+				Line("	refs[i] = t.repo.Doc(id)"),
+				Line("}"),
+				Line("docs, err := t.tx.GetAll(refs)"),
+				If("err != nil", Return("nil, err")),
+				Linef("var results []*%s", m.GoName),
+				Line("for _, doc := range docs {"),
+				If("!doc.Exists()", Line("continue")),
+				Line("	e, err := t.repo.fromFirestoreDoc(doc)"),
+				If("err != nil", Return("nil, err")),
+				live,
+				Line("	results = append(results, e)"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Comment("Create fails with ErrAlreadyExists when the transaction commits if the " + m.GoName),
+		Comment("exists by then."),
+		Method(tRecv, "Create", "entity *"+m.GoName, "(string, error)",
+			Concat(CodeMonoid, []Code{
+				When(m.HasCreatedAt || m.HasUpdatedAt, Concat(CodeMonoid, []Code{
+					Line("now := timestamppb.Now()"),
+					When(m.HasCreatedAt, Line("entity.CreatedAt = now")),
+					When(m.HasUpdatedAt, Line("entity.UpdatedAt = now")),
+				})),
+				When(m.Version != "" && !m.ETag, Linef("entity.%s = 1", m.VersionGoName)),
+				Linef("ref := t.repo.Doc(entity.%s)", m.IDGoName),
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Concat(CodeMonoid, []Code{
+					Line("ref = t.repo.Collection().NewDoc()"),
+					Linef("entity.%s = ref.ID", m.IDGoName),
+				})),
+				If("err := t.tx.Create(ref, t.repo.toFirestoreData(entity)); err != nil", Return(`"", err`)),
+				Return("ref.ID, nil"),
+			})),
+		Blank(), txUpdate(m, txName),
+		Blank(), Comment("Patch fails with ErrNotFound when the transaction commits if the " + m.GoName),
+		Comment("doesn't exist by then."),
+		Method(tRecv, "Patch", "id string, entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Line("updates, err := t.repo.patchUpdates(entity, mask)"),
+				If("err != nil", Return("err")),
+				Return("t.tx.Update(t.repo.Doc(id), updates)"),
+			})),
+		Blank(), Method(tRecv, "Delete", "id string", "error", Concat(CodeMonoid, []Code{If(`id == ""`, Return("ErrInvalidID")), Return("t.tx.Delete(t.repo.Doc(id))")})),
+		Blank(), Comment("Query runs filters as a query of the transaction. Like other queries, those"),
+		Comment("combining filters on several fields need a composite index."),
+		Method(tRecv, "Query", "filters ...Filter", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("q := t.repo.Collection().Query"),
+				When(m.HasDeletedAt, Line("q = q.Where(\"deleted_at\", \"==\", nil)")),
+				Line("for _, f := range filters {"),
+				Line("	q = q.Where(documents.key(protoreflect.Name(f.Field)), f.Op, documents.queryValue(f.Value))"),
+				Line("}"),
+				Line("iter := t.tx.Documents(q)"),
+				Line("defer iter.Stop()"),
+				Linef("var results []*%s", m.GoName),
+				Line("for {"),
+				Line("	doc, err := iter.Next()"),
+				If("err == iterator.Done", Line("break")),
+				If("err != nil", Return("nil, err")),
+				Line("	e, err := t.repo.fromFirestoreDoc(doc)"),
+				If("err != nil", Return("nil, err")),
+				Line("	results = append(results, e)"),
+				Line("}"),
+				Linef("slices.SortFunc(results, func(a, b *%s) int { return strings.Compare(a.%s, b.%s) })", m.GoName, m.IDGoName, m.IDGoName),
+				Return("results, nil"),
+			})),
+	})
+}
+
+// txUpdate is the transaction's Update. With a version field it reads the
+// stored document first, as Firestore requires reads before writes.
+func txUpdate(m MessageInfo, txName string) Code {
+	if m.Version == "" {
+		return Method("t *"+txName, "Update", "entity *"+m.GoName, "error",
+			Concat(CodeMonoid, []Code{
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				Return(fmt.Sprintf("t.tx.Set(t.repo.Doc(entity.%s), t.repo.toFirestoreData(entity))", m.IDGoName)),
+			}))
+	}
+	check := If(fmt.Sprintf("stored.%s != entity.%s", m.VersionGoName, m.VersionGoName), Return("ErrConflict"))
+	doc := Comment("Update fails with ErrConflict when the document changed since entity was read.")
+	if m.ETag {
+		doc = Join(doc, Commentf("entity.%s is the read's until the transaction commits.", m.VersionGoName))
+	}
+	return Concat(CodeMonoid, []Code{
+		doc,
+		Method("t *"+txName, "Update", "entity *"+m.GoName, "error",
+			Concat(CodeMonoid, []Code{
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+				Linef("ref := t.repo.Doc(entity.%s)", m.IDGoName),
+				Line("doc, err := t.tx.Get(ref)"),
+				If("status.Code(err) == codes.NotFound", Return("ErrNotFound")),
+				If("err != nil", Return("err")),
+				Line("stored, err := t.repo.fromFirestoreDoc(doc)"),
+				If("err != nil", Return("err")),
+				check,
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				When(!m.ETag, Linef("entity.%s++", m.VersionGoName)),
+				Return("t.tx.Set(ref, t.repo.toFirestoreData(entity))"),
+			})),
+	})
+}
+
+// Converters generates toFirestoreData and fromFirestoreDoc, which the package's
+// document codec implements; the ID is the document's name, and an etag its
+// update time, so neither is stored as a field.
+func Converters(m MessageInfo) Code {
+	recv := "r *Firestore" + m.GoName + "Repository"
+	var skip []string
+	for _, f := range m.Fields {
+		if f.IsID || m.ETag && f.Name == m.Version {
+			skip = append(skip, fmt.Sprintf("%q", f.Name))
+		}
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Converters ==="),
+		Blank(), Method(recv, "toFirestoreData", "entity *"+m.GoName, "map[string]interface{}",
+			Return("documents.encode("+strings.Join(append([]string{"entity.ProtoReflect()"}, skip...), ", ")+")")),
+		Blank(), Method(recv, "fromFirestoreDoc", "doc *firestore.DocumentSnapshot", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				If("!doc.Exists()", Return("nil, ErrNotFound")),
+				Linef("entity := &%s{}", m.GoName),
+				If("err := documents.decode(doc.Data(), entity.ProtoReflect()); err != nil",
+					Return(`nil, fmt.Errorf("%s: %w", doc.Ref.Path, err)`)),
+				When(m.HasID, Linef("entity.%s = doc.Ref.ID", m.IDGoName)),
+				When(m.ETag, Linef("entity.%s = etagOf(doc.UpdateTime)", m.VersionGoName)),
+				Return("entity, nil"),
+			})),
+	})
+}
+
+// =============================================================================
+// MAIN COMPOSITION - FoldMap over messages!
+// =============================================================================
+
+func MessageRepository(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("// ============================================================================"),
+		Linef("// %s Repository - CRUD + Find Methods", m.GoName),
+		Linef("// ============================================================================"),
+		RepositoryStruct(m), Constructor(m), CollectionHelpers(m),
+		CreateMethod(m), GetMethod(m), UpdateMethod(m), PatchMethod(m), DeleteMethod(m),
+		SoftDeleteMethods(m), ListMethod(m), ListPageMethod(m), ExistsMethod(m), CountMethod(m), AggregateMethods(m),
+		FindMethods(m), BatchMethods(m), QueryBuilder(m), WatchMethods(m), TransactionHelpers(m), Converters(m),
+	})
+}
+
+// GenerateFile generates the repositories of entityMessages. withHelpers adds
+// the declarations the repositories of a Go package share, which go in one
+// of its files only; enumNames makes their codec store enums by name.
+func GenerateFile(file *protogen.File, entityMessages []*protogen.Message, reg *entities.Registry, withHelpers, enumNames bool) Code {
+	if len(entityMessages) == 0 {
+		return CodeMonoid.Empty()
+	}
+
+	messages := Map(entityMessages, func(msg *protogen.Message) MessageInfo {
+		return ExtractMessageInfo(msg, reg.Config(msg))
+	})
+
+	return Concat(CodeMonoid, []Code{
+		Header(), Blank(), Package(string(file.GoPackageName)),
+		Imports("context", "crypto/hmac", "crypto/rand", "crypto/sha256", "encoding/base64",
+			"encoding/json", "errors", "fmt", "reflect", "slices", "strconv", "strings", "time", "unicode", "",
+			"cloud.google.com/go/firestore", "cloud.google.com/go/firestore/apiv1/firestorepb",
+			"google.golang.org/api/iterator", "google.golang.org/grpc/codes",
+			"google.golang.org/grpc/status", "google.golang.org/protobuf/reflect/protoreflect",
+			"google.golang.org/protobuf/types/known/fieldmaskpb",
+			"google.golang.org/protobuf/types/known/timestamppb"),
+		When(withHelpers, PackageHelpers(enumNames)),
+		When(withHelpers && hasETags(file.GoImportPath, reg), ETagHelpers()),
+		When(withHelpers, PackageTransactions(reg.Package(file.GoImportPath, false))),
+		FoldMap(messages, CodeMonoid, MessageRepository),
+	})
+}
+
+// hasETags reports whether an entity of the Go package keeps an etag.
+func hasETags(path protogen.GoImportPath, reg *entities.Registry) bool {
+	for _, msg := range reg.Package(path, false) {
+		if reg.Config(msg).ETag {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageTransactions declares the transactions over the entities of the Go
+// package, from every file of it, so that one can span entities declared
+// apart.
+func PackageTransactions(entityMessages []*protogen.Message) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Transactions ==="),
+		Blank(), Comment("FirestoreTx is the Tx of RunFirestoreTransaction."),
+		Struct("FirestoreTx", Concat(CodeMonoid, []Code{Field("client", "*firestore.Client"), Field("tx", "*firestore.Transaction")})),
+		Blank(), Line("var _ Tx = (*FirestoreTx)(nil)"),
+		FoldMap(entityMessages, CodeMonoid, func(msg *protogen.Message) Code {
+			name := msg.GoIdent.GoName
+			return Concat(CodeMonoid, []Code{
+				Blank(), Commentf("%s is the %s side of the transaction.", name, name),
+				Method("t *FirestoreTx", name, "", repository.TxInterfaceName(name),
+					Return(fmt.Sprintf("&Firestore%sTx{repo: NewFirestore%sRepository(t.client), tx: t.tx}", name, name))),
+			})
+		}),
+		Blank(), Raw(transactions),
+	})
+}
+
+const transactions = `// RunFirestoreTransaction runs fn in a transaction, whose writes commit
+// together when fn returns nil. Firestore runs fn again, up to a few times,
+// when another transaction interferes, so fn must not have other side effects.
+func RunFirestoreTransaction(ctx context.Context, client *firestore.Client, fn func(context.Context, Tx) error) error {
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return fn(ctx, &FirestoreTx{client: client, tx: tx})
+	})
+	return transactionError(err)
+}
+
+// transactionError returns the sentinel of the failure of a write that
+// Firestore reports when the transaction commits, ErrConflict when it aborted
+// every attempt, or err.
+func transactionError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.AlreadyExists:
+		return ErrAlreadyExists
+	case codes.Aborted:
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	return err
+}
+`
+
+// ETagHelpers declares the etag codec of the package's repositories: an etag
+// is the document's update time, which Firestore compares atomically through
+// the LastUpdateTime precondition.
+func ETagHelpers() Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== ETags ==="),
+		Blank(), Raw(etags),
+	})
+}
+
+const etags = `// etagOf returns the etag of a document updated at t.
+func etagOf(t time.Time) string { return t.UTC().Format(time.RFC3339Nano) }
+
+// parseEtag returns the update time an etag encodes.
+func parseEtag(etag string) (time.Time, error) { return time.Parse(time.RFC3339Nano, etag) }
+`
+
+// PackageHelpers declares what the repositories of a Go package share: the
+// document codec, with enums stored by name when enumNames, and the page token
+// codec behind ListPage and QueryPage among others.
+func PackageHelpers(enumNames bool) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Documents ==="),
+		Blank(), Comment("documents converts the entities to document data and back."),
+		Linef("var documents = documentCodec{enumNames: %t}", enumNames),
+		Blank(), Raw(codec),
+		Blank(), Comment("=== Page Tokens ==="),
+		Blank(), Raw(pageTokens),
+		Blank(), Raw(paging),
+		Blank(), Comment("=== Aggregations ==="),
+		Blank(), Raw(aggregations),
+		Blank(), Comment("=== Batches ==="),
+		Blank(), Raw(batches),
+		Blank(), Raw(bulkWrites),
+		Blank(), Comment("=== Changes ==="),
+		Blank(), Raw(changes),
+		Blank(), Raw(watches),
+	})
+}
+
+//go:embed documents/documents.go
+var documentsGo string
+
+//go:embed pagetokens/pagetokens.go
+var pageTokensGo string
+
+//go:embed batches/batches.go
+var batchesGo string
+
+//go:embed changes/changes.go
+var changesGo string
+
+// codec is the document codec, pageTokens the page token codec, batches the
+// batch reports and changes the change streams: the files of the packages of
+// the same names without the package clause and imports, which the generated
+// files declare themselves.
+var (
+	codec      = embedded(documentsGo)
+	pageTokens = embedded(pageTokensGo)
+	batches    = embedded(batchesGo)
+	changes    = embedded(changesGo)
+)
+
+// embedded returns the declarations of the Go file src.
+func embedded(src string) string {
+	_, imports, _ := strings.Cut(src, "\nimport (")
+	_, body, _ := strings.Cut(imports, "\n)\n")
+	return strings.TrimLeft(body, "\n")
+}
+
+// watches turn the snapshots of listeners into the changes of changes.
+const watches = `// watch streams the changes to the documents q matches until ctx is done or
+// the listener fails.
+func watch[T any](ctx context.Context, q firestore.Query, decode func(*firestore.DocumentSnapshot) (T, error)) <-chan Change[T] {
+	changes := make(chan Change[T])
+	go func() {
+		defer close(changes)
+		it := q.Snapshots(ctx)
+		defer it.Stop()
+		for {
+			snap, err := it.Next()
+			if err != nil {
+				sendChange(ctx, changes, Change[T]{Err: err})
+				return
+			}
+			for _, dc := range snap.Changes {
+				c := Change[T]{Kind: ChangeModified, ID: dc.Doc.Ref.ID, ReadTime: snap.ReadTime}
+				switch dc.Kind {
+				case firestore.DocumentAdded:
+					c.Kind = ChangeAdded
+				case firestore.DocumentRemoved:
+					c.Kind = ChangeRemoved
+				}
+				c.Entity, c.Err = decode(dc.Doc)
+				if !sendChange(ctx, changes, c) {
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// watchDoc streams the changes to the document at ref until ctx is done or
+// the listener fails. decode returns ErrNotFound for documents that don't
+// hold an entity.
+func watchDoc[T any](ctx context.Context, ref *firestore.DocumentRef, decode func(*firestore.DocumentSnapshot) (T, error)) <-chan Change[T] {
+	changes := make(chan Change[T])
+	go func() {
+		defer close(changes)
+		it := ref.Snapshots(ctx)
+		defer it.Stop()
+		var state docState[T]
+		for {
+			doc, err := it.Next()
+			if err != nil {
+				sendChange(ctx, changes, Change[T]{Err: err})
+				return
+			}
+			entity, err := decode(doc)
+			c, changed := state.next(!errors.Is(err, ErrNotFound), entity, err)
+			if !changed {
+				continue
+			}
+			c.ID, c.ReadTime = ref.ID, doc.ReadTime
+			if !sendChange(ctx, changes, c) {
+				return
+			}
+		}
+	}()
+	return changes
+}
+`
+
+// bulkWrites write any number of documents through a BulkWriter, which sends
+// them in batches of its own sizing and retries failed writes with backoff,
+// and report the outcome of each in a BatchReport of batches.
+const bulkWrites = `// bulkWrite submits write(bw, i) for every item of report that hasn't failed
+// yet, waits for the writes and records their errors in the items. written is
+// called with the result of each successful write unless nil.
+func bulkWrite(ctx context.Context, client *firestore.Client, report *BatchReport, write func(bw *firestore.BulkWriter, i int) (*firestore.BulkWriterJob, error), written func(i int, wr *firestore.WriteResult)) {
+	bw := client.BulkWriter(ctx)
+	submit := func(i int) (*firestore.BulkWriterJob, error) { return write(bw, i) }
+	fillReport(report, submit, bw.End, func(i int, job *firestore.BulkWriterJob) error {
+		wr, err := job.Results()
+		switch status.Code(err) {
+		case codes.OK:
+			if written != nil {
+				written(i, wr)
+			}
+			return nil
+		case codes.NotFound:
+			return ErrNotFound
+		case codes.AlreadyExists:
+			return ErrAlreadyExists
+		case codes.FailedPrecondition:
+			return ErrConflict
+		}
+		return err
+	})
+}
+
+// fieldUpdates turns document data into the updates that set each field.
+func fieldUpdates(data map[string]interface{}) []firestore.Update {
+	updates := make([]firestore.Update, 0, len(data))
+	for path, v := range data {
+		updates = append(updates, firestore.Update{Path: path, Value: v})
+	}
+	return updates
+}
+`
+
+// aggregations run aggregation queries, which return the count, sum or
+// average of the matching documents instead of the documents.
+const aggregations = `// countOf returns the number of documents q matches.
+func countOf(ctx context.Context, q firestore.Query) (int64, error) {
+	res, err := q.NewAggregationQuery().WithCount("count").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return aggregateValue(res, "count").GetIntegerValue(), nil
+}
+
+// sumInt returns the sum of the integer field at path over the documents q
+// matches. Firestore returns a sum that overflows int64 as a double.
+func sumInt(ctx context.Context, q firestore.Query, path string) (int64, error) {
+	res, err := q.NewAggregationQuery().WithSum(path, "sum").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	v := aggregateValue(res, "sum")
+	if _, ok := v.GetValueType().(*firestorepb.Value_DoubleValue); ok {
+		return 0, fmt.Errorf("sum of %s overflows int64", path)
+	}
+	return v.GetIntegerValue(), nil
+}
+
+// sumFloat returns the sum of the floating-point field at path over the
+// documents q matches, which Firestore returns as an integer when it matches
+// none.
+func sumFloat(ctx context.Context, q firestore.Query, path string) (float64, error) {
+	res, err := q.NewAggregationQuery().WithSum(path, "sum").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	v := aggregateValue(res, "sum")
+	if _, ok := v.GetValueType().(*firestorepb.Value_IntegerValue); ok {
+		return float64(v.GetIntegerValue()), nil
+	}
+	return v.GetDoubleValue(), nil
+}
+
+// avgOf returns the average of the field at path over the documents q
+// matches, 0 when it matches none (Firestore returns null).
+func avgOf(ctx context.Context, q firestore.Query, path string) (float64, error) {
+	res, err := q.NewAggregationQuery().WithAvg(path, "avg").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return aggregateValue(res, "avg").GetDoubleValue(), nil
+}
+
+func aggregateValue(res firestore.AggregationResult, alias string) *firestorepb.Value {
+	v, _ := res[alias].(*firestorepb.Value)
+	return v
+}
+`
+
+// paging pages through a query with the page tokens of pagetokens.
+const paging = `// pageDocuments runs q, already ordered by fields, with the document ID as
+// tie-breaker, from the position pageToken encodes. It returns up to pageSize
+// documents and, when more follow, the token of the next page. scope names
+// the query; tokens issued for one scope are rejected by another.
+func pageDocuments(ctx context.Context, q firestore.Query, scope string, fields []string, pageSize int, pageToken string) ([]*firestore.DocumentSnapshot, string, error) {
+	switch {
+	case pageSize <= 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+	q = q.OrderBy(firestore.DocumentID, firestore.Asc)
+	if pageToken != "" {
+		c, err := parsePageToken(pageToken, scope)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(c.Values) != len(fields) {
+			return nil, "", ErrInvalidPageToken
+		}
+		after := make([]interface{}, 0, len(fields)+1)
+		for _, v := range c.Values {
+			after = append(after, v.value())
+		}
+		q = q.StartAfter(append(after, c.ID)...)
+	}
+
+	// One extra document tells whether there is a next page
+	docs, err := q.Limit(pageSize + 1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(docs) <= pageSize {
+		return docs, "", nil
+	}
+	docs = docs[:pageSize]
+	last := docs[pageSize-1]
+	c := pageCursor{Scope: scope, ID: last.Ref.ID}
+	for _, field := range fields {
+		v, err := last.DataAt(field)
+		if err != nil {
+			return nil, "", err
+		}
+		cv, err := newCursorValue(v)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", field, err)
+		}
+		c.Values = append(c.Values, cv)
+	}
+	next, err := signPageToken(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return docs, next, nil
+}
+`
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-firestore")
+	softDelete := flags.Bool("soft_delete", true, "manage deleted_at on entities that have it unless the entity option says otherwise")
+	timestamps := flags.Bool("timestamps", true, "manage created_at/updated_at on entities that have them unless the entity option says otherwise")
+	enumNames := false
+	flags.Func("enums", "store enum values by number or by name (default number)", func(v string) error {
+		switch v {
+		case "number", "name":
+			enumNames = v == "name"
+			return nil
+		}
+		return fmt.Errorf("want number or name, got %q", v)
+	})
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		reg, err := entities.Defaults{SoftDelete: *softDelete, Timestamps: *timestamps}.Registry(gen)
+		if err != nil {
+			return err
+		}
+
+		// The page token helpers are declared once per Go package
+		helpersDeclared := make(map[protogen.GoImportPath]bool)
+		generated := false
+
+		for _, f := range gen.Files {
+			if !f.Generate || len(f.Messages) == 0 {
+				continue
+			}
+
+			// Collect entity messages (those with entity option)
+			rep.Entities(reg, f, false)
+			entityMessages := reg.Entities(f, false)
+
+			if len(entityMessages) == 0 {
+				continue
+			}
+
+			withHelpers := !helpersDeclared[f.GoImportPath]
+			helpersDeclared[f.GoImportPath] = true
+
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_firestore.pb.go", f.GoImportPath, GenerateFile(f, entityMessages, reg, withHelpers, enumNames).Run()); err != nil {
+				return err
+			}
+			generated = true
+		}
+		if !generated {
+			return nil
+		}
+		indexes, err := GenerateIndexes(gen, reg, rep)
+		if err != nil {
+			return err
+		}
+		if _, err := gen.NewGeneratedFile("firestore.indexes.json", "").Write(indexes); err != nil {
+			return err
+		}
+
+		// Security rules for the entities clients may access directly
+		if es := RulesEntities(gen, reg); len(es) > 0 {
+			if _, err := gen.NewGeneratedFile("firestore.rules", "").Write([]byte(GenerateRules(es).Run())); err != nil {
+				return err
+			}
+			if _, err := gen.NewGeneratedFile("firestore.rules.test.ts", "").Write([]byte(GenerateRulesTest(es).Run())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func toSnakeCase(s string) string {
+	var result []rune
+	for i, r := range s {
+		if i > 0 && unicode.IsUpper(r) {
+			result = append(result, '_')
+		}
+		result = append(result, unicode.ToLower(r))
+	}
+	return string(result)
+}
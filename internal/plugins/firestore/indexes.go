@@ -0,0 +1,180 @@
+package firestore
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// =============================================================================
+// INDEX DEFINITIONS (firestore.indexes.json)
+// =============================================================================
+
+// IndexFile is the firestore.indexes.json read by `firebase deploy --only
+// firestore:indexes`.
+type IndexFile struct {
+	Indexes        []Index         `json:"indexes"`
+	FieldOverrides []FieldOverride `json:"fieldOverrides"`
+}
+
+// Index is a composite index.
+type Index struct {
+	CollectionGroup string       `json:"collectionGroup"`
+	QueryScope      string       `json:"queryScope"`
+	Fields          []IndexField `json:"fields"`
+}
+
+// IndexField is one field of an index: ordered, or an array for
+// array-contains.
+type IndexField struct {
+	FieldPath   string `json:"fieldPath"`
+	Order       string `json:"order,omitempty"`
+	ArrayConfig string `json:"arrayConfig,omitempty"`
+}
+
+// FieldOverride replaces the automatic single-field indexes of a field.
+type FieldOverride struct {
+	CollectionGroup string       `json:"collectionGroup"`
+	FieldPath       string       `json:"fieldPath"`
+	Indexes         []IndexField `json:"indexes"`
+}
+
+// EntityIndexes returns the composite indexes the generated queries of an
+// entity need, and the field overrides that exempt the fields no generated
+// query reads from automatic indexing.
+//
+// Firestore serves single-field filters and orders from its automatic
+// indexes, but a filter combined with an order on another field needs a
+// composite index, and FAILED_PRECONDITION without one. The generated
+// queries combine:
+//   - FindBy<Field>: an equality (array-contains for repeated fields) filter
+//     and, with soft delete, deleted_at == nil;
+//   - ListPage: deleted_at == nil ordered by created_at;
+//   - the query builder over the declared order_by: each sort order, alone or
+//     after one indexed field's filter, with deleted_at == nil;
+//   - Sum<Field> and Avg<Field>: the aggregated field with deleted_at == nil.
+func EntityIndexes(msg *protogen.Message, config *entities.Config, e *explain.Entry) ([]Index, []FieldOverride) {
+	m := ExtractMessageInfo(msg, config)
+	var (
+		indexes []Index
+		seen    = make(map[string]bool)
+	)
+	add := func(why string, fields ...IndexField) {
+		// A composite index has at least two fields; one is served by the
+		// automatic single-field indexes.
+		if len(fields) < 2 {
+			return
+		}
+		ix := Index{CollectionGroup: m.Collection, QueryScope: "COLLECTION", Fields: fields}
+		key := indexKey(ix)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		indexes = append(indexes, ix)
+		e.Because("index (%s): %s", key, why)
+	}
+
+	var notDeleted []IndexField
+	if m.HasDeletedAt {
+		notDeleted = []IndexField{{FieldPath: "deleted_at", Order: "ASCENDING"}}
+	}
+	var sorts []IndexField
+	if m.HasCreatedAt {
+		add("ListPage", append(notDeleted, IndexField{FieldPath: "created_at", Order: "ASCENDING"})...)
+	}
+	for _, o := range config.Orders {
+		sort := IndexField{FieldPath: toSnakeCase(o.Field), Order: "ASCENDING"}
+		if o.Desc {
+			sort.Order = "DESCENDING"
+		}
+		sorts = append(sorts, sort)
+		add("order_by "+o.Field, append(notDeleted, sort)...)
+	}
+
+	for _, f := range m.Fields {
+		if aggregatable(m, f) {
+			add("Sum"+f.GoName+", Avg"+f.GoName, append(notDeleted, IndexField{FieldPath: toSnakeCase(f.Name), Order: "ASCENDING"})...)
+		}
+	}
+
+	queried := map[string]bool{m.IDField: true}
+	for _, o := range config.Orders {
+		queried[o.Field] = true
+	}
+	for _, f := range m.Fields {
+		if !f.IsIndexed || f.IsID {
+			continue
+		}
+		queried[f.Name] = true
+		filter := IndexField{FieldPath: toSnakeCase(f.Name), Order: "ASCENDING"}
+		if f.IsRepeated {
+			filter = IndexField{FieldPath: toSnakeCase(f.Name), ArrayConfig: "CONTAINS"}
+		}
+		add("FindBy"+f.GoName, append([]IndexField{filter}, notDeleted...)...)
+		if f.IsUnique {
+			continue // at most one match, nothing to sort
+		}
+		for _, sort := range sorts {
+			add("Where "+f.Name+" ordered by order_by", append(append([]IndexField{filter}, notDeleted...), sort)...)
+		}
+	}
+
+	var overrides []FieldOverride
+	for _, f := range msg.Fields {
+		name := string(f.Desc.Name())
+		if queried[name] || !unindexable(f) {
+			continue
+		}
+		overrides = append(overrides, FieldOverride{CollectionGroup: m.Collection, FieldPath: toSnakeCase(name), Indexes: []IndexField{}})
+		e.Because("field override: %s exempt from indexing, no generated query reads it", name)
+	}
+	return indexes, overrides
+}
+
+// unindexable reports whether f holds values Firestore indexes at a cost
+// out of proportion to their use in queries: arrays and maps get an index
+// entry per element, bytes and nested messages can be large.
+func unindexable(f *protogen.Field) bool {
+	switch {
+	case f.Desc.IsList(), f.Desc.IsMap(), f.Desc.Kind() == protoreflect.BytesKind:
+		return true
+	case f.Desc.Kind() == protoreflect.MessageKind:
+		return f.Message.Desc.FullName() != "google.protobuf.Timestamp"
+	default:
+		return false
+	}
+}
+
+func indexKey(ix Index) string {
+	parts := make([]string, len(ix.Fields))
+	for i, f := range ix.Fields {
+		parts[i] = f.FieldPath + " " + strings.ToLower(f.Order+f.ArrayConfig)
+	}
+	return ix.CollectionGroup + ": " + strings.Join(parts, ", ")
+}
+
+// GenerateIndexes renders the index file for the entities of every file to
+// generate.
+func GenerateIndexes(gen *protogen.Plugin, reg *entities.Registry, rep *explain.Report) ([]byte, error) {
+	file := IndexFile{Indexes: []Index{}, FieldOverrides: []FieldOverride{}}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		for _, msg := range reg.Entities(f, false) {
+			indexes, overrides := EntityIndexes(msg, reg.Config(msg), rep.Message(msg))
+			file.Indexes = append(file.Indexes, indexes...)
+			file.FieldOverrides = append(file.FieldOverrides, overrides...)
+		}
+	}
+	b, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
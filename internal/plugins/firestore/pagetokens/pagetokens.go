@@ -0,0 +1,203 @@
+// Package pagetokens is the page token codec of the generated Firestore
+// repositories. protoc-gen-firestore embeds this file, without its package
+// clause and imports, in the helpers of every Go package it generates, as it
+// does the document codec.
+//
+// A token is base64(JSON) "." base64(HMAC-SHA256), so that clients cannot
+// forge a position or reuse a token on another query.
+package pagetokens
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPageSize is the page size of ListPage and Page when none is given,
+// and MaxPageSize the largest they return.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// ErrInvalidPageToken is returned for a page token that was altered, was
+// signed with another key, or belongs to another query.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+var pageTokenKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// SetPageTokenKey sets the key page tokens are signed with. The default is
+// random per process, so tokens do not survive a restart and are not
+// accepted by other instances; set the same key everywhere before serving.
+func SetPageTokenKey(key []byte) { pageTokenKey = append([]byte(nil), key...) }
+
+// pageCursor is the position a page token encodes: the ordered field values
+// and document ID of the last document of the previous page.
+type pageCursor struct {
+	Scope  string        `json:"s"`
+	Values []cursorValue `json:"v,omitempty"`
+	ID     string        `json:"id"`
+}
+
+// cursorValue keeps the Firestore type of an ordered field value, which
+// plain JSON would lose (int64 against float64, timestamps as strings).
+type cursorValue struct {
+	Bool   *bool      `json:"b,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	Float  *float64   `json:"f,omitempty"`
+	String *string    `json:"s,omitempty"`
+	Bytes  []byte     `json:"y,omitempty"`
+	Time   *time.Time `json:"t,omitempty"`
+}
+
+func newCursorValue(v interface{}) (cursorValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return cursorValue{}, nil
+	case bool:
+		return cursorValue{Bool: &v}, nil
+	case int64:
+		return cursorValue{Int: &v}, nil
+	case float64:
+		return cursorValue{Float: &v}, nil
+	case string:
+		return cursorValue{String: &v}, nil
+	case []byte:
+		return cursorValue{Bytes: v}, nil
+	case time.Time:
+		return cursorValue{Time: &v}, nil
+	default:
+		return cursorValue{}, fmt.Errorf("cannot page on a field of type %T", v)
+	}
+}
+
+func (c cursorValue) value() interface{} {
+	switch {
+	case c.Bool != nil:
+		return *c.Bool
+	case c.Int != nil:
+		return *c.Int
+	case c.Float != nil:
+		return *c.Float
+	case c.String != nil:
+		return *c.String
+	case c.Bytes != nil:
+		return c.Bytes
+	case c.Time != nil:
+		return *c.Time
+	default:
+		return nil
+	}
+}
+
+func signPageToken(c pageCursor) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, pageTokenKey)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func parsePageToken(token, scope string) (pageCursor, error) {
+	var c pageCursor
+	data, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return c, ErrInvalidPageToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+	mac := hmac.New(sha256.New, pageTokenKey)
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return c, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(payload, &c); err != nil || c.Scope != scope {
+		return c, ErrInvalidPageToken
+	}
+	return c, nil
+}
+
+// scopeValue returns the form of v, a filter value as documents store it, in
+// the scope of a page token: the same for equal values however they were
+// built, and different for values of different types, so that a token of
+// price == 1 does not resume price == "1". Integers are all int64 and floats
+// float64, as Firestore stores them, map keys are sorted, and structs, such
+// as document references, compare by their exported fields.
+func scopeValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return "b:" + base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return "t:" + v.UTC().Format(time.RFC3339Nano)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "i:" + strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "i:" + strconv.FormatInt(int64(rv.Uint()), 10)
+	case reflect.Float32, reflect.Float64:
+		return "f:" + strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = scopeValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	case reflect.Map:
+		entries := make([]string, 0, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			entries = append(entries, scopeValue(iter.Key().Interface())+":"+scopeValue(iter.Value().Interface()))
+		}
+		slices.Sort(entries)
+		return "{" + strings.Join(entries, ",") + "}"
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "null"
+		}
+		return scopeValue(rv.Elem().Interface())
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < rv.NumField(); i++ {
+			if f := rv.Type().Field(i); f.IsExported() {
+				fields = append(fields, f.Name+":"+scopeValue(rv.Field(i).Interface()))
+			}
+		}
+		return fmt.Sprintf("%T{%s}", v, strings.Join(fields, ","))
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,158 @@
+package pagetokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func cursor(t *testing.T, id string, values ...interface{}) pageCursor {
+	t.Helper()
+	c := pageCursor{Scope: "products\nwhere \"price\" \">\" i:100", ID: id}
+	for _, v := range values {
+		cv, err := newCursorValue(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Values = append(c.Values, cv)
+	}
+	return c
+}
+
+func TestRoundTrip(t *testing.T) {
+	SetPageTokenKey([]byte("key"))
+	at := time.Date(2024, 5, 1, 12, 30, 0, 5, time.UTC)
+	c := cursor(t, "doc-1", nil, true, int64(1), float64(1), "1", []byte{1}, at)
+	token, err := signPageToken(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parsePageToken(token, c.Scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []interface{}
+	for _, v := range got.Values {
+		values = append(values, v.value())
+	}
+	// the values keep their types: 1 stays an int64 and 1.0 a float64
+	want := []interface{}{nil, true, int64(1), float64(1), "1", []byte{1}, at}
+	if got.ID != "doc-1" || !reflect.DeepEqual(values, want) {
+		t.Errorf("parsed %s %#v, want doc-1 %#v", got.ID, values, want)
+	}
+
+	if _, err := newCursorValue(int32(1)); err == nil {
+		t.Errorf("newCursorValue accepted an int32, which documents never hold")
+	}
+}
+
+func TestRejectedTokens(t *testing.T) {
+	SetPageTokenKey([]byte("key"))
+	c := cursor(t, "doc-1", int64(100))
+	token, err := signPageToken(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	forged := cursor(t, "doc-9", int64(100))
+	forgedToken, err := signPageToken(forged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedPayload, _, _ := strings.Cut(forgedToken, ".")
+	flipped := []byte(sig)
+	flipped[0] ^= 1
+	mac := hmac.New(sha256.New, []byte("key"))
+	mac.Write([]byte("{"))
+	notJSON := base64.RawURLEncoding.EncodeToString([]byte("{")) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name, token, scope string
+		key                []byte
+	}{
+		{name: "empty", token: ""},
+		{name: "no signature", token: payload},
+		{name: "payload not base64", token: "!!." + sig},
+		{name: "signature not base64", token: payload + ".!!"},
+		{name: "tampered payload", token: forgedPayload + "." + sig},
+		{name: "tampered signature", token: payload + "." + string(flipped)},
+		{name: "signed with another key", token: token, key: []byte("other")},
+		{name: "another query", token: token, scope: "products\nwhere \"price\" \">\" i:200"},
+		{name: "signed, but not JSON", token: notJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPageTokenKey([]byte("key"))
+			if tt.key != nil {
+				SetPageTokenKey(tt.key)
+			}
+			scope := c.Scope
+			if tt.scope != "" {
+				scope = tt.scope
+			}
+			if _, err := parsePageToken(tt.token, scope); !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("parsePageToken = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}
+
+func TestSetPageTokenKeyCopies(t *testing.T) {
+	key := []byte("key")
+	SetPageTokenKey(key)
+	token, err := signPageToken(cursor(t, "doc-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key[0] = 'K'
+	if _, err := parsePageToken(token, cursor(t, "").Scope); err != nil {
+		t.Errorf("changing the caller's slice changed the key: %v", err)
+	}
+}
+
+type ref struct {
+	Path   string
+	Parent *ref
+	client *int
+}
+
+func TestScopeValue(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	one, other := 1, 2
+	equal := [][]interface{}{
+		{int64(1), 1, int32(1), uint8(1)},
+		{1.5, float32(1.5)},
+		{at, at.UTC()},
+		{[]interface{}{int64(1), "a"}, []interface{}{1, "a"}, [2]interface{}{int32(1), "a"}},
+		{map[string]interface{}{"a": int64(1), "b": "x"}, map[string]interface{}{"b": "x", "a": 1}},
+		{&ref{Path: "a/b", client: &one}, &ref{Path: "a/b", client: &other}, ref{Path: "a/b"}},
+		{nil, (*ref)(nil)},
+	}
+	for _, values := range equal {
+		want := scopeValue(values[0])
+		for _, v := range values[1:] {
+			if got := scopeValue(v); got != want {
+				t.Errorf("scopeValue(%#v) = %s, want %s as for %#v", v, got, want, values[0])
+			}
+		}
+	}
+
+	distinct := []interface{}{
+		nil, int64(1), 1.0, "1", true, "true", []byte("1"), at, "a,b", []string{"a", "b"},
+		[]interface{}{[]string{"a"}, "b"}, map[string]interface{}{"a": "b"}, map[string]interface{}{`a":"b`: nil},
+		&ref{Path: "a/b"}, &ref{Path: "a/b", Parent: &ref{Path: "a"}},
+	}
+	seen := make(map[string]interface{})
+	for _, v := range distinct {
+		s := scopeValue(v)
+		if prev, ok := seen[s]; ok {
+			t.Errorf("scopeValue(%#v) = scopeValue(%#v) = %s", v, prev, s)
+		}
+		seen[s] = v
+	}
+}
@@ -0,0 +1,362 @@
+package firestore
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// =============================================================================
+// SECURITY RULES (firestore.rules + emulator test scaffold)
+// =============================================================================
+
+// RulesEntity is an entity clients may access directly.
+type RulesEntity struct {
+	Msg    *protogen.Message
+	Config *entities.Config
+}
+
+// RulesEntities returns the entities of the files to generate that declare
+// the access option.
+func RulesEntities(gen *protogen.Plugin, reg *entities.Registry) []RulesEntity {
+	var out []RulesEntity
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		for _, msg := range reg.Entities(f, false) {
+			if c := reg.Config(msg); c.Access != nil {
+				out = append(out, RulesEntity{Msg: msg, Config: c})
+			}
+		}
+	}
+	return out
+}
+
+// GenerateRules renders firestore.rules. Collections without the access
+// option get no match block, so clients are denied and only the server,
+// which bypasses rules, reaches them.
+//
+// Roles are read from the role claim protoc-gen-auth puts in ID tokens, and
+// a tenant field is compared with the caller's claim of the same name, so the
+// rules enforce what RequireRole and RequireOwner enforce on the server.
+func GenerateRules(es []RulesEntity) Code {
+	return Join(
+		Line("rules_version = '2';"),
+		Blank(),
+		Line("// Code generated by protoc-gen-firestore. DO NOT EDIT."),
+		Line("// Collections not listed here are closed to clients; the server's Admin SDK"),
+		Line("// bypasses these rules."),
+		block("service cloud.firestore {", "}",
+			block("match /databases/{database}/documents {", "}",
+				block("function signedIn() {", "}", Line("return request.auth != null;")),
+				Blank(),
+				block("function hasRole(roles) {", "}", Line("return signedIn() && ('*' in roles || request.auth.token.get('role', '') in roles);")),
+				FoldMap(es, CodeMonoid, rulesMatch),
+			),
+		),
+	)
+}
+
+func rulesMatch(e RulesEntity) Code {
+	a := e.Config.Access
+	var fns []Code
+	if a.OwnerField != "" {
+		fns = append(fns, block("function owns(data) {", "}",
+			Linef("return signedIn() && data.%s == request.auth.uid;", toSnakeCase(a.OwnerField))))
+	}
+	if a.TenantField != "" {
+		tenant := toSnakeCase(a.TenantField)
+		fns = append(fns, block("function inTenant(data) {", "}",
+			Linef("return signedIn() && data.%s == request.auth.token.get('%s', null);", tenant, tenant)))
+	}
+
+	// allow returns the condition of op: a caller with one of the roles, or
+	// the owner, of data in the caller's tenant.
+	allow := func(roles []string, data string) string {
+		var who []string
+		if len(roles) > 0 {
+			who = append(who, "hasRole("+rulesList(roles)+")")
+		}
+		if a.OwnerField != "" {
+			who = append(who, "owns("+data+")")
+		}
+		cond := strings.Join(who, " || ")
+		switch {
+		case len(who) == 0:
+			return "false"
+		case a.TenantField == "":
+			return cond
+		case len(who) > 1:
+			cond = "(" + cond + ")"
+		}
+		return "inTenant(" + data + ") && " + cond
+	}
+	update := Linef("allow update: if %s;", allow(a.Update, "resource.data"))
+	if cond := allow(a.Update, "resource.data"); len(a.Immutable) > 0 && cond != "false" {
+		names := make([]string, len(a.Immutable))
+		for i, n := range a.Immutable {
+			names[i] = toSnakeCase(n)
+		}
+		update = Join(
+			Linef("allow update: if %s", parenthesize(cond)),
+			Linef("  && !request.resource.data.diff(resource.data).affectedKeys().hasAny(%s);", rulesList(names)),
+		)
+	}
+
+	return Join(
+		Blank(),
+		Commentf("%s (%s)", e.Msg.GoIdent.GoName, e.Msg.Desc.FullName()),
+		block(fmt.Sprintf("match /%s/{id} {", e.Config.Collection), "}",
+			FoldMap(fns, CodeMonoid, func(fn Code) Code { return Join(fn, Blank()) }),
+			Linef("allow read: if %s;", allow(a.Read, "resource.data")),
+			Linef("allow create: if %s;", allow(a.Create, "request.resource.data")),
+			update,
+			Linef("allow delete: if %s;", allow(a.Delete, "resource.data")),
+		),
+	)
+}
+
+// block emits header, body indented by two spaces and closing, the layout of
+// rules and TypeScript sources.
+func block(header, closing string, body ...Code) Code {
+	return Code{Run: func() string {
+		lines := strings.Split(Join(body...).Run(), "\n")
+		for i, l := range lines {
+			if l != "" {
+				lines[i] = "  " + l
+			}
+		}
+		return header + "\n" + strings.Join(lines, "\n") + closing + "\n"
+	}}
+}
+
+// parenthesize wraps a condition joined with || so that an && may follow.
+func parenthesize(cond string) string {
+	if strings.Contains(cond, " || ") {
+		return "(" + cond + ")"
+	}
+	return cond
+}
+
+func rulesList(xs []string) string {
+	quoted := make([]string, len(xs))
+	for i, x := range xs {
+		quoted[i] = "'" + x + "'"
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// GenerateRulesTest renders firestore.rules.test.ts, a scaffold of
+// @firebase/rules-unit-testing cases run against the Firestore emulator:
+// one per rule the access option implies. Extend it with the cases specific
+// to your data.
+func GenerateRulesTest(es []RulesEntity) Code {
+	return Join(
+		Line("// Code generated by protoc-gen-firestore. DO NOT EDIT."),
+		Line("//"),
+		Line("// Security rules tests against the Firestore emulator:"),
+		Line("//"),
+		Line("//   npm install --save-dev vitest firebase @firebase/rules-unit-testing"),
+		Line("//   firebase emulators:exec --only firestore 'npx vitest run firestore.rules.test.ts'"),
+		Blank(),
+		Line("import { readFileSync } from 'node:fs';"),
+		Line("import {"),
+		Line("  assertFails,"),
+		Line("  assertSucceeds,"),
+		Line("  initializeTestEnvironment,"),
+		Line("  type RulesTestEnvironment,"),
+		Line("} from '@firebase/rules-unit-testing';"),
+		Line("import { deleteDoc, doc, getDoc, setDoc, updateDoc, type DocumentData } from 'firebase/firestore';"),
+		Line("import { afterAll, beforeAll, beforeEach, describe, it } from 'vitest';"),
+		Blank(),
+		Line("let env: RulesTestEnvironment;"),
+		Blank(),
+		Line("beforeAll(async () => {"),
+		Line("  env = await initializeTestEnvironment({"),
+		Line("    projectId: 'demo-rules',"),
+		Line("    firestore: { rules: readFileSync(new URL('./firestore.rules', import.meta.url), 'utf8') },"),
+		Line("  });"),
+		Line("});"),
+		Line("afterAll(() => env.cleanup());"),
+		Line("beforeEach(() => env.clearFirestore());"),
+		Blank(),
+		Line("// as returns a Firestore client signed in as uid with the given token claims."),
+		Line("const as = (uid: string, claims: Record<string, unknown> = {}) => env.authenticatedContext(uid, claims).firestore();"),
+		Line("const anonymous = () => env.unauthenticatedContext().firestore();"),
+		Blank(),
+		Line("// seed writes a document with the rules disabled."),
+		Line("async function seed(path: string, data: DocumentData) {"),
+		Line("  await env.withSecurityRulesDisabled((ctx) => setDoc(doc(ctx.firestore(), path), data));"),
+		Line("}"),
+		FoldMap(es, CodeMonoid, rulesTests),
+	)
+}
+
+func rulesTests(e RulesEntity) Code {
+	a := e.Config.Access
+	path := e.Config.Collection + "/doc-1"
+
+	// A document of the owner "alice" in tenant-a, and the claims that put a
+	// caller in that tenant.
+	var data, claims []string
+	if a.OwnerField != "" {
+		data = append(data, fmt.Sprintf("%s: 'alice'", toSnakeCase(a.OwnerField)))
+	}
+	if a.TenantField != "" {
+		data = append(data, fmt.Sprintf("%s: 'tenant-a'", toSnakeCase(a.TenantField)))
+		claims = append(claims, fmt.Sprintf("%s: 'tenant-a'", toSnakeCase(a.TenantField)))
+	}
+	var tests []Code
+	test := func(name string, body ...Code) {
+		tests = append(tests, Blank(), block("it('"+name+"', async () => {", "});", body...))
+	}
+	object := func(props []string) string {
+		if len(props) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(props, ", ") + " }"
+	}
+	// withRole returns the claims of a caller with role; any signed-in user
+	// has '*'.
+	withRole := func(role string) string {
+		if role == "*" {
+			return object(claims)
+		}
+		return object(append([]string{"role: '" + role + "'"}, claims...))
+	}
+	tenantClaims := object(claims)
+
+	test("denies signed-out reads",
+		Line("await seed(path, doc1);"),
+		Line("await assertFails(getDoc(doc(anonymous(), path)));"),
+	)
+	if a.OwnerField != "" {
+		owner := toSnakeCase(a.OwnerField)
+		test("lets the owner create, read and delete their document",
+			Linef("const db = as('alice', %s);", tenantClaims),
+			Line("await assertSucceeds(setDoc(doc(db, path), doc1));"),
+			Line("await assertSucceeds(getDoc(doc(db, path)));"),
+			Line("await assertSucceeds(deleteDoc(doc(db, path)));"),
+		)
+		test("denies creating a document owned by someone else",
+			Linef("await assertFails(setDoc(doc(as('mallory', %s), path), doc1));", tenantClaims),
+		)
+		test("denies handing the document to another owner",
+			Line("await seed(path, doc1);"),
+			Linef("await assertFails(updateDoc(doc(as('alice', %s), path), { %s: 'mallory' }));", tenantClaims, owner),
+		)
+	}
+	if a.TenantField != "" {
+		tenant := toSnakeCase(a.TenantField)
+		who := "as('alice', { " + tenant + ": 'tenant-b' })"
+		if a.OwnerField == "" && len(a.Read) > 0 {
+			who = "as('bob', { role: '" + a.Read[0] + "', " + tenant + ": 'tenant-b' })"
+		}
+		test("denies access from another tenant",
+			Line("await seed(path, doc1);"),
+			Linef("await assertFails(getDoc(doc(%s, path)));", who),
+		)
+	}
+	for _, role := range a.Read {
+		test("lets "+roleName(role)+" read",
+			Line("await seed(path, doc1);"),
+			Linef("await assertSucceeds(getDoc(doc(as('bob', %s), path)));", withRole(role)),
+		)
+	}
+	for _, role := range a.Update {
+		if f := mutableField(e.Msg, e.Config); f != nil {
+			test("lets "+roleName(role)+" update",
+				Line("await seed(path, doc1);"),
+				Linef("await assertSucceeds(updateDoc(doc(as('bob', %s), path), { %s: %s }));", withRole(role), toSnakeCase(string(f.Desc.Name())), sampleValue(f)),
+			)
+		}
+	}
+	for _, role := range a.Delete {
+		test("lets "+roleName(role)+" delete",
+			Line("await seed(path, doc1);"),
+			Linef("await assertSucceeds(deleteDoc(doc(as('bob', %s), path)));", withRole(role)),
+		)
+	}
+	for _, name := range a.Immutable {
+		if name == a.OwnerField || name == a.TenantField {
+			continue
+		}
+		f := fieldNamed(e.Msg, name)
+		who := "as('alice', " + tenantClaims + ")"
+		if a.OwnerField == "" && len(a.Update) > 0 {
+			who = "as('bob', " + withRole(a.Update[0]) + ")"
+		}
+		test("denies changing "+name,
+			Line("await seed(path, doc1);"),
+			Linef("await assertFails(updateDoc(doc(%s, path), { %s: %s }));", who, toSnakeCase(name), sampleValue(f)),
+		)
+	}
+
+	return Join(
+		Blank(),
+		block(fmt.Sprintf("describe('%s (%s)', () => {", e.Config.Collection, e.Msg.Desc.FullName()), "});",
+			Linef("const path = '%s';", path),
+			Linef("const doc1 = %s;", object(data)),
+			Join(tests...),
+		),
+	)
+}
+
+func roleName(role string) string {
+	if role == "*" {
+		return "any signed-in user"
+	}
+	return role
+}
+
+// mutableField returns a string field an update may change, for the update
+// tests.
+func mutableField(msg *protogen.Message, config *entities.Config) *protogen.Field {
+	for _, f := range msg.Fields {
+		name := string(f.Desc.Name())
+		if f.Desc.Kind() != protoreflect.StringKind || f.Desc.IsList() || containsName(config.Access.Immutable, name) || name == config.IDField {
+			continue
+		}
+		return f
+	}
+	return nil
+}
+
+func fieldNamed(msg *protogen.Message, name string) *protogen.Field {
+	for _, f := range msg.Fields {
+		if string(f.Desc.Name()) == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleValue returns a TypeScript literal that f may hold.
+func sampleValue(f *protogen.Field) string {
+	switch {
+	case f.Desc.IsList():
+		return "['changed']"
+	case f.Desc.Kind() == protoreflect.BoolKind:
+		return "true"
+	case f.Desc.Kind() == protoreflect.StringKind:
+		return "'changed'"
+	case f.Desc.Kind() == protoreflect.MessageKind:
+		return "{}"
+	default:
+		return "42"
+	}
+}
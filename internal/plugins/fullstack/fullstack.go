@@ -0,0 +1,254 @@
+// Package fullstack implements protoc-gen-fullstack, which runs a selection
+// of the other generators over one request from a single manifest.
+//
+// Running every generator as its own plugin repeats the out/opt blocks of
+// buf.gen.yaml per binary and lets their settings drift apart: firestore
+// told soft_delete=false while inmemory keeps the default. The driver takes
+// one manifest instead, and every setting in it reaches each selected
+// generator that declares it. The generators share the request's analysis
+// (see entities.Registry), so the backend, the wire graph, the server and the
+// React app are generated from the same decisions.
+//
+// The manifest is given inline as parameters,
+//
+//	opt:
+//	  - generators=repository+firestore+connect-server+wire+wire-inject+react-app
+//	  - soft_delete=false
+//	  - cors
+//
+// or as a JSON file the parameters point to, relative to the directory buf or
+// protoc runs in:
+//
+//	opt:
+//	  - manifest=fullstack.json
+//
+//	{"generators": ["repository", "firestore"], "options": {"soft_delete": "false"}}
+//
+// Inline parameters take precedence over the file's options.
+package fullstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/auth"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/authemail"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/authoauth"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/connectserver"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/deploy"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/firestore"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/geo"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/graphql"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/inmemory"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/llm"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/mock"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/notification"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/openapi"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/reactadmin"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/reactapp"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/realtime"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/repository"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/servicestubs"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/stripe"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/test"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/validation"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/wire"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/plugins/wireinject"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Generators are the generators the driver can run, by the name of their
+// standalone plugin without protoc-gen-. They run in this order, whatever
+// the order of the manifest.
+var Generators = []struct {
+	Name   string
+	Plugin func(*params.Set) func(*protogen.Plugin) error
+}{
+	{"repository", repository.Plugin},
+	{"firestore", firestore.Plugin},
+	{"inmemory", inmemory.Plugin},
+	{"mock", mock.Plugin},
+	{"validation", validation.Plugin},
+	{"connect-server", connectserver.Plugin},
+	{"service-stubs", servicestubs.Plugin},
+	{"wire", wire.Plugin},
+	{"wire-inject", wireinject.Plugin},
+	{"auth", auth.Plugin},
+	{"auth-email", authemail.Plugin},
+	{"auth-oauth", authoauth.Plugin},
+	{"stripe", stripe.Plugin},
+	{"notification", notification.Plugin},
+	{"geo", geo.Plugin},
+	{"realtime", realtime.Plugin},
+	{"graphql", graphql.Plugin},
+	{"openapi", openapi.Plugin},
+	{"llm", llm.Plugin},
+	{"test", test.Plugin},
+	{"deploy", deploy.Plugin},
+	{"react-admin", reactadmin.Plugin},
+	{"react-app", reactapp.Plugin},
+}
+
+// Manifest selects the generators to run and the settings they share.
+type Manifest struct {
+	Generators []string          `json:"generators"`
+	Options    map[string]string `json:"options"`
+}
+
+type generator struct {
+	name     string
+	flags    params.Set
+	generate func(*protogen.Plugin) error
+}
+
+// Plugin declares the parameters on flags and returns the generator.
+//
+// Besides manifest and generators, flags takes every parameter of every
+// generator and forwards it to all generators that declare it.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	manifestPath := flags.String("manifest", "", "JSON manifest file: {\"generators\": [...], \"options\": {...}}")
+	var selected []string
+	flags.Func("generators", "generators to run, separated by +", func(v string) error {
+		selected = append(selected, strings.Split(v, "+")...)
+		return nil
+	})
+
+	gens := make([]*generator, len(Generators))
+	declaredBy := make(map[string][]*generator)
+	for i, g := range Generators {
+		gens[i] = &generator{name: g.Name}
+		gens[i].generate = g.Plugin(&gens[i].flags)
+		for _, name := range gens[i].flags.Names() {
+			declaredBy[name] = append(declaredBy[name], gens[i])
+		}
+	}
+
+	// given records the settings, inline or from the manifest file, so
+	// that they can be checked against the selection once it is known
+	given := make(map[string]bool)
+	set := func(name, value string) error {
+		given[name] = true
+		for _, g := range declaredBy[name] {
+			if err := g.flags.Set(name, value); err != nil {
+				return fmt.Errorf("%s: %w", g.name, err)
+			}
+		}
+		return nil
+	}
+	names := make([]string, 0, len(declaredBy))
+	for name := range declaredBy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name := name
+		flags.Func(name, "forwarded to "+strings.Join(generatorNames(declaredBy[name]), ", "), func(v string) error {
+			return set(name, v)
+		})
+	}
+
+	return func(gen *protogen.Plugin) error {
+		if *manifestPath != "" {
+			m, err := readManifest(*manifestPath)
+			if err != nil {
+				return err
+			}
+			if len(selected) == 0 {
+				selected = m.Generators
+			}
+			for _, name := range sortedKeys(m.Options) {
+				if given[name] {
+					continue // inline parameters win
+				}
+				if declaredBy[name] == nil {
+					return fmt.Errorf("%s: unknown option %q", *manifestPath, name)
+				}
+				if err := set(name, m.Options[name]); err != nil {
+					return fmt.Errorf("%s: %w", *manifestPath, err)
+				}
+			}
+		}
+
+		run, err := selection(gens, selected)
+		if err != nil {
+			return err
+		}
+		for name := range given {
+			if !usedBy(name, declaredBy[name], run) {
+				return fmt.Errorf("parameter %q is not taken by any selected generator (taken by %s)", name, strings.Join(generatorNames(declaredBy[name]), ", "))
+			}
+		}
+		for _, g := range run {
+			if err := g.generate(gen); err != nil {
+				return fmt.Errorf("%s: %w", g.name, err)
+			}
+		}
+		return nil
+	}
+}
+
+// selection returns the generators named in selected, in Generators order.
+func selection(gens []*generator, selected []string) ([]*generator, error) {
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no generators selected: set generators=a+b+... or a manifest")
+	}
+	want := make(map[string]bool)
+	for _, name := range selected {
+		want[strings.TrimSpace(name)] = true
+	}
+	var run []*generator
+	for _, g := range gens {
+		if want[g.name] {
+			run = append(run, g)
+			delete(want, g.name)
+		}
+	}
+	if len(want) > 0 {
+		return nil, fmt.Errorf("unknown generators %s (known: %s)", strings.Join(sortedKeys(want), ", "), strings.Join(generatorNames(gens), ", "))
+	}
+	return run, nil
+}
+
+func readManifest(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+func usedBy(name string, declaring, run []*generator) bool {
+	for _, d := range declaring {
+		for _, r := range run {
+			if d == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func generatorNames(gens []*generator) []string {
+	names := make([]string, len(gens))
+	for i, g := range gens {
+		names[i] = g.name
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,2091 @@
+// Package inmemory implements protoc-gen-inmemory, which generates In-Memory CRUD stubs using Category Theory composition
+// No string append - uses: Monoid, Functor (Map), Fold, When
+// Perfect for testing, prototyping, or simple applications
+//
+// OpenInMemory<Entity>Repository and OpenInMemoryStore open durable
+// repositories, whose writes a journal on local disk records (see
+// journal/journal.go).
+//
+// Parameters:
+//   - soft_delete: manage deleted_at when present (default true)
+//   - timestamps:  manage created_at/updated_at when present (default true)
+package inmemory
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/repository"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// MESSAGE INFO (Pure data extraction)
+// =============================================================================
+
+type MessageInfo struct {
+	Name, GoName, Collection, IDGoName              string
+	Fields                                          []FieldInfo
+	HasID, HasCreatedAt, HasUpdatedAt, HasDeletedAt bool
+
+	// VersionGoName is the field that guards updates, a counter or, when
+	// ETag, a random etag per write; "" for none.
+	VersionGoName string
+	ETag          bool
+}
+
+type FieldInfo struct {
+	Name, GoName, GoType                          string // GoType is the element type of repeated fields
+	IsID, IsIndexed, IsUnique, IsEnum, IsRepeated bool
+
+	IsOptional bool // an optional scalar, a pointer in Go
+	IsOneof    bool // a member of a oneof, reached through a getter in Go
+}
+
+func ExtractMessageInfo(msg *protogen.Message, config *entities.Config) MessageInfo {
+	fields := Map(msg.Fields, func(f *protogen.Field) FieldInfo { return ExtractFieldInfo(f, config) })
+	hasID, hasCreatedAt, hasUpdatedAt, hasDeletedAt := false, false, false, false
+	var versionGoName string
+	for _, f := range fields {
+		snake := toSnakeCase(f.Name)
+		switch {
+		case f.IsID:
+			hasID = true
+		case f.Name == config.Version:
+			versionGoName = f.GoName
+		case snake == "created_at":
+			hasCreatedAt = config.Timestamps
+		case snake == "updated_at":
+			hasUpdatedAt = config.Timestamps
+		case snake == "deleted_at":
+			hasDeletedAt = config.SoftDelete
+		}
+	}
+	return MessageInfo{
+		Name: string(msg.Desc.Name()), GoName: msg.GoIdent.GoName,
+		Collection: config.Collection, IDGoName: config.IDGoName, Fields: fields,
+		HasID: hasID, HasCreatedAt: hasCreatedAt, HasUpdatedAt: hasUpdatedAt, HasDeletedAt: hasDeletedAt,
+		VersionGoName: versionGoName, ETag: config.ETag,
+	}
+}
+
+func ExtractFieldInfo(field *protogen.Field, config *entities.Config) FieldInfo {
+	name := string(field.Desc.Name())
+	isUnique := config.IsUnique(name)
+	isIndexed := isUnique || config.IsIndexed(name)
+	inOneof := field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+	return FieldInfo{
+		Name: name, GoName: field.GoName, GoType: fieldGoType(field),
+		IsID: strings.EqualFold(name, config.IDField), IsIndexed: isIndexed, IsUnique: isUnique,
+		IsEnum: field.Desc.Kind() == protoreflect.EnumKind, IsRepeated: field.Desc.IsList(),
+		IsOptional: field.Desc.HasPresence() && field.Message == nil && !inOneof, IsOneof: inOneof,
+	}
+}
+
+func fieldGoType(field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	case protoreflect.EnumKind:
+		return field.Enum.GoIdent.GoName
+	case protoreflect.MessageKind:
+		if field.Message.GoIdent.GoName == "Timestamp" {
+			return "*timestamppb.Timestamp"
+		}
+		return "*" + field.Message.GoIdent.GoName
+	default:
+		return "interface{}"
+	}
+}
+
+// =============================================================================
+// IN-MEMORY GENERATORS (Monoid composition)
+// =============================================================================
+
+func Header() Code {
+	return Concat(CodeMonoid, []Code{
+		Comment("Code generated by protoc-gen-inmemory. DO NOT EDIT."),
+		Comment("Generated using Category Theory: Monoid + Functor + Fold"),
+		Comment("Thread-safe in-memory storage for testing and prototyping."),
+	})
+}
+
+func RepositoryStruct(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("InMemory%sRepository implements %sRepository using in-memory storage", m.GoName, m.GoName),
+		Struct("InMemory"+m.GoName+"Repository", Concat(CodeMonoid, []Code{
+			Field("mu", "sync.RWMutex"),
+			Linef("data map[string]*%s", m.GoName),
+			Comment("Indexes for fast lookups"),
+			FoldMap(Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID }), CodeMonoid, func(f FieldInfo) Code {
+				return Linef("idx%s map[%s]string // %s -> id", f.GoName, f.GoType, toSnakeCase(f.Name))
+			}),
+			FoldMap(Filter(m.Fields, valueIndexed), CodeMonoid, func(f FieldInfo) Code {
+				return Linef("by%s *valueIndex[%s] // %s -> ids", f.GoName, f.GoType, toSnakeCase(f.Name))
+			}),
+			Blank(),
+			Linef("undo    map[string]*%s // the entities the running write replaced, nil for none", m.GoName),
+			Line("journal *journal        // nil unless durable"),
+		})),
+		Blank(), Line(repository.Assertion(m.GoName, "InMemory"+m.GoName+"Repository")),
+	})
+}
+
+func Constructor(m MessageInfo) Code {
+	uniqueFields := Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID })
+	indexInits := FoldMap(uniqueFields, CodeMonoid, func(f FieldInfo) Code {
+		return Linef("idx%s: make(map[%s]string),", f.GoName, f.GoType)
+	})
+	valueIndexInits := FoldMap(Filter(m.Fields, valueIndexed), CodeMonoid, func(f FieldInfo) Code {
+		return Linef("by%s: newValueIndex[%s](),", f.GoName, f.GoType)
+	})
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("NewInMemory%sRepository creates a new in-memory repository", m.GoName),
+		Func("NewInMemory"+m.GoName+"Repository", "", "*InMemory"+m.GoName+"Repository",
+			Concat(CodeMonoid, []Code{
+				Linef("return &InMemory%sRepository{", m.GoName),
+				Linef("\tdata: make(map[string]*%s),", m.GoName),
+				Indent(indexInits),
+				Indent(valueIndexInits),
+				Linef("\tundo: make(map[string]*%s),", m.GoName),
+				Line("}"),
+			})),
+	})
+}
+
+// nextVersion returns the statement that gives the entity held by v a new
+// version, after previous (a counter expression) for counters.
+func nextVersion(m MessageInfo, v, previous string) Code {
+	switch {
+	case m.VersionGoName == "":
+		return Empty()
+	case m.ETag:
+		return Linef("%s.%s = uuid.New().String()", v, m.VersionGoName)
+	default:
+		return Linef("%s.%s = %s + 1", v, m.VersionGoName, previous)
+	}
+}
+
+func CloneMethod(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("clone creates a deep copy to prevent external mutation"),
+		Func("(r *InMemory"+m.GoName+"Repository) clone", "entity *"+m.GoName, "*"+m.GoName,
+			Concat(CodeMonoid, []Code{
+				If("entity == nil", Return("nil")),
+				Line("clone := proto.Clone(entity).(*" + m.GoName + ")"),
+				Return("clone"),
+			})),
+	})
+}
+
+func CreateMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	uniqueFields := Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID })
+
+	uniqueChecks := checkUnique(m, "entity", `""`)
+	collides := "has its ID."
+	if len(uniqueFields) > 0 {
+		collides = "has its ID or, even soft-deleted, the nonzero value of one of its unique fields."
+	}
+
+	indexUpdates := index(m, "entity")
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Create creates a new %s. It fails with ErrAlreadyExists when a stored %s", m.GoName, m.GoName),
+		Comment(collides),
+		Method(recv, "Create", "ctx context.Context, entity *"+m.GoName, "(string, error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				Line("id, err := r.create(entity)"),
+				If("err = r.journal.commit(err, r); err != nil", Return(`"", err`)),
+				Return("id, nil"),
+			})),
+		Blank(), Comment("create is Create with r.mu held."),
+		Method(recv, "create", "entity *"+m.GoName, "(string, error)",
+			Concat(CodeMonoid, []Code{
+				If("entity == nil", Return(`"", errors.New("entity cannot be nil")`)),
+				Blank(),
+				Comment("Generate ID if not provided"),
+				IfElse(fmt.Sprintf("entity.%s == \"\"", m.IDGoName),
+					Linef("entity.%s = uuid.New().String()", m.IDGoName),
+					Concat(CodeMonoid, []Code{
+						If(fmt.Sprintf("_, exists := r.data[entity.%s]; exists", m.IDGoName), Return(`"", ErrAlreadyExists`)),
+					})),
+				Blank(),
+				When(len(uniqueFields) > 0, Concat(CodeMonoid, []Code{
+					Comment("Check unique constraints"),
+					uniqueChecks,
+					Blank(),
+				})),
+				When(m.HasCreatedAt || m.HasUpdatedAt, Concat(CodeMonoid, []Code{
+					Comment("Set timestamps"),
+					Line("now := timestamppb.Now()"),
+					When(m.HasCreatedAt, Line("entity.CreatedAt = now")),
+					When(m.HasUpdatedAt, Line("entity.UpdatedAt = now")),
+					Blank(),
+				})),
+				When(m.VersionGoName != "", Concat(CodeMonoid, []Code{
+					nextVersion(m, "entity", "0"),
+					Blank(),
+				})),
+				Comment("Store a clone to prevent external mutation"),
+				Linef("r.touch(entity.%s)", m.IDGoName),
+				Linef("r.data[entity.%s] = r.clone(entity)", m.IDGoName),
+				Blank(),
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Comment("Update indexes"),
+					indexUpdates,
+					Blank(),
+				})),
+				Return("entity." + m.IDGoName + ", nil"),
+			})),
+	})
+}
+
+func GetMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Get retrieves a %s by ID", m.GoName),
+		Method(recv, "Get", "ctx context.Context, id string", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Return("r.get(id)"),
+			})),
+		Blank(), Comment("get is Get with r.mu held."),
+		Method(recv, "get", "id string", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("nil, ErrInvalidID")),
+				Blank(),
+				Line("entity, exists := r.data[id]"),
+				If("!exists", Return("nil, ErrNotFound")),
+				Blank(),
+				When(m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					If("entity.DeletedAt != nil", Return("nil, ErrNotFound")),
+					Blank(),
+				})),
+				Return("r.clone(entity), nil"),
+			})),
+		Blank(), Commentf("GetOrNil returns nil if not found"),
+		Method(recv, "GetOrNil", "ctx context.Context, id string", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("entity, err := r.Get(ctx, id)"),
+				If("errors.Is(err, ErrNotFound)", Return("nil, nil")),
+				Return("entity, err"),
+			})),
+		Blank(), Commentf("MustGet panics if not found"),
+		Method(recv, "MustGet", "ctx context.Context, id string", "*"+m.GoName,
+			Concat(CodeMonoid, []Code{
+				Line("entity, err := r.Get(ctx, id)"),
+				If("err != nil", Line("panic(err)")),
+				Return("entity"),
+			})),
+	})
+}
+
+func UpdateMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+
+	uniqueFields := Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID })
+	indexCleanup := unindex(m, "old")
+	indexUpdates := index(m, "entity")
+
+	// Determine if we need the old value
+	needsOld := m.HasDeletedAt || m.HasCreatedAt || hasIndexes(m) || m.VersionGoName != ""
+
+	// Upsert needs the stored version only
+	old := "_"
+	if m.VersionGoName != "" {
+		old = "old"
+	}
+
+	var getOld Code
+	if needsOld {
+		getOld = Linef("old, exists := r.data[entity.%s]", m.IDGoName)
+	} else {
+		getOld = Linef("_, exists := r.data[entity.%s]", m.IDGoName)
+	}
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Update updates an existing %s", m.GoName),
+		Method(recv, "Update", "ctx context.Context, entity *"+m.GoName, "error", locked("r.update(entity)")),
+		Blank(), Comment("update is Update with r.mu held."),
+		Method(recv, "update", "entity *"+m.GoName, "error",
+			Concat(CodeMonoid, []Code{
+				If("entity == nil", Return(`errors.New("entity cannot be nil")`)),
+				If(fmt.Sprintf("entity.%s == \"\"", m.IDGoName), Return("ErrInvalidID")),
+				Blank(),
+				getOld,
+				If("!exists", Return("ErrNotFound")),
+				When(m.HasDeletedAt, If("old.DeletedAt != nil", Return("ErrNotFound"))),
+				When(m.VersionGoName != "", Concat(CodeMonoid, []Code{
+					Comment("Reject updates based on a stale read"),
+					If(fmt.Sprintf("old.%s != entity.%s", m.VersionGoName, m.VersionGoName), Return("ErrConflict")),
+				})),
+				When(len(uniqueFields) > 0, Concat(CodeMonoid, []Code{
+					Comment("Check unique constraints before changing anything"),
+					checkUnique(m, "entity"),
+				})),
+				nextVersion(m, "entity", "old."+m.VersionGoName),
+				Blank(),
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Comment("Clean up old index entries"),
+					indexCleanup,
+					Blank(),
+				})),
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				When(m.HasCreatedAt, Line("entity.CreatedAt = old.CreatedAt // Preserve original")),
+				Blank(),
+				Linef("r.touch(entity.%s)", m.IDGoName),
+				Linef("r.data[entity.%s] = r.clone(entity)", m.IDGoName),
+				Blank(),
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Comment("Update indexes"),
+					indexUpdates,
+					Blank(),
+				})),
+				Return("nil"),
+			})),
+		Blank(), When(m.VersionGoName == "", Commentf("Upsert creates or updates a %s", m.GoName)),
+		When(m.VersionGoName != "", Concat(CodeMonoid, []Code{
+			Commentf("Upsert creates or updates a %s. Unlike Update, it replaces the stored", m.GoName),
+			Commentf("%s whatever its version, without checking for a stale read.", m.GoName),
+		})),
+		Method(recv, "Upsert", "ctx context.Context, entity *"+m.GoName, "error", locked("r.upsert(entity)")),
+		Blank(), Comment("upsert is Upsert with r.mu held."),
+		Method(recv, "upsert", "entity *"+m.GoName, "error",
+			Concat(CodeMonoid, []Code{
+				If("entity == nil", Return(`errors.New("entity cannot be nil")`)),
+				Blank(),
+				Linef("%s, exists := r.data[entity.%s]", old, m.IDGoName),
+				If("!exists", Concat(CodeMonoid, []Code{
+					Line("_, err := r.create(entity)"),
+					Return("err"),
+				})),
+				When(m.VersionGoName != "", Linef("entity.%s = old.%[1]s", m.VersionGoName)),
+				Return("r.update(entity)"),
+			})),
+	})
+}
+
+// locked returns the body of a write method that calls call, the method's
+// counterpart without locking, with r.mu held, and commits its changes.
+func locked(call string) Code {
+	return Concat(CodeMonoid, []Code{
+		Line("r.mu.Lock()"),
+		Line("defer r.mu.Unlock()"),
+		Blank(),
+		Return("r.journal.commit(" + call + ", r)"),
+	})
+}
+
+// valueIndexed reports whether f has a value index, which finds the entities
+// by a value of f, or in a range of them: the indexed fields that aren't unique,
+// whose values make map keys and compare like Firestore compares them.
+// Floating-point fields, whose NaNs make neither, messages and oneof members
+// are scanned instead.
+func valueIndexed(f FieldInfo) bool {
+	if !f.IsIndexed || f.IsUnique || f.IsID || f.IsOneof {
+		return false
+	}
+	return f.IsEnum || f.GoType == "string" || f.GoType == "bool" || isInteger(f.GoType)
+}
+
+// hasIndexes reports whether m has indexes to maintain.
+func hasIndexes(m MessageInfo) bool {
+	return len(Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID || valueIndexed(f) })) > 0
+}
+
+// checkUnique returns the statements that fail with ErrAlreadyExists, after
+// the results before it, when the entity held by v has the value of a unique
+// field that another stored entity, soft-deleted or not, has, like a unique
+// index of a SQL database would. Zero values, like NULLs there, never collide.
+func checkUnique(m MessageInfo, v string, results ...string) Code {
+	return FoldMap(Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID }), CodeMonoid, func(f FieldInfo) Code {
+		return If(fmt.Sprintf("holder, exists := r.idx%s[%s.%s]; exists && holder != %s.%s", f.GoName, v, f.GoName, v, m.IDGoName),
+			Return(append(results, fmt.Sprintf("fmt.Errorf(\"%s already exists: %%w\", ErrAlreadyExists)", toSnakeCase(f.Name)))...))
+	})
+}
+
+// index returns the statements that add the entity held by v to the indexes.
+func index(m MessageInfo, v string) Code {
+	return indexStatements(m, v, func(f FieldInfo, key string) string {
+		return fmt.Sprintf("r.idx%s[%s] = %s.%s", f.GoName, key, v, m.IDGoName)
+	}, "add")
+}
+
+// unindex returns the statements that remove the entity held by v from the
+// indexes.
+func unindex(m MessageInfo, v string) Code {
+	return indexStatements(m, v, func(f FieldInfo, key string) string {
+		return fmt.Sprintf("delete(r.idx%s, %s)", f.GoName, key)
+	}, "remove")
+}
+
+// indexStatements returns unique(f, key) for the set unique fields of the
+// entity held by v, and calls the method of the value indexes named op for
+// every value of the others.
+func indexStatements(m MessageInfo, v string, unique func(f FieldInfo, key string) string, op string) Code {
+	return Concat(CodeMonoid, []Code{
+		FoldMap(Filter(m.Fields, func(f FieldInfo) bool { return f.IsUnique && !f.IsID }), CodeMonoid, func(f FieldInfo) Code {
+			key := v + "." + f.GoName
+			return If(fmt.Sprintf("%s != %s", key, zeroValue(f.GoType)), Line(unique(f, key)))
+		}),
+		FoldMap(Filter(m.Fields, valueIndexed), CodeMonoid, func(f FieldInfo) Code {
+			key, call := v+"."+f.GoName, fmt.Sprintf("r.by%s.%s(%%s, %s.%s)", f.GoName, op, v, m.IDGoName)
+			switch {
+			case f.IsRepeated:
+				return Concat(CodeMonoid, []Code{
+					Linef("for _, k := range %s {", key),
+					Linef("	"+call, "k"),
+					Line("}"),
+				})
+			case f.IsOptional:
+				return If(key+" != nil", Linef(call, "*"+key))
+			}
+			return Linef(call, key)
+		}),
+	})
+}
+
+// PatchMethod generates Patch, which copies the fields the mask names
+// through protoreflect, so that message, list and unset fields are copied
+// like scalars.
+func PatchMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	paths := Map(Filter(m.Fields, func(f FieldInfo) bool { return patchable(m, f) }), func(f FieldInfo) string {
+		return fmt.Sprintf("%q", f.Name)
+	})
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Patch sets the fields of the stored %s that mask names to entity's. A path", m.GoName),
+		Comment("names a field or, with dots, a field of a message field (see patchPath). A"),
+		Comment("path naming no field, the ID or a field the repository manages fails with"),
+		Comment("ErrInvalidMask and changes nothing."),
+		Method(recv, "Patch", "ctx context.Context, id string, entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "error", locked("r.patch(id, entity, mask)")),
+		Blank(), Comment("patch is Patch with r.mu held."),
+		Method(recv, "patch", "id string, entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "error",
+			Concat(CodeMonoid, []Code{
+				If("entity == nil", Return(`errors.New("entity cannot be nil")`)),
+				If(`id == ""`, Return("ErrInvalidID")),
+				If("len(mask.GetPaths()) == 0", Return(`fmt.Errorf("%w: no paths", ErrInvalidMask)`)),
+				Blank(),
+				Line("old, exists := r.data[id]"),
+				If("!exists", Return("ErrNotFound")),
+				When(m.HasDeletedAt, If("old.DeletedAt != nil", Return("ErrNotFound"))),
+				Blank(),
+				Comment("Patch a copy, so that an invalid path leaves the stored entity as it was"),
+				Line("patched := r.clone(old)"),
+				Line("src, dst := entity.ProtoReflect(), patched.ProtoReflect()"),
+				Line("for _, path := range mask.GetPaths() {"),
+				Line(`switch top, _, _ := strings.Cut(path, "."); top {`),
+				Linef("case %s:", strings.Join(paths, ", ")),
+				Line("default:"),
+				Linef(`return fmt.Errorf("%%w: %%q is not a patchable field of %s", ErrInvalidMask, path)`, m.Name),
+				Line("}"),
+				If("err := patchPath(src, dst, path); err != nil", Return("err")),
+				Line("}"),
+				When(m.HasUpdatedAt, Line("patched.UpdatedAt = timestamppb.Now()")),
+				nextVersion(m, "patched", "old."+m.VersionGoName),
+				checkUnique(m, "patched"),
+				Blank(),
+				unindex(m, "old"),
+				index(m, "patched"),
+				Line("r.touch(id)"),
+				Line("r.data[id] = r.clone(patched)"),
+				Return("nil"),
+			})),
+	})
+}
+
+// patchable reports whether a Patch mask may name f: the ID, the timestamps
+// and the version are the repository's to set.
+func patchable(m MessageInfo, f FieldInfo) bool {
+	switch toSnakeCase(f.Name) {
+	case "created_at":
+		return !m.HasCreatedAt
+	case "updated_at":
+		return !m.HasUpdatedAt
+	case "deleted_at":
+		return !m.HasDeletedAt
+	}
+	return !f.IsID && f.GoName != m.VersionGoName
+}
+
+func DeleteMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+
+	indexCleanup := unindex(m, "entity")
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Delete permanently deletes a %s", m.GoName),
+		Method(recv, "Delete", "ctx context.Context, id string", "error", locked("r.remove(id)")),
+		Blank(), Comment("remove is Delete with r.mu held."),
+		Method(recv, "remove", "id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Blank(),
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Line("entity, exists := r.data[id]"),
+					If("!exists", Return("ErrNotFound")),
+					Blank(),
+					Comment("Clean up indexes"),
+					indexCleanup,
+					Blank(),
+				})),
+				When(!hasIndexes(m), Concat(CodeMonoid, []Code{
+					Line("_, exists := r.data[id]"),
+					If("!exists", Return("ErrNotFound")),
+					Blank(),
+				})),
+				Line("r.touch(id)"),
+				Line("delete(r.data, id)"),
+				Return("nil"),
+			})),
+	})
+}
+
+func SoftDeleteMethods(m MessageInfo) Code {
+	if !m.HasDeletedAt {
+		return CodeMonoid.Empty()
+	}
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("SoftDelete marks %s as deleted", m.GoName),
+		Method(recv, "SoftDelete", "ctx context.Context, id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Blank(),
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				Line("stored, exists := r.data[id]"),
+				If("!exists", Return("ErrNotFound")),
+				If("stored.DeletedAt != nil", Return("nil // Already deleted")),
+				Blank(),
+				Line("entity := r.clone(stored)"),
+				Line("entity.DeletedAt = timestamppb.Now()"),
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				nextVersion(m, "entity", "stored."+m.VersionGoName),
+				Line("r.touch(id)"),
+				unindex(m, "stored"),
+				Line("r.data[id] = entity"),
+				index(m, "entity"),
+				Return("r.journal.commit(nil, r)"),
+			})),
+		Blank(), Commentf("Restore restores soft-deleted %s", m.GoName),
+		Method(recv, "Restore", "ctx context.Context, id string", "error",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("ErrInvalidID")),
+				Blank(),
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				Line("stored, exists := r.data[id]"),
+				If("!exists", Return("ErrNotFound")),
+				Blank(),
+				Line("entity := r.clone(stored)"),
+				Line("entity.DeletedAt = nil"),
+				When(m.HasUpdatedAt, Line("entity.UpdatedAt = timestamppb.Now()")),
+				nextVersion(m, "entity", "stored."+m.VersionGoName),
+				Line("r.touch(id)"),
+				unindex(m, "stored"),
+				Line("r.data[id] = entity"),
+				index(m, "entity"),
+				Return("r.journal.commit(nil, r)"),
+			})),
+		Blank(), Commentf("HardDelete permanently removes a soft-deleted %s", m.GoName),
+		Method(recv, "HardDelete", "ctx context.Context, id string", "error",
+			Return("r.Delete(ctx, id)")),
+	})
+}
+
+func ListMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("List retrieves up to limit %s (all when limit <= 0)", m.GoName),
+		Method(recv, "List", "ctx context.Context, limit int", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Linef("results := make([]*%s, 0, len(r.data))", m.GoName),
+				Line("for _, entity := range r.data {"),
+				When(m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					If("entity.DeletedAt != nil", Line("continue")),
+				})),
+				If("limit > 0 && len(results) >= limit", Line("break")),
+				Line("\tresults = append(results, r.clone(entity))"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Commentf("ListAll retrieves all %s including soft-deleted", m.GoName),
+		Method(recv, "ListAll", "ctx context.Context", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Linef("results := make([]*%s, 0, len(r.data))", m.GoName),
+				Line("for _, entity := range r.data {"),
+				Line("\tresults = append(results, r.clone(entity))"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+	})
+}
+
+func ExistsMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Exists checks if %s exists", m.GoName),
+		Method(recv, "Exists", "ctx context.Context, id string", "(bool, error)",
+			Concat(CodeMonoid, []Code{
+				If(`id == ""`, Return("false, ErrInvalidID")),
+				Blank(),
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				When(m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					Line("entity, exists := r.data[id]"),
+					If("!exists || entity.DeletedAt != nil", Return("false, nil")),
+				})),
+				When(!m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					Line("_, exists := r.data[id]"),
+					If("!exists", Return("false, nil")),
+				})),
+				Return("true, nil"),
+			})),
+	})
+}
+
+func CountMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Count returns total %s (excluding soft-deleted)", m.GoName),
+		Method(recv, "Count", "ctx context.Context", "(int64, error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				When(m.HasDeletedAt, Concat(CodeMonoid, []Code{
+					Line("count := int64(0)"),
+					Line("for _, entity := range r.data {"),
+					If("entity.DeletedAt == nil", Line("count++")),
+					Line("}"),
+					Return("count, nil"),
+				})),
+				When(!m.HasDeletedAt, Return("int64(len(r.data)), nil")),
+			})),
+	})
+}
+
+// CountWhereMethod generates CountWhere, which takes the field, operator and
+// value of a Firestore Where and evaluates them like Firestore does (see the
+// where helper).
+func CountWhereMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("CountWhere returns the number of %ss whose field compares to value as op says", m.GoName),
+		Method(recv, "CountWhere", "ctx context.Context, field, op string, value interface{}", "(int64, error)",
+			Concat(CodeMonoid, []Code{
+				Linef("match, err := where((&%s{}).ProtoReflect().Descriptor(), field, op, value)", m.GoName),
+				If("err != nil", Return("0, err")),
+				Blank(),
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Line("count := int64(0)"),
+				Linef("r.candidates([]Filter{{Field: field, Op: op, Value: value}}, func(entity *%s) {", m.GoName),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Return())),
+				If("match(entity.ProtoReflect())", Line("count++")),
+				Line("})"),
+				Return("count, nil"),
+			})),
+	})
+}
+
+// AggregateMethods generates Sum<Field> and Avg<Field> for the numeric fields,
+// typed like the Firestore repository's: sums of integer fields are int64, of
+// floating-point fields float64.
+func AggregateMethods(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return FoldMap(Filter(m.Fields, func(f FieldInfo) bool { return aggregatable(m, f) }), CodeMonoid, func(f FieldInfo) Code {
+		sumType := "float64"
+		if isInteger(f.GoType) {
+			sumType = "int64"
+		}
+		return Concat(CodeMonoid, []Code{
+			Blank(), Commentf("Sum%s returns the sum of %s over the %ss", f.GoName, f.Name, m.GoName),
+			Method(recv, "Sum"+f.GoName, "ctx context.Context", "("+sumType+", error)",
+				Concat(CodeMonoid, []Code{
+					Line("r.mu.RLock()"),
+					Line("defer r.mu.RUnlock()"),
+					Blank(),
+					Linef("var sum %s", sumType),
+					Line("for _, entity := range r.data {"),
+					When(m.HasDeletedAt, If("entity.DeletedAt != nil", Line("continue"))),
+					When(f.IsOptional, If(fmt.Sprintf("entity.%s == nil", f.GoName), Line("continue"))),
+					Linef("	sum += %s(entity.Get%s())", sumType, f.GoName),
+					Line("}"),
+					Return("sum, nil"),
+				})),
+			Blank(), Commentf("Avg%s returns the average of %s over the %ss, 0 when there are none", f.GoName, f.Name, m.GoName),
+			Method(recv, "Avg"+f.GoName, "ctx context.Context", "(float64, error)",
+				Concat(CodeMonoid, []Code{
+					Line("r.mu.RLock()"),
+					Line("defer r.mu.RUnlock()"),
+					Blank(),
+					Line("var sum float64"),
+					Line("n := 0"),
+					Line("for _, entity := range r.data {"),
+					When(m.HasDeletedAt, If("entity.DeletedAt != nil", Line("continue"))),
+					When(f.IsOptional, If(fmt.Sprintf("entity.%s == nil", f.GoName), Line("continue"))),
+					Linef("	sum += float64(entity.Get%s())", f.GoName),
+					Line("	n++"),
+					Line("}"),
+					If("n == 0", Return("0, nil")),
+					Return("sum / float64(n), nil"),
+				})),
+		})
+	})
+}
+
+// aggregatable reports whether Sum and Avg methods are generated for f: a
+// singular number that isn't the ID, the version counter or a oneof member.
+// Unset optional numbers are left out, as Firestore leaves out nulls.
+func aggregatable(m MessageInfo, f FieldInfo) bool {
+	if f.IsID || f.IsRepeated || f.IsOneof || f.GoName == m.VersionGoName {
+		return false
+	}
+	return isInteger(f.GoType) || f.GoType == "float32" || f.GoType == "float64"
+}
+
+func isInteger(goType string) bool {
+	switch goType {
+	case "int32", "int64", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func FindByFieldMethod(m MessageInfo, f FieldInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	methodName := "FindBy" + f.GoName
+
+	if f.IsUnique {
+		return Concat(CodeMonoid, []Code{
+			Blank(), Commentf("%s finds %s by %s (unique, indexed)", methodName, m.GoName, f.Name),
+			Method(recv, methodName, fmt.Sprintf("ctx context.Context, %s %s", lowerFirst(f.GoName), f.GoType), "(*"+m.GoName+", error)",
+				Concat(CodeMonoid, []Code{
+					Line("r.mu.RLock()"),
+					Line("defer r.mu.RUnlock()"),
+					Blank(),
+					Linef("id, exists := r.idx%s[%s]", f.GoName, lowerFirst(f.GoName)),
+					If("!exists", Return("nil, ErrNotFound")),
+					Blank(),
+					Line("entity, ok := r.data[id]"),
+					If("!ok", Return("nil, ErrNotFound")),
+					When(m.HasDeletedAt, If("entity.DeletedAt != nil", Return("nil, ErrNotFound"))),
+					Return("r.clone(entity), nil"),
+				})),
+		})
+	}
+
+	if valueIndexed(f) {
+		return Concat(CodeMonoid, []Code{
+			Blank(), Commentf("%s finds all %s by %s (indexed)", methodName, m.GoName, f.Name),
+			Method(recv, methodName, fmt.Sprintf("ctx context.Context, %s %s, limit int", lowerFirst(f.GoName), f.GoType), "([]*"+m.GoName+", error)",
+				Concat(CodeMonoid, []Code{
+					Line("r.mu.RLock()"),
+					Line("defer r.mu.RUnlock()"),
+					Blank(),
+					Linef("var results []*%s", m.GoName),
+					Linef("for id := range r.by%s.ids[%s] {", f.GoName, lowerFirst(f.GoName)),
+					Line("	entity := r.data[id]"),
+					When(m.HasDeletedAt, If("entity.DeletedAt != nil", Line("continue"))),
+					Line("	results = append(results, r.clone(entity))"),
+					If("limit > 0 && len(results) >= limit", Line("break")),
+					Line("}"),
+					Return("results, nil"),
+				})),
+		})
+	}
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("%s finds all %s by %s (scan)", methodName, m.GoName, f.Name),
+		Method(recv, methodName, fmt.Sprintf("ctx context.Context, %s %s, limit int", lowerFirst(f.GoName), f.GoType), "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Linef("var results []*%s", m.GoName),
+				Line("for _, entity := range r.data {"),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Line("continue"))),
+				When(!f.IsRepeated, Linef("\tif entity.%s == %s {", f.GoName, lowerFirst(f.GoName))),
+				When(f.IsRepeated, Linef("\tif slices.Contains(entity.%s, %s) {", f.GoName, lowerFirst(f.GoName))),
+				Line("\t\tresults = append(results, r.clone(entity))"),
+				If("limit > 0 && len(results) >= limit", Line("break")),
+				Line("\t}"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+	})
+}
+
+func FindMethods(m MessageInfo) Code {
+	indexed := Filter(m.Fields, func(f FieldInfo) bool { return (f.IsIndexed || f.IsUnique) && !f.IsID })
+	return FoldMap(indexed, CodeMonoid, func(f FieldInfo) Code { return FindByFieldMethod(m, f) })
+}
+
+func FilterMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Commentf("Filter finds all %s matching predicate", m.GoName),
+		Method(recv, "Filter", "ctx context.Context, predicate func(*"+m.GoName+") bool, limit int", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Linef("var results []*%s", m.GoName),
+				Line("for _, entity := range r.data {"),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Line("continue"))),
+				If("predicate(entity)",
+					Concat(CodeMonoid, []Code{
+						Line("results = append(results, r.clone(entity))"),
+						If("limit > 0 && len(results) >= limit", Line("break")),
+					})),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Commentf("FindOne finds first %s matching predicate", m.GoName),
+		Method(recv, "FindOne", "ctx context.Context, predicate func(*"+m.GoName+") bool", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("results, err := r.Filter(ctx, predicate, 1)"),
+				If("err != nil", Return("nil, err")),
+				If("len(results) == 0", Return("nil, ErrNotFound")),
+				Return("results[0], nil"),
+			})),
+	})
+}
+
+func ClearMethod(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Clear removes all data (useful for tests). A durable repository that fails to"),
+		Comment("record it keeps its data."),
+		Method(recv, "Clear", "", "",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				removeAll(m),
+				Line("r.journal.commit(nil, r)"),
+			})),
+	})
+}
+
+// removeAll returns the statements that remove every stored entity, as a
+// write that touches them.
+func removeAll(m MessageInfo) Code {
+	if !hasIndexes(m) {
+		return Concat(CodeMonoid, []Code{
+			Line("for id := range r.data {"),
+			Line("\tr.touch(id)"),
+			Line("\tdelete(r.data, id)"),
+			Line("}"),
+		})
+	}
+	return Concat(CodeMonoid, []Code{
+		Line("for id, entity := range r.data {"),
+		Line("\tr.touch(id)"),
+		Indent(unindex(m, "entity")),
+		Line("\tdelete(r.data, id)"),
+		Line("}"),
+	})
+}
+
+func SnapshotMethods(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("Snapshot returns a copy of all data (for debugging/testing)"),
+		Method(recv, "Snapshot", "", "map[string]*"+m.GoName,
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Blank(),
+				Linef("snapshot := make(map[string]*%s, len(r.data))", m.GoName),
+				Line("for id, entity := range r.data {"),
+				Line("\tsnapshot[id] = r.clone(entity)"),
+				Line("}"),
+				Return("snapshot"),
+			})),
+		Blank(), Comment("Load replaces all data from a snapshot (for testing), keyed by ID. A durable"),
+		Comment("repository that fails to record it keeps its data."),
+		Method(recv, "Load", "data map[string]*"+m.GoName, "",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				removeAll(m),
+				Line("for id, entity := range data {"),
+				Line("\tentity = r.clone(entity)"),
+				Linef("\tentity.%s = id", m.IDGoName),
+				Line("\tr.touch(id)"),
+				Line("\tr.data[id] = entity"),
+				Indent(index(m, "entity")),
+				Line("}"),
+				Line("r.journal.commit(nil, r)"),
+			})),
+	})
+}
+
+// QueryBuilder generates InMemory<Entity>Query, the in-memory <Entity>Query
+// of the repository contract, with its methods: the same filters and orders
+// select the same entities in the same order as the Firestore repository's.
+func QueryBuilder(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	qName := "InMemory" + m.GoName + "Query"
+	qRecv := "q *" + qName
+	query := repository.QueryInterfaceName(m.GoName)
+	id := "id"
+	for _, f := range m.Fields {
+		if f.IsID {
+			id = f.Name
+		}
+	}
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Query Builder ==="),
+		Blank(), Commentf("%s is a query of the %ss, which evaluates filters and orders", qName, m.GoName),
+		Comment("like the Firestore repository's queries do."),
+		Struct(qName, Concat(CodeMonoid, []Code{
+			Field("repo", "*InMemory"+m.GoName+"Repository"),
+			Field("filters", "[]Filter"),
+			Field("orders", "[]order"),
+			Field("limitVal", "int"),
+			Field("offsetVal", "int"),
+		})),
+		Blank(), Line(repository.QueryAssertion(m.GoName, qName)),
+		Blank(), When(m.HasDeletedAt, Commentf("Query starts a query of the %ss that aren't soft-deleted.", m.GoName)),
+		When(!m.HasDeletedAt, Commentf("Query starts a query of the %ss.", m.GoName)),
+		Method(recv, "Query", "", query, Return("&"+qName+"{repo: r}")),
+		Blank(), Comment("Where keeps the results whose field, a proto field name, compares to value as"),
+		Comment("op says (see the where helper). Get, First and Count fail for invalid filters."),
+		Method(qRecv, "Where", "field string, op string, value interface{}", query, Concat(CodeMonoid, []Code{
+			Line("q.filters = append(q.filters, Filter{Field: field, Op: op, Value: value})"),
+			Return("q"),
+		})),
+		Blank(), Method(qRecv, "OrderBy", "field string, dir Direction", query, Concat(CodeMonoid, []Code{
+			Line("q.orders = append(q.orders, order{field: field, dir: dir})"),
+			Return("q"),
+		})),
+		Blank(), Method(qRecv, "Limit", "n int", query, Concat(CodeMonoid, []Code{Line("q.limitVal = n"), Return("q")})),
+		Blank(), Method(qRecv, "Offset", "n int", query, Concat(CodeMonoid, []Code{Line("q.offsetVal = n"), Return("q")})),
+		Blank(), Comment("Get returns the results in the order Firestore returns them in (see the orderBy"),
+		Comment("helper): the OrderBy fields, then the fields of inequality filters, then ID."),
+		Method(qRecv, "Get", "ctx context.Context", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Linef("desc := (&%s{}).ProtoReflect().Descriptor()", m.GoName),
+				Line("match, err := whereAll(desc, q.filters)"),
+				If("err != nil", Return("nil, err")),
+				Linef("compare, err := orderBy(desc, %q, q.filters, q.orders)", id),
+				If("err != nil", Return("nil, err")),
+				Blank(),
+				Line("q.repo.mu.RLock()"),
+				Line("defer q.repo.mu.RUnlock()"),
+				Blank(),
+				Linef("var results []*%s", m.GoName),
+				Linef("q.repo.candidates(q.filters, func(entity *%s) {", m.GoName),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Return())),
+				If("match(entity.ProtoReflect())", Line("results = append(results, entity)")),
+				Line("})"),
+				Linef("slices.SortFunc(results, func(a, b *%s) int { return compare(a.ProtoReflect(), b.ProtoReflect()) })", m.GoName),
+				Line("results = window(results, q.offsetVal, q.limitVal)"),
+				Line("for i, entity := range results {"),
+				Line("\tresults[i] = q.repo.clone(entity)"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Comment("Count returns the number of results; Limit and Offset do not apply."),
+		Method(qRecv, "Count", "ctx context.Context", "(int64, error)",
+			Concat(CodeMonoid, []Code{
+				Linef("match, err := whereAll((&%s{}).ProtoReflect().Descriptor(), q.filters)", m.GoName),
+				If("err != nil", Return("0, err")),
+				Blank(),
+				Line("q.repo.mu.RLock()"),
+				Line("defer q.repo.mu.RUnlock()"),
+				Blank(),
+				Line("count := int64(0)"),
+				Linef("q.repo.candidates(q.filters, func(entity *%s) {", m.GoName),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Return())),
+				If("match(entity.ProtoReflect())", Line("count++")),
+				Line("})"),
+				Return("count, nil"),
+			})),
+		Blank(), Method(qRecv, "First", "ctx context.Context", "(*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Line("q.limitVal = 1"),
+				Line("results, err := q.Get(ctx)"),
+				If("err != nil", Return("nil, err")),
+				If("len(results) == 0", Return("nil, ErrNotFound")),
+				Return("results[0], nil"),
+			})),
+	})
+}
+
+// IndexMethods generates lookup, which finds the entities that may match a
+// filter through the value index of its field, and candidates, through which
+// the queries visit the entities the most selective of their filters leaves.
+func IndexMethods(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	indexed := Filter(m.Fields, valueIndexed)
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("lookup returns the IDs of the entities whose field compares to value as op"),
+		Comment("says, from the value index of the field, or false when none serves the filter."),
+		Method(recv, "lookup", "field, op string, value interface{}", "([]string, bool)", Concat(CodeMonoid, []Code{
+			When(len(indexed) > 0, Concat(CodeMonoid, []Code{
+				Linef("fd := (&%s{}).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))", m.GoName),
+				Line("switch field {"),
+				FoldMap(indexed, CodeMonoid, func(f FieldInfo) Code {
+					return Concat(CodeMonoid, []Code{
+						Linef("case %q:", f.Name),
+						Linef("\treturn r.by%s.lookup(fd, op, value)", f.GoName),
+					})
+				}),
+				Line("}"),
+			})),
+			Return("nil, false"),
+		})),
+		Blank(), Comment("candidates calls fn with the stored entities that may match every filter:"),
+		Comment("those the value index of a filtered field gives, from the filter that leaves"),
+		Comment("the fewest, or else all of them."),
+		Method(recv, "candidates", "filters []Filter, fn func(entity *"+m.GoName+")", "",
+			Concat(CodeMonoid, []Code{
+				Line("var ids []string"),
+				Line("indexed := false"),
+				Line("for _, f := range filters {"),
+				If("found, ok := r.lookup(f.Field, f.Op, f.Value); ok && (!indexed || len(found) < len(ids))",
+					Line("ids, indexed = found, true")),
+				Line("}"),
+				If("!indexed", Concat(CodeMonoid, []Code{
+					Line("for _, entity := range r.data {"),
+					Line("\tfn(entity)"),
+					Line("}"),
+					Return(),
+				})),
+				Line("for _, id := range ids {"),
+				Line("\tfn(r.data[id])"),
+				Line("}"),
+			})),
+	})
+}
+
+// DurabilityMethods generates the methods through which the package's journal
+// records the writes of the repository, and replays them into a durable one
+// (see journal/journal.go), and OpenInMemory<Entity>Repository, Compact and
+// Close, which manage a durable repository. Every write, in a transaction or
+// not, touches the IDs it changes first, so that it can be undone when it
+// fails.
+func DurabilityMethods(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	repo := "InMemory" + m.GoName + "Repository"
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Durability ==="),
+		Blank(), Commentf("OpenInMemory%sRepository opens the durable repository in dir, creating it", m.GoName),
+		Comment("when missing: it replays the journal of the writes of earlier runs, and"),
+		Comment("records every write before it returns. Close it to stop the periodic"),
+		Comment("compaction of the journal."),
+		Func("OpenInMemory"+m.GoName+"Repository", "dir string, opts DurableOptions", "(*"+repo+", error)",
+			Concat(CodeMonoid, []Code{
+				Linef("r := NewInMemory%sRepository()", m.GoName),
+				Linef("j, err := openJournal(dir, opts, map[string]journaled{%q: r})", m.Collection),
+				If("err != nil", Return("nil, err")),
+				Line("r.journal = j"),
+				Line("j.start(r.Compact)"),
+				Return("r, nil"),
+			})),
+		Blank(), Comment("Compact replaces the journal of a durable repository with a snapshot of its"),
+		Comment("data. It runs every DurableOptions.SnapshotInterval. The repositories of a"),
+		Comment("durable InMemoryStore share the store's journal, which the store compacts."),
+		Method(recv, "Compact", "", "error",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.RLock()"),
+				Line("defer r.mu.RUnlock()"),
+				Return("r.journal.compact(r)"),
+			})),
+		Blank(), Comment("Close compacts the journal of a durable repository a last time and closes it;"),
+		Comment("writes fail afterwards. Like Compact, it fails for the repositories of a store."),
+		Method(recv, "Close", "", "error", Return("r.journal.close(r.Compact, r)")),
+		Blank(), Comment("touch records the entity with the given ID before the running write changes it."),
+		Method(recv, "touch", "id string", "",
+			If("_, ok := r.undo[id]; !ok", Line("r.undo[id] = r.data[id]"))),
+		Blank(), Comment("revert undoes the running write: the stored entities are replaced, never"),
+		Comment("modified, so the entities it touched are as they were."),
+		Method(recv, "revert", "", "",
+			Concat(CodeMonoid, []Code{
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Line("for id := range r.undo {"),
+					Line("\tif entity, ok := r.data[id]; ok {"),
+					Indent(Indent(unindex(m, "entity"))),
+					Line("\t}"),
+					Line("}"),
+				})),
+				Line("for id, entity := range r.undo {"),
+				Line("\tif entity == nil {"),
+				Line("\t\tdelete(r.data, id)"),
+				Line("\t\tcontinue"),
+				Line("\t}"),
+				Line("\tr.data[id] = entity"),
+				Indent(index(m, "entity")),
+				Line("}"),
+				Line("clear(r.undo)"),
+			})),
+		Blank(), Comment("keep ends the running write, keeping its changes."),
+		Method(recv, "keep", "", "", Line("clear(r.undo)")),
+		Blank(), Comment("changes returns the mutations of the running write."),
+		Method(recv, "changes", "", "([]mutation, error)",
+			Concat(CodeMonoid, []Code{
+				Line("batch := make([]mutation, 0, len(r.undo))"),
+				Line("for id := range r.undo {"),
+				Line("\tentity, ok := r.data[id]"),
+				Line("\tif !ok {"),
+				Linef("\t\tbatch = append(batch, mutation{collection: %q, kind: mutationDelete, id: id})", m.Collection),
+				Line("\t\tcontinue"),
+				Line("\t}"),
+				Line("\tb, err := proto.Marshal(entity)"),
+				If("err != nil", Return("nil, err")),
+				Linef("\tbatch = append(batch, mutation{collection: %q, kind: mutationPut, id: id, entity: b})", m.Collection),
+				Line("}"),
+				Return("batch, nil"),
+			})),
+		Blank(), Comment("apply replays a mutation of the journal."),
+		Method(recv, "apply", "m mutation", "error",
+			Concat(CodeMonoid, []Code{
+				When(hasIndexes(m), Concat(CodeMonoid, []Code{
+					Line("if old, ok := r.data[m.id]; ok {"),
+					Indent(unindex(m, "old")),
+					Line("}"),
+				})),
+				If("m.kind == mutationDelete", Concat(CodeMonoid, []Code{
+					Line("delete(r.data, m.id)"),
+					Return("nil"),
+				})),
+				Linef("entity := &%s{}", m.GoName),
+				If("err := proto.Unmarshal(m.entity, entity); err != nil",
+					Return(fmt.Sprintf(`fmt.Errorf("journal: %s %%s: %%w", m.id, err)`, m.Collection))),
+				Line("r.data[m.id] = entity"),
+				index(m, "entity"),
+				Return("nil"),
+			})),
+		Blank(), Comment("entities calls put with a mutation storing every entity."),
+		Method(recv, "entities", "put func(mutation) error", "error",
+			Concat(CodeMonoid, []Code{
+				Line("for id, entity := range r.data {"),
+				Line("\tb, err := proto.Marshal(entity)"),
+				If("err != nil", Return("err")),
+				Linef("\tif err := put(mutation{collection: %q, kind: mutationPut, id: id, entity: b}); err != nil {", m.Collection),
+				Line("\t\treturn err"),
+				Line("\t}"),
+				Line("}"),
+				Return("nil"),
+			})),
+	})
+}
+
+// TransactionMethods generates the repository's side of a transaction,
+// InMemory<Entity>Tx, which calls the repository's methods without locking,
+// and RunTransaction, which runs one over the entity alone. The transactions
+// of an InMemoryStore span the entities of the package.
+func TransactionMethods(m MessageInfo) Code {
+	recv := "r *InMemory" + m.GoName + "Repository"
+	txName := "InMemory" + m.GoName + "Tx"
+	tRecv := "t *" + txName
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Transaction Support ==="),
+		Blank(), Commentf("RunTransaction runs fn in a transaction over the %ss; see", m.GoName),
+		Comment("InMemoryStore.RunTransaction."),
+		Method(recv, "RunTransaction", "ctx context.Context, fn func(context.Context, "+repository.TxInterfaceName(m.GoName)+") error", "error",
+			Concat(CodeMonoid, []Code{
+				Line("r.mu.Lock()"),
+				Line("defer r.mu.Unlock()"),
+				Blank(),
+				Line("committed := false"),
+				Line("defer func() {"),
+				If("!committed", Line("r.revert()")),
+				Line("}()"),
+				Linef("err := fn(ctx, &%s{repo: r})", txName),
+				Line("committed = true"),
+				Return("r.journal.commit(err, r)"),
+			})),
+		Blank(), Commentf("%s is the %s side of an in-memory transaction.", txName, m.GoName),
+		Struct(txName, Field("repo", "*InMemory"+m.GoName+"Repository")),
+		Blank(), Line(repository.TxAssertion(m.GoName, txName)),
+		Blank(), Method(tRecv, "Get", "id string", "(*"+m.GoName+", error)", Return("t.repo.get(id)")),
+		Blank(), Method(tRecv, "GetAll", "ids []string", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Linef("var results []*%s", m.GoName),
+				Line("for _, id := range ids {"),
+				Line("	entity, err := t.repo.get(id)"),
+				If("errors.Is(err, ErrNotFound)", Line("continue")),
+				If("err != nil", Return("nil, err")),
+				Line("	results = append(results, entity)"),
+				Line("}"),
+				Return("results, nil"),
+			})),
+		Blank(), Method(tRecv, "Create", "entity *"+m.GoName, "(string, error)", Return("t.repo.create(entity)")),
+		Blank(), Method(tRecv, "Update", "entity *"+m.GoName, "error", Return("t.repo.update(entity)")),
+		Blank(), Method(tRecv, "Patch", "id string, entity *"+m.GoName+", mask *fieldmaskpb.FieldMask", "error", Return("t.repo.patch(id, entity, mask)")),
+		Blank(), Method(tRecv, "Delete", "id string", "error", Return("t.repo.remove(id)")),
+		Blank(), Comment("Query evaluates filters like Firestore does (see the where helper)."),
+		Method(tRecv, "Query", "filters ...Filter", "([]*"+m.GoName+", error)",
+			Concat(CodeMonoid, []Code{
+				Linef("match, err := whereAll((&%s{}).ProtoReflect().Descriptor(), filters)", m.GoName),
+				If("err != nil", Return("nil, err")),
+				Linef("var results []*%s", m.GoName),
+				Linef("t.repo.candidates(filters, func(entity *%s) {", m.GoName),
+				When(m.HasDeletedAt, If("entity.DeletedAt != nil", Return())),
+				If("match(entity.ProtoReflect())", Line("results = append(results, t.repo.clone(entity))")),
+				Line("})"),
+				Linef("slices.SortFunc(results, func(a, b *%s) int { return strings.Compare(a.%s, b.%s) })", m.GoName, m.IDGoName, m.IDGoName),
+				Return("results, nil"),
+			})),
+	})
+}
+
+// =============================================================================
+// MAIN COMPOSITION - FoldMap over messages!
+// =============================================================================
+
+func MessageRepository(m MessageInfo) Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(),
+		Linef("// ============================================================================"),
+		Linef("// %s Repository - Thread-Safe In-Memory CRUD", m.GoName),
+		Linef("// ============================================================================"),
+		RepositoryStruct(m), Constructor(m), CloneMethod(m),
+		CreateMethod(m), GetMethod(m), UpdateMethod(m), PatchMethod(m), DeleteMethod(m),
+		SoftDeleteMethods(m), ListMethod(m), ExistsMethod(m), CountMethod(m), CountWhereMethod(m), AggregateMethods(m),
+		FindMethods(m), FilterMethod(m), QueryBuilder(m), IndexMethods(m), ClearMethod(m), SnapshotMethods(m), TransactionMethods(m), DurabilityMethods(m),
+	})
+}
+
+// GenerateFile generates the repositories of entityMessages. withHelpers adds
+// the declarations the repositories of a Go package share, which go in one
+// of its files only.
+func GenerateFile(file *protogen.File, entityMessages []*protogen.Message, reg *entities.Registry, withHelpers bool) Code {
+	if len(entityMessages) == 0 {
+		return CodeMonoid.Empty()
+	}
+
+	messages := Map(entityMessages, func(msg *protogen.Message) MessageInfo {
+		return ExtractMessageInfo(msg, reg.Config(msg))
+	})
+	return Concat(CodeMonoid, []Code{
+		Header(), Blank(), Package(string(file.GoPackageName)),
+		Imports("bufio", "bytes", "cmp", "context", "encoding/binary", "errors", "fmt", "hash/crc32", "os",
+			"path/filepath", "reflect", "slices", "sort", "strings", "sync", "time", "",
+			"github.com/google/uuid",
+			"google.golang.org/protobuf/encoding/protowire",
+			"google.golang.org/protobuf/proto",
+			"google.golang.org/protobuf/reflect/protoreflect",
+			"google.golang.org/protobuf/types/known/fieldmaskpb",
+			"google.golang.org/protobuf/types/known/timestamppb"),
+		When(withHelpers, PackageHelpers()),
+		When(withHelpers, PackageStore(reg.Package(file.GoImportPath, true), reg)),
+		FoldMap(messages, CodeMonoid, MessageRepository),
+	})
+}
+
+func PackageHelpers() Code {
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Filters ==="),
+		Blank(), Raw(filters),
+		Blank(), Comment("=== Queries ==="),
+		Blank(), Raw(queries),
+		Blank(), Comment("=== Field Masks ==="),
+		Blank(), Raw(masks),
+		Blank(), Comment("=== Indexes ==="),
+		Blank(), Raw(indexes),
+		Blank(), Comment("=== Journal ==="),
+		Blank(), Raw(journal),
+	})
+}
+
+//go:embed journal/journal.go
+var journalGo string
+
+// journal is the journal of durable repositories, journal/journal.go without
+// the package clause and imports, which the generated files declare
+// themselves.
+var journal = func() string {
+	_, imports, _ := strings.Cut(journalGo, "\nimport (")
+	_, body, _ := strings.Cut(imports, "\n)\n")
+	return strings.TrimLeft(body, "\n")
+}()
+
+// PackageStore declares InMemoryStore, which holds a repository of every
+// entity of the Go package, from every file of it, and runs transactions over
+// them, with one journal when durable.
+func PackageStore(entityMessages []*protogen.Message, reg *entities.Registry) Code {
+	names := Map(entityMessages, func(msg *protogen.Message) string { return msg.GoIdent.GoName })
+	each := func(format string) Code {
+		return FoldMap(names, CodeMonoid, func(name string) Code { return Linef(format, name) })
+	}
+	repos := strings.Join(Map(names, func(name string) string { return "s." + name }), ", ")
+	return Concat(CodeMonoid, []Code{
+		Blank(), Comment("=== Store ==="),
+		Blank(), Comment("InMemoryStore holds an in-memory repository of every entity of the package."),
+		Struct("InMemoryStore", Concat(CodeMonoid, []Code{
+			FoldMap(names, CodeMonoid, func(name string) Code {
+				return Field(name, "*InMemory"+name+"Repository")
+			}),
+			Blank(),
+			Line("journal *journal // nil unless durable"),
+			Blank(),
+			Line("mu        sync.Mutex"),
+			Line("conflicts int // the commits InjectConflicts has left to fail"),
+		})),
+		Blank(), Comment("transactionAttempts is the number of times RunTransaction runs a function whose"),
+		Comment("commit conflicts, Firestore's default."),
+		Line("const transactionAttempts = 5"),
+		Blank(), Comment("NewInMemoryStore creates a store of empty repositories."),
+		Func("NewInMemoryStore", "", "*InMemoryStore",
+			Concat(CodeMonoid, []Code{
+				Line("return &InMemoryStore{"),
+				each("\t%s: NewInMemory%[1]sRepository(),"),
+				Line("}"),
+			})),
+		Blank(), Comment("OpenInMemoryStore opens the durable store in dir, creating it when missing:"),
+		Comment("its repositories share one journal, which records the writes of a"),
+		Comment("transaction together. See OpenInMemory<Entity>Repository."),
+		Func("OpenInMemoryStore", "dir string, opts DurableOptions", "(*InMemoryStore, error)",
+			Concat(CodeMonoid, []Code{
+				Line("s := NewInMemoryStore()"),
+				Line("j, err := openJournal(dir, opts, map[string]journaled{"),
+				FoldMap(entityMessages, CodeMonoid, func(msg *protogen.Message) Code {
+					return Linef("\t%q: s.%s,", reg.Config(msg).Collection, msg.GoIdent.GoName)
+				}),
+				Line("})"),
+				If("err != nil", Return("nil, err")),
+				Line("s.journal = j"),
+				each("s.%s.journal = j"),
+				Line("j.start(s.Compact)"),
+				Return("s, nil"),
+			})),
+		Blank(), Comment("Compact replaces the journal of a durable store with a snapshot of its data."),
+		Comment("It runs every DurableOptions.SnapshotInterval."),
+		Method("s *InMemoryStore", "Compact", "", "error",
+			Concat(CodeMonoid, []Code{
+				FoldMap(names, CodeMonoid, func(name string) Code {
+					return Concat(CodeMonoid, []Code{Linef("s.%s.mu.RLock()", name), Linef("defer s.%s.mu.RUnlock()", name)})
+				}),
+				Return("s.journal.compact(" + repos + ")"),
+			})),
+		Blank(), Comment("Close compacts the journal of a durable store a last time and closes it;"),
+		Comment("writes fail afterwards."),
+		Method("s *InMemoryStore", "Close", "", "error", Return("s.journal.close(s.Compact, "+repos+")")),
+		Blank(), Comment("RunTransaction runs fn in a transaction over the repositories of s, whose"),
+		Comment("writes are undone unless fn returns nil, panics included, and a durable"),
+		Comment("store records them. The repositories are locked until fn returns, so fn"),
+		Comment("must go through tx, not them, and sees no other writes. Like Firestore's,"),
+		Comment("a transaction whose commit conflicts runs fn again, transactionAttempts"),
+		Comment("times in all, and then fails with ErrConflict; only InjectConflicts makes"),
+		Comment("commits conflict."),
+		Method("s *InMemoryStore", "RunTransaction", "ctx context.Context, fn func(context.Context, Tx) error", "error",
+			Concat(CodeMonoid, []Code{
+				Line("for attempt := 0; attempt < transactionAttempts; attempt++ {"),
+				If("err := ctx.Err(); err != nil", Return("err")),
+				If("conflict, err := s.transaction(ctx, fn); !conflict", Return("err")),
+				Line("}"),
+				Return(`fmt.Errorf("%w: transaction failed %d attempts", ErrConflict, transactionAttempts)`),
+			})),
+		Blank(), Comment("transaction runs an attempt of RunTransaction, and reports whether its commit"),
+		Comment("conflicted, undoing its writes."),
+		Method("s *InMemoryStore", "transaction", "ctx context.Context, fn func(context.Context, Tx) error", "(bool, error)",
+			Concat(CodeMonoid, []Code{
+				FoldMap(names, CodeMonoid, func(name string) Code {
+					return Concat(CodeMonoid, []Code{Linef("s.%s.mu.Lock()", name), Linef("defer s.%s.mu.Unlock()", name)})
+				}),
+				Blank(),
+				Line("committed := false"),
+				Line("defer func() {"),
+				Line("\tif !committed {"),
+				each("\t\ts.%s.revert()"),
+				Line("\t}"),
+				Line("}()"),
+				Line("err := fn(ctx, &InMemoryTx{store: s})"),
+				If("err == nil && s.conflict()", Return("true, nil")),
+				Line("committed = true"),
+				Return("false, s.journal.commit(err, " + repos + ")"),
+			})),
+		Blank(), Comment("InjectConflicts makes the next n commits of transactions conflict, as"),
+		Comment("concurrent writes make Firestore's, so that tests exercise what a function"),
+		Comment("does when it runs again: n of transactionAttempts or more fails the"),
+		Comment("transaction with ErrConflict."),
+		Method("s *InMemoryStore", "InjectConflicts", "n int", "",
+			Concat(CodeMonoid, []Code{
+				Line("s.mu.Lock()"),
+				Line("defer s.mu.Unlock()"),
+				Line("s.conflicts = n"),
+			})),
+		Blank(), Comment("conflict reports whether the commit to come conflicts."),
+		Method("s *InMemoryStore", "conflict", "", "bool",
+			Concat(CodeMonoid, []Code{
+				Line("s.mu.Lock()"),
+				Line("defer s.mu.Unlock()"),
+				If("s.conflicts <= 0", Return("false")),
+				Line("s.conflicts--"),
+				Return("true"),
+			})),
+		Blank(), Comment("InMemoryTx is the Tx of InMemoryStore.RunTransaction."),
+		Struct("InMemoryTx", Field("store", "*InMemoryStore")),
+		Blank(), Line("var _ Tx = (*InMemoryTx)(nil)"),
+		FoldMap(names, CodeMonoid, func(name string) Code {
+			return Concat(CodeMonoid, []Code{
+				Blank(), Commentf("%s is the %s side of the transaction.", name, name),
+				Method("t *InMemoryTx", name, "", repository.TxInterfaceName(name),
+					Return(fmt.Sprintf("&InMemory%sTx{repo: t.store.%s}", name, name))),
+			})
+		}),
+	})
+}
+
+// filters evaluate the operators of Firestore queries against entities, so
+// that the same filters select the same entities in both backends.
+const filters = `// where returns the predicate reporting whether the field of a message of type
+// desc compares to value as op says. field is a proto field name, which is
+// the field's Firestore path too, and op a Firestore operator: ==, !=, <, <=,
+// >, >=, in, not-in, array-contains or array-contains-any. Like Firestore,
+// it compares integers and floating-point numbers as numbers, enums as their
+// numbers and timestamps as times, and never matches values of different types
+// but with !=. Enums may be given by name too, as Firestore documents may
+// store them.
+func where(desc protoreflect.MessageDescriptor, field, op string, value interface{}) (func(protoreflect.Message) bool, error) {
+	fd := desc.Fields().ByName(protoreflect.Name(field))
+	if fd == nil {
+		return nil, fmt.Errorf("where: %s has no field %q", desc.FullName(), field)
+	}
+	value = enumValues(fd, value)
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if fd.IsList() || fd.IsMap() {
+			return nil, fmt.Errorf("where: %s %s needs a singular field", field, op)
+		}
+		return func(m protoreflect.Message) bool {
+			c, ok := compareValues(valueOf(fd, m), value)
+			switch op {
+			case "==":
+				return ok && c == 0
+			case "!=":
+				return !ok || c != 0
+			case "<":
+				return ok && c < 0
+			case "<=":
+				return ok && c <= 0
+			case ">":
+				return ok && c > 0
+			default:
+				return ok && c >= 0
+			}
+		}, nil
+	case "in", "not-in":
+		values, ok := listOf(value)
+		if !ok || fd.IsList() || fd.IsMap() {
+			return nil, fmt.Errorf("where: %s %s needs a singular field and a slice", field, op)
+		}
+		return func(m protoreflect.Message) bool {
+			return containsValue(values, valueOf(fd, m)) == (op == "in")
+		}, nil
+	case "array-contains", "array-contains-any":
+		values := []interface{}{value}
+		if op == "array-contains-any" {
+			var ok bool
+			if values, ok = listOf(value); !ok {
+				return nil, fmt.Errorf("where: %s %s needs a slice", field, op)
+			}
+		}
+		if !fd.IsList() {
+			return nil, fmt.Errorf("where: %s %s needs a repeated field", field, op)
+		}
+		return func(m protoreflect.Message) bool {
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				if containsValue(values, fieldValue(fd, list.Get(i))) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	return nil, fmt.Errorf("where: unsupported operator %q", op)
+}
+
+// whereAll returns the predicate reporting whether a message of type desc
+// matches every filter.
+func whereAll(desc protoreflect.MessageDescriptor, filters []Filter) (func(protoreflect.Message) bool, error) {
+	matches := make([]func(protoreflect.Message) bool, len(filters))
+	for i, f := range filters {
+		match, err := where(desc, f.Field, f.Op, f.Value)
+		if err != nil {
+			return nil, err
+		}
+		matches[i] = match
+	}
+	return func(m protoreflect.Message) bool {
+		for _, match := range matches {
+			if !match(m) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// valueOf returns the value of the field fd of m as Firestore compares it: nil
+// when fd has presence but is unset, which documents store as null, a slice
+// of its elements for a repeated field, and fieldValue's otherwise.
+func valueOf(fd protoreflect.FieldDescriptor, m protoreflect.Message) interface{} {
+	switch {
+	case fd.IsList():
+		list := m.Get(fd).List()
+		values := make([]interface{}, list.Len())
+		for i := range values {
+			values[i] = fieldValue(fd, list.Get(i))
+		}
+		return values
+	case fd.IsMap():
+		return m.Get(fd).Map()
+	case fd.HasPresence() && !m.Has(fd):
+		return nil
+	}
+	return fieldValue(fd, m.Get(fd))
+}
+
+// fieldValue returns v, a value of the field fd, as the Go value Firestore
+// compares: int64 for integers and enums, float64, string, bool, []byte,
+// time.Time for timestamps and nil for unset messages.
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return int64(v.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !v.Message().IsValid() {
+			return nil
+		}
+		if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime()
+		}
+		return v.Message().Interface()
+	}
+	return v.Interface()
+}
+
+// compareValues orders a before, like or after b as -1, 0 or 1, and reports
+// whether they are comparable at all.
+func compareValues(a, b interface{}) (int, bool) {
+	a, b = normalizeValue(a), normalizeValue(b)
+	switch a := a.(type) {
+	case nil:
+		return 0, b == nil
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, b), true
+		case float64:
+			return cmp.Compare(float64(a), b), true
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, float64(b)), true
+		case float64:
+			return cmp.Compare(a, b), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, true
+			case b:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case []byte:
+		if b, ok := b.([]byte); ok {
+			return bytes.Compare(a, b), true
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), true
+		}
+	}
+	return 0, false
+}
+
+// normalizeValue converts a value given to a query to the type fieldValue
+// returns for the fields it may compare with.
+func normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, []byte, time.Time:
+		return v
+	case *timestamppb.Timestamp:
+		if v == nil {
+			return nil
+		}
+		return v.AsTime()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	}
+	return v
+}
+
+// enumValues returns value, a value or slice of values of the field fd, with
+// the names of enum values replaced by their numbers.
+func enumValues(fd protoreflect.FieldDescriptor, value interface{}) interface{} {
+	ed := fd.Enum()
+	if ed == nil {
+		return value
+	}
+	if values, ok := listOf(value); ok {
+		for i := range values {
+			values[i] = enumNumber(ed, values[i])
+		}
+		return values
+	}
+	return enumNumber(ed, value)
+}
+
+// enumNumber returns the number of the value of ed that v names, or v when it
+// is not the name of one.
+func enumNumber(ed protoreflect.EnumDescriptor, v interface{}) interface{} {
+	if name, ok := v.(string); ok {
+		if ev := ed.Values().ByName(protoreflect.Name(name)); ev != nil {
+			return int64(ev.Number())
+		}
+	}
+	return v
+}
+
+// listOf returns the elements of the slice or array v.
+func listOf(v interface{}) ([]interface{}, bool) {
+	if _, ok := v.([]byte); ok {
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, candidate := range values {
+		if c, ok := compareValues(v, candidate); ok && c == 0 {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// masks apply the paths of Patch's field masks the way the Firestore
+// repository writes them.
+const masks = `// patchPath sets the field a field mask path names in dst to its value in src,
+// or clears it when src has none. The path names a field, or a field of a
+// message field with the names separated by dots, like
+// "ship_from.location.latitude". As the Firestore repository stores messages
+// as maps, every field but the last must be a singular message other than a
+// timestamp, wrapper or Struct; dst gets the messages on the way.
+func patchPath(src, dst protoreflect.Message, path string) error {
+	for rest := path; ; {
+		name, tail, nested := strings.Cut(rest, ".")
+		fd := dst.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("%w: %q: %s has no field %q", ErrInvalidMask, path, dst.Descriptor().FullName(), name)
+		}
+		if !nested {
+			if src.Has(fd) {
+				dst.Set(fd, src.Get(fd))
+			} else {
+				dst.Clear(fd)
+			}
+			return nil
+		}
+		if !maskParent(fd) {
+			return fmt.Errorf("%w: %q: %s is not a message stored as a map", ErrInvalidMask, path, name)
+		}
+		src, dst, rest = src.Get(fd).Message(), dst.Mutable(fd).Message(), tail
+	}
+}
+
+// maskParent reports whether a field mask path may name a field of fd's
+// message.
+func maskParent(fd protoreflect.FieldDescriptor) bool {
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return false
+	}
+	switch fd.Message().FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Struct", "google.protobuf.Value", "google.protobuf.ListValue",
+		"google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return false
+	}
+	return true
+}
+`
+
+// queries order the results of the query builders like Firestore orders those
+// of queries.
+const queries = `// order is an OrderBy of a query.
+type order struct {
+	field string
+	dir   Direction
+}
+
+// orderBy returns the comparison that orders messages of type desc like
+// Firestore orders the results of a query with the given filters and orders:
+// by the OrderBy fields, then by the fields of inequality filters not among
+// them, by name, and last by the ID field id. The last two go in the direction
+// of the last OrderBy.
+func orderBy(desc protoreflect.MessageDescriptor, id string, filters []Filter, orders []order) (func(a, b protoreflect.Message) int, error) {
+	dir := Asc
+	if len(orders) > 0 {
+		dir = orders[len(orders)-1].dir
+	}
+	var inequalities []string
+	for _, f := range filters {
+		switch f.Op {
+		case "<", "<=", ">", ">=", "!=", "not-in":
+			if !slices.ContainsFunc(orders, func(o order) bool { return o.field == f.Field }) && !slices.Contains(inequalities, f.Field) {
+				inequalities = append(inequalities, f.Field)
+			}
+		}
+	}
+	slices.Sort(inequalities)
+	orders = slices.Clip(orders)
+	for _, field := range append(inequalities, id) {
+		orders = append(orders, order{field: field, dir: dir})
+	}
+
+	fields := make([]protoreflect.FieldDescriptor, len(orders))
+	for i, o := range orders {
+		if fields[i] = desc.Fields().ByName(protoreflect.Name(o.field)); fields[i] == nil {
+			return nil, fmt.Errorf("order by: %s has no field %q", desc.FullName(), o.field)
+		}
+	}
+	return func(a, b protoreflect.Message) int {
+		for i, fd := range fields {
+			c := orderValues(valueOf(fd, a), valueOf(fd, b))
+			if orders[i].dir == Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	}, nil
+}
+
+// orderValues orders a before, like or after b as -1, 0 or 1, the way
+// Firestore orders the values of a field: by type first, see typeOrder, then
+// by value.
+func orderValues(a, b interface{}) int {
+	a, b = normalizeValue(a), normalizeValue(b)
+	if c := cmp.Compare(typeOrder(a), typeOrder(b)); c != 0 {
+		return c
+	}
+	if a, ok := a.([]interface{}); ok {
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := orderValues(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(a), len(b))
+	}
+	c, _ := compareValues(a, b)
+	return c
+}
+
+// typeOrder returns the position of the type of v in Firestore's order of
+// types: null, booleans, numbers, timestamps, strings, bytes, arrays and maps.
+func typeOrder(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case int64, float64:
+		return 2
+	case time.Time:
+		return 3
+	case string:
+		return 4
+	case []byte:
+		return 5
+	case []interface{}:
+		return 6
+	}
+	return 7
+}
+
+// window returns the results after the first offset, at most limit of them
+// when limit is positive.
+func window[T any](results []T, offset, limit int) []T {
+	results = results[min(max(offset, 0), len(results)):]
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+	return results
+}
+`
+
+// indexes find the entities of the repositories by the values of a field,
+// for the queries whose filters name it.
+const indexes = `// valueIndex indexes entities by the values of a field of type K: ids holds
+// the IDs of the entities with each value, and sorted the values in the order
+// compareValues gives them, for range queries.
+type valueIndex[K comparable] struct {
+	ids    map[K]map[string]struct{}
+	sorted []K
+}
+
+func newValueIndex[K comparable]() *valueIndex[K] {
+	return &valueIndex[K]{ids: make(map[K]map[string]struct{})}
+}
+
+// add indexes the entity with the given ID under k.
+func (x *valueIndex[K]) add(k K, id string) {
+	set, ok := x.ids[k]
+	if !ok {
+		set = make(map[string]struct{})
+		x.ids[k] = set
+		x.sorted = slices.Insert(x.sorted, x.search(k, false), k)
+	}
+	set[id] = struct{}{}
+}
+
+// remove drops the entity with the given ID from under k.
+func (x *valueIndex[K]) remove(k K, id string) {
+	set, ok := x.ids[k]
+	if !ok {
+		return
+	}
+	delete(set, id)
+	if len(set) == 0 {
+		delete(x.ids, k)
+		i := x.search(k, false)
+		x.sorted = slices.Delete(x.sorted, i, i+1)
+	}
+}
+
+// search returns the position in sorted of the first value not before v, or
+// after it when after is set.
+func (x *valueIndex[K]) search(v interface{}, after bool) int {
+	return sort.Search(len(x.sorted), func(i int) bool {
+		c, _ := compareValues(x.sorted[i], v)
+		return c > 0 || c == 0 && !after
+	})
+}
+
+// lookup returns the IDs of the entities whose value of fd, the field x
+// indexes, compares to value as op says, like where does, or false when op is
+// not one x serves: ==, <, <=, >, >=, in, array-contains or
+// array-contains-any.
+func (x *valueIndex[K]) lookup(fd protoreflect.FieldDescriptor, op string, value interface{}) ([]string, bool) {
+	value = enumValues(fd, value)
+	values := []interface{}{value}
+	switch op {
+	case "==", "array-contains":
+	case "in", "array-contains-any":
+		var ok bool
+		if values, ok = listOf(value); !ok {
+			return nil, false
+		}
+	case "<", "<=", ">", ">=":
+		var zero K
+		if _, ok := compareValues(zero, value); !ok {
+			return nil, true // never comparable, as where says
+		}
+		from, to := 0, len(x.sorted)
+		switch op {
+		case "<":
+			to = x.search(value, false)
+		case "<=":
+			to = x.search(value, true)
+		case ">":
+			from = x.search(value, true)
+		default:
+			from = x.search(value, false)
+		}
+		return x.collect(x.sorted[from:to], nil), true
+	default:
+		return nil, false
+	}
+
+	// an entity may have more than one of the values, in a repeated field
+	var seen map[string]struct{}
+	if len(values) > 1 {
+		seen = make(map[string]struct{})
+	}
+	var ids []string
+	for _, v := range values {
+		var zero K
+		if _, ok := compareValues(zero, v); !ok {
+			continue
+		}
+		from, to := x.search(v, false), x.search(v, true)
+		ids = append(ids, x.collect(x.sorted[from:to], seen)...)
+	}
+	return ids, true
+}
+
+// collect returns the IDs of the entities with the given values. When seen is
+// not nil, it leaves out the IDs in it and adds the others.
+func (x *valueIndex[K]) collect(keys []K, seen map[string]struct{}) []string {
+	var ids []string
+	for _, k := range keys {
+		for id := range x.ids[k] {
+			if seen != nil {
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = struct{}{}
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+`
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-inmemory")
+	softDelete := flags.Bool("soft_delete", true, "manage deleted_at on entities that have it unless the entity option says otherwise")
+	timestamps := flags.Bool("timestamps", true, "manage created_at/updated_at on entities that have them unless the entity option says otherwise")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		reg, err := entities.Defaults{SoftDelete: *softDelete, Timestamps: *timestamps}.Registry(gen)
+		if err != nil {
+			return err
+		}
+
+		// The filter helpers are declared once per Go package
+		helpersDeclared := make(map[protogen.GoImportPath]bool)
+
+		for _, f := range gen.Files {
+			if !f.Generate || len(f.Messages) == 0 {
+				continue
+			}
+
+			// Collect entity messages: those with the entity option, plus
+			// messages with an id field when the Go package declares no options
+			rep.Entities(reg, f, true)
+			entityMessages := reg.Entities(f, true)
+			if len(entityMessages) == 0 {
+				continue
+			}
+
+			withHelpers := !helpersDeclared[f.GoImportPath]
+			helpersDeclared[f.GoImportPath] = true
+
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_inmemory.pb.go", f.GoImportPath, GenerateFile(f, entityMessages, reg, withHelpers).Run()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func toSnakeCase(s string) string {
+	var result []rune
+	for i, r := range s {
+		if i > 0 && unicode.IsUpper(r) {
+			result = append(result, '_')
+		}
+		result = append(result, unicode.ToLower(r))
+	}
+	return string(result)
+}
+
+func zeroValue(goType string) string {
+	switch goType {
+	case "string":
+		return `""`
+	case "int32", "int64", "uint32", "uint64", "float32", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	default:
+		return "nil"
+	}
+}
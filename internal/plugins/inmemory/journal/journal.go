@@ -0,0 +1,435 @@
+// Package journal is the durability layer of the generated in-memory
+// repositories. protoc-gen-inmemory embeds this file, without its package
+// clause and imports, in the helpers of every Go package it generates; it is
+// a package of its own so that it is compiled and tested like any other code.
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// DurableOptions configures the journal of a durable in-memory repository or
+// store.
+type DurableOptions struct {
+	// SnapshotInterval is the period of the compactions that write a snapshot
+	// and empty the write-ahead log: a minute when 0, none when negative.
+	SnapshotInterval time.Duration
+
+	// NoSync skips the fsync after every write. Writes are faster, but those
+	// of the last moments before a machine crash may be lost.
+	NoSync bool
+}
+
+// mutationKind says what a mutation does to the entity with its ID.
+type mutationKind uint64
+
+const (
+	mutationPut    mutationKind = 1 // store entity, the entity's encoding
+	mutationDelete mutationKind = 2 // remove the entity
+)
+
+// mutation is the state of a stored entity after a write. A journal records
+// states rather than operations, so that replaying a mutation twice is
+// harmless.
+type mutation struct {
+	collection string
+	kind       mutationKind
+	id         string
+	entity     []byte
+}
+
+// journaled is a repository whose writes a journal records. Its methods run
+// with the repository locked.
+type journaled interface {
+	// changes returns the mutations of the running write.
+	changes() ([]mutation, error)
+	// revert undoes the running write.
+	revert()
+	// keep ends the running write, keeping its changes.
+	keep()
+	// apply replays m.
+	apply(m mutation) error
+	// entities calls put with a mutationPut of every stored entity.
+	entities(put func(mutation) error) error
+}
+
+// journal keeps the writes of in-memory repositories on disk, in dir: an
+// append-only write-ahead log of mutations, wal, and a compacted snapshot of
+// every entity, snapshot. Both are sequences of frames, each the
+// uvarint-prefixed protobuf encoding of a batch of mutations followed by its
+// CRC-32C; the mutations of a write are one batch, so that replaying stops
+// before or after a write, never in the middle. A write the journal fails to
+// append is undone, and every later write fails with the same error.
+type journal struct {
+	mu   sync.Mutex
+	dir  string
+	opts DurableOptions
+	wal  *os.File
+	err  error // the failure of an append, sticky
+
+	collections int // of the repositories that share the journal
+
+	stop chan struct{} // closed by close to end the compaction loop
+	done chan struct{} // closed when the compaction loop has ended
+}
+
+var (
+	errJournalClosed = errors.New("journal closed")
+	errJournalShared = errors.New("journal shared by the repositories of a store: compact and close the store")
+	crcTable         = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// openJournal opens the journal in dir, creating dir when missing, and
+// replays its snapshot and write-ahead log into repos, keyed by collection.
+// A frame cut short at the end of the log, by a crash during the write, is
+// dropped.
+func openJournal(dir string, opts DurableOptions, repos map[string]journaled) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	apply := func(m mutation) error {
+		r, ok := repos[m.collection]
+		if !ok {
+			return fmt.Errorf("journal: unknown collection %q", m.collection)
+		}
+		return r.apply(m)
+	}
+	snapshot := filepath.Join(dir, "snapshot")
+	if size, intact, err := replay(snapshot, apply); err != nil {
+		return nil, err
+	} else if intact != size {
+		return nil, fmt.Errorf("journal: %s is corrupt at offset %d", snapshot, intact)
+	}
+	wal := filepath.Join(dir, "wal")
+	size, intact, err := replay(wal, apply)
+	if err != nil {
+		return nil, err
+	}
+	if intact != size {
+		if err := os.Truncate(wal, intact); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(wal, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &journal{dir: dir, opts: opts, wal: f, collections: len(repos)}, nil
+}
+
+// start runs compact every SnapshotInterval until close.
+func (j *journal) start(compact func() error) {
+	interval := j.opts.SnapshotInterval
+	if interval == 0 {
+		interval = time.Minute
+	}
+	if interval < 0 {
+		return
+	}
+	j.stop, j.done = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-j.stop:
+				return
+			case <-ticker.C:
+				// a failed compaction loses nothing: the log still holds
+				// every write, and the next one tries again
+				_ = compact()
+			}
+		}
+	}()
+}
+
+// commit ends the running write of repos, whose error is err: it records the
+// changes of the write and keeps them, or undoes them when err is set or the
+// journal fails to record them. A nil journal keeps the changes in memory
+// only.
+func (j *journal) commit(err error, repos ...journaled) error {
+	if err == nil && j != nil {
+		var batch []mutation
+		for _, r := range repos {
+			changes, cerr := r.changes()
+			if cerr != nil {
+				err = cerr
+				break
+			}
+			batch = append(batch, changes...)
+		}
+		if err == nil {
+			err = j.append(batch)
+		}
+	}
+	for _, r := range repos {
+		if err != nil {
+			r.revert()
+		} else {
+			r.keep()
+		}
+	}
+	return err
+}
+
+// append writes batch to the log as one frame.
+func (j *journal) append(batch []mutation) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return j.err
+	}
+	if _, err := j.wal.Write(frame(batch)); err != nil {
+		j.err = fmt.Errorf("journal: %w", err)
+		return j.err
+	}
+	if !j.opts.NoSync {
+		if err := j.wal.Sync(); err != nil {
+			j.err = fmt.Errorf("journal: %w", err)
+			return j.err
+		}
+	}
+	return nil
+}
+
+// compact replaces the snapshot with the entities of repos, every repository
+// that shares the journal, and empties the log. The repositories must not
+// change meanwhile. The new snapshot takes
+// the old one's place atomically; should the log outlive it after a crash,
+// replaying the log over it is harmless, as mutations are states.
+func (j *journal) compact(repos ...journaled) error {
+	if j == nil {
+		return nil
+	}
+	if len(repos) != j.collections {
+		return errJournalShared
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return j.err
+	}
+	tmp, err := os.CreateTemp(j.dir, "snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // after a failure; renamed otherwise
+	w := bufio.NewWriter(tmp)
+	for _, r := range repos {
+		if err := r.entities(func(m mutation) error {
+			_, err := w.Write(frame([]mutation{m}))
+			return err
+		}); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(j.dir, "snapshot")); err != nil {
+		return err
+	}
+	if err := syncDir(j.dir); err != nil {
+		return err
+	}
+	// the writes go to the end of the file, O_APPEND, so to its start next
+	if err := j.wal.Truncate(0); err != nil {
+		j.err = fmt.Errorf("journal: %w", err)
+		return j.err
+	}
+	return nil
+}
+
+// close stops the compaction loop, compacts a last time and closes the log,
+// for repos, every repository that shares the journal. Writes fail from then
+// on.
+func (j *journal) close(compact func() error, repos ...journaled) error {
+	if j == nil {
+		return nil
+	}
+	if len(repos) != j.collections {
+		return errJournalShared
+	}
+	if j.stop != nil {
+		close(j.stop)
+		<-j.done
+		j.stop = nil
+	}
+	err := compact()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err == errJournalClosed {
+		return nil
+	}
+	j.err = errJournalClosed
+	return errors.Join(err, j.wal.Close())
+}
+
+// frame returns the frame of batch: its length, its encoding and the encoding's
+// CRC-32C.
+func frame(batch []mutation) []byte {
+	var payload []byte
+	for _, m := range batch {
+		var b []byte
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, m.collection)
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.kind))
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, m.id)
+		if m.entity != nil {
+			b = protowire.AppendTag(b, 4, protowire.BytesType)
+			b = protowire.AppendBytes(b, m.entity)
+		}
+		payload = protowire.AppendTag(payload, 1, protowire.BytesType)
+		payload = protowire.AppendBytes(payload, b)
+	}
+	out := binary.AppendUvarint(nil, uint64(len(payload)))
+	out = append(out, payload...)
+	return binary.LittleEndian.AppendUint32(out, crc32.Checksum(payload, crcTable))
+}
+
+// replay calls apply with the mutations of the frames of the file at path, a
+// missing file having none, and returns the file's size and the size of its
+// intact prefix, where replaying stopped.
+func replay(path string, apply func(mutation) error) (size, intact int64, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	rest := data
+	for len(rest) > 0 {
+		n, k := binary.Uvarint(rest)
+		if k <= 0 || uint64(len(rest)-k) < n+4 {
+			break // cut short
+		}
+		payload := rest[k : k+int(n)]
+		if binary.LittleEndian.Uint32(rest[k+int(n):]) != crc32.Checksum(payload, crcTable) {
+			break
+		}
+		batch, err := parseBatch(payload)
+		if err != nil {
+			return 0, 0, fmt.Errorf("journal: %s at offset %d: %w", path, len(data)-len(rest), err)
+		}
+		for _, m := range batch {
+			if err := apply(m); err != nil {
+				return 0, 0, err
+			}
+		}
+		rest = rest[k+int(n)+4:]
+	}
+	return int64(len(data)), int64(len(data) - len(rest)), nil
+}
+
+// parseBatch decodes the payload of a frame.
+func parseBatch(b []byte) ([]mutation, error) {
+	var batch []mutation
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num != 1 || typ != protowire.BytesType {
+			if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		m, err := parseMutation(v)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, m)
+	}
+	return batch, nil
+}
+
+func parseMutation(b []byte) (mutation, error) {
+	var m mutation
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return m, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			m.kind, b = mutationKind(v), b[n:]
+		case (num == 1 || num == 3 || num == 4) && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			switch num {
+			case 1:
+				m.collection = string(v)
+			case 3:
+				m.id = string(v)
+			case 4:
+				m.entity = append([]byte{}, v...)
+			}
+			b = b[n:]
+		default:
+			if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	if m.kind != mutationPut && m.kind != mutationDelete {
+		return m, fmt.Errorf("unknown mutation kind %d", m.kind)
+	}
+	return m, nil
+}
+
+// syncDir flushes the entries of dir, so that files created or renamed in it
+// survive a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
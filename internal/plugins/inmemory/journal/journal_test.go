@@ -0,0 +1,269 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// store is a journaled repository of byte strings, the way the generated
+// repositories keep entities.
+type store struct {
+	collection string
+	data       map[string][]byte
+	undo       map[string][]byte // replaced values of the running write, nil for none
+}
+
+func newStore(collection string) *store {
+	return &store{collection: collection, data: map[string][]byte{}, undo: map[string][]byte{}}
+}
+
+func (s *store) put(id, v string) {
+	s.touch(id)
+	s.data[id] = []byte(v)
+}
+
+func (s *store) remove(id string) {
+	s.touch(id)
+	delete(s.data, id)
+}
+
+func (s *store) touch(id string) {
+	if _, ok := s.undo[id]; !ok {
+		s.undo[id] = s.data[id]
+	}
+}
+
+func (s *store) changes() ([]mutation, error) {
+	var batch []mutation
+	for id := range s.undo {
+		if v, ok := s.data[id]; ok {
+			batch = append(batch, mutation{collection: s.collection, kind: mutationPut, id: id, entity: v})
+		} else {
+			batch = append(batch, mutation{collection: s.collection, kind: mutationDelete, id: id})
+		}
+	}
+	return batch, nil
+}
+
+func (s *store) revert() {
+	for id, v := range s.undo {
+		if v == nil {
+			delete(s.data, id)
+		} else {
+			s.data[id] = v
+		}
+	}
+	s.keep()
+}
+
+func (s *store) keep() { clear(s.undo) }
+
+func (s *store) apply(m mutation) error {
+	if m.kind == mutationDelete {
+		delete(s.data, m.id)
+	} else {
+		s.data[m.id] = m.entity
+	}
+	return nil
+}
+
+func (s *store) entities(put func(mutation) error) error {
+	for id, v := range s.data {
+		if err := put(mutation{collection: s.collection, kind: mutationPut, id: id, entity: v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func open(t *testing.T, dir string) (*journal, *store, *store) {
+	t.Helper()
+	a, b := newStore("a"), newStore("b")
+	j, err := openJournal(dir, DurableOptions{SnapshotInterval: -1}, map[string]journaled{"a": a, "b": b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j, a, b
+}
+
+func contents(s *store) map[string]string {
+	m := map[string]string{}
+	for id, v := range s.data {
+		m[id] = string(v)
+	}
+	return m
+}
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	j, a, b := open(t, dir)
+	a.put("1", "one")
+	a.put("2", "two")
+	b.put("1", "uno")
+	if err := j.commit(nil, a, b); err != nil {
+		t.Fatal(err)
+	}
+	a.remove("2")
+	a.put("1", "eins")
+	if err := j.commit(nil, a); err != nil {
+		t.Fatal(err)
+	}
+	a.put("3", "rolled back")
+	if err := j.commit(errors.New("fn failed"), a); err == nil {
+		t.Fatal("commit(err) = nil")
+	}
+	if _, ok := a.data["3"]; ok {
+		t.Error("failed write kept")
+	}
+	if err := j.close(func() error { return nil }, a, b); err != nil {
+		t.Fatal(err)
+	}
+
+	_, a2, b2 := open(t, dir)
+	if got, want := contents(a2), contents(a); !reflect.DeepEqual(got, want) {
+		t.Errorf("a after replay = %v, want %v", got, want)
+	}
+	if got, want := contents(b2), contents(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("b after replay = %v, want %v", got, want)
+	}
+}
+
+func TestTornTail(t *testing.T) {
+	dir := t.TempDir()
+	j, a, b := open(t, dir)
+	a.put("1", "one")
+	j.commit(nil, a)
+	a.put("2", "two")
+	a.put("3", "three")
+	j.commit(nil, a)
+	j.close(func() error { return nil }, a, b)
+
+	// cut the second write short, as a crash in the middle of it would
+	wal := filepath.Join(dir, "wal")
+	info, err := os.Stat(wal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(wal, info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	j2, a2, b2 := open(t, dir)
+	if got := contents(a2); !reflect.DeepEqual(got, map[string]string{"1": "one"}) {
+		t.Errorf("after a torn write = %v, want the writes before it", got)
+	}
+	a2.put("4", "four")
+	if err := j2.commit(nil, a2); err != nil {
+		t.Fatal(err)
+	}
+	j2.close(func() error { return nil }, a2, b2)
+	_, a3, _ := open(t, dir)
+	if got := contents(a3); !reflect.DeepEqual(got, map[string]string{"1": "one", "4": "four"}) {
+		t.Errorf("writes after a torn one = %v, want them appended to the intact log", got)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	j, a, b := open(t, dir)
+	for _, id := range []string{"1", "2", "3"} {
+		a.put(id, "v"+id)
+		j.commit(nil, a)
+	}
+	a.remove("2")
+	b.put("x", "y")
+	j.commit(nil, a, b)
+	if err := j.compact(a); err != errJournalShared {
+		t.Fatalf("compact of one of the repositories = %v, want %v", err, errJournalShared)
+	}
+	if err := j.compact(a, b); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "wal")); err != nil || info.Size() != 0 {
+		t.Fatalf("log after compaction: %v, %v; want it empty", info, err)
+	}
+	a.put("4", "v4")
+	j.commit(nil, a)
+	wal, err := os.ReadFile(filepath.Join(dir, "wal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.close(func() error { return j.compact(a, b) }, a, b)
+
+	// the log outliving its snapshot, as after a crash between the two
+	// steps of a compaction, replays harmlessly
+	if err := os.WriteFile(filepath.Join(dir, "wal"), wal, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, a2, b2 := open(t, dir)
+	if got, want := contents(a2), map[string]string{"1": "v1", "3": "v3", "4": "v4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("a = %v, want %v", got, want)
+	}
+	if got := contents(b2); !reflect.DeepEqual(got, map[string]string{"x": "y"}) {
+		t.Errorf("b = %v, want x: y", got)
+	}
+}
+
+func TestFailedAppend(t *testing.T) {
+	j, a, _ := open(t, t.TempDir())
+	a.put("1", "one")
+	j.commit(nil, a)
+	j.wal.Close() // writes fail from now on
+	a.put("1", "changed")
+	a.put("2", "two")
+	if err := j.commit(nil, a); err == nil {
+		t.Fatal("commit to a failed log = nil")
+	}
+	if got := contents(a); !reflect.DeepEqual(got, map[string]string{"1": "one"}) {
+		t.Errorf("after a failed append = %v, want the write undone", got)
+	}
+	a.put("3", "three")
+	if err := j.commit(nil, a); err == nil {
+		t.Error("commit after a failed append = nil, want the failure again")
+	}
+}
+
+func TestCorruptSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	j, a, b := open(t, dir)
+	a.put("1", "one")
+	j.commit(nil, a)
+	j.close(func() error { return j.compact(a, b) }, a, b)
+	snapshot := filepath.Join(dir, "snapshot")
+	data, err := os.ReadFile(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 0xff
+	if err := os.WriteFile(snapshot, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openJournal(dir, DurableOptions{}, map[string]journaled{"a": newStore("a")}); err == nil {
+		t.Error("openJournal over a corrupt snapshot = nil, want an error")
+	}
+}
+
+func TestFrame(t *testing.T) {
+	batch := []mutation{
+		{collection: "users", kind: mutationPut, id: "u1", entity: []byte{1, 2, 3}},
+		{collection: "users", kind: mutationDelete, id: "u2"},
+	}
+	var got []mutation
+	path := filepath.Join(t.TempDir(), "f")
+	if err := os.WriteFile(path, frame(batch), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	size, intact, err := replay(path, func(m mutation) error { got = append(got, m); return nil })
+	if err != nil || size != intact {
+		t.Fatalf("replay = %d, %d, %v", size, intact, err)
+	}
+	if !reflect.DeepEqual(got, batch) {
+		t.Errorf("replay(frame(batch)) = %+v, want %+v", got, batch)
+	}
+	if _, err := parseMutation([]byte{0x10, 0x09}); err == nil {
+		t.Error("parseMutation(kind 9) = nil, want an error")
+	}
+}
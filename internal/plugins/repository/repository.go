@@ -0,0 +1,130 @@
+// Package repository implements protoc-gen-repository, which generates the canonical repository contract for entities
+// Generates: <Entity>Repository, <Entity>Query and <Entity>Tx interfaces + the ErrNotFound/ErrInvalidID/ErrAlreadyExists/ErrConflict/ErrInvalidMask sentinels
+// + the package-wide Direction of queries, Tx interface and its query Filter
+//
+// Every storage backend (protoc-gen-firestore, protoc-gen-inmemory) asserts
+// that it implements these interfaces, and the consuming plugins (realtime,
+// geo, mock, auth, ...) program against them, so run this plugin alongside
+// any backend.
+package repository
+
+import (
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/repository"
+	"google.golang.org/protobuf/compiler/protogen"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// REPOSITORY GENERATOR
+// =============================================================================
+
+// GenerateFile renders the contract of a file's entities. The declarations
+// shared by the Go package, the errors, Direction and Tx over
+// packageEntities, go in the file withErrors.
+func GenerateFile(pkgName string, entityNames []string, withErrors bool, packageEntities []string) Code {
+	imports := Join(
+		Line("import ("),
+		Line(`	"context"`),
+		When(withErrors, Line(`	"errors"`)),
+		Blank(),
+		Line(`	"google.golang.org/protobuf/types/known/fieldmaskpb"`),
+		Line(")"),
+	)
+
+	var interfaces []Code
+	for _, name := range entityNames {
+		interfaces = append(interfaces,
+			Blank(), Raw(repository.Interface(name)),
+			Blank(), Raw(repository.QueryInterface(name)),
+			Blank(), Raw(repository.TxInterface(name)))
+	}
+
+	return Join(
+		Line("// Code generated by protoc-gen-repository. DO NOT EDIT."),
+		Line("// Canonical repository contract shared by all storage backends."),
+		Blank(),
+		Linef("package %s", pkgName),
+		Blank(),
+		imports,
+		generateErrors(withErrors),
+		When(withErrors, Join(Blank(), Raw(repository.Direction), Blank(), Raw(repository.Tx(packageEntities)))),
+		Join(interfaces...),
+	)
+}
+
+func generateErrors(enabled bool) Code {
+	if !enabled {
+		return Empty()
+	}
+	var errs []Code
+	for _, e := range repository.Errors {
+		errs = append(errs, Linef("	%s = errors.New(%q)", e.Name, e.Message))
+	}
+	return Join(
+		Blank(),
+		Line("// Errors returned by every repository backend."),
+		Line("var ("),
+		Join(errs...),
+		Line(")"),
+	)
+}
+
+// =============================================================================
+// MAIN
+// =============================================================================
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-repository")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		reg, err := entities.NewRegistry(gen)
+		if err != nil {
+			return err
+		}
+
+		// The sentinel errors are declared once per Go package
+		errorsDeclared := make(map[protogen.GoImportPath]bool)
+
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+
+			// Same entity set as the backends: declared options, or messages
+			// with an id field when the Go package declares none (protoc-gen-inmemory)
+			rep.Entities(reg, f, true)
+			entityMessages := reg.Entities(f, true)
+			if len(entityMessages) == 0 {
+				continue
+			}
+
+			var names []string
+			for _, msg := range entityMessages {
+				names = append(names, msg.GoIdent.GoName)
+			}
+
+			withErrors := !errorsDeclared[f.GoImportPath]
+			errorsDeclared[f.GoImportPath] = true
+
+			// Tx spans the entities of every file of the Go package, so a
+			// transaction can cover entities declared apart
+			var packageNames []string
+			for _, msg := range reg.Package(f.GoImportPath, true) {
+				packageNames = append(packageNames, msg.GoIdent.GoName)
+			}
+
+			src := GenerateFile(string(f.GoPackageName), names, withErrors, packageNames).Run()
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_repository.pb.go", f.GoImportPath, src); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
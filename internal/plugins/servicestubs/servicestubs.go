@@ -0,0 +1,408 @@
+// Package servicestubs implements protoc-gen-service-stubs, which generates Connect service implementations
+// that wire to Firestore repositories. CRUD methods are implemented,
+// complex methods return Unimplemented (override as needed).
+package servicestubs
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// INFO TYPES
+// =============================================================================
+
+type EntityInfo struct {
+	GoName  string
+	IDField string
+}
+
+type MethodInfo struct {
+	GoName     string
+	InputType  string
+	OutputType string
+	Op         string // "get", "list", "delete", "create", "update", or ""
+	Entity     string
+	IDField    string
+	ListField  string // for list responses
+	OutputMsg  *protogen.Message
+	Reason     string // why Op was, or was not, detected, for explain reports
+}
+
+type ServiceInfo struct {
+	GoName  string
+	Methods []MethodInfo
+}
+
+// =============================================================================
+// ANALYSIS
+// =============================================================================
+
+func analyzeMethod(m *protogen.Method, entities map[string]*EntityInfo) MethodInfo {
+	name := string(m.Desc.Name())
+	inputType := m.Input.GoIdent.GoName
+	outputType := m.Output.GoIdent.GoName
+
+	// Fix Empty types
+	if inputType == "Empty" {
+		inputType = "emptypb.Empty"
+	}
+	if outputType == "Empty" {
+		outputType = "emptypb.Empty"
+	}
+
+	info := MethodInfo{
+		GoName:     m.GoName,
+		InputType:  inputType,
+		OutputType: outputType,
+	}
+
+	// Detect operation and entity
+	var op, entity string
+	if strings.HasPrefix(name, "Get") {
+		op = "get"
+		entity = strings.TrimPrefix(name, "Get")
+	} else if strings.HasPrefix(name, "List") {
+		op = "list"
+		entity = strings.TrimSuffix(strings.TrimPrefix(name, "List"), "s")
+	} else if strings.HasPrefix(name, "Delete") {
+		op = "delete"
+		entity = strings.TrimPrefix(name, "Delete")
+	} else if strings.HasPrefix(name, "Create") {
+		op = "create"
+		entity = strings.TrimPrefix(name, "Create")
+	} else if strings.HasPrefix(name, "Update") {
+		op = "update"
+		entity = strings.TrimPrefix(name, "Update")
+	}
+
+	// Check if entity exists
+	switch entityInfo, ok := entities[entity]; {
+	case op == "":
+		info.Reason = "no stub: name starts with none of Get, List, Delete, Create, Update"
+	case !ok:
+		info.Reason = fmt.Sprintf("no stub: %s is not an entity of the package", entity)
+	default:
+		info.Reason = fmt.Sprintf("method name %s: %s on entity %s", name, op, entity)
+		info.Op = op
+		info.Entity = entity
+		info.IDField = entityInfo.IDField
+
+		// Find list field for list operations; the response may be
+		// declared in another file
+		if op == "list" {
+			info.OutputMsg = m.Output
+			for _, f := range m.Output.Fields {
+				if f.Desc.IsList() && f.Desc.Kind() == protoreflect.MessageKind {
+					info.ListField = f.GoName
+					break
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+// =============================================================================
+// CODE GENERATION
+// =============================================================================
+
+func generateFile(file *protogen.File, services []ServiceInfo, entities map[string]*EntityInfo, pkgName, connectPkg string) Code {
+	return Join(
+		generateHeader(pkgName, connectPkg),
+		generateServices(services, entities, connectPkg),
+		generateWireProviders(services),
+	)
+}
+
+func generateHeader(pkgName, connectPkg string) Code {
+	return Join(
+		Line("// Code generated by protoc-gen-service-stubs. DO NOT EDIT."),
+		Line("// Service implementations wired to Firestore repositories."),
+		Line("// Override methods as needed for custom business logic."),
+		Blank(),
+		Linef("package %s", pkgName),
+		Blank(),
+		Line("import ("),
+		Line(`	"context"`),
+		Line(`	"errors"`),
+		Blank(),
+		Line(`	"connectrpc.com/connect"`),
+		Line(`	"github.com/google/wire"`),
+		Line(`	"google.golang.org/protobuf/types/known/emptypb"`),
+		Linef(`	"%s"`, connectPkg),
+		Line(")"),
+		Blank(),
+	)
+}
+
+func generateServices(services []ServiceInfo, entities map[string]*EntityInfo, connectPkg string) Code {
+	var result []Code
+	for _, svc := range services {
+		result = append(result, generateService(svc, entities, connectPkg))
+	}
+	return Join(result...)
+}
+
+func generateService(svc ServiceInfo, entities map[string]*EntityInfo, connectPkg string) Code {
+	// All services use the shared Repositories struct
+	connectPkgName := extractPkgName(connectPkg)
+
+	// Build methods
+	var methods []Code
+	for _, m := range svc.Methods {
+		methods = append(methods, generateMethod(svc.GoName, m, connectPkg))
+	}
+
+	return Join(
+		Line("// ============================================================================="),
+		Linef("// %s", strings.ToUpper(svc.GoName)),
+		Line("// ============================================================================="),
+		Blank(),
+		Linef("type %s struct {", svc.GoName),
+		Linef("	%s.Unimplemented%sHandler", connectPkgName, svc.GoName),
+		Line("	repos *Repositories"),
+		Line("}"),
+		Blank(),
+		Linef("func New%s(repos *Repositories) *%s {", svc.GoName, svc.GoName),
+		Linef("	return &%s{repos: repos}", svc.GoName),
+		Line("}"),
+		Join(methods...),
+		Blank(),
+	)
+}
+
+func generateMethod(svcName string, m MethodInfo, connectPkg string) Code {
+	recv := fmt.Sprintf("s *%s", svcName)
+	params := fmt.Sprintf("ctx context.Context, req *connect.Request[%s]", m.InputType)
+	returns := fmt.Sprintf("(*connect.Response[%s], error)", m.OutputType)
+
+	var body Code
+	switch m.Op {
+	case "get":
+		body = generateGet(m)
+	case "list":
+		body = generateList(m)
+	case "delete":
+		body = generateDelete(m)
+	case "create":
+		body = generateCreate(m)
+	case "update":
+		body = generateUpdate(m)
+	default:
+		body = generateUnimplemented()
+	}
+
+	return Join(
+		Blank(),
+		Linef("func (%s) %s(%s) %s {", recv, m.GoName, params, returns),
+		body,
+		Line("}"),
+	)
+}
+
+func generateGet(m MethodInfo) Code {
+	e := m.Entity
+	return Join(
+		Linef("	id := req.Msg.Get%s()", m.IDField),
+		Line(`	if id == "" {`),
+		Line(`		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))`),
+		Line(`	}`),
+		Linef("	entity, err := s.repos.%s.Get(ctx, id)", e),
+		Line("	if err != nil {"),
+		Line("		if errors.Is(err, ErrNotFound) {"),
+		Line("			return nil, connect.NewError(connect.CodeNotFound, err)"),
+		Line("		}"),
+		Line("		return nil, connect.NewError(connect.CodeInternal, err)"),
+		Line("	}"),
+		Line("	return connect.NewResponse(entity), nil"),
+	)
+}
+
+func generateList(m MethodInfo) Code {
+	e := m.Entity
+	listField := m.ListField
+	if listField == "" {
+		listField = m.Entity + "s"
+	}
+
+	// Handle Empty input (no pagination)
+	if m.InputType == "emptypb.Empty" {
+		return Join(
+			Linef("	entities, err := s.repos.%s.List(ctx, 100)", e),
+			Line("	if err != nil {"),
+			Line("		return nil, connect.NewError(connect.CodeInternal, err)"),
+			Line("	}"),
+			Linef("	return connect.NewResponse(&%s{%s: entities}), nil", m.OutputType, listField),
+		)
+	}
+
+	return Join(
+		Line("	limit := int(req.Msg.GetLimit())"),
+		Line("	if limit <= 0 || limit > 100 {"),
+		Line("		limit = 100"),
+		Line("	}"),
+		Linef("	entities, err := s.repos.%s.List(ctx, limit)", e),
+		Line("	if err != nil {"),
+		Line("		return nil, connect.NewError(connect.CodeInternal, err)"),
+		Line("	}"),
+		Linef("	return connect.NewResponse(&%s{%s: entities}), nil", m.OutputType, listField),
+	)
+}
+
+func generateDelete(m MethodInfo) Code {
+	e := m.Entity
+	return Join(
+		Linef("	id := req.Msg.Get%s()", m.IDField),
+		Line(`	if id == "" {`),
+		Line(`		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id required"))`),
+		Line(`	}`),
+		Linef("	if err := s.repos.%s.Delete(ctx, id); err != nil {", e),
+		Line("		if errors.Is(err, ErrNotFound) {"),
+		Line("			return nil, connect.NewError(connect.CodeNotFound, err)"),
+		Line("		}"),
+		Line("		return nil, connect.NewError(connect.CodeInternal, err)"),
+		Line("	}"),
+		Line("	return connect.NewResponse(&emptypb.Empty{}), nil"),
+	)
+}
+
+func generateCreate(m MethodInfo) Code {
+	// Create operations need custom logic (validation, password hashing, etc.)
+	// Generate a TODO stub
+	return Join(
+		Line("	// TODO: Implement create logic"),
+		Line("	// - Validate input"),
+		Line("	// - Set defaults (CreatedAt, UpdatedAt)"),
+		Line("	// - Call s.xxxRepo.Create(ctx, entity)"),
+		Line(`	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))`),
+	)
+}
+
+func generateUpdate(m MethodInfo) Code {
+	// Update operations need custom logic
+	return Join(
+		Line("	// TODO: Implement update logic"),
+		Line("	// - Get existing entity"),
+		Line("	// - Apply updates from request"),
+		Line("	// - Set UpdatedAt"),
+		Line("	// - Call s.xxxRepo.Update(ctx, entity)"),
+		Line("	// - Map ErrConflict (stale version field) to connect.CodeAborted"),
+		Line(`	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))`),
+	)
+}
+
+func generateUnimplemented() Code {
+	return Line(`	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not implemented"))`)
+}
+
+func generateWireProviders(services []ServiceInfo) Code {
+	var providers []Code
+	for _, svc := range services {
+		providers = append(providers, Linef("	New%s,", svc.GoName))
+	}
+
+	return Join(
+		Line("// ============================================================================="),
+		Line("// WIRE PROVIDERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// ServiceSet provides all service constructors for Wire."),
+		Line("var ServiceSet = wire.NewSet("),
+		Join(providers...),
+		Line(")"),
+		Blank(),
+	)
+}
+
+// =============================================================================
+// MAIN
+// =============================================================================
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-service-stubs")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		reg, err := entities.NewRegistry(gen)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range gen.Files {
+			rep.Entities(reg, f, false)
+		}
+
+		// ServiceSet is declared once per Go package, so the stubs cover the
+		// services of every file in the package
+		for _, pkg := range reg.Packages() {
+			svcs := reg.Services(pkg)
+			if len(svcs) == 0 {
+				continue
+			}
+
+			// Build entity map
+			entityInfos := make(map[string]*EntityInfo)
+			for _, msg := range reg.Package(pkg, false) {
+				entityInfos[msg.GoIdent.GoName] = &EntityInfo{
+					GoName:  msg.GoIdent.GoName,
+					IDField: reg.Config(msg).IDGoName,
+				}
+			}
+
+			// Build service info
+			var services []ServiceInfo
+			for _, svc := range svcs {
+				svcInfo := ServiceInfo{GoName: svc.GoName}
+				for _, m := range svc.Methods {
+					info := analyzeMethod(m, entityInfos)
+					if info.Op != "" {
+						rep.Method(m).Detect(info.Op, info.Reason)
+					} else {
+						rep.Method(m).Because("%s", info.Reason)
+					}
+					svcInfo.Methods = append(svcInfo.Methods, info)
+				}
+				services = append(services, svcInfo)
+			}
+
+			f := reg.Anchor(pkg)
+			pkgName := string(f.GoPackageName)
+			// Construct connect package path
+			connectPkg := string(f.GoImportPath) + "/" + strings.ToLower(pkgName) + "connect"
+
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_services.pb.go", f.GoImportPath, generateFile(f, services, entityInfos, pkgName, connectPkg).Run()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// =============================================================================
+// HELPERS
+// =============================================================================
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func extractPkgName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	return parts[len(parts)-1]
+}
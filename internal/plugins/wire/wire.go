@@ -0,0 +1,331 @@
+// Package wire implements protoc-gen-wire, which generates Google Wire provider sets
+// Uses entity options to determine which messages get providers
+// Generates: RepositorySet, ServiceSet, HandlerSet, ServerSet
+package wire
+
+import (
+	"strings"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// INFO TYPES
+// =============================================================================
+
+type EntityInfo struct {
+	GoName string
+}
+
+type ServiceInfo struct {
+	GoName string
+}
+
+// =============================================================================
+// WIRE GENERATOR
+// =============================================================================
+
+func GenerateWire(entities []EntityInfo, services []ServiceInfo, pkgName, importPath string) Code {
+	return Join(
+		generateHeader(pkgName, importPath),
+		generateRepositorySet(entities),
+		generateRepositoryStruct(entities),
+		generateServiceProviders(services),
+		generateHandlerSet(services, importPath),
+		generateServerSet(),
+		generateServerStruct(services, importPath),
+		generateWireInjectorExample(pkgName, importPath),
+	)
+}
+
+func generateHeader(pkgName, importPath string) Code {
+	return Join(
+		Line("// Code generated by protoc-gen-wire. DO NOT EDIT."),
+		Line("// Wire dependency injection providers for proto-generated services."),
+		Blank(),
+		Linef("package %s", pkgName),
+		Blank(),
+		Line("import ("),
+		Line(`	"net/http"`),
+		Line(`	"time"`),
+		Blank(),
+		Line(`	"github.com/google/wire"`),
+		Line(`	"github.com/rs/cors"`),
+		Line(`	"golang.org/x/net/http2"`),
+		Line(`	"golang.org/x/net/http2/h2c"`),
+		Line(")"),
+		Blank(),
+	)
+}
+
+func generateRepositorySet(entities []EntityInfo) Code {
+	var providers []Code
+	for _, e := range entities {
+		providers = append(providers, Linef("	NewFirestore%sRepository,", e.GoName))
+		providers = append(providers, Linef("	wire.Bind(new(%sRepository), new(*Firestore%sRepository)),", e.GoName, e.GoName))
+	}
+
+	return Join(
+		Line("// ============================================================================="),
+		Line("// REPOSITORY PROVIDERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// RepositorySet provides all Firestore repositories, bound to the"),
+		Line("// backend-agnostic repository interfaces."),
+		Line("var RepositorySet = wire.NewSet("),
+		Join(providers...),
+		Line("	NewRepositories,"),
+		Line(")"),
+		Blank(),
+	)
+}
+
+func generateRepositoryStruct(entities []EntityInfo) Code {
+	var fields []Code
+	for _, e := range entities {
+		fields = append(fields, Linef("	%s %sRepository", e.GoName, e.GoName))
+	}
+
+	var params []Code
+	for _, e := range entities {
+		params = append(params, Linef("	%s %sRepository,", lowerFirst(e.GoName), e.GoName))
+	}
+
+	var assigns []Code
+	for _, e := range entities {
+		assigns = append(assigns, Linef("		%s: %s,", e.GoName, lowerFirst(e.GoName)))
+	}
+
+	return Join(
+		Line("// Repositories holds all repository instances. Fields are interfaces so"),
+		Line("// any generated backend (Firestore, in-memory) can be plugged in."),
+		Line("type Repositories struct {"),
+		Join(fields...),
+		Line("}"),
+		Blank(),
+		Line("// NewRepositories creates a Repositories container."),
+		Line("func NewRepositories("),
+		Join(params...),
+		Line(") *Repositories {"),
+		Line("	return &Repositories{"),
+		Join(assigns...),
+		Line("	}"),
+		Line("}"),
+		Blank(),
+	)
+}
+
+func generateServiceProviders(services []ServiceInfo) Code {
+	return Join(
+		Line("// ============================================================================="),
+		Line("// SERVICE PROVIDERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// ServiceSet provides all service implementations."),
+		Line("// Note: Custom services (TokenService, UserService, etc.) should be"),
+		Line("// provided separately as they require custom logic."),
+		Line("var ServiceSet = wire.NewSet("),
+		Line("	// Add custom service providers here"),
+		Line(")"),
+		Blank(),
+	)
+}
+
+func generateHandlerSet(services []ServiceInfo, importPath string) Code {
+	// Handler wiring requires custom service implementations
+	// This is a placeholder - wire handlers manually in main.go
+	return Join(
+		Line("// ============================================================================="),
+		Line("// HANDLER REGISTRATION HELPERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// RegisterHandlers registers all Connect handlers with the mux."),
+		Line("// Call this from main.go after creating your services."),
+		Line("// Example:"),
+		Line("//   mux := NewServerMux()"),
+		Line("//   mux.Handle(purecertsv1connect.NewUserServiceHandler(userSvc))"),
+		Line("//   mux.Handle(purecertsv1connect.NewTokenServiceHandler(tokenSvc))"),
+		Line("//   ..."),
+		Blank(),
+	)
+}
+
+func generateServerSet() Code {
+	return Join(
+		Line("// ============================================================================="),
+		Line("// SERVER SET"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// ServerSet wires repositories + handlers into a server."),
+		Line("var ServerSet = wire.NewSet("),
+		Line("	RepositorySet,"),
+		Line("	// ServiceSet, // Uncomment when custom services added"),
+		Line("	// HandlerSet, // Uncomment when handlers wired"),
+		Line("	NewServerMux,"),
+		Line("	NewHTTPServer,"),
+		Line(")"),
+		Blank(),
+	)
+}
+
+func generateServerStruct(services []ServiceInfo, importPath string) Code {
+	return Join(
+		Line("// ============================================================================="),
+		Line("// SERVER HELPERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// ServerConfig holds server configuration."),
+		Line("type ServerConfig struct {"),
+		Line("	Port            string"),
+		Line("	AllowedOrigins  []string"),
+		Line("	ReadTimeout     time.Duration"),
+		Line("	WriteTimeout    time.Duration"),
+		Line("}"),
+		Blank(),
+		Line("// DefaultServerConfig returns sensible defaults."),
+		Line("func DefaultServerConfig() *ServerConfig {"),
+		Line("	return &ServerConfig{"),
+		Line(`		Port:           "8080",`),
+		Line(`		AllowedOrigins: []string{"http://localhost:3000", "http://localhost:5173"},`),
+		Line("		ReadTimeout:    30 * time.Second,"),
+		Line("		WriteTimeout:   30 * time.Second,"),
+		Line("	}"),
+		Line("}"),
+		Blank(),
+		Line("// NewServerMux creates a new HTTP mux."),
+		Line("func NewServerMux() *http.ServeMux {"),
+		Line("	mux := http.NewServeMux()"),
+		Line("	"),
+		Line("	// Health check"),
+		Line(`	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {`),
+		Line(`		w.Header().Set("Content-Type", "application/json")`),
+		Line("		w.Write([]byte(`{\"status\":\"ok\"}`))"),
+		Line("	})"),
+		Line("	"),
+		Line("	return mux"),
+		Line("}"),
+		Blank(),
+		Line("// RegisterHandler registers a Connect handler with the mux."),
+		Line("func RegisterHandler(mux *http.ServeMux, path string, handler http.Handler) {"),
+		Line("	mux.Handle(path, handler)"),
+		Line("}"),
+		Blank(),
+		Line("// NewHTTPServer creates an HTTP server with CORS and HTTP/2."),
+		Line("func NewHTTPServer(mux *http.ServeMux, cfg *ServerConfig) *http.Server {"),
+		Line("	if cfg == nil {"),
+		Line("		cfg = DefaultServerConfig()"),
+		Line("	}"),
+		Blank(),
+		Line("	corsHandler := cors.New(cors.Options{"),
+		Line("		AllowedOrigins:   cfg.AllowedOrigins,"),
+		Line(`		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},`),
+		Line(`		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Connect-Protocol-Version"},`),
+		Line(`		ExposedHeaders:   []string{"Grpc-Status", "Grpc-Message"},`),
+		Line("		AllowCredentials: true,"),
+		Line("	}).Handler(mux)"),
+		Blank(),
+		Line("	return &http.Server{"),
+		Line(`		Addr:         ":" + cfg.Port,`),
+		Line("		Handler:      h2c.NewHandler(corsHandler, &http2.Server{}),"),
+		Line("		ReadTimeout:  cfg.ReadTimeout,"),
+		Line("		WriteTimeout: cfg.WriteTimeout,"),
+		Line("	}"),
+		Line("}"),
+		Blank(),
+	)
+}
+
+func generateWireInjectorExample(pkgName, importPath string) Code {
+	return Join(
+		Line("// ============================================================================="),
+		Line("// WIRE INJECTOR EXAMPLE"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("/*"),
+		Line("Copy this to cmd/server/wire.go:"),
+		Blank(),
+		Line("//go:build wireinject"),
+		Blank(),
+		Line("package main"),
+		Blank(),
+		Line("import ("),
+		Line(`	"cloud.google.com/go/firestore"`),
+		Line(`	"github.com/google/wire"`),
+		Linef(`	pb "%s"`, importPath),
+		Line(")"),
+		Blank(),
+		Line("func InitializeServer(client *firestore.Client, cfg *pb.ServerConfig) (*http.Server, error) {"),
+		Line("	wire.Build(pb.ServerSet)"),
+		Line("	return nil, nil"),
+		Line("}"),
+		Blank(),
+		Line("Then run: wire ./cmd/server"),
+		Line("*/"),
+		Blank(),
+	)
+}
+
+// =============================================================================
+// MAIN
+// =============================================================================
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-wire")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		reg, err := entities.NewRegistry(gen)
+		if err != nil {
+			return err
+		}
+
+		// The provider sets and Repositories are declared once per Go
+		// package and cover the entities and services of all its files
+		for _, f := range gen.Files {
+			rep.Entities(reg, f, false)
+		}
+		for _, pkg := range reg.Packages() {
+			var entityInfos []EntityInfo
+			for _, msg := range reg.Package(pkg, false) {
+				entityInfos = append(entityInfos, EntityInfo{GoName: msg.GoIdent.GoName})
+			}
+
+			var services []ServiceInfo
+			for _, svc := range reg.Services(pkg) {
+				services = append(services, ServiceInfo{GoName: svc.GoName})
+			}
+
+			if len(entityInfos) == 0 && len(services) == 0 {
+				continue
+			}
+
+			f := reg.Anchor(pkg)
+			pkgName := string(f.GoPackageName)
+			importPath := string(f.GoImportPath)
+
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_wire.pb.go", f.GoImportPath, GenerateWire(entityInfos, services, pkgName, importPath).Run()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// =============================================================================
+// HELPERS
+// =============================================================================
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
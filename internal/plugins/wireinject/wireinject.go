@@ -0,0 +1,146 @@
+// Package wireinject implements protoc-gen-wire-inject, which generates the wire.go injector file
+// This is the final piece - generates InitializeServer and ProviderSet
+package wireinject
+
+import (
+	"strings"
+
+	. "github.com/vinodhalaharvi/buf-go-plugins/internal/codegen"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/diag"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/entities"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/explain"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/gosrc"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	"google.golang.org/protobuf/compiler/protogen"
+	pluginpb "google.golang.org/protobuf/types/pluginpb"
+)
+
+// =============================================================================
+// CODE GENERATION
+// =============================================================================
+
+func generateWireInject(services []*protogen.Service, pkgName, pbImportPath, connectPkg string) Code {
+	// Build handler registrations
+	var handlerParams []Code
+	var handlerCalls []Code
+	for _, svc := range services {
+		handlerParams = append(handlerParams, Linef("	%s *%s,", lowerFirst(svc.GoName), svc.GoName))
+		handlerCalls = append(handlerCalls, Linef("	mux.Handle(%s.New%sHandler(%s))", extractPkgName(connectPkg), svc.GoName, lowerFirst(svc.GoName)))
+	}
+
+	return Join(
+		Line("// Code generated by protoc-gen-wire-inject. DO NOT EDIT."),
+		Line("// Wire dependency injection setup."),
+		Blank(),
+		Line("//go:build wireinject"),
+		Line("// +build wireinject"),
+		Blank(),
+		Linef("package %s", pkgName),
+		Blank(),
+		Line("import ("),
+		Line(`	"net/http"`),
+		Blank(),
+		Line(`	"cloud.google.com/go/firestore"`),
+		Line(`	"github.com/google/wire"`),
+		Linef(`	"%s"`, connectPkg),
+		Line(")"),
+		Blank(),
+		Line("// ============================================================================="),
+		Line("// SERVER"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// Server holds the HTTP server and all services"),
+		Line("type Server struct {"),
+		Line("	HTTPServer *http.Server"),
+		Line("}"),
+		Blank(),
+		Line("// RegisterHandlers wires all service handlers to the mux"),
+		Line("func RegisterHandlers("),
+		Line("	mux *http.ServeMux,"),
+		Line("	httpServer *http.Server,"),
+		Join(handlerParams...),
+		Line(") *Server {"),
+		Join(handlerCalls...),
+		Line("	return &Server{HTTPServer: httpServer}"),
+		Line("}"),
+		Blank(),
+		Line("// ============================================================================="),
+		Line("// WIRE PROVIDERS"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// ProviderSet combines all providers needed for the server"),
+		Line("var ProviderSet = wire.NewSet("),
+		Line("	RepositorySet,"),
+		Line("	ServiceSet,"),
+		Line("	NewServerMux,"),
+		Line("	NewHTTPServer,"),
+		Line("	RegisterHandlers,"),
+		Line(")"),
+		Blank(),
+		Line("// ============================================================================="),
+		Line("// WIRE INJECTOR"),
+		Line("// ============================================================================="),
+		Blank(),
+		Line("// InitializeServer creates a fully wired server"),
+		Line("func InitializeServer(client *firestore.Client, cfg *ServerConfig) (*Server, error) {"),
+		Line("	wire.Build(ProviderSet)"),
+		Line("	return nil, nil"),
+		Line("}"),
+		Blank(),
+	)
+}
+
+// =============================================================================
+// MAIN
+// =============================================================================
+
+// Plugin declares the parameters on flags and returns the generator.
+func Plugin(flags *params.Set) func(*protogen.Plugin) error {
+	ex := explain.Declare(flags, "protoc-gen-wire-inject")
+	return ex.Wrap(func(gen *protogen.Plugin, rep *explain.Report, diags *diag.Set) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		reg, err := entities.NewRegistry(gen)
+		if err != nil {
+			return err
+		}
+
+		// RegisterHandlers is declared once per Go package and takes the
+		// services of all its files
+		for _, f := range gen.Files {
+			rep.Entities(reg, f, false)
+		}
+		for _, pkg := range reg.Packages() {
+			services := reg.Services(pkg)
+			if len(services) == 0 || len(reg.Package(pkg, false)) == 0 {
+				continue
+			}
+
+			f := reg.Anchor(pkg)
+			pkgName := string(f.GoPackageName)
+			pbImportPath := string(f.GoImportPath)
+			connectPkg := pbImportPath + "/" + strings.ToLower(pkgName) + "connect"
+
+			if err := gosrc.Generate(gen, f.GeneratedFilenamePrefix+"_wire_inject.pb.go", f.GoImportPath, generateWireInject(services, pkgName, pbImportPath, connectPkg).Run()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// =============================================================================
+// HELPERS
+// =============================================================================
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func extractPkgName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,307 @@
+// Package plugintest runs plugins in-process against .proto fixtures and
+// compares their output with golden files checked in next to each plugin.
+//
+// A plugin test looks like
+//
+//	func TestGolden(t *testing.T) {
+//		plugintest.Golden(t, plugin,
+//			plugintest.Case{Name: "shop", Files: []string{"shop/v1/shop.proto"}},
+//		)
+//	}
+//
+// Goldens live in testdata/golden/<case>/ of the plugin's directory and are
+// rewritten with
+//
+//	go test ./cmd/... -update
+//
+// Every generated .go file must also parse and be gofmt-ed, so output that is
+// not valid, formatted Go fails even when the goldens are updated along with
+// it.
+//
+// Generated keeps generated code checked in where it compiles, next to the
+// tests that run it, and rewrites it with -update too.
+package plugintest
+
+import (
+	"context"
+	"flag"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/vinodhalaharvi/buf-go-plugins/internal/params"
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current plugin output")
+
+// Plugin is the shape of every cmd/protoc-gen-*/main.go: it declares its
+// parameters on flags and returns the generator.
+type Plugin func(flags *params.Set) func(*protogen.Plugin) error
+
+// Case is one golden test: the files to generate and the plugin parameter.
+type Case struct {
+	Name  string   // directory under testdata/golden
+	Files []string // files to generate, relative to an import root
+	Param string   // plugin parameter, as given by opt: in buf.gen.yaml
+
+	// Roots are extra import roots, relative to the test's package
+	// directory. The fixtures in this package's testdata and the repo's
+	// proto/ directory are always searched.
+	Roots []string
+}
+
+// Golden runs p on every case and compares the response with the goldens.
+func Golden(t *testing.T, p Plugin, cases ...Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := Run(t, p, Request(t, c))
+			got := Outputs(t, resp)
+			dir := filepath.Join("testdata", "golden", c.Name)
+			compare(t, dir, readDir(t, dir), got)
+		})
+	}
+}
+
+// Generated runs plugins on c and compares the .go files they generate with
+// the .pb.go files of dir, a Go package of this module, so that the package
+// compiles the generated code and the tests next to it run it. The files are
+// named after their base names: c.Files must make up one Go package.
+func Generated(t *testing.T, dir string, c Case, plugins ...Plugin) {
+	t.Helper()
+	got := make(map[string]string)
+	req := Request(t, c)
+	for _, p := range plugins {
+		for name, content := range Outputs(t, Run(t, p, req)) {
+			if strings.HasSuffix(name, ".go") || name == "error" {
+				got[filepath.Base(name)] = content
+			}
+		}
+	}
+	want := readDir(t, dir)
+	for name := range want {
+		if !strings.HasSuffix(name, ".pb.go") {
+			delete(want, name)
+		}
+	}
+	compare(t, dir, want, got)
+}
+
+// Go is protoc-gen-go, which generates the message types the code of the
+// other plugins needs to compile.
+func Go(flags *params.Set) func(*protogen.Plugin) error {
+	return func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = gengo.SupportedFeatures
+		for _, f := range gen.Files {
+			if f.Generate {
+				gengo.GenerateFile(gen, f)
+			}
+		}
+		return nil
+	}
+}
+
+// Request compiles the case's files, with their imports, into the request
+// protoc would send.
+func Request(t *testing.T, c Case) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+	roots := append([]string{}, c.Roots...)
+	roots = append(roots, fixtureDir(), filepath.Join(repoRoot(), "proto"))
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: roots,
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	files, err := compiler.Compile(context.Background(), c.Files...)
+	if err != nil {
+		t.Fatalf("compile %v: %v", c.Files, err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{FileToGenerate: c.Files}
+	if c.Param != "" {
+		req.Parameter = proto.String(c.Param)
+	}
+	seen := make(map[string]bool)
+	var add func(f protoreflect.FileDescriptor)
+	add = func(f protoreflect.FileDescriptor) {
+		if seen[f.Path()] {
+			return
+		}
+		seen[f.Path()] = true
+		imports := f.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(f))
+	}
+	for _, f := range files {
+		add(f)
+	}
+
+	// Round-trip through the wire format so options reach the plugin the
+	// same way they do from protoc or buf.
+	b, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	decoded := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(b, decoded); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+	return decoded
+}
+
+// Run executes p on req in-process, as protogen.Options.Run would.
+func Run(t *testing.T, p Plugin, req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	t.Helper()
+	var flags params.Set
+	generate := p(&flags)
+	gen, err := flags.Options().New(req)
+	if err != nil {
+		// Parameter errors surface here, before the generator runs
+		return &pluginpb.CodeGeneratorResponse{Error: proto.String(err.Error())}
+	}
+	if err := generate(gen); err != nil {
+		gen.Error(err)
+	}
+	return gen.Response()
+}
+
+// Outputs returns the response's files keyed by name. An error response is
+// returned as a single file named "error" so that failures are goldens too.
+// Every .go file must parse and be gofmt-ed.
+func Outputs(t *testing.T, resp *pluginpb.CodeGeneratorResponse) map[string]string {
+	t.Helper()
+	if resp.Error != nil {
+		return map[string]string{"error": resp.GetError() + "\n"}
+	}
+	out := make(map[string]string)
+	for _, f := range resp.File {
+		name := f.GetName()
+		if _, dup := out[name]; dup {
+			t.Errorf("%s generated twice", name)
+		}
+		out[name] = f.GetContent()
+		if strings.HasSuffix(name, ".go") {
+			formatted, err := format.Source([]byte(f.GetContent()))
+			switch {
+			case err != nil:
+				t.Errorf("generated invalid Go: %s: %v", name, err)
+			case string(formatted) != f.GetContent():
+				t.Errorf("%s is not gofmt-ed:\n%s", name, diff(f.GetContent(), string(formatted)))
+			}
+		}
+	}
+	return out
+}
+
+func compare(t *testing.T, dir string, want, got map[string]string) {
+	t.Helper()
+	if *update {
+		for name := range want {
+			if _, ok := got[name]; !ok {
+				if err := os.Remove(filepath.Join(dir, name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		for name, content := range got {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	for _, name := range sortedKeys(got) {
+		w, ok := want[name]
+		switch {
+		case !ok:
+			t.Errorf("%s: generated but has no golden (run with -update)", name)
+		case w != got[name]:
+			t.Errorf("%s differs from golden (run with -update):\n%s", name, diff(w, got[name]))
+		}
+	}
+	for _, name := range sortedKeys(want) {
+		if _, ok := got[name]; !ok {
+			t.Errorf("%s: golden exists but was not generated", name)
+		}
+	}
+}
+
+func readDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = string(b)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// diff reports the first differing line of two files with some context;
+// the full picture is one -update and git diff away.
+func diff(want, got string) string {
+	w, g := strings.Split(want, "\n"), strings.Split(got, "\n")
+	i := 0
+	for i < len(w) && i < len(g) && w[i] == g[i] {
+		i++
+	}
+	var b strings.Builder
+	for j := max(0, i-2); j < i; j++ {
+		b.WriteString("   " + w[j] + "\n")
+	}
+	if i < len(w) {
+		b.WriteString("-  " + w[i] + "\n")
+	}
+	if i < len(g) {
+		b.WriteString("+  " + g[i] + "\n")
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fixtureDir is this package's testdata directory, shared by every plugin.
+func fixtureDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata")
+}
+
+func repoRoot() string { return filepath.Join(fixtureDir(), "..", "..", "..") }
@@ -0,0 +1,228 @@
+// Package repository defines the canonical per-entity repository contract.
+//
+// protoc-gen-repository emits the contract (sentinel errors plus one
+// <Entity>Repository interface per entity, the query interfaces <Entity>Query
+// and their Direction, and the transaction interfaces <Entity>Tx and Tx) and
+// every storage backend asserts
+// that its implementation satisfies it, so swapping backends is a
+// compile-time guarantee rather than a convention. Plugins that consume a
+// repository (realtime, geo, mock, auth, ...) program against the interface
+// and must only call the methods listed here.
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Method is one method of the repository interface. Params and Results are
+// Go source in which *T stands for a pointer to the entity type, and Results
+// Q for the entity's query interface.
+type Method struct {
+	Name    string
+	Params  string
+	Results string
+	Doc     string
+}
+
+// Methods is the canonical method set, in declaration order.
+var Methods = []Method{
+	{"Create", "ctx context.Context, entity *T", "(string, error)",
+		"Create stores entity, assigning its ID when empty, and returns the ID."},
+	{"Get", "ctx context.Context, id string", "(*T, error)",
+		"Get returns the entity with the given ID or ErrNotFound."},
+	{"Update", "ctx context.Context, entity *T", "error",
+		"Update replaces the stored entity with the same ID, or returns ErrConflict when its version field is stale."},
+	{"Patch", "ctx context.Context, id string, entity *T, mask *fieldmaskpb.FieldMask", "error",
+		"Patch sets the fields of the stored entity that mask names to entity's, or returns ErrInvalidMask."},
+	{"Delete", "ctx context.Context, id string", "error",
+		"Delete removes the entity with the given ID."},
+	{"List", "ctx context.Context, limit int", "([]*T, error)",
+		"List returns up to limit entities; limit <= 0 returns all of them."},
+	{"Exists", "ctx context.Context, id string", "(bool, error)",
+		"Exists reports whether an entity with the given ID is stored."},
+	{"Count", "ctx context.Context", "(int64, error)",
+		"Count returns the number of stored entities."},
+	{"Query", "", "Q",
+		"Query starts a query of the stored entities."},
+}
+
+// QueryMethods is the method set of an entity's query, in declaration order.
+// Where, OrderBy, Limit and Offset refine the query and return it.
+var QueryMethods = []Method{
+	{"Where", "field string, op string, value interface{}", "Q",
+		"Where keeps the results whose field, a proto field name, compares to value with op, one of the operators of Filter."},
+	{"OrderBy", "field string, dir Direction", "Q",
+		"OrderBy orders the results by field, then by the fields of inequality filters, then by ID."},
+	{"Limit", "n int", "Q",
+		"Limit keeps the first n results."},
+	{"Offset", "n int", "Q",
+		"Offset skips the first n results."},
+	{"Get", "ctx context.Context", "([]*T, error)",
+		"Get returns the results."},
+	{"First", "ctx context.Context", "(*T, error)",
+		"First returns the first result or ErrNotFound."},
+	{"Count", "ctx context.Context", "(int64, error)",
+		"Count returns the number of results; Limit and Offset do not apply."},
+}
+
+// TxMethods is the method set of an entity's side of a transaction, in
+// declaration order: the repository's, minus what a transaction can't do
+// atomically, plus GetAll and Query. The transaction carries the context.
+var TxMethods = []Method{
+	{"Get", "id string", "(*T, error)",
+		"Get returns the entity with the given ID or ErrNotFound."},
+	{"GetAll", "ids []string", "([]*T, error)",
+		"GetAll returns the entities with the given IDs, in the order of ids, leaving out the missing ones."},
+	{"Create", "entity *T", "(string, error)",
+		"Create stores entity, assigning its ID when empty, and returns the ID."},
+	{"Update", "entity *T", "error",
+		"Update replaces the stored entity with the same ID, or returns ErrConflict when its version field is stale."},
+	{"Patch", "id string, entity *T, mask *fieldmaskpb.FieldMask", "error",
+		"Patch sets the fields of the stored entity that mask names to entity's, or returns ErrInvalidMask."},
+	{"Delete", "id string", "error",
+		"Delete removes the entity with the given ID."},
+	{"Query", "filters ...Filter", "([]*T, error)",
+		"Query returns the entities that match every filter, ordered by ID."},
+}
+
+// Errors are the sentinel errors every backend returns, keyed by name.
+var Errors = []struct{ Name, Message string }{
+	{"ErrNotFound", "not found"},
+	{"ErrInvalidID", "invalid id"},
+	{"ErrAlreadyExists", "already exists"},
+	{"ErrConflict", "conflict: modified concurrently"},
+	{"ErrInvalidMask", "invalid field mask"},
+}
+
+// InterfaceName returns the interface name for an entity type.
+func InterfaceName(entity string) string { return entity + "Repository" }
+
+// Signature returns "Name(params) results" for m on the given entity type.
+func (m Method) Signature(entity string) string {
+	return m.Name + "(" + substitute(m.Params, entity) + ") " + substitute(m.Results, entity)
+}
+
+// Interface returns the Go declaration of the entity's repository interface.
+func Interface(entity string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s is implemented by every generated %s storage backend.\n", InterfaceName(entity), entity)
+	b.WriteString("// List, Count and queries skip soft-deleted entities.\n")
+	fmt.Fprintf(&b, "type %s interface {\n", InterfaceName(entity))
+	for i, m := range Methods {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t// %s\n\t%s\n", m.Doc, m.Signature(entity))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Assertion returns the compile-time check that impl satisfies the entity's
+// repository interface.
+func Assertion(entity, impl string) string {
+	return fmt.Sprintf("var _ %s = (*%s)(nil)", InterfaceName(entity), impl)
+}
+
+// QueryInterfaceName returns the name of the interface of an entity's query.
+func QueryInterfaceName(entity string) string { return entity + "Query" }
+
+// QueryInterface returns the Go declaration of the entity's query interface.
+func QueryInterface(entity string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s is a query of the %ss, which every backend evaluates alike: the same\n", QueryInterfaceName(entity), entity)
+	b.WriteString("// filters and orders select the same entities in the same order.\n")
+	fmt.Fprintf(&b, "type %s interface {\n", QueryInterfaceName(entity))
+	for i, m := range QueryMethods {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t// %s\n\t%s\n", m.Doc, m.Signature(entity))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// QueryAssertion returns the compile-time check that impl satisfies the
+// entity's query interface.
+func QueryAssertion(entity, impl string) string {
+	return fmt.Sprintf("var _ %s = (*%s)(nil)", QueryInterfaceName(entity), impl)
+}
+
+// Direction is the Go declaration of the direction of an OrderBy, which the
+// queries of every entity of a Go package share.
+const Direction = `// Direction is the direction of an OrderBy, numbered like firestore.Direction.
+type Direction int32
+
+const (
+	Asc  Direction = 1
+	Desc Direction = 2
+)
+`
+
+// TxInterfaceName returns the name of the interface of an entity's side of a
+// transaction.
+func TxInterfaceName(entity string) string { return entity + "Tx" }
+
+// TxInterface returns the Go declaration of the entity's side of a
+// transaction.
+func TxInterface(entity string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s is the %s side of a Tx.\n", TxInterfaceName(entity), entity)
+	b.WriteString("// Get, GetAll and Query skip soft-deleted entities.\n")
+	fmt.Fprintf(&b, "type %s interface {\n", TxInterfaceName(entity))
+	for i, m := range TxMethods {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t// %s\n\t%s\n", m.Doc, m.Signature(entity))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Tx returns the Go declarations of Filter and of the Tx interface over the
+// entities of a Go package, which every backend's transactions implement.
+func Tx(entities []string) string {
+	var b strings.Builder
+	b.WriteString(`// Filter is a condition of a transactional query: the proto field named Field
+// compared to Value with Op, one of the Firestore operators ==, !=, <, <=, >,
+// >=, in, not-in, array-contains and array-contains-any.
+type Filter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+// Tx is a transaction over the entities of the package. The backends' run
+// functions commit the writes of a transaction together, or none of them when
+// it fails. Firestore requires every read of a transaction to come before its
+// writes, reports some write failures, such as Create's ErrAlreadyExists, when
+// it commits, and runs the function again when another transaction interferes.
+type Tx interface {
+`)
+	for i, entity := range entities {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t// %s is the %s side of the transaction.\n\t%s() %s\n", entity, entity, entity, TxInterfaceName(entity))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// TxAssertion returns the compile-time check that impl satisfies the entity's
+// transaction interface.
+func TxAssertion(entity, impl string) string {
+	return fmt.Sprintf("var _ %s = (*%s)(nil)", TxInterfaceName(entity), impl)
+}
+
+// substitute replaces the T placeholder in "*T" type expressions, and the Q
+// placeholder of a query result.
+func substitute(s, entity string) string {
+	if s == "Q" {
+		return QueryInterfaceName(entity)
+	}
+	return strings.ReplaceAll(s, "*T", "*"+entity)
+}